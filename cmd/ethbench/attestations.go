@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/vBenchmark/internal/attestation"
+	"github.com/vBenchmark/internal/report"
+)
+
+// runAttestations reads recent attestation performance of validators from a
+// beacon node and matches missed and late duties with the bottlenecks in a
+// benchmark report of the node's machine
+func runAttestations(args []string) int {
+	fs := flag.NewFlagSet("attestations", flag.ContinueOnError)
+	beaconURL := fs.String("beacon-url", "http://localhost:5052", "Beacon node REST API endpoint")
+	validatorList := fs.String("validators", "", "Comma-separated validator indices")
+	epochs := fs.Int("epochs", 10, "Number of finalized epochs to analyze")
+	reportPath := fs.String("report", "", "Benchmark report (JSON) of the node's machine")
+	if err := fs.Parse(args); err != nil {
+		return exitFatal
+	}
+	if *validatorList == "" {
+		fmt.Println("Usage: ethbench attestations -validators 1,2,3 [-beacon-url URL] [-epochs 10] [-report ethbench.json]")
+		return exitFatal
+	}
+	var validators []uint64
+	for _, s := range strings.Split(*validatorList, ",") {
+		index, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			fmt.Printf("Error: invalid validator index %q\n", s)
+			return exitFatal
+		}
+		validators = append(validators, index)
+	}
+	if *epochs < 1 || *epochs > 225 {
+		fmt.Println("Error: -epochs must be between 1 and 225")
+		return exitFatal
+	}
+
+	var benchReport *report.Report
+	if *reportPath != "" {
+		var err error
+		if benchReport, err = report.LoadReport(*reportPath); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitFatal
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("Reading attestation rewards of %d validators over %d epochs...\n\n", len(validators), *epochs)
+	perf, err := attestation.Fetch(ctx, *beaconURL, validators, *epochs)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitFatal
+	}
+	fmt.Print(report.FormatAttestations(perf, report.CorrelateAttestations(perf, benchReport)))
+	return 0
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/vBenchmark/internal/benchmark"
+	"github.com/vBenchmark/internal/disk"
+	"github.com/vBenchmark/internal/report"
+	"github.com/vBenchmark/internal/system"
+	"github.com/vBenchmark/internal/types"
+)
+
+// runDevcheck runs the suite at several multiples of the default durations
+// and reports how much each metric depends on the duration. It is meant for
+// choosing benchmark durations and is left out of the help text.
+func runDevcheck(args []string) int {
+	fs := flag.NewFlagSet("devcheck", flag.ContinueOnError)
+	scaleList := fs.String("scales", "0.25,0.5,1,2", "Comma-separated multiples of the default benchmark durations")
+	tolerance := fs.Float64("tolerance", 5, "Percent from the longest run's value that counts as converged")
+	testDir := fs.String("test-dir", ".", "Directory for disk I/O tests")
+	maxWrite := fs.String("max-write", "", "Maximum bytes written by disk benchmarks per scale, e.g. 2G (default depends on storage type)")
+	only := fs.String("only", "", "Comma-separated benchmarks or categories to run, e.g. cpu,disk.random")
+	skip := fs.String("skip", "", "Comma-separated benchmarks or categories to skip, e.g. disk")
+	verbose := fs.Bool("verbose", false, "Show detailed progress")
+	if err := fs.Parse(args); err != nil {
+		return exitFatal
+	}
+	scales, err := parseScales(*scaleList)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitFatal
+	}
+	selection, err := benchmark.ParseSelection(*only, *skip)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitFatal
+	}
+
+	sysInfo, err := system.Detect()
+	if err != nil {
+		fmt.Printf("Warning: Could not detect all system info: %v\n", err)
+	}
+	if err := system.CheckPrerequisites(*testDir); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitFatal
+	}
+	lock, err := acquireRunLock(*testDir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitFatal
+	}
+	defer lock.release()
+	disk.CleanOrphans(*testDir)
+	maxWriteBytes := benchmark.DefaultMaxWrite(sysInfo.DiskType)
+	if *maxWrite != "" {
+		if maxWriteBytes, err = benchmark.ParseByteSize(*maxWrite); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitFatal
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	runs := make([]*types.Results, 0, len(scales))
+	for i, scale := range scales {
+		// No idle baseline or anomaly re-runs: only the benchmarks themselves
+		// should differ between scales
+		config := benchmark.DefaultConfig()
+		config.IdleDuration = 0
+		config.AnomalySigma = 0
+		config.CPUDuration = scaleDuration(config.CPUDuration, scale)
+		config.MemoryDuration = scaleDuration(config.MemoryDuration, scale)
+		config.DiskDuration = scaleDuration(config.DiskDuration, scale)
+		config.PackDuration = scaleDuration(config.PackDuration, scale)
+		config.TestDir = *testDir
+		config.Verbose = *verbose
+		config.IOJobs = 1
+		config.MaxWriteBytes = maxWriteBytes
+		_, free, _ := system.DiskCapacity(*testDir)
+		config.RandomFileSize = benchmark.DefaultRandomFileSize(sysInfo.RAMTotalMB, free)
+
+		fmt.Printf("[%d/%d] Running suite at x%g durations...\n", i+1, len(scales), scale)
+		results := benchmark.NewRunner(config).RunAll(ctx, selection)
+		if results.Interrupted {
+			disk.CleanOrphans(*testDir)
+			fmt.Println("Interrupted")
+			return exitInterrupted
+		}
+		runs = append(runs, results)
+	}
+
+	fmt.Println()
+	fmt.Print(report.FormatDurationCheck(report.CheckDurations(scales, runs, *tolerance)))
+	return 0
+}
+
+// parseScales parses a comma-separated list of positive duration multipliers
+// and returns them in ascending order
+func parseScales(s string) ([]float64, error) {
+	var scales []float64
+	for _, field := range strings.Split(s, ",") {
+		scale, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil || scale <= 0 {
+			return nil, fmt.Errorf("invalid duration scale %q: must be a positive number", field)
+		}
+		scales = append(scales, scale)
+	}
+	slices.Sort(scales)
+	scales = slices.Compact(scales)
+	if len(scales) < 2 {
+		return nil, fmt.Errorf("-scales needs at least two different values to compare")
+	}
+	return scales, nil
+}
+
+// scaleDuration multiplies d by scale
+func scaleDuration(d time.Duration, scale float64) time.Duration {
+	return time.Duration(float64(d) * scale)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+
+	"github.com/vBenchmark/internal/history"
+)
+
+// runHistory lists the runs recorded in the local history and the trend of
+// each metric over the runs comparable with the latest one
+func runHistory(args []string) int {
+	flags := flag.NewFlagSet("history", flag.ContinueOnError)
+	file := flags.String("file", history.DefaultPath(), "History file to read")
+	last := flags.Int("last", 20, "Number of most recent runs to list (0 lists all)")
+	metric := flags.String("metric", "summary.", "Trend the metrics starting with this prefix, e.g. disk.random (empty trends all)")
+	if err := flags.Parse(args); err != nil {
+		return exitFatal
+	}
+	if *file == "" {
+		fmt.Println("Error: no home directory for the default history; pass -file")
+		return exitFatal
+	}
+	entries, skipped, err := history.Load(*file)
+	if errors.Is(err, fs.ErrNotExist) {
+		fmt.Printf("No runs recorded in %s yet; every completed benchmark run is added\n", *file)
+		return 0
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitFatal
+	}
+	if skipped > 0 {
+		fmt.Printf("Warning: skipped %d unreadable line(s) in %s\n", skipped, *file)
+	}
+	fmt.Print(history.FormatHistory(*file, entries, *last, *metric))
+	return 0
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+
+	"github.com/vBenchmark/internal/report"
+)
+
+// runHook runs an operator command through the shell with the ETHBENCH_*
+// variables in env added to its environment. Its output goes to the terminal.
+func runHook(name, command string, env map[string]string) error {
+	fmt.Printf("Running %s hook: %s\n", name, command)
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	for key, value := range env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s hook failed: %w", name, err)
+	}
+	return nil
+}
+
+// postRunEnv describes the finished run to the post-run hook. r is nil and
+// jsonPath empty when no report was produced.
+func postRunEnv(r *report.Report, jsonPath, outputDir string, exitCode int) map[string]string {
+	env := map[string]string{
+		"ETHBENCH_OUTPUT_DIR": outputDir,
+		"ETHBENCH_REPORT":     jsonPath,
+		"ETHBENCH_EXIT_CODE":  strconv.Itoa(exitCode),
+	}
+	if r == nil {
+		return env
+	}
+	env["ETHBENCH_TOTAL_SCORE"] = strconv.Itoa(r.Summary.TotalScore)
+	env["ETHBENCH_CPU_SCORE"] = strconv.Itoa(r.Summary.CPUScore)
+	env["ETHBENCH_MEMORY_SCORE"] = strconv.Itoa(r.Summary.MemoryScore)
+	env["ETHBENCH_DISK_SCORE"] = strconv.Itoa(r.Summary.DiskScore)
+	env["ETHBENCH_EXECUTION_CLIENT"] = r.Verdict.ExecutionClient
+	env["ETHBENCH_CONSENSUS_CLIENT"] = r.Verdict.ConsensusClient
+	env["ETHBENCH_SUMMARY"] = fmt.Sprintf("Score %d/100 (CPU %d, Memory %d, Disk %d); execution client: %s; consensus client: %s",
+		r.Summary.TotalScore, r.Summary.CPUScore, r.Summary.MemoryScore, r.Summary.DiskScore, r.Verdict.ExecutionClient, r.Verdict.ConsensusClient)
+	return env
+}
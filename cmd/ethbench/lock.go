@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// lockFileName is the run lock kept in the test and output directories. The
+// file is left in place after a run; only the flock on it marks a run as
+// active, so a crashed run never leaves a stale lock behind.
+const lockFileName = ".ethbench.lock"
+
+// runLock holds exclusive locks on the directories a run writes to
+type runLock struct {
+	files []*os.File
+}
+
+// acquireRunLock locks each directory against other ethbench runs, which
+// would compete for the disk and delete each other's test files. It fails
+// without waiting when another run holds a lock, naming its pid.
+func acquireRunLock(dirs ...string) (*runLock, error) {
+	lock := &runLock{}
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		abs, err := filepath.Abs(dir)
+		if err != nil || seen[abs] {
+			continue
+		}
+		seen[abs] = true
+		if err := os.MkdirAll(abs, 0755); err != nil {
+			lock.release()
+			return nil, fmt.Errorf("failed to create %s: %w", abs, err)
+		}
+
+		path := filepath.Join(abs, lockFileName)
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+		if err != nil {
+			lock.release()
+			return nil, fmt.Errorf("failed to open lock file: %w", err)
+		}
+		if err := unix.Flock(int(f.Fd()), unix.LOCK_EX|unix.LOCK_NB); err != nil {
+			f.Close()
+			lock.release()
+			if errors.Is(err, unix.EWOULDBLOCK) {
+				return nil, fmt.Errorf("another ethbench run%s is using %s", lockHolder(path), abs)
+			}
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		f.Truncate(0)
+		f.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+		lock.files = append(lock.files, f)
+	}
+	return lock, nil
+}
+
+// release unlocks the directories; exiting the process releases them too
+func (l *runLock) release() {
+	for _, f := range l.files {
+		unix.Flock(int(f.Fd()), unix.LOCK_UN)
+		f.Close()
+	}
+	l.files = nil
+}
+
+// lockHolder describes the pid recorded in a held lock file, if readable
+func lockHolder(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	if pid := strings.TrimSpace(string(data)); pid != "" {
+		return " (pid " + pid + ")"
+	}
+	return ""
+}
@@ -2,14 +2,28 @@
 package main
 
 import (
+	"context"
+	"crypto/ecdsa"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
+	"github.com/vBenchmark/internal/baseline"
 	"github.com/vBenchmark/internal/benchmark"
+	"github.com/vBenchmark/internal/disk"
+	"github.com/vBenchmark/internal/history"
+	"github.com/vBenchmark/internal/reference"
+	"github.com/vBenchmark/internal/replay"
 	"github.com/vBenchmark/internal/report"
 	"github.com/vBenchmark/internal/system"
+	"github.com/vBenchmark/internal/types"
 )
 
 const (
@@ -27,6 +41,35 @@ Target: Raspberry Pi 5 / ARM64 Linux
 )
 
 func main() {
+	// Subcommands
+	if len(os.Args) > 1 && os.Args[1] == "compare" {
+		os.Exit(runCompare(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		os.Exit(runServe(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "trial" {
+		os.Exit(runTrial(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "observe" {
+		os.Exit(runObserve(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "attestations" {
+		os.Exit(runAttestations(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "sysinfo" {
+		os.Exit(runSysinfo(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "history" {
+		os.Exit(runHistory(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "query" {
+		os.Exit(runQuery(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "devcheck" {
+		os.Exit(runDevcheck(os.Args[2:]))
+	}
+
 	// Get executable directory for default paths
 	execPath, err := os.Executable()
 	if err != nil {
@@ -35,10 +78,61 @@ func main() {
 	execDir := filepath.Dir(execPath)
 
 	// Parse command line arguments
+	configFile := flag.String("config", "", "JSON or YAML config file; command line flags override its settings")
 	testDir := flag.String("test-dir", execDir, "Directory for disk I/O tests")
 	outputDir := flag.String("output", execDir, "Directory for JSON output file")
+	format := flag.String("format", "text", "Report format saved next to the JSON: text (terminal only), html, markdown or csv")
 	quick := flag.Bool("quick", false, "Quick mode: ~1 minute benchmark")
+	runs := flag.Int("runs", 1, "Run the suite N times and report per-metric mean, median, spread and confidence interval")
+	paranoid := flag.Bool("paranoid", false, "Developer mode: shortened run checking every benchmark for goroutine leaks, prompt cancellation and impossible values")
+	soak := flag.Duration("soak", 0, "Loop a reduced suite for this long, e.g. 2h, tracking drift, throttling and errors over time")
 	verbose := flag.Bool("verbose", false, "Show detailed progress")
+	keepTestFiles := flag.Bool("keep-testfiles", false, "Keep prepared disk test files for reuse by the next run")
+	randomSize := flag.String("random-size", "", "Random I/O working set, e.g. 16G (default: max(4x RAM, 8G), capped by free space)")
+	maxWrite := flag.String("max-write", "", "Maximum bytes written by disk benchmarks, e.g. 10G (0 = unlimited, default depends on storage type)")
+	copyDest := flag.String("copy-dest", "", "Second location, e.g. a USB backup disk, to measure backup and datadir migration speed to")
+	replayBlocks := flag.String("replay", "", "Block export (geth export, optionally .gz) to import through go-ethereum, or \"builtin\" for a generated segment")
+	ioJobs := flag.Int("io-jobs", 1, "Goroutines for additional concurrent random I/O phases (1 = QD1 only)")
+	queueDepths := flag.String("queue-depths", "", "Comma-separated queue depths to sweep random reads at, e.g. 1,8,32")
+	directIO := flag.Bool("direct-io", false, "Also run sequential, random and batch disk benchmarks with O_DIRECT")
+	cpuWorkers := flag.Int("cpu-workers", 0, "Goroutines for the multi-core CPU benchmark (0 = number of CPUs)")
+	gomaxprocs := flag.Int("gomaxprocs", 0, "Override GOMAXPROCS for this run (0 keeps the default)")
+	gogc := flag.String("gogc", "", "Override GOGC for this run (percentage or \"off\")")
+	gogcSweep := flag.String("gogc-sweep", "", "Comma-separated GOGC values to re-run memory benchmarks with, e.g. 50,100,200")
+	memoryGOGC := flag.String("memory-gogc", "", "GOGC for the memory benchmarks only (percentage or \"off\")")
+	memoryBallast := flag.String("memory-ballast", "", "Heap ballast held during the memory benchmarks, e.g. 1G")
+	memoryPressure := flag.Bool("memory-pressure", false, "Allocate toward the RAM limit to measure swap behavior")
+	mptDisk := flag.Bool("mpt-disk", false, "Also run the go-ethereum trie benchmark on a Pebble database in the test directory")
+	only := flag.String("only", "", "Comma-separated benchmarks or categories to run, e.g. cpu,disk.random")
+	skip := flag.String("skip", "", "Comma-separated benchmarks or categories to skip, e.g. memory")
+	packs := flag.String("packs", "", "Comma-separated fork benchmark packs to enable (pectra, fusaka, all)")
+	profile := flag.String("profile", "", "Baseline profile the machine must meet, e.g. geth-mainnet; exits with code 3 if it does not")
+	compareTo := flag.String("reference", reference.DefaultComparison, "Reference device to show each metric as a percentage of, e.g. rock5b-nvme")
+	scoring := flag.String("scoring", report.DefaultScoringProfile, "Scoring profile or JSON file to compute scores with, e.g. v1-2024 to compare with older reports or solo-staker")
+	anomalySigma := flag.Float64("anomaly-sigma", 3, "Re-run benchmarks deviating more than N sigma from the hardware reference (0 disables)")
+	setPerformance := flag.Bool("set-performance-governor", false, "Switch every CPU to the performance governor while benchmarking (needs root)")
+	applyTuning := flag.Bool("apply-tuning", false, "Apply the planned IRQ affinity spread when disk interrupts land on one CPU, and re-run the affected benchmark")
+	annotate := flag.String("annotate", "", "CSV file of external sensor readings to merge into the report")
+	canonical := flag.Bool("canonical", false, "Save JSON with sorted keys and fixed float precision")
+	deterministic := flag.Bool("deterministic", false, "Canonical JSON without timestamps, saved as ethbench-report.json")
+	hwProfile := flag.Bool("hardware-profile", false, "Also save a compact hardware profile JSON for client auto-tuning")
+	operatorReadiness := flag.Bool("operator-readiness", false, "Also save an operator readiness JSON for DVT cluster applications (Obol, SSV)")
+	eip712 := flag.Bool("eip712", false, "Also save the summary as EIP-712 typed data for a wallet to sign, for hardware registries")
+	eip712Key := flag.String("eip712-key", "", "Sign the EIP-712 attestation with the hex secp256k1 private key in this file (implies -eip712)")
+	eip712ChainID := flag.Int64("eip712-chain-id", 1, "Chain ID of the EIP-712 domain (0 to leave it out)")
+	eip712Contract := flag.String("eip712-contract", "", "Registry contract address of the EIP-712 domain")
+	bundle := flag.Bool("bundle", false, "Create a redacted .tar.zst support bundle")
+	preRun := flag.String("pre-run", "", "Shell command to run before benchmarking, e.g. to stop a node")
+	postRun := flag.String("post-run", "", "Shell command to run after the report is saved, e.g. to restart a node and upload results")
+	bundleMaxMB := flag.Int("bundle-max-size", 20, "Maximum uncompressed support bundle size in MB")
+	force := flag.Bool("force", false, "Run even if another ethbench run holds the test or output directory lock")
+	historyFile := flag.String("history", history.DefaultPath(), "File each completed run is appended to for \"ethbench history\" (empty disables)")
+	tagList := flag.String("tags", "", "Comma-separated key=value labels recorded with the run for \"ethbench query\", e.g. case=argon40,cooling=fan")
+	iface := flag.String("iface", "", "Network interface to detect and probe the gateway through instead of the default route's, e.g. eth1")
+	netEndpoints := flag.String("net-endpoints", strings.Join(system.DefaultPathEndpoints, ","), "Comma-separated hosts to measure path MTU, packet loss and jitter to (empty disables)")
+	peer := flag.String("peer", "", "Host of the other client in a split deployment, as host:port or URL, e.g. 192.168.1.20:8551, to measure the latency to")
+	clusterPeers := flag.String("cluster-peers", "", "Comma-separated host:port p2p addresses of the other nodes in a distributed validator cluster, to measure the latency to")
+	offline := flag.Bool("offline", false, "Skip the network probes, refuse hooks, and block DNS lookups and the default HTTP client")
 	showHelp := flag.Bool("help", false, "Show help message")
 
 	flag.Parse()
@@ -48,6 +142,46 @@ func main() {
 		return
 	}
 
+	// Fill in flags not given on the command line from the config file
+	var fileConfig *benchmark.FileConfig
+	if *configFile != "" {
+		if fileConfig, err = benchmark.LoadConfigFile(*configFile); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(exitFatal)
+		}
+		explicit := make(map[string]bool)
+		flag.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+		for name, value := range fileConfig.Flags() {
+			if explicit[name] {
+				continue
+			}
+			if err := flag.Set(name, value); err != nil {
+				fmt.Printf("Error: invalid %s in config file: %v\n", name, err)
+				os.Exit(exitFatal)
+			}
+		}
+	}
+	if *offline {
+		if *preRun != "" || *postRun != "" {
+			fmt.Println("Error: -offline cannot be combined with -pre-run or -post-run, whose commands could reach the network")
+			os.Exit(exitFatal)
+		}
+		enableOffline()
+	}
+	tags, err := report.ParseTags(*tagList)
+	if err != nil {
+		fmt.Printf("Error: -tags: %v\n", err)
+		os.Exit(exitFatal)
+	}
+	switch *format {
+	case "text", "html", "markdown", "csv":
+	case "md":
+		*format = "markdown"
+	default:
+		fmt.Printf("Error: unknown format %q (want text, html, markdown or csv)\n", *format)
+		os.Exit(exitFatal)
+	}
+
 	// Print banner
 	fmt.Printf(banner, version)
 	fmt.Println()
@@ -65,66 +199,683 @@ func main() {
 	fmt.Printf("  RAM: %d MB\n", sysInfo.RAMTotalMB)
 	fmt.Printf("  Storage: %s\n", sysInfo.DiskModel)
 	fmt.Printf("  Serial: %s\n", sysInfo.SerialNumber)
+	if *iface != "" {
+		if sysInfo.Network, err = system.NetworkLinkOf(*iface); err != nil {
+			fmt.Printf("Error: -iface: %v\n", err)
+			os.Exit(exitFatal)
+		}
+	}
+	if link := sysInfo.Network; link != nil {
+		fmt.Printf("  Network: %s (%s)\n", link.Interface, link.Type)
+		// Jitter to the gateway shows what the link does to block and
+		// attestation propagation; -offline sends nothing
+		if link.Gateway != "" && !*offline {
+			link.Probe = system.ProbeGateway(link.Gateway, *iface, 20, 100*time.Millisecond)
+		}
+		// Loss beyond the gateway and a reduced path MTU hurt gossip just as
+		// much, and the LAN cannot show them
+		if *netEndpoints != "" && !*offline {
+			for _, endpoint := range strings.Split(*netEndpoints, ",") {
+				if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+					fmt.Printf("  Probing path to %s...\n", endpoint)
+					link.Paths = append(link.Paths, link.ProbePath(endpoint, 50, 40*time.Millisecond))
+				}
+			}
+		}
+	}
+	// With a split deployment every engine API call crosses to the peer
+	if *peer != "" && !*offline {
+		fmt.Printf("  Probing peer %s...\n", *peer)
+		sysInfo.Peer = system.ProbePeer(*peer, 20, 100*time.Millisecond)
+	}
+	// Distributed validator duties wait on consensus between the operators
+	if *clusterPeers != "" && !*offline {
+		for _, p := range strings.Split(*clusterPeers, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				fmt.Printf("  Probing cluster peer %s...\n", p)
+				sysInfo.ClusterPeers = append(sysInfo.ClusterPeers, *system.ProbePeer(p, 20, 100*time.Millisecond))
+			}
+		}
+	}
 	fmt.Println()
 
 	// Check prerequisites
 	fmt.Printf("Testing write access to %s...\n", *testDir)
 	if err := system.CheckPrerequisites(*testDir); err != nil {
 		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitFatal)
 	}
 	fmt.Println("  OK")
+
+	// Hold the directories for the whole run; another run's orphan cleanup
+	// would delete this run's test files
+	var lock *runLock
+	if !*force {
+		if lock, err = acquireRunLock(*testDir, *outputDir); err != nil {
+			fmt.Printf("Error: %v (use -force to run anyway)\n", err)
+			os.Exit(exitFatal)
+		}
+		defer lock.release()
+		// Without the lock the checkpoint may belong to a run still going
+		if path, err := report.RecoverPartial(*outputDir); err != nil {
+			fmt.Printf("  Warning: Could not recover partial results: %v\n", err)
+		} else if path != "" {
+			fmt.Printf("  Recovered partial results of a run that did not finish to %s\n", path)
+		}
+		// Likewise the test files may belong to a run still going
+		if removed, err := disk.CleanOrphans(*testDir); err == nil && len(removed) > 0 {
+			fmt.Printf("  Removed %d leftover test file(s) from an interrupted run\n", len(removed))
+		}
+	}
+	if *copyDest != "" {
+		fmt.Printf("Testing write access to %s...\n", *copyDest)
+		if err := system.CheckPrerequisites(*copyDest); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(exitFatal)
+		}
+		fmt.Println("  OK")
+		if !*force {
+			disk.CleanOrphans(*copyDest)
+		}
+	}
+	if *replayBlocks != "" && *replayBlocks != replay.Builtin {
+		if _, err := os.Stat(*replayBlocks); err != nil {
+			fmt.Printf("Error: -replay: %v\n", err)
+			os.Exit(exitFatal)
+		}
+	}
 	fmt.Println()
 
+	// Apply Go runtime overrides before any benchmark runs
+	if *paranoid && *gomaxprocs == 0 {
+		*gomaxprocs = max(runtime.NumCPU(), benchmark.ParanoidMinProcs)
+	}
+	if err := benchmark.ApplyRuntimeOverrides(*gomaxprocs, *gogc); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitFatal)
+	}
+	sweep, err := benchmark.ParseGOGCSweep(*gogcSweep)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitFatal)
+	}
+	depths, err := benchmark.ParseQueueDepths(*queueDepths)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitFatal)
+	}
+	enabledPacks, err := benchmark.ParsePacks(*packs)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitFatal)
+	}
+	selection, err := benchmark.ParseSelection(*only, *skip)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitFatal)
+	}
+	var baselineProfile *baseline.Profile
+	if *profile != "" {
+		db, err := baseline.Load()
+		if err == nil {
+			baselineProfile, err = db.Find(*profile)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(exitFatal)
+		}
+	}
+	scoringProfile, err := report.ResolveScoringProfile(*scoring)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitFatal)
+	}
+	var comparisonProfile *reference.Profile
+	refDB, err := reference.Load()
+	if err == nil {
+		comparisonProfile, err = refDB.Find(*compareTo)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitFatal)
+	}
+	goRuntime := benchmark.CaptureRuntime()
+
 	// Configure benchmark
 	var config *benchmark.Config
-	if *quick {
+	if *paranoid {
+		config = benchmark.ParanoidConfig()
+		fmt.Printf("Paranoid mode: shortened benchmarks with invariant checks, GOMAXPROCS %d\n", runtime.GOMAXPROCS(0))
+		if !benchmark.RaceDetectorEnabled() {
+			fmt.Println("  Built without the race detector, so data races go unnoticed; build with \"make build-race\"")
+		}
+	} else if *quick {
 		config = benchmark.QuickConfig()
 		fmt.Println("Quick mode enabled - benchmark will take approximately 1 minute")
 	} else {
 		config = benchmark.DefaultConfig()
 		fmt.Println("Full benchmark mode - this will take approximately 3 minutes")
 	}
+	if fileConfig != nil {
+		if err := fileConfig.ApplyDurations(config); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(exitFatal)
+		}
+		fmt.Printf("Configuration loaded from %s\n", *configFile)
+	}
 	config.TestDir = *testDir
+	config.KeepTestFiles = *keepTestFiles
+	config.MemoryPressure = *memoryPressure
+	config.MPTDisk = *mptDisk
+	config.MaxWriteBytes = benchmark.DefaultMaxWrite(sysInfo.DiskType)
+	if *maxWrite != "" {
+		if config.MaxWriteBytes, err = benchmark.ParseByteSize(*maxWrite); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(exitFatal)
+		}
+	}
+	if *memoryGOGC != "" {
+		if config.MemoryGOGC, err = benchmark.ParseGOGC(*memoryGOGC); err != nil || config.MemoryGOGC == 0 {
+			fmt.Printf("Error: invalid -memory-gogc value %q: must be a positive integer or \"off\"\n", *memoryGOGC)
+			os.Exit(exitFatal)
+		}
+	}
+	if *memoryBallast != "" {
+		if config.MemoryBallast, err = benchmark.ParseByteSize(*memoryBallast); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(exitFatal)
+		}
+	}
+	if *randomSize != "" {
+		if config.RandomFileSize, err = benchmark.ParseByteSize(*randomSize); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(exitFatal)
+		}
+	} else {
+		_, free, _ := system.DiskCapacity(*testDir)
+		config.RandomFileSize = benchmark.DefaultRandomFileSize(sysInfo.RAMTotalMB, free)
+	}
+	if total, free, err := system.DiskCapacity(*testDir); err == nil {
+		fmt.Printf("Test volume: %.0f GB free of %.0f GB\n", float64(free)/(1<<30), float64(total)/(1<<30))
+	}
+	if config.MaxWriteBytes > 0 {
+		fmt.Printf("Disk write limit: %.1f GB\n", float64(config.MaxWriteBytes)/(1<<30))
+	}
+	// The random I/O file is shrunk to half the write budget left when it
+	// starts; below RAM the page cache can absorb its reads
+	randomFile, limit := config.RandomFileSize, "-random-size"
+	if config.MaxWriteBytes > 0 && config.MaxWriteBytes/2 < randomFile {
+		randomFile, limit = config.MaxWriteBytes/2, "-max-write"
+	}
+	if ram := int64(sysInfo.RAMTotalMB) << 20; randomFile < ram && selection.Includes("disk.random") {
+		fmt.Printf("Warning: the random I/O test file will be at most %.1f GB, less than the %.1f GB of RAM; raise %s for IOPS the page cache cannot inflate\n",
+			float64(randomFile)/(1<<30), float64(ram)/(1<<30), limit)
+	}
+	if *ioJobs < 1 {
+		fmt.Println("Error: -io-jobs must be at least 1")
+		os.Exit(exitFatal)
+	}
+	if *runs < 1 {
+		fmt.Println("Error: -runs must be at least 1")
+		os.Exit(exitFatal)
+	}
+	if *soak < 0 || (*soak > 0 && *runs > 1) {
+		fmt.Println("Error: -soak must be positive and cannot be combined with -runs")
+		os.Exit(exitFatal)
+	}
+	eip712Domain, err := report.NewEIP712Domain(*eip712ChainID, *eip712Contract)
+	if err != nil {
+		fmt.Printf("Error: EIP-712 domain: %v\n", err)
+		os.Exit(exitFatal)
+	}
+	// Read the key before benchmarking, so a wrong path does not cost a run
+	var eip712Signer *ecdsa.PrivateKey
+	if *eip712Key != "" {
+		if eip712Signer, err = report.LoadSigningKey(*eip712Key); err != nil {
+			fmt.Printf("Error: -eip712-key: %v\n", err)
+			os.Exit(exitFatal)
+		}
+		*eip712 = true
+	}
+	config.IOJobs = *ioJobs
+	config.DirectIO = *directIO
+	config.QueueDepths = depths
+	config.CopyDest = *copyDest
+	config.Replay = *replayBlocks
 	config.Verbose = *verbose
+	config.CPUWorkers = *cpuWorkers
+	config.AnomalySigma = *anomalySigma
+	if *paranoid {
+		config.AnomalySigma = 0
+	}
+	if *soak > 0 {
+		// Drift is the measurement: re-running deviating benchmarks would
+		// hide it, and the write limit would end the disk series early
+		config.AnomalySigma = 0
+		suite := "the selected benchmarks"
+		if *only == "" && *skip == "" {
+			selection, _ = benchmark.ParseSelection(benchmark.SoakSuite, "")
+			suite = benchmark.SoakSuite
+		}
+		fmt.Printf("Soak mode: looping %s for %s", suite, *soak)
+		if *maxWrite == "" {
+			config.MaxWriteBytes = 0
+			fmt.Print(" without a disk write limit (set -max-write to cap it)")
+		}
+		fmt.Println()
+	}
+	config.ApplyTuning = *applyTuning
+	config.GOGCSweep = sweep
+	config.Packs = enabledPacks
+
+	// Match embedded reference results for the detected hardware
+	config.Reference = refDB.Match(sysInfo)
+	if config.Reference != nil {
+		fmt.Printf("Reference profile: %s\n", config.Reference.Description)
+	}
+	if baselineProfile != nil {
+		fmt.Printf("Baseline profile: %s (%s)\n", baselineProfile.Name, baselineProfile.Description)
+	}
+
+	// The post-run hook also runs when the pre-run hook fails, so a node it
+	// partly stopped is restarted
+	if *preRun != "" {
+		fmt.Println()
+		if err := runHook("pre-run", *preRun, map[string]string{"ETHBENCH_TEST_DIR": *testDir, "ETHBENCH_OUTPUT_DIR": *outputDir}); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			if *postRun != "" {
+				if err := runHook("post-run", *postRun, postRunEnv(nil, "", *outputDir, exitFatal)); err != nil {
+					fmt.Printf("Warning: %v\n", err)
+				}
+			}
+			os.Exit(exitFatal)
+		}
+	}
+
+	// Clock every core at full speed before anything is measured, so the
+	// sleep guard does not count it as a governor change mid-run. It is set
+	// after the last check that can stop the run, and put back once the
+	// benchmarks end, also when they are interrupted.
+	restoreGovernors := sync.OnceFunc(func() {
+		if err := system.RestoreGovernors(sysInfo.CPUGovernorChanges); err != nil {
+			fmt.Printf("Warning: Could not restore the CPU governor: %v\n", err)
+		}
+	})
+	if *setPerformance {
+		fmt.Println()
+		fmt.Println("Setting the CPU governor to performance...")
+		sysInfo.CPUGovernorChanges = system.SetPerformanceGovernor()
+		for _, g := range sysInfo.CPUGovernorChanges {
+			if g.Applied {
+				fmt.Printf("  cpu%s: %s -> performance\n", g.CPUs, g.From)
+			} else {
+				fmt.Printf("  Warning: cpu%s left on %s: %s\n", g.CPUs, g.From, g.Error)
+			}
+		}
+		if len(sysInfo.CPUGovernorChanges) == 0 {
+			fmt.Println("  Already set (or no cpufreq support)")
+		}
+		sysInfo.RefreshCPUFrequencies()
+	}
 
 	fmt.Println()
 	fmt.Println("Starting benchmarks...")
 	fmt.Println()
 
+	// Ctrl-C or SIGTERM stops the run cleanly: the running benchmark returns
+	// early, its test files are removed and a partial report is still saved.
+	// A second signal terminates immediately, after restoring the governors.
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		fmt.Println("\nInterrupted, cleaning up test files (press Ctrl-C again to force quit)...")
+		cancel()
+		<-signals
+		restoreGovernors()
+		os.Exit(exitInterrupted)
+	}()
+
 	// Create and run benchmark
 	runner := benchmark.NewRunner(config)
-	results := runner.RunAll()
+	checkpoint := report.NewCheckpoint(*outputDir, version, sysInfo, scoringProfile)
+	var checkpointFailed bool
+	runner.Checkpoint = func(results *types.Results) {
+		if err := checkpoint.Save(results, runner.Duration()); err != nil && !checkpointFailed {
+			fmt.Printf("    Warning: Could not save partial results: %v\n", err)
+			checkpointFailed = true
+		}
+	}
+	var completed []*types.Results
+	var results *types.Results
+	var soakSamples []report.SoakSample
+	soakStart := time.Now()
+	for i := 1; *soak > 0 || i <= *runs; i++ {
+		start := time.Now()
+		left := soakStart.Add(*soak).Sub(start)
+		if *soak > 0 && i > 1 && left <= 0 {
+			break
+		}
+		if i > 1 {
+			fmt.Println()
+		}
+		switch {
+		case *soak > 0:
+			fmt.Printf("Soak iteration %d, %s left\n\n", i, left.Round(time.Second))
+		case *runs > 1:
+			fmt.Printf("Run %d/%d\n\n", i, *runs)
+		}
+		results = runner.RunAll(ctx, selection)
+		if results.Interrupted {
+			break
+		}
+		completed = append(completed, results)
+		if *soak > 0 {
+			soakSamples = append(soakSamples, report.NewSoakSample(i, soakStart, start, results, scoringProfile))
+		}
+		// The idle baseline is measured once, before the first run
+		config.IdleDuration = 0
+	}
+	if results.Idle == nil && len(completed) > 0 {
+		results.Idle = completed[0].Idle
+	}
+	restoreGovernors()
+	if results.Interrupted && !*force {
+		disk.CleanOrphans(*testDir)
+		if *copyDest != "" {
+			disk.CleanOrphans(*copyDest)
+		}
+	}
 
 	// Generate report
 	fmt.Println()
 	fmt.Println("Generating report...")
 
-	benchReport := report.NewReport(version, sysInfo, results, runner.Duration())
+	benchReport := report.NewReport(version, sysInfo, results, runner.Duration(), scoringProfile)
+	benchReport.Metadata.Offline = *offline
+	if len(tags) > 0 {
+		benchReport.Metadata.Tags = tags
+	}
+	benchReport.Runtime = &goRuntime
+	if len(completed) > 1 && *soak == 0 {
+		benchReport.RunStatistics = report.AggregateRuns(completed, scoringProfile)
+	}
+	if len(soakSamples) > 0 {
+		benchReport.ApplySoak(report.AnalyzeSoak(soakSamples, time.Since(soakStart)))
+	}
+	benchReport.PlaceInClass(config.Reference)
+	benchReport.CompareTo(comparisonProfile)
+	benchReport.CheckBaseline(baselineProfile)
+	if total, free, err := system.DiskCapacity(*testDir); err == nil {
+		benchReport.AssessStorage(total, free)
+	}
+
+	// Merge external sensor readings onto the benchmark timeline
+	if *annotate != "" {
+		annotations, err := report.LoadAnnotations(*annotate, results.Timeline)
+		if err != nil {
+			fmt.Printf("Warning: Could not load annotations: %v\n", err)
+		} else {
+			benchReport.Annotations = annotations
+		}
+	}
 
 	// Print text report to terminal
 	textOutput := report.FormatText(benchReport)
 	fmt.Print(textOutput)
 
 	// Save JSON report
-	jsonPath, err := report.SaveJSON(benchReport, *outputDir)
+	var jsonPath string
+	if *canonical || *deterministic {
+		jsonPath, err = report.SaveCanonicalJSON(benchReport, *outputDir, *deterministic)
+	} else {
+		jsonPath, err = report.SaveJSON(benchReport, *outputDir)
+	}
 	if err != nil {
 		fmt.Printf("Warning: Could not save JSON report: %v\n", err)
 	} else {
 		fmt.Printf("\nJSON report saved to: %s\n", jsonPath)
+		checkpoint.Remove()
+	}
+	// Raw samples saved next to the report also go into the support bundle
+	var sampleFiles []string
+	if *annotate != "" {
+		sampleFiles = append(sampleFiles, *annotate)
+	}
+	if benchReport.Soak != nil {
+		soakPath, err := report.SaveSoak(benchReport.Soak, *outputDir)
+		if err != nil {
+			fmt.Printf("Warning: Could not save soak time series: %v\n", err)
+		} else {
+			fmt.Printf("Soak time series saved to: %s\n", soakPath)
+			sampleFiles = append(sampleFiles, soakPath)
+		}
 	}
+
+	// Append to the local run history; interrupted runs would show as drops,
+	// and shortened paranoid runs as slower hardware
+	if *historyFile != "" && !results.Interrupted && !*paranoid {
+		if err := history.Append(*historyFile, history.NewEntry(benchReport, *testDir, *quick)); err != nil {
+			fmt.Printf("Warning: Could not append to run history: %v\n", err)
+		}
+	}
+
+	// Save HTML, Markdown or CSV report
+	switch *format {
+	case "html":
+		htmlPath, err := report.SaveHTML(benchReport, *outputDir)
+		if err != nil {
+			fmt.Printf("Warning: Could not save HTML report: %v\n", err)
+		} else {
+			fmt.Printf("HTML report saved to: %s\n", htmlPath)
+		}
+	case "markdown":
+		mdPath, err := report.SaveMarkdown(benchReport, *outputDir)
+		if err != nil {
+			fmt.Printf("Warning: Could not save Markdown report: %v\n", err)
+		} else {
+			fmt.Printf("Markdown report saved to: %s\n", mdPath)
+		}
+	case "csv":
+		csvPath, err := report.SaveCSV(benchReport, *outputDir)
+		if err != nil {
+			fmt.Printf("Warning: Could not save CSV report: %v\n", err)
+		} else {
+			fmt.Printf("CSV report saved to: %s\n", csvPath)
+		}
+	}
+
+	if *hwProfile {
+		profilePath, err := report.SaveHardwareProfile(benchReport, *outputDir)
+		if err != nil {
+			fmt.Printf("Warning: Could not save hardware profile: %v\n", err)
+		} else {
+			fmt.Printf("Hardware profile saved to: %s\n", profilePath)
+		}
+	}
+
+	if *operatorReadiness {
+		readinessPath, err := report.SaveOperatorReadiness(benchReport, *outputDir)
+		if err != nil {
+			fmt.Printf("Warning: Could not save operator readiness: %v\n", err)
+		} else {
+			fmt.Printf("Operator readiness saved to: %s\n", readinessPath)
+		}
+	}
+
+	if *eip712 {
+		attestation, err := report.NewEIP712Attestation(benchReport, eip712Domain, eip712Signer)
+		if err == nil {
+			var attestationPath string
+			if attestationPath, err = report.SaveEIP712Attestation(attestation, *outputDir); err == nil {
+				fmt.Printf("EIP-712 attestation saved to: %s\n", attestationPath)
+				if attestation.Signer != "" {
+					fmt.Printf("  Signed by %s\n", attestation.Signer)
+				}
+			}
+		}
+		if err != nil {
+			fmt.Printf("Warning: Could not save EIP-712 attestation: %v\n", err)
+		}
+	}
+
+	// Save support bundle
+	if *bundle {
+		bundlePath, err := report.SaveBundle(benchReport, *outputDir, report.BundleOptions{
+			MaxSizeBytes: int64(*bundleMaxMB) * 1024 * 1024,
+			Log:          runner.Log(),
+			Dmesg:        system.DmesgExcerpt(200),
+			Files:        sampleFiles,
+		})
+		if err != nil {
+			fmt.Printf("Warning: Could not save support bundle: %v\n", err)
+		} else {
+			fmt.Printf("Support bundle saved to: %s\n", bundlePath)
+		}
+	}
+
+	// Report partial failure so automation can tell incomplete runs apart
+	exitCode := 0
+	if results.Interrupted {
+		fmt.Println("\nBenchmark run was interrupted; results are incomplete.")
+		exitCode = exitInterrupted
+	} else if b := benchReport.Baseline; b != nil && !b.Passed {
+		fmt.Printf("\nMachine does not meet the %s baseline (%d of %d checks failed).\n", b.Profile, len(b.Failed()), len(b.Checks))
+		exitCode = exitBaselineFailed
+	} else if p := results.Paranoid; p != nil && len(p.Violations) > 0 {
+		fmt.Printf("\n%d invariant violation(s) in %d checked benchmark(s).\n", len(p.Violations), p.Benchmarks)
+		exitCode = exitInvariantFailed
+	} else if len(results.Errors) > 0 {
+		fmt.Printf("\n%d benchmark(s) failed; results are incomplete.\n", len(results.Errors))
+		exitCode = exitPartialFailure
+	}
+
+	// A failing post-run hook is reported but does not change the exit code,
+	// which describes the benchmark run
+	if *postRun != "" {
+		fmt.Println()
+		if err := runHook("post-run", *postRun, postRunEnv(benchReport, jsonPath, *outputDir, exitCode)); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+	}
+	// os.Exit skips the deferred release; keep the lock reachable until
+	// here so its files are not finalized and unlocked mid-run
+	runtime.KeepAlive(lock)
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+}
+
+// runCompare prints the metric deltas between two saved JSON reports
+func runCompare(args []string) int {
+	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	scoring := fs.String("scoring", "", "Re-score both reports with this scoring profile, e.g. v1-2024")
+	if err := fs.Parse(args); err != nil {
+		return exitFatal
+	}
+	if fs.NArg() != 2 {
+		fmt.Println("Usage: ethbench compare [-scoring profile] old.json new.json")
+		return exitFatal
+	}
+	var scoringProfile *report.ScoringProfile
+	if *scoring != "" {
+		var err error
+		if scoringProfile, err = report.ResolveScoringProfile(*scoring); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitFatal
+		}
+	}
+	comparison, err := report.CompareReports(fs.Arg(0), fs.Arg(1), scoringProfile)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitFatal
+	}
+	fmt.Print(report.FormatComparison(comparison))
+	return 0
 }
 
+// Exit codes
+const (
+	exitFatal           = 1   // Setup failed, no benchmarks ran
+	exitPartialFailure  = 2   // Some benchmarks failed, report is incomplete
+	exitBaselineFailed  = 3   // Machine does not meet the -profile baseline
+	exitInvariantFailed = 4   // Internal invariant checks failed (-paranoid)
+	exitInterrupted     = 130 // Interrupted by SIGINT or SIGTERM, partial report saved
+)
+
 func printHelp() {
 	fmt.Printf(banner, version)
 	fmt.Println()
 	fmt.Println("Usage: ethbench [options]")
+	fmt.Println("       ethbench compare [-scoring profile] old.json new.json")
+	fmt.Println("       ethbench sysinfo [-json] [-iface name]")
+	fmt.Println("       ethbench history [-file path] [-last 20] [-metric summary.]")
+	fmt.Println("       ethbench query [-file path] [-tag k=v,...] [-host name] [-since date] [-metric summary.]")
+	fmt.Println("       ethbench serve [-listen :9437] [-interval 24h] [-test-dir dir] [-quick=false]")
+	fmt.Println("       ethbench trial -client nimbus [-duration 10m] [-data-dir dir] [-report ethbench.json]")
+	fmt.Println("       ethbench observe -pid N [-duration 10m] [-report ethbench.json]")
+	fmt.Println("       ethbench attestations -validators 1,2,3 [-beacon-url URL] [-epochs 10] [-report ethbench.json]")
 	fmt.Println()
 	fmt.Println("Options:")
+	fmt.Println("  -config file        Load settings from a JSON or YAML file (flags override it)")
 	fmt.Println("  -test-dir string    Directory for disk I/O tests (default: executable directory)")
 	fmt.Println("  -output string      Directory for JSON output file (default: executable directory)")
+	fmt.Println("  -format name        Also save an html (charts), markdown (GitHub tables) or csv (one row) report (default: text)")
 	fmt.Println("  -quick              Quick mode: ~1 minute benchmark instead of 3 minutes")
+	fmt.Println("  -runs N             Run the suite N times; report mean, median, CV and 95% CI per metric")
+	fmt.Println("  -soak duration      Loop a reduced suite for this long, e.g. 2h; report drift, throttling, errors and stability")
+	fmt.Println("  -paranoid           Developer mode: shortened run checking benchmarks for goroutine leaks and slow cancellation")
 	fmt.Println("  -verbose            Show detailed progress during benchmarks")
+	fmt.Println("  -keep-testfiles     Keep prepared disk test files for reuse by the next run")
+	fmt.Println("  -max-write size     Maximum bytes written by disk benchmarks, e.g. 10G (default: 1G on SD cards, 10G USB/SATA, 64G NVMe; 0 = unlimited)")
+	fmt.Println("  -random-size size   Random I/O working set, e.g. 16G (default: max(4×RAM, 8G), capped at half the free space)")
+	fmt.Println("  -copy-dest dir      Measure copy speed to a second disk and estimate datadir backup and migration times")
+	fmt.Println("  -replay file        Import a geth block export (from block 1) and measure Mgas/sec; \"builtin\" uses a generated segment")
+	fmt.Println("  -io-jobs N          Also run the random I/O phases from N goroutines (default: 1, QD1 only)")
+	fmt.Println("  -queue-depths list  Also measure random read IOPS at these queue depths, e.g. 1,8,32")
+	fmt.Println("  -direct-io          Also run sequential, random and batch I/O with O_DIRECT and report both numbers")
+	fmt.Println("  -cpu-workers N      Goroutines for the multi-core CPU benchmark (default: number of CPUs)")
+	fmt.Println("  -gomaxprocs N       Override GOMAXPROCS for this run (default: number of CPUs)")
+	fmt.Println("  -gogc N|off         Override GOGC for this run")
+	fmt.Println("  -gogc-sweep list    Re-run memory benchmarks at each GOGC value, e.g. 50,100,200")
+	fmt.Println("  -memory-gogc N|off  GOGC for the memory benchmarks only (default: the run-wide setting)")
+	fmt.Println("  -memory-ballast N   Hold an untouched heap ballast during the memory benchmarks, e.g. 1G")
+	fmt.Println("  -memory-pressure    Allocate toward the RAM limit and measure swap-out and swap-in speed")
+	fmt.Println("  -mpt-disk           Also run the go-ethereum trie benchmark on a Pebble database in the test directory")
+	fmt.Println("  -only list          Run only these benchmarks or categories, e.g. cpu,disk.random")
+	fmt.Println("  -skip list          Skip these benchmarks or categories, e.g. memory")
+	fmt.Println("  -packs list         Enable fork benchmark packs: pectra, fusaka or all (scored separately)")
+	fmt.Println("  -profile name       Exit with code 3 unless the machine meets a baseline: geth-mainnet, nimbus-only, holesky-testnet")
+	fmt.Println("  -reference name     Compare metrics with a device: rpi5-nvme (default), rpi5-sd, rpi4-usb-ssd, rock5b-nvme, intel-nuc-nvme, x86-vps")
+	fmt.Println("  -scoring name       Scoring profile: v2-2026 (default), v1-2024, solo-staker, archive-node, light-infra,")
+	fmt.Println("                      split-consensus, split-execution, dvt-operator or a JSON file")
+	fmt.Println("  -anomaly-sigma N    Re-run benchmarks deviating more than N sigma from the hardware reference (default: 3, 0 disables)")
+	fmt.Println("  -set-performance-governor")
+	fmt.Println("                      Switch every CPU to the performance governor while benchmarking (root)")
+	fmt.Println("  -apply-tuning       Spread disk interrupts concentrated on one CPU (root) and re-run the affected benchmark")
+	fmt.Println("  -annotate file.csv  Merge external sensor readings (timestamp,sensor,...) into the report")
+	fmt.Println("  -canonical          Save JSON with sorted keys and fixed float precision")
+	fmt.Println("  -deterministic      Canonical JSON without timestamps, saved as ethbench-report.json")
+	fmt.Println("  -hardware-profile   Also save a compact hardware profile JSON for client auto-tuning")
+	fmt.Println("  -operator-readiness Also save an operator readiness checklist JSON for DVT cluster applications")
+	fmt.Println("  -eip712             Also save the summary and a hardware hash as EIP-712 typed data for a wallet to sign")
+	fmt.Println("  -eip712-key file    Sign it with the hex secp256k1 private key in file (implies -eip712)")
+	fmt.Println("  -eip712-chain-id N  Chain ID of the EIP-712 domain (default: 1; 0 leaves it out)")
+	fmt.Println("  -eip712-contract address")
+	fmt.Println("                      Registry contract verifying the signature, added to the EIP-712 domain")
+	fmt.Println("  -pre-run command    Run a shell command before benchmarking, e.g. to stop a node")
+	fmt.Println("  -post-run command   Run a shell command afterwards with ETHBENCH_REPORT, ETHBENCH_SUMMARY etc. set")
+	fmt.Println("  -bundle             Create a redacted .tar.zst support bundle for sharing")
+	fmt.Println("  -bundle-max-size N  Maximum uncompressed bundle size in MB (default: 20)")
+	fmt.Println("  -force              Run even if another ethbench run holds the test or output directory")
+	fmt.Println("  -history file       Append each completed run to this file (default: ~/.ethbench/history.jsonl; \"\" disables)")
+	fmt.Println("  -tags list          Label the run in the history, e.g. case=argon40,cooling=fan")
+	fmt.Println("  -iface name         Detect and probe the network through this interface instead of the default route's")
+	fmt.Println("  -net-endpoints list Hosts to measure path MTU, loss and jitter to (default: 1.1.1.1,8.8.8.8; \"\" disables)")
+	fmt.Println("  -peer host:port     Measure the latency to the other client of a split deployment, e.g. 192.168.1.20:8551")
+	fmt.Println("  -cluster-peers list Measure the latency to the other nodes of a DVT cluster, e.g. 10.0.0.2:3610,10.0.0.3:3610")
+	fmt.Println("  -offline            Skip network probes and hooks, block DNS and the default HTTP client")
 	fmt.Println("  -help               Show this help message")
 	fmt.Println()
 	fmt.Println("Examples:")
@@ -132,6 +883,34 @@ func printHelp() {
 	fmt.Println("  ethbench -test-dir /mnt/nvme    Use specific directory for disk tests")
 	fmt.Println("  ethbench -quick                 Run quick 1-minute benchmark")
 	fmt.Println("  ethbench -output /home/user     Save JSON to specific directory")
+	fmt.Println("  ethbench -format html           Save an HTML report to open in a browser")
+	fmt.Println("  ethbench -format markdown       Save a Markdown report for GitHub issues")
+	fmt.Println("  ethbench -format csv            Save a one-row CSV to collect results in a spreadsheet")
+	fmt.Println("  ethbench -runs 5                Repeat the suite 5 times and flag noisy metrics")
+	fmt.Println("  ethbench -soak 2h               Find throttling and write cliffs that short runs hide")
+	fmt.Println("  ethbench -profile geth-mainnet  Gate a node install on the machine meeting the Geth mainnet baseline")
+	fmt.Println("  ethbench -replay blocks.rlp     Also time importing a geth block export through go-ethereum")
+	fmt.Println("  ethbench -bundle                Create support bundle for help channels")
+	fmt.Println("  ethbench compare a.json b.json  Show per-metric changes between two reports")
+	fmt.Println("  ethbench sysinfo -json          Print the hardware inventory as JSON without benchmarking")
+	fmt.Println("  ethbench history -metric disk.  Show past runs and how the disk metrics changed over time")
+	fmt.Println("  ethbench query -tag case=argon40 -metric disk.random.read_iops")
+	fmt.Println("                                  Average random read IOPS over the runs tagged case=argon40")
+	fmt.Println("  ethbench serve -interval 12h    Benchmark twice a day and export metrics for Prometheus")
+	fmt.Println("  ethbench trial -client nimbus -report ethbench.json")
+	fmt.Println("                                  Checkpoint sync Nimbus in Docker and fold it into the verdict")
+	fmt.Println("  ethbench observe -pid $(pidof geth) -report ethbench.json")
+	fmt.Println("                                  Show the CPU, RAM, disk and file headroom a running node leaves")
+	fmt.Println("  ethbench attestations -validators 1234 -report ethbench.json")
+	fmt.Println("                                  Match missed and late attestations with hardware bottlenecks")
+	fmt.Println()
+	fmt.Println("Exit Codes:")
+	fmt.Println("  0  All benchmarks completed")
+	fmt.Println("  1  Setup failed, no benchmarks ran")
+	fmt.Println("  2  Some benchmarks failed, report is incomplete (see \"errors\" in JSON)")
+	fmt.Println("  3  Machine does not meet the -profile baseline")
+	fmt.Println("  4  Internal invariant checks failed (-paranoid)")
+	fmt.Println("  130 Interrupted by Ctrl-C or SIGTERM, partial report saved")
 	fmt.Println()
 	fmt.Println("System Requirements:")
 	fmt.Println("  - sysbench (sudo apt install sysbench)")
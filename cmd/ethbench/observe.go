@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/vBenchmark/internal/report"
+	"github.com/vBenchmark/internal/system"
+	"github.com/vBenchmark/internal/types"
+)
+
+// runObserve samples an already-running client and reports how much of the
+// machine's capacity it leaves unused
+func runObserve(args []string) int {
+	fs := flag.NewFlagSet("observe", flag.ContinueOnError)
+	pid := fs.Int("pid", 0, "Process ID of the running client, e.g. $(pidof geth)")
+	duration := fs.Duration("duration", 10*time.Minute, "How long to observe the process")
+	interval := fs.Duration("interval", 5*time.Second, "Time between samples")
+	reportPath := fs.String("report", "", "Benchmark report (JSON) of this machine for disk throughput capacity")
+	if err := fs.Parse(args); err != nil {
+		return exitFatal
+	}
+	if *pid <= 0 {
+		fmt.Println("Usage: ethbench observe -pid N [-duration 10m] [-interval 5s] [-report ethbench.json]")
+		return exitFatal
+	}
+	if *interval <= 0 || *interval > *duration {
+		fmt.Println("Error: -interval must be positive and no longer than -duration")
+		return exitFatal
+	}
+
+	// A report describes the machine as benchmarked; without one only CPU
+	// and RAM capacity are known
+	var sysInfo *system.Info
+	var disk *types.DiskResults
+	if *reportPath != "" {
+		benchReport, err := report.LoadReport(*reportPath)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitFatal
+		}
+		sysInfo, disk = benchReport.System, &benchReport.Disk
+	} else {
+		var err error
+		if sysInfo, err = system.Detect(); err != nil {
+			fmt.Printf("Warning: Could not detect all system info: %v\n", err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("Observing pid %d for %s (Ctrl-C to stop early)...\n\n", *pid, *duration)
+	usage, err := system.ObserveProcess(ctx, *pid, *duration, *interval)
+	if err != nil && usage.Samples == 0 {
+		fmt.Printf("Error: %v\n", err)
+		return exitFatal
+	}
+	if err != nil {
+		fmt.Printf("Warning: %v; reporting %d samples\n\n", err, usage.Samples)
+	}
+	if usage.Samples == 0 {
+		fmt.Println("Error: no samples taken")
+		return exitFatal
+	}
+	fmt.Print(report.FormatHeadroom(report.ComputeHeadroom(usage, sysInfo, disk)))
+	return 0
+}
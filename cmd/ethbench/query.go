@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/vBenchmark/internal/history"
+	"github.com/vBenchmark/internal/report"
+)
+
+// runQuery selects runs from the local history by tag, host, disk, suite and
+// date and prints the mean, range and spread of their metrics. It only reads
+// the history.
+func runQuery(args []string) int {
+	flags := flag.NewFlagSet("query", flag.ContinueOnError)
+	file := flags.String("file", history.DefaultPath(), "History file to read")
+	tagList := flags.String("tag", "", "Comma-separated key=value tags the runs must all have, e.g. case=argon40")
+	host := flags.String("host", "", "Hostname or hardware fingerprint of the runs")
+	diskType := flags.String("disk", "", "Disk type of the runs, e.g. nvme, ssd or sd")
+	suite := flags.String("suite", "", "Suite length of the runs: quick or full (empty matches both)")
+	scoring := flags.String("scoring", "", "Scoring profile of the runs")
+	since := flags.String("since", "", "Only runs on or after this date, e.g. 2026-01-31")
+	metric := flags.String("metric", "summary.", "Aggregate the metrics starting with this prefix, e.g. disk.random.read_iops (empty aggregates all)")
+	last := flags.Int("last", 20, "Number of most recent matching runs to list (0 lists all)")
+	if err := flags.Parse(args); err != nil {
+		return exitFatal
+	}
+	if *file == "" {
+		fmt.Println("Error: no home directory for the default history; pass -file")
+		return exitFatal
+	}
+
+	filter := history.Filter{Host: *host, DiskType: *diskType, ScoringProfile: *scoring}
+	var err error
+	if filter.Tags, err = report.ParseTags(*tagList); err != nil {
+		fmt.Printf("Error: -tag: %v\n", err)
+		return exitFatal
+	}
+	switch *suite {
+	case "", "quick", "full":
+		filter.Suite = *suite
+	default:
+		fmt.Printf("Error: unknown suite %q (want quick or full)\n", *suite)
+		return exitFatal
+	}
+	if *since != "" {
+		if filter.Since, err = time.ParseInLocation("2006-01-02", *since, time.Local); err != nil {
+			fmt.Printf("Error: invalid -since %q: want a date like 2026-01-31\n", *since)
+			return exitFatal
+		}
+	}
+
+	entries, skipped, err := history.Load(*file)
+	if errors.Is(err, fs.ErrNotExist) {
+		fmt.Printf("No runs recorded in %s yet; every completed benchmark run is added\n", *file)
+		return 0
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitFatal
+	}
+	if skipped > 0 {
+		fmt.Printf("Warning: skipped %d unreadable line(s) in %s\n", skipped, *file)
+	}
+	fmt.Print(history.FormatQuery(*file, len(entries), history.Select(entries, filter), *last, *metric))
+	return 0
+}
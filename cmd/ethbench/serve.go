@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/vBenchmark/internal/benchmark"
+	"github.com/vBenchmark/internal/disk"
+	"github.com/vBenchmark/internal/history"
+	"github.com/vBenchmark/internal/reference"
+	"github.com/vBenchmark/internal/report"
+	"github.com/vBenchmark/internal/system"
+)
+
+// minServeInterval keeps periodic runs from wearing out the storage they measure
+const minServeInterval = 10 * time.Minute
+
+// exporter serves the metrics of the last completed run
+type exporter struct {
+	mu       sync.Mutex
+	latest   string // Last completed run in Prometheus text format
+	runs     int
+	failures int // Runs with at least one failed benchmark
+	running  bool
+}
+
+// ServeHTTP writes the exporter's own counters followed by the last run's metrics
+func (e *exporter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	running := 0
+	if e.running {
+		running = 1
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	fmt.Fprintf(w, "# HELP ethbench_runs_total Completed benchmark runs since the exporter started\n")
+	fmt.Fprintf(w, "# TYPE ethbench_runs_total counter\nethbench_runs_total %d\n", e.runs)
+	fmt.Fprintf(w, "# HELP ethbench_failed_runs_total Completed runs in which at least one benchmark failed\n")
+	fmt.Fprintf(w, "# TYPE ethbench_failed_runs_total counter\nethbench_failed_runs_total %d\n", e.failures)
+	fmt.Fprintf(w, "# HELP ethbench_run_in_progress Whether a benchmark run is in progress\n")
+	fmt.Fprintf(w, "# TYPE ethbench_run_in_progress gauge\nethbench_run_in_progress %d\n", running)
+	fmt.Fprint(w, e.latest)
+}
+
+// setRunning marks a run as started or finished
+func (e *exporter) setRunning(running bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.running = running
+}
+
+// publish replaces the exported metrics with those of a completed run
+func (e *exporter) publish(r *report.Report) {
+	metrics := report.FormatPrometheus(r)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.latest = metrics
+	e.runs++
+	if len(r.Errors) > 0 {
+		e.failures++
+	}
+}
+
+// runServe runs the benchmarks every interval and exposes the results of the
+// last completed run as Prometheus metrics until interrupted
+func runServe(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	listen := fs.String("listen", ":9437", "Address to serve Prometheus metrics on")
+	interval := fs.Duration("interval", 24*time.Hour, "Time between the start of two benchmark runs")
+	testDir := fs.String("test-dir", ".", "Directory for disk I/O tests")
+	outputDir := fs.String("output", "", "Also save each run's JSON report to this directory")
+	quick := fs.Bool("quick", true, "Run the quick suite (false runs the full 3-minute suite)")
+	maxWrite := fs.String("max-write", "", "Maximum bytes written by disk benchmarks per run, e.g. 2G (default depends on storage type)")
+	only := fs.String("only", "", "Comma-separated benchmarks or categories to run, e.g. cpu,disk.random")
+	skip := fs.String("skip", "", "Comma-separated benchmarks or categories to skip, e.g. disk.sequential")
+	keepTestFiles := fs.Bool("keep-testfiles", false, "Keep prepared disk test files between runs")
+	verbose := fs.Bool("verbose", false, "Show detailed progress")
+	scoring := fs.String("scoring", report.DefaultScoringProfile, "Scoring profile to compute scores with")
+	historyFile := fs.String("history", history.DefaultPath(), "File each completed run is appended to (empty disables)")
+	if err := fs.Parse(args); err != nil {
+		return exitFatal
+	}
+	if *interval < minServeInterval {
+		fmt.Printf("Error: -interval must be at least %s\n", minServeInterval)
+		return exitFatal
+	}
+	selection, err := benchmark.ParseSelection(*only, *skip)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitFatal
+	}
+	scoringProfile, err := report.ResolveScoringProfile(*scoring)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitFatal
+	}
+
+	sysInfo, err := system.Detect()
+	if err != nil {
+		fmt.Printf("Warning: Could not detect all system info: %v\n", err)
+	}
+	if err := system.CheckPrerequisites(*testDir); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitFatal
+	}
+	if lock, err := acquireRunLock(*testDir); err == nil {
+		disk.CleanOrphans(*testDir)
+		lock.release()
+	}
+	maxWriteBytes := benchmark.DefaultMaxWrite(sysInfo.DiskType)
+	if *maxWrite != "" {
+		if maxWriteBytes, err = benchmark.ParseByteSize(*maxWrite); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitFatal
+		}
+	}
+
+	// Each run gets a fresh config and runner, so its write budget and
+	// random I/O working set follow the current free space
+	newConfig := func() *benchmark.Config {
+		config := benchmark.DefaultConfig()
+		if *quick {
+			config = benchmark.QuickConfig()
+		}
+		config.TestDir = *testDir
+		config.KeepTestFiles = *keepTestFiles
+		config.Verbose = *verbose
+		config.IOJobs = 1
+		config.MaxWriteBytes = maxWriteBytes
+		_, free, _ := system.DiskCapacity(*testDir)
+		config.RandomFileSize = benchmark.DefaultRandomFileSize(sysInfo.RAMTotalMB, free)
+		if refDB, err := reference.Load(); err == nil {
+			config.Reference = refDB.Match(sysInfo)
+		}
+		return config
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	exp := &exporter{}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exp)
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprintln(w, "ethbench exporter: metrics at /metrics")
+	})
+	listener, err := net.Listen("tcp", *listen)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitFatal
+	}
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	serveErr := make(chan error, 1)
+	go func() {
+		if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+		}
+	}()
+	fmt.Printf("Serving Prometheus metrics on %s/metrics, benchmarking every %s\n", *listen, *interval)
+
+	for ctx.Err() == nil {
+		start := time.Now()
+		// A manual run in progress takes precedence; this interval is skipped
+		lock, err := acquireRunLock(*testDir)
+		if err != nil {
+			fmt.Printf("\n[%s] Skipping benchmark run: %v\n", start.Format(time.RFC3339), err)
+		} else {
+			config := newConfig()
+			runner := benchmark.NewRunner(config)
+			fmt.Printf("\n[%s] Starting benchmark run\n", start.Format(time.RFC3339))
+			exp.setRunning(true)
+			results := runner.RunAll(ctx, selection)
+			exp.setRunning(false)
+
+			if results.Interrupted {
+				disk.CleanOrphans(*testDir)
+			} else {
+				benchReport := report.NewReport(version, sysInfo, results, runner.Duration(), scoringProfile)
+				benchReport.PlaceInClass(config.Reference)
+				exp.publish(benchReport)
+				fmt.Printf("[%s] Run finished: overall score %d/100, %d benchmark(s) failed\n",
+					time.Now().Format(time.RFC3339), benchReport.Summary.TotalScore, len(results.Errors))
+				if *outputDir != "" {
+					if path, err := report.SaveJSON(benchReport, *outputDir); err != nil {
+						fmt.Printf("Warning: Could not save JSON report: %v\n", err)
+					} else {
+						fmt.Printf("JSON report saved to: %s\n", path)
+					}
+				}
+				if *historyFile != "" {
+					if err := history.Append(*historyFile, history.NewEntry(benchReport, *testDir, *quick)); err != nil {
+						fmt.Printf("Warning: Could not append to run history: %v\n", err)
+					}
+				}
+			}
+			lock.release()
+		}
+
+		select {
+		case <-ctx.Done():
+		case err := <-serveErr:
+			fmt.Printf("Error: metrics server failed: %v\n", err)
+			return exitFatal
+		case <-time.After(time.Until(start.Add(*interval))):
+		}
+	}
+
+	fmt.Println("\nStopping exporter")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	server.Shutdown(shutdownCtx)
+	return 0
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/vBenchmark/internal/report"
+	"github.com/vBenchmark/internal/system"
+)
+
+// runSysinfo prints the hardware inventory without running any benchmark,
+// as text or as the JSON of the report's "system" section
+func runSysinfo(args []string) int {
+	fs := flag.NewFlagSet("sysinfo", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "Print the inventory as JSON, as in the report's \"system\" section")
+	iface := fs.String("iface", "", "Network interface to describe instead of the default route's")
+	if err := fs.Parse(args); err != nil {
+		return exitFatal
+	}
+
+	sysInfo, err := system.Detect()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Could not detect all system info: %v\n", err)
+	}
+	if *iface != "" {
+		if sysInfo.Network, err = system.NetworkLinkOf(*iface); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -iface: %v\n", err)
+			return exitFatal
+		}
+	}
+
+	if *asJSON {
+		data, err := json.MarshalIndent(sysInfo, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to encode system info: %v\n", err)
+			return exitFatal
+		}
+		fmt.Println(string(data))
+		return 0
+	}
+	fmt.Print(report.FormatSystemInfo(sysInfo))
+	return 0
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/vBenchmark/internal/report"
+	"github.com/vBenchmark/internal/system"
+	"github.com/vBenchmark/internal/trial"
+)
+
+// runTrial checkpoint syncs a real consensus client in Docker and folds what
+// it observed into a saved benchmark report's verdict
+func runTrial(args []string) int {
+	fs := flag.NewFlagSet("trial", flag.ContinueOnError)
+	client := fs.String("client", "", "Consensus client to run: "+strings.Join(trial.Clients(), ", "))
+	network := fs.String("network", "mainnet", "Network to checkpoint sync: mainnet, holesky or sepolia")
+	duration := fs.Duration("duration", 10*time.Minute, "How long to run the client")
+	checkpointURL := fs.String("checkpoint-url", "", "Checkpoint sync provider (default: the network's sigp.io endpoint)")
+	image := fs.String("image", "", "Docker image to run instead of the client's default")
+	dataDir := fs.String("data-dir", ".", "Directory for the client database, on the disk a node would use")
+	keep := fs.Bool("keep", false, "Keep the client database after the trial")
+	reportPath := fs.String("report", "", "Benchmark report (JSON) to fold the trial into; saved as a new report")
+	outputDir := fs.String("output", "", "Directory for the updated report (default: the -report file's directory)")
+	verbose := fs.Bool("verbose", false, "Show sync progress")
+	if err := fs.Parse(args); err != nil {
+		return exitFatal
+	}
+	if *client == "" {
+		fmt.Println("Usage: ethbench trial -client name [-duration 10m] [-network mainnet] [-report ethbench.json]")
+		fmt.Printf("Clients: %s\n", strings.Join(trial.Clients(), ", "))
+		return exitFatal
+	}
+	if !slices.Contains(trial.Clients(), *client) {
+		fmt.Printf("Error: unknown client %q (available: %s)\n", *client, strings.Join(trial.Clients(), ", "))
+		return exitFatal
+	}
+
+	var benchReport *report.Report
+	if *reportPath != "" {
+		var err error
+		if benchReport, err = report.LoadReport(*reportPath); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return exitFatal
+		}
+		if *outputDir == "" {
+			*outputDir = filepath.Dir(*reportPath)
+		}
+	}
+	sysInfo, err := system.Detect()
+	if err != nil {
+		fmt.Printf("Warning: Could not detect all system info: %v\n", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("Running %s on %s for %s...\n", *client, *network, *duration)
+	result, err := trial.Run(ctx, trial.Options{
+		Client:        *client,
+		Network:       *network,
+		CheckpointURL: *checkpointURL,
+		Image:         *image,
+		Duration:      *duration,
+		DataDir:       *dataDir,
+		Keep:          *keep,
+		Verbose:       *verbose,
+	})
+	if ctx.Err() != nil {
+		fmt.Println("Interrupted")
+		return exitInterrupted
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitFatal
+	}
+
+	fmt.Println()
+	fmt.Print(report.FormatTrial(&result, sysInfo))
+	if benchReport != nil {
+		benchReport.ApplyTrial(&result)
+		jsonPath, err := report.SaveJSON(benchReport, *outputDir)
+		if err != nil {
+			fmt.Printf("Warning: Could not save JSON report: %v\n", err)
+		} else {
+			fmt.Printf("\nReport with trial saved to: %s (consensus client: %s)\n", jsonPath, benchReport.Verdict.ConsensusClient)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,217 @@
+// Package attestation reads how a node's validators performed over recent
+// epochs from the standard beacon API attestation rewards, so missed and
+// late duties can be matched with the benchmark's bottlenecks
+package attestation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// slotsPerEpoch is the mainnet and testnet preset
+const slotsPerEpoch = 32
+
+// requestTimeout bounds each beacon API call; rewards for many validators
+// can take a few seconds to compute
+const requestTimeout = 30 * time.Second
+
+// rewards holds the head, target and source components of one attestation
+// reward entry, in Gwei; penalties are negative
+type rewards struct {
+	head, target, source int64
+}
+
+// Fetch reads the attestation rewards of the validators for the last
+// `epochs` epochs whose attestations can no longer be included, i.e. up to
+// two epochs before the head.
+func Fetch(ctx context.Context, endpoint string, validators []uint64, epochs int) (*types.AttestationPerformance, error) {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	headSlot, err := fetchHeadSlot(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if headSlot/slotsPerEpoch < uint64(epochs)+2 {
+		return nil, fmt.Errorf("chain is only %d epochs old", headSlot/slotsPerEpoch)
+	}
+	balances, err := fetchEffectiveBalances(ctx, endpoint, validators)
+	if err != nil {
+		return nil, err
+	}
+
+	perf := &types.AttestationPerformance{
+		Endpoint:   endpoint,
+		LastEpoch:  headSlot/slotsPerEpoch - 2,
+		Validators: len(validators),
+	}
+	perf.FirstEpoch = perf.LastEpoch - uint64(epochs) + 1
+
+	var earned, ideal int64
+	for epoch := perf.FirstEpoch; epoch <= perf.LastEpoch; epoch++ {
+		total, idealByBalance, err := fetchRewards(ctx, endpoint, epoch, validators)
+		if err != nil {
+			return nil, fmt.Errorf("epoch %d: %w", epoch, err)
+		}
+		e := types.EpochAttestations{Epoch: epoch}
+		for index, r := range total {
+			e.Duties++
+			switch {
+			case r.source <= 0:
+				e.Missed++
+			case r.head <= 0:
+				e.HeadMissed++
+			}
+			if r.source > 0 && r.target <= 0 {
+				perf.TargetMissed++
+			}
+			if want, ok := idealByBalance[balances[index]]; ok {
+				earned += max(0, r.head) + max(0, r.target) + max(0, r.source)
+				ideal += want.head + want.target + want.source
+			}
+		}
+		perf.Duties += e.Duties
+		perf.Missed += e.Missed
+		perf.HeadMissed += e.HeadMissed
+		perf.Epochs = append(perf.Epochs, e)
+	}
+	if ideal > 0 {
+		perf.EffectivenessPercent = float64(earned) / float64(ideal) * 100
+	}
+	return perf, nil
+}
+
+// fetchHeadSlot returns the slot of the node's current head block
+func fetchHeadSlot(ctx context.Context, endpoint string) (uint64, error) {
+	var body struct {
+		Data struct {
+			Header struct {
+				Message struct {
+					Slot string `json:"slot"`
+				} `json:"message"`
+			} `json:"header"`
+		} `json:"data"`
+	}
+	if err := call(ctx, http.MethodGet, endpoint+"/eth/v1/beacon/headers/head", nil, &body); err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(body.Data.Header.Message.Slot, 10, 64)
+}
+
+// fetchEffectiveBalances returns the effective balance of each validator,
+// which selects its ideal reward entry
+func fetchEffectiveBalances(ctx context.Context, endpoint string, validators []uint64) (map[uint64]string, error) {
+	ids := validatorIDs(validators)
+	var body struct {
+		Data []struct {
+			Index     string `json:"index"`
+			Validator struct {
+				EffectiveBalance string `json:"effective_balance"`
+			} `json:"validator"`
+		} `json:"data"`
+	}
+	url := endpoint + "/eth/v1/beacon/states/head/validators?id=" + strings.Join(ids, ",")
+	if err := call(ctx, http.MethodGet, url, nil, &body); err != nil {
+		return nil, err
+	}
+	balances := make(map[uint64]string, len(body.Data))
+	for _, v := range body.Data {
+		index, _ := strconv.ParseUint(v.Index, 10, 64)
+		balances[index] = v.Validator.EffectiveBalance
+	}
+	if len(balances) != len(validators) {
+		return nil, fmt.Errorf("beacon node knows %d of %d validators", len(balances), len(validators))
+	}
+	return balances, nil
+}
+
+// fetchRewards returns each validator's earned attestation rewards for the
+// epoch and the ideal rewards keyed by effective balance
+func fetchRewards(ctx context.Context, endpoint string, epoch uint64, validators []uint64) (map[uint64]rewards, map[string]rewards, error) {
+	ids := validatorIDs(validators)
+	type entry struct {
+		ValidatorIndex   string `json:"validator_index"`
+		EffectiveBalance string `json:"effective_balance"`
+		Head             string `json:"head"`
+		Target           string `json:"target"`
+		Source           string `json:"source"`
+	}
+	var body struct {
+		Data struct {
+			IdealRewards []entry `json:"ideal_rewards"`
+			TotalRewards []entry `json:"total_rewards"`
+		} `json:"data"`
+	}
+	url := fmt.Sprintf("%s/eth/v1/beacon/rewards/attestations/%d", endpoint, epoch)
+	if err := call(ctx, http.MethodPost, url, ids, &body); err != nil {
+		return nil, nil, err
+	}
+
+	parse := func(e entry) rewards {
+		var r rewards
+		r.head, _ = strconv.ParseInt(e.Head, 10, 64)
+		r.target, _ = strconv.ParseInt(e.Target, 10, 64)
+		r.source, _ = strconv.ParseInt(e.Source, 10, 64)
+		return r
+	}
+	total := make(map[uint64]rewards, len(body.Data.TotalRewards))
+	for _, e := range body.Data.TotalRewards {
+		index, _ := strconv.ParseUint(e.ValidatorIndex, 10, 64)
+		total[index] = parse(e)
+	}
+	ideal := make(map[string]rewards, len(body.Data.IdealRewards))
+	for _, e := range body.Data.IdealRewards {
+		ideal[e.EffectiveBalance] = parse(e)
+	}
+	return total, ideal, nil
+}
+
+// validatorIDs formats validator indices the way the beacon API takes them
+func validatorIDs(validators []uint64) []string {
+	ids := make([]string, len(validators))
+	for i, v := range validators {
+		ids[i] = strconv.FormatUint(v, 10)
+	}
+	return ids
+}
+
+// call sends a beacon API request with an optional JSON body and decodes
+// the JSON response into out
+func call(ctx context.Context, method, url string, in, out any) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("beacon API unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		json.NewDecoder(resp.Body).Decode(&apiErr)
+		return fmt.Errorf("%s %s: %s %s", method, url, resp.Status, apiErr.Message)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
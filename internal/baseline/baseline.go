@@ -0,0 +1,95 @@
+// Package baseline provides embedded minimum requirements for node setups
+package baseline
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//go:embed baseline.json
+var baselineData []byte
+
+// Threshold bounds one metric; either side may be unset
+type Threshold struct {
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+}
+
+// Passes reports whether value lies within the threshold
+func (t Threshold) Passes(value float64) bool {
+	if t.Min != nil && value < *t.Min {
+		return false
+	}
+	if t.Max != nil && value > *t.Max {
+		return false
+	}
+	return true
+}
+
+// String formats the threshold, e.g. ">= 10000" or "<= 20"
+func (t Threshold) String() string {
+	var parts []string
+	if t.Min != nil {
+		parts = append(parts, fmt.Sprintf(">= %g", *t.Min))
+	}
+	if t.Max != nil {
+		parts = append(parts, fmt.Sprintf("<= %g", *t.Max))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Profile holds the minimum results for one node setup, keyed by dotted
+// report metric, e.g. "disk.random.read_iops"
+type Profile struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Thresholds  map[string]Threshold `json:"thresholds"`
+}
+
+// Metrics returns the profile's metric names in sorted order
+func (p *Profile) Metrics() []string {
+	metrics := make([]string, 0, len(p.Thresholds))
+	for metric := range p.Thresholds {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+	return metrics
+}
+
+// Database is the embedded collection of baseline profiles
+type Database struct {
+	Version  string    `json:"version"`
+	Note     string    `json:"note"`
+	Profiles []Profile `json:"profiles"`
+}
+
+// Load parses the embedded baseline database
+func Load() (*Database, error) {
+	var db Database
+	if err := json.Unmarshal(baselineData, &db); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline database: %w", err)
+	}
+	return &db, nil
+}
+
+// Find returns the named profile
+func (db *Database) Find(name string) (*Profile, error) {
+	for i := range db.Profiles {
+		if db.Profiles[i].Name == name {
+			return &db.Profiles[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown baseline profile %q (available: %s)", name, strings.Join(db.Names(), ", "))
+}
+
+// Names lists the available profile names
+func (db *Database) Names() []string {
+	names := make([]string, len(db.Profiles))
+	for i, p := range db.Profiles {
+		names[i] = p.Name
+	}
+	return names
+}
@@ -0,0 +1,96 @@
+package benchmark
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// recheckAnomalies re-runs, once, any benchmark whose metrics deviate more
+// than AnomalySigma from the reference profile while the other benchmarks in
+// its category look normal. A whole category being off points to an inherent
+// hardware limit rather than a disturbed measurement, so it is not re-run.
+func (r *Runner) recheckAnomalies(ctx context.Context, results *types.Results) []types.Anomaly {
+	profile := r.config.Reference
+	limit := r.config.AnomalySigma
+	metrics := types.FlattenMetrics(results)
+
+	// Find the worst deviating metric of each benchmark
+	outliers := make(map[string]string)
+	normal := make(map[string]int)
+	checked := make(map[string]int)
+	for _, b := range r.benchmarks() {
+		// Failed or skipped benchmarks have no meaningful metrics
+		if !b.status(results).OK() {
+			continue
+		}
+		worst, worstSigma := "", 0.0
+		hasReference := false
+		for metric, value := range metrics {
+			if !strings.HasPrefix(metric, b.name+".") {
+				continue
+			}
+			sigma, ok := profile.Deviation(metric, value)
+			if !ok {
+				continue
+			}
+			hasReference = true
+			if math.Abs(sigma) > math.Abs(worstSigma) {
+				worst, worstSigma = metric, sigma
+			}
+		}
+		if !hasReference {
+			continue
+		}
+		checked[b.category]++
+		if math.Abs(worstSigma) > limit {
+			outliers[b.name] = worst
+		} else {
+			normal[b.category]++
+		}
+	}
+
+	var anomalies []types.Anomaly
+	for _, b := range r.benchmarks() {
+		metric, ok := outliers[b.name]
+		if !ok {
+			continue
+		}
+		// Correlated metrics must look normal for this to be an anomaly
+		others := checked[b.category] - 1
+		if others <= 0 || normal[b.category]*2 < others {
+			continue
+		}
+
+		first := metrics[metric]
+		firstSigma, _ := profile.Deviation(metric, first)
+		r.log("  Re-running %s: %s deviates %.1f sigma from %s reference...", b.label, metric, firstSigma, profile.Name)
+		r.runBenchmark(ctx, b, results)
+		if ctx.Err() != nil {
+			break
+		}
+
+		rerun := types.FlattenMetrics(results)[metric]
+		rerunSigma, _ := profile.Deviation(metric, rerun)
+
+		anomaly := types.Anomaly{
+			Benchmark:       b.name,
+			Metric:          metric,
+			FirstValue:      first,
+			RerunValue:      rerun,
+			ReferenceMean:   profile.Metrics[metric].Mean,
+			ReferenceStdDev: profile.Metrics[metric].StdDev,
+			Sigma:           firstSigma,
+		}
+		if math.Abs(rerunSigma) > limit {
+			anomaly.Note = "Re-run confirms the deviation; likely a real hardware or configuration issue."
+		} else {
+			anomaly.Note = "Re-run is within the reference range; the first measurement was likely disturbed."
+		}
+		anomalies = append(anomalies, anomaly)
+	}
+
+	return anomalies
+}
@@ -0,0 +1,174 @@
+package benchmark
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/vBenchmark/internal/deadline"
+	"github.com/vBenchmark/internal/fastrand"
+	"github.com/vBenchmark/internal/types"
+	"github.com/vBenchmark/internal/workload"
+)
+
+// calibrationDuration is how long the deadline check is timed
+const calibrationDuration = 200 * time.Millisecond
+
+// harnessLoop describes the bookkeeping one benchmark loop does per iteration
+// besides the work it measures. Map operations that are themselves the
+// simulated workload, e.g. state cache lookups, are not counted.
+type harnessLoop struct {
+	metric     string                           // Dotted report metric
+	iterations func(res *types.Results) float64 // Loop iterations per second
+	rngCalls   float64                          // Payload RNG draws per iteration
+	rngBytes   float64                          // Payload bytes drawn per iteration
+}
+
+// harnessLoops lists the loops fast enough for the harness to matter. Slower
+// benchmarks such as BLS or disk I/O spend microseconds per iteration, where
+// a few nanoseconds of bookkeeping are noise.
+var harnessLoops = []harnessLoop{
+	{
+		metric:     "cpu.keccak.hashes_per_second",
+		iterations: func(res *types.Results) float64 { return res.CPU.Keccak.HashesPerSecond / 4 },
+	},
+	{
+		metric:     "cpu.sha256.node_hashes_per_second",
+		iterations: func(res *types.Results) float64 { return res.CPU.SHA256.NodeHashesPerSecond },
+	},
+	{
+		metric:     "cpu.rlp.encodes_per_second",
+		iterations: func(res *types.Results) float64 { return res.CPU.RLP.EncodesPerSecond / 3 },
+	},
+	{
+		metric:     "cpu.rlp.decodes_per_second",
+		iterations: func(res *types.Results) float64 { return res.CPU.RLP.DecodesPerSecond / 3 },
+	},
+	{
+		metric:     "memory.trie.inserts_per_second",
+		iterations: func(res *types.Results) float64 { return res.Memory.Trie.InsertsPerSecond },
+		rngCalls:   2,
+		rngBytes:   20 + workload.Current.Dataset.AccountRLPSize.Mean(),
+	},
+	{
+		metric:     "memory.trie.lookups_per_second",
+		iterations: func(res *types.Results) float64 { return res.Memory.Trie.LookupsPerSecond },
+		rngCalls:   0.2, // Only the 20% negative lookups draw a 20-byte address
+		rngBytes:   4,
+	},
+	{
+		metric: "memory.state_cache.cache_hits_per_second",
+		iterations: func(res *types.Results) float64 {
+			return res.Memory.StateCache.CacheHitsPerSecond + res.Memory.StateCache.CacheMissesPerSecond
+		},
+		rngCalls: 0.2, // Only the 20% miss path draws a 20-byte address
+		rngBytes: 4,
+	},
+	{
+		// Its RNG fills stand in for MSTOREs, so they count as workload
+		metric: "memory.pool.reuses_per_second",
+		iterations: func(res *types.Results) float64 {
+			return res.Memory.Pool.AllocationsPerSecond + res.Memory.Pool.ReusesPerSecond
+		},
+	},
+}
+
+// calibrateHarness times the primitives benchmark loops use for bookkeeping:
+// the deadline check, payload RNG draws and a map lookup
+func calibrateHarness(ctx context.Context) *types.HarnessOverhead {
+	h := &types.HarnessOverhead{}
+
+	// One deadline check: a clock read and a cancellation check
+	var checks uint64
+	start := time.Now()
+	for time.Since(start) < calibrationDuration && ctx.Err() == nil {
+		checks++
+	}
+	h.LoopCheckNs = float64(time.Since(start).Nanoseconds()) / float64(max(checks, 1))
+
+	// RNG: small draws give the per-call cost, 4 KB draws the per-byte cost
+	const draws = 1 << 18
+	rng := fastrand.New("harness")
+	small, large := make([]byte, 4), make([]byte, 4096)
+	start = time.Now()
+	for i := 0; i < draws; i++ {
+		rng.Read(small)
+	}
+	h.RNGCallNs = float64(time.Since(start).Nanoseconds()) / draws
+	start = time.Now()
+	for i := 0; i < draws/64; i++ {
+		rng.Read(large)
+	}
+	perLarge := float64(time.Since(start).Nanoseconds()) / (draws / 64)
+	h.RNGByteNs = max(perLarge-h.RNGCallNs, 0) / float64(len(large))
+
+	// Map lookup on a table the size of the simulated state
+	keys := make([][20]byte, workload.Current.State.Accounts)
+	table := make(map[[20]byte]int, len(keys))
+	for i := range keys {
+		rng.Read(keys[i][:])
+		table[keys[i]] = i
+	}
+	var sink int
+	start = time.Now()
+	for i := 0; i < draws; i++ {
+		sink += table[keys[i%len(keys)]]
+	}
+	h.MapLookupNs = float64(time.Since(start).Nanoseconds()) / draws
+	_ = sink
+
+	return h
+}
+
+// harnessMetrics estimates the harness share of each fast metric that
+// completed and the rate it would reach without that overhead. Deadline
+// checks are counted from the loop's recorded stats; a loop without stats is
+// assumed to check on every iteration.
+func (r *Runner) harnessMetrics(h *types.HarnessOverhead, results *types.Results, loops map[string]deadline.Stats) []types.HarnessMetric {
+	statuses := make(map[string]*types.Status)
+	for _, b := range r.benchmarks() {
+		statuses[b.name] = b.status(results)
+	}
+	values := types.FlattenMetrics(results)
+
+	var metrics []types.HarnessMetric
+	for _, loop := range harnessLoops {
+		if s := statuses[benchmarkOf(loop.metric)]; s == nil || !s.OK() {
+			continue
+		}
+		rate := loop.iterations(results)
+		if rate <= 0 {
+			continue
+		}
+		checks, duty := 1.0, 0.0
+		if stats, ok := loops[loop.metric]; ok && stats.Iterations > 0 {
+			checks = stats.ChecksPerIteration()
+			duty = stats.DutyCycle(time.Duration(h.LoopCheckNs))
+		}
+		overhead := checks*h.LoopCheckNs + loop.rngCalls*h.RNGCallNs + loop.rngBytes*h.RNGByteNs
+		share := overhead * rate / 1e9
+		if share >= 1 {
+			continue // Calibration and benchmark disagree, e.g. clocks changed between them
+		}
+		value := values[loop.metric]
+		metrics = append(metrics, types.HarnessMetric{
+			Metric:             loop.metric,
+			ChecksPerIteration: checks,
+			DutyCycle:          duty,
+			OverheadNs:         overhead,
+			OverheadPercent:    share * 100,
+			MeasuredValue:      value,
+			CorrectedValue:     value / (1 - share),
+		})
+	}
+	return metrics
+}
+
+// benchmarkOf returns the benchmark name of a dotted metric, e.g. cpu.keccak
+// for cpu.keccak.hashes_per_second
+func benchmarkOf(metric string) string {
+	if i := strings.LastIndex(metric, "."); i >= 0 {
+		return metric[:i]
+	}
+	return metric
+}
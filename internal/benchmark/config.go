@@ -3,41 +3,117 @@ package benchmark
 
 import (
 	"time"
+
+	"github.com/vBenchmark/internal/reference"
 )
 
 // Config holds benchmark configuration
 type Config struct {
 	// Duration settings
+	IdleDuration   time.Duration
 	CPUDuration    time.Duration
 	MemoryDuration time.Duration
 	DiskDuration   time.Duration
 
 	// Test directory for disk benchmarks
 	TestDir string
+	// MaxWriteBytes caps the bytes written by all disk benchmarks (0 = unlimited)
+	MaxWriteBytes int64
+	// KeepTestFiles leaves prepared test files in TestDir for the next run
+	KeepTestFiles bool
+	// RandomFileSize is the random I/O working set in bytes (0 = disk default)
+	RandomFileSize int64
+	// CopyDest is a second location, e.g. a USB backup disk, to measure
+	// copy speed to and from ("" = copy benchmark off)
+	CopyDest string
+	// Replay is a block export to import through go-ethereum, or "builtin"
+	// for the generated segment ("" = replay benchmark off)
+	Replay string
+	// IOJobs is the goroutine count for the concurrent random I/O phases (1 = QD1 only)
+	IOJobs int
+	// QueueDepths lists the outstanding request counts the random read
+	// sweep runs at (empty = no sweep)
+	QueueDepths []int
+	// DirectIO repeats the sequential, random and batch benchmarks with
+	// O_DIRECT alongside the page cache numbers
+	DirectIO bool
+
+	// CPUWorkers is the goroutine count for the multi-core CPU benchmark (0 = NumCPU)
+	CPUWorkers int
 
 	// Output settings
 	Verbose bool
+
+	// Reference profile for the detected hardware, nil if unknown
+	Reference *reference.Profile
+	// AnomalySigma is the deviation from the reference that triggers a re-run (0 disables)
+	AnomalySigma float64
+	// ApplyTuning applies the interrupt affinity spread planned for a disk
+	// benchmark whose interrupts landed on one CPU, and re-runs it
+	ApplyTuning bool
+
+	// GOGCSweep lists GOGC values to re-run the memory benchmarks with
+	GOGCSweep []int
+	// MemoryGOGC is the GOGC the memory benchmarks run with (0 = run-wide
+	// setting, -1 = off)
+	MemoryGOGC int
+	// MemoryBallast is the size of an untouched allocation held while the
+	// memory benchmarks run, raising the heap size GC paces against
+	MemoryBallast int64
+	// MPTDisk also runs the go-ethereum trie benchmark on a Pebble database
+	// in TestDir
+	MPTDisk bool
+	// MemoryPressure enables the test that allocates toward the RAM limit
+	// to measure swap behavior
+	MemoryPressure bool
+	// Paranoid checks every benchmark for leaked goroutines and prompt
+	// cancellation, and the results for impossible values
+	Paranoid bool
+
+	// Packs lists the enabled fork benchmark packs, each run for PackDuration
+	Packs        []string
+	PackDuration time.Duration
 }
 
 // DefaultConfig returns the default benchmark configuration
 func DefaultConfig() *Config {
 	return &Config{
+		IdleDuration:   30 * time.Second,
 		CPUDuration:    60 * time.Second,
 		MemoryDuration: 60 * time.Second,
 		DiskDuration:   60 * time.Second,
+		PackDuration:   20 * time.Second,
 		TestDir:        ".",
 		Verbose:        false,
+		AnomalySigma:   3,
 	}
 }
 
 // QuickConfig returns a quick benchmark configuration (~1 minute total)
 func QuickConfig() *Config {
 	return &Config{
+		IdleDuration:   10 * time.Second,
 		CPUDuration:    20 * time.Second,
 		MemoryDuration: 20 * time.Second,
 		DiskDuration:   20 * time.Second,
+		PackDuration:   8 * time.Second,
 		TestDir:        ".",
 		Verbose:        false,
+		AnomalySigma:   3,
+	}
+}
+
+// ParanoidConfig returns the shortened configuration of -paranoid, which
+// checks invariants rather than measuring: half the quick durations, no idle
+// baseline and no anomaly re-runs
+func ParanoidConfig() *Config {
+	return &Config{
+		CPUDuration:    10 * time.Second,
+		MemoryDuration: 10 * time.Second,
+		DiskDuration:   10 * time.Second,
+		PackDuration:   4 * time.Second,
+		TestDir:        ".",
+		Paranoid:       true,
 	}
 }
 
@@ -47,49 +123,87 @@ type CPUTimeBudget struct {
 	ECDSA     time.Duration
 	BLS       time.Duration
 	BN256     time.Duration
+	RLP       time.Duration
+	EVM       time.Duration
+	SHA256    time.Duration
+	KZG       time.Duration
+	Parallel  time.Duration
 }
 
 // GetCPUTimeBudget calculates time budget for CPU benchmarks
 func (c *Config) GetCPUTimeBudget() CPUTimeBudget {
 	total := c.CPUDuration
 	return CPUTimeBudget{
-		Keccak256: total * 15 / 60, // 25%
-		ECDSA:     total * 20 / 60, // 33%
-		BLS:       total * 15 / 60, // 25%
-		BN256:     total * 10 / 60, // 17%
+		Keccak256: total * 7 / 60,  // 12%
+		ECDSA:     total * 10 / 60, // 17%
+		BLS:       total * 8 / 60,  // 13%
+		BN256:     total * 6 / 60,  // 10%
+		RLP:       total * 5 / 60,  // 8%
+		EVM:       total * 5 / 60,  // 8%
+		SHA256:    total * 5 / 60,  // 8%
+		KZG:       total * 6 / 60,  // 10%
+		Parallel:  total * 8 / 60,  // 13%
 	}
 }
 
 // MemoryTimeBudget returns time allocations for each memory benchmark
 type MemoryTimeBudget struct {
-	Trie       time.Duration
-	Pool       time.Duration
-	StateCache time.Duration
+	Trie        time.Duration
+	Pool        time.Duration
+	StateCache  time.Duration
+	Latency     time.Duration
+	Correctness time.Duration
+	MPT         time.Duration // On top of the total
+	Pressure    time.Duration // Optional, on top of the total
 }
 
 // GetMemoryTimeBudget calculates time budget for memory benchmarks
 func (c *Config) GetMemoryTimeBudget() MemoryTimeBudget {
 	total := c.MemoryDuration
 	return MemoryTimeBudget{
-		Trie:       total * 25 / 60, // 42%
-		Pool:       total * 15 / 60, // 25%
-		StateCache: total * 20 / 60, // 33%
+		Trie:        total * 20 / 60, // 33%
+		Pool:        total * 11 / 60, // 18%
+		StateCache:  total * 15 / 60, // 25%
+		Latency:     total * 7 / 60,  // 12%
+		Correctness: total * 7 / 60,  // 12%
+		MPT:         total * 12 / 60,
+		Pressure:    total * 20 / 60,
 	}
 }
 
 // DiskTimeBudget returns time allocations for each disk benchmark
 type DiskTimeBudget struct {
-	Sequential time.Duration
-	Random     time.Duration
-	Batch      time.Duration
+	Sequential  time.Duration
+	Random      time.Duration
+	Batch       time.Duration
+	StateScheme time.Duration
+	Blob        time.Duration
+	KVStore     time.Duration
+	Fsync       time.Duration
+	Mmap        time.Duration // On top of the total
+	Slot        time.Duration // On top of the total
+	Contention  time.Duration // On top of the total
+	TrieCommit  time.Duration // On top of the total
+	Copy        time.Duration // Optional, on top of the total
+	Migration   time.Duration // Optional, on top of the total
 }
 
 // GetDiskTimeBudget calculates time budget for disk benchmarks
 func (c *Config) GetDiskTimeBudget() DiskTimeBudget {
 	total := c.DiskDuration
 	return DiskTimeBudget{
-		Sequential: total * 20 / 60, // 33%
-		Random:     total * 25 / 60, // 42%
-		Batch:      total * 15 / 60, // 25%
+		Sequential:  total * 10 / 60, // 17%
+		Random:      total * 15 / 60, // 25%
+		Batch:       total * 7 / 60,  // 12%
+		StateScheme: total * 8 / 60,  // 13%
+		Blob:        total * 6 / 60,  // 10%
+		KVStore:     total * 9 / 60,  // 15%
+		Fsync:       total * 5 / 60,  // 8%
+		Mmap:        total * 6 / 60,
+		Slot:        total * 10 / 60,
+		Contention:  total * 12 / 60,
+		TrieCommit:  total * 10 / 60,
+		Copy:        total * 20 / 60,
+		Migration:   total * 20 / 60,
 	}
 }
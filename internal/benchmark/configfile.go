@@ -0,0 +1,209 @@
+package benchmark
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileConfig is a benchmark configuration loaded with -config. Settings left
+// out of the file keep their defaults, and command line flags override it.
+type FileConfig struct {
+	// Durations, e.g. "90s"; they replace the default or quick mode values
+	IdleDuration   string `json:"idle_duration" yaml:"idle_duration"`
+	CPUDuration    string `json:"cpu_duration" yaml:"cpu_duration"`
+	MemoryDuration string `json:"memory_duration" yaml:"memory_duration"`
+	DiskDuration   string `json:"disk_duration" yaml:"disk_duration"`
+	PackDuration   string `json:"pack_duration" yaml:"pack_duration"`
+
+	// Settings mirroring the command line flags
+	Quick         *bool     `json:"quick" yaml:"quick"`
+	Runs          *int      `json:"runs" yaml:"runs"`
+	Soak          string    `json:"soak" yaml:"soak"`
+	Verbose       *bool     `json:"verbose" yaml:"verbose"`
+	TestDir       string    `json:"test_dir" yaml:"test_dir"`
+	OutputDir     string    `json:"output_dir" yaml:"output_dir"`
+	Format        string    `json:"format" yaml:"format"`
+	Only          []string  `json:"only" yaml:"only"`
+	Skip          []string  `json:"skip" yaml:"skip"`
+	Packs         []string  `json:"packs" yaml:"packs"`
+	MaxWrite      string    `json:"max_write" yaml:"max_write"`
+	RandomSize    string    `json:"random_size" yaml:"random_size"`
+	CopyDest      string    `json:"copy_dest" yaml:"copy_dest"`
+	Replay        string    `json:"replay" yaml:"replay"`
+	MemoryGOGC    string    `json:"memory_gogc" yaml:"memory_gogc"`
+	MemoryBallast string    `json:"memory_ballast" yaml:"memory_ballast"`
+	Profile       string    `json:"profile" yaml:"profile"`
+	Scoring       string    `json:"scoring" yaml:"scoring"`
+	Reference     string    `json:"reference" yaml:"reference"`
+	PreRun        string    `json:"pre_run" yaml:"pre_run"`
+	PostRun       string    `json:"post_run" yaml:"post_run"`
+	History       *string   `json:"history" yaml:"history"`
+	Tags          []string  `json:"tags" yaml:"tags"`
+	Iface         string    `json:"iface" yaml:"iface"`
+	NetEndpoints  *[]string `json:"net_endpoints" yaml:"net_endpoints"`
+	Peer          string    `json:"peer" yaml:"peer"`
+	ClusterPeers  []string  `json:"cluster_peers" yaml:"cluster_peers"`
+	IOJobs        *int      `json:"io_jobs" yaml:"io_jobs"`
+	QueueDepths   []int     `json:"queue_depths" yaml:"queue_depths"`
+	KeepTestFiles *bool     `json:"keep_testfiles" yaml:"keep_testfiles"`
+	MemPressure   *bool     `json:"memory_pressure" yaml:"memory_pressure"`
+	MPTDisk       *bool     `json:"mpt_disk" yaml:"mpt_disk"`
+	DirectIO      *bool     `json:"direct_io" yaml:"direct_io"`
+	Offline       *bool     `json:"offline" yaml:"offline"`
+	ApplyTuning   *bool     `json:"apply_tuning" yaml:"apply_tuning"`
+	SetGovernor   *bool     `json:"set_performance_governor" yaml:"set_performance_governor"`
+	CPUWorkers    *int      `json:"cpu_workers" yaml:"cpu_workers"`
+	AnomalySigma  *float64  `json:"anomaly_sigma" yaml:"anomaly_sigma"`
+}
+
+// LoadConfigFile reads a JSON (.json) or YAML (.yaml, .yml) config file.
+// Unknown keys are an error so typos do not silently fall back to defaults.
+func LoadConfigFile(path string) (*FileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	fc := &FileConfig{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(fc)
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(fc)
+	default:
+		return nil, fmt.Errorf("unsupported config file %q (want .json, .yaml or .yml)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	return fc, nil
+}
+
+// Flags returns the file's settings keyed by command line flag name, in the
+// flag's string form
+func (fc *FileConfig) Flags() map[string]string {
+	flags := make(map[string]string)
+	setString := func(name, value string) {
+		if value != "" {
+			flags[name] = value
+		}
+	}
+	setList := func(name string, values []string) {
+		if len(values) > 0 {
+			flags[name] = strings.Join(values, ",")
+		}
+	}
+
+	if fc.Quick != nil {
+		flags["quick"] = strconv.FormatBool(*fc.Quick)
+	}
+	if fc.Verbose != nil {
+		flags["verbose"] = strconv.FormatBool(*fc.Verbose)
+	}
+	if fc.KeepTestFiles != nil {
+		flags["keep-testfiles"] = strconv.FormatBool(*fc.KeepTestFiles)
+	}
+	if fc.MemPressure != nil {
+		flags["memory-pressure"] = strconv.FormatBool(*fc.MemPressure)
+	}
+	if fc.MPTDisk != nil {
+		flags["mpt-disk"] = strconv.FormatBool(*fc.MPTDisk)
+	}
+	if fc.DirectIO != nil {
+		flags["direct-io"] = strconv.FormatBool(*fc.DirectIO)
+	}
+	if fc.Offline != nil {
+		flags["offline"] = strconv.FormatBool(*fc.Offline)
+	}
+	if fc.ApplyTuning != nil {
+		flags["apply-tuning"] = strconv.FormatBool(*fc.ApplyTuning)
+	}
+	if fc.SetGovernor != nil {
+		flags["set-performance-governor"] = strconv.FormatBool(*fc.SetGovernor)
+	}
+	if fc.History != nil {
+		flags["history"] = *fc.History
+	}
+	if fc.NetEndpoints != nil {
+		flags["net-endpoints"] = strings.Join(*fc.NetEndpoints, ",")
+	}
+	if fc.Runs != nil {
+		flags["runs"] = strconv.Itoa(*fc.Runs)
+	}
+	if fc.IOJobs != nil {
+		flags["io-jobs"] = strconv.Itoa(*fc.IOJobs)
+	}
+	if fc.CPUWorkers != nil {
+		flags["cpu-workers"] = strconv.Itoa(*fc.CPUWorkers)
+	}
+	if fc.AnomalySigma != nil {
+		flags["anomaly-sigma"] = strconv.FormatFloat(*fc.AnomalySigma, 'g', -1, 64)
+	}
+	setString("test-dir", fc.TestDir)
+	setString("output", fc.OutputDir)
+	setString("format", fc.Format)
+	setString("max-write", fc.MaxWrite)
+	setString("random-size", fc.RandomSize)
+	setString("copy-dest", fc.CopyDest)
+	setString("replay", fc.Replay)
+	setString("memory-gogc", fc.MemoryGOGC)
+	setString("memory-ballast", fc.MemoryBallast)
+	setString("profile", fc.Profile)
+	setString("scoring", fc.Scoring)
+	setString("reference", fc.Reference)
+	setString("pre-run", fc.PreRun)
+	setString("post-run", fc.PostRun)
+	setString("soak", fc.Soak)
+	setString("iface", fc.Iface)
+	setString("peer", fc.Peer)
+	setList("only", fc.Only)
+	setList("skip", fc.Skip)
+	setList("packs", fc.Packs)
+	setList("cluster-peers", fc.ClusterPeers)
+	setList("tags", fc.Tags)
+	if len(fc.QueueDepths) > 0 {
+		depths := make([]string, len(fc.QueueDepths))
+		for i, d := range fc.QueueDepths {
+			depths[i] = strconv.Itoa(d)
+		}
+		flags["queue-depths"] = strings.Join(depths, ",")
+	}
+	return flags
+}
+
+// ApplyDurations replaces the durations of config with those set in the file
+func (fc *FileConfig) ApplyDurations(config *Config) error {
+	for _, d := range []struct {
+		name  string
+		value string
+		dest  *time.Duration
+	}{
+		{"idle_duration", fc.IdleDuration, &config.IdleDuration},
+		{"cpu_duration", fc.CPUDuration, &config.CPUDuration},
+		{"memory_duration", fc.MemoryDuration, &config.MemoryDuration},
+		{"disk_duration", fc.DiskDuration, &config.DiskDuration},
+		{"pack_duration", fc.PackDuration, &config.PackDuration},
+	} {
+		if d.value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(d.value)
+		if err != nil || parsed < 0 {
+			return fmt.Errorf("invalid %s %q in config file", d.name, d.value)
+		}
+		*d.dest = parsed
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vBenchmark/internal/memory"
+	"github.com/vBenchmark/internal/types"
+)
+
+// CaptureRuntime records the Go runtime settings the benchmarks run under
+func CaptureRuntime() types.RuntimeInfo {
+	// SetGCPercent and SetMemoryLimit return the current value; restore immediately
+	gogc := debug.SetGCPercent(100)
+	debug.SetGCPercent(gogc)
+	memLimit := debug.SetMemoryLimit(-1)
+
+	info := types.RuntimeInfo{
+		GoVersion:  runtime.Version(),
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+		NumCPU:     runtime.NumCPU(),
+		GOGC:       formatGOGC(gogc),
+		GOMEMLIMIT: memLimit,
+	}
+	if memLimit == math.MaxInt64 {
+		info.GOMEMLIMIT = 0 // No limit
+	}
+	return info
+}
+
+// ApplyRuntimeOverrides sets GOMAXPROCS and GOGC for this run.
+// gomaxprocs <= 0 and an empty gogc leave the current values untouched.
+func ApplyRuntimeOverrides(gomaxprocs int, gogc string) error {
+	if gomaxprocs > 0 {
+		runtime.GOMAXPROCS(gomaxprocs)
+	}
+	if gogc == "" {
+		return nil
+	}
+	percent, err := ParseGOGC(gogc)
+	if err != nil {
+		return err
+	}
+	debug.SetGCPercent(percent)
+	return nil
+}
+
+// ParseGOGC parses a GOGC value, a non-negative percentage or "off" (-1)
+func ParseGOGC(s string) (int, error) {
+	if strings.EqualFold(s, "off") {
+		return -1, nil
+	}
+	percent, err := strconv.Atoi(s)
+	if err != nil || percent < 0 {
+		return 0, fmt.Errorf("invalid GOGC value %q: must be a non-negative integer or \"off\"", s)
+	}
+	return percent, nil
+}
+
+// formatGOGC formats a GOGC percentage the way the GOGC variable spells it
+func formatGOGC(percent int) string {
+	if percent < 0 {
+		return "off"
+	}
+	return strconv.Itoa(percent)
+}
+
+// isolateMemoryGC applies the memory benchmarks' GOGC and ballast and
+// returns a function restoring the run-wide settings. The ballast is never
+// written, so it adds to the live heap GC paces against without being
+// faulted into RAM.
+func (r *Runner) isolateMemoryGC() (restore func()) {
+	restore = func() {}
+	if r.config.MemoryGOGC != 0 {
+		original := debug.SetGCPercent(r.config.MemoryGOGC)
+		restore = func() { debug.SetGCPercent(original) }
+	}
+	if r.config.MemoryBallast > 0 {
+		ballast := make([]byte, r.config.MemoryBallast)
+		reset := restore
+		restore = func() {
+			runtime.KeepAlive(ballast)
+			reset()
+		}
+	}
+	return restore
+}
+
+// memoryGOGC returns the GOGC the memory benchmarks run with
+func (r *Runner) memoryGOGC() string {
+	if r.config.MemoryGOGC != 0 {
+		return formatGOGC(r.config.MemoryGOGC)
+	}
+	gogc := debug.SetGCPercent(100)
+	debug.SetGCPercent(gogc)
+	return formatGOGC(gogc)
+}
+
+// ParseGOGCSweep parses a comma-separated list of GOGC values, e.g. "50,100,200"
+func ParseGOGCSweep(s string) ([]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var values []int
+	for _, part := range strings.Split(s, ",") {
+		v, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || v <= 0 {
+			return nil, fmt.Errorf("invalid GOGC sweep value %q", part)
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// sweepGOGC re-runs the memory benchmarks at each GOGC value to show how GC
+// tuning changes the results. Each point gets a third of the memory budget.
+func (r *Runner) sweepGOGC(ctx context.Context, values []int) []types.GCSweepPoint {
+	original := debug.SetGCPercent(100)
+	defer debug.SetGCPercent(original)
+
+	budget := r.config.GetMemoryTimeBudget()
+	points := make([]types.GCSweepPoint, 0, len(values))
+
+	for i, gogc := range values {
+		r.log("  [%d/%d] GOGC=%d...", i+1, len(values), gogc)
+		debug.SetGCPercent(gogc)
+		runtime.GC()
+
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+
+		point := types.GCSweepPoint{GOGC: gogc}
+		r.track(fmt.Sprintf("gogc_sweep.%d", gogc), func() {
+			trie := memory.BenchmarkTrie(ctx, r.memoryLimit, budget.Trie/3, r.verbose)
+			pool := memory.BenchmarkPool(ctx, budget.Pool/3, r.verbose)
+			cache := memory.BenchmarkStateCache(ctx, r.memoryLimit, budget.StateCache/3, r.verbose)
+
+			point.TrieInsertsPerSecond = trie.InsertsPerSecond
+			point.PoolOpsPerSecond = pool.AllocationsPerSecond + pool.ReusesPerSecond
+			point.CacheHitsPerSecond = cache.CacheHitsPerSecond
+		})
+
+		// A point cut short by an interrupt is not comparable to the others
+		if ctx.Err() != nil {
+			break
+		}
+		runtime.ReadMemStats(&after)
+		point.NumGC = after.NumGC - before.NumGC
+		point.GCPauseTotal = time.Duration(after.PauseTotalNs - before.PauseTotalNs)
+		points = append(points, point)
+	}
+
+	return points
+}
@@ -0,0 +1,104 @@
+package benchmark
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/vBenchmark/internal/system"
+	"github.com/vBenchmark/internal/types"
+)
+
+// tuneIRQAffinity plans an interrupt affinity spread for the disk benchmark
+// whose device interrupts were most concentrated on one CPU. With
+// ApplyTuning the spread is applied and the benchmark re-run, replacing its
+// first results when the re-run completes, so the report shows the
+// difference it made.
+func (r *Runner) tuneIRQAffinity(ctx context.Context, results *types.Results) *types.IRQTuning {
+	var worst *types.PhaseTiming
+	for i, p := range r.timeline {
+		if p.IRQ == nil || !p.IRQ.Concentrated {
+			continue
+		}
+		if worst == nil || p.IRQ.InterruptsPerSecond > worst.IRQ.InterruptsPerSecond {
+			worst = &r.timeline[i]
+		}
+	}
+	if worst == nil {
+		return nil
+	}
+	moves := system.PlanIRQAffinity(worst.IRQ, runtime.NumCPU())
+	if len(moves) == 0 {
+		return nil
+	}
+	tuning := &types.IRQTuning{
+		Phase:      worst.Name,
+		CPU:        worst.IRQ.DeviceCPU,
+		CPUPercent: worst.IRQ.DeviceCPUPercent,
+		Moves:      moves,
+	}
+	if !r.config.ApplyTuning {
+		return tuning
+	}
+
+	system.ApplyIRQAffinity(tuning.Moves)
+	for _, m := range tuning.Moves {
+		if m.Applied {
+			tuning.Applied = true
+		} else {
+			r.log("  Could not move IRQ %s (%s) to CPU %d: %s", m.IRQ, m.Source, m.ToCPU, m.Error)
+		}
+	}
+	if !tuning.Applied {
+		return tuning
+	}
+
+	var b benchmark
+	for _, candidate := range r.benchmarks() {
+		if candidate.name == tuning.Phase {
+			b = candidate
+		}
+	}
+	if b.run == nil || !b.status(results).OK() {
+		return tuning
+	}
+	// A re-run that cannot write would only be skipped
+	if r.writes.Exhausted() || r.writes.VolumeErr(nil) != nil {
+		r.log("  Not re-running %s: disk writes are stopped", b.label)
+		return tuning
+	}
+	before := benchmarkMetrics(results, b.name)
+	// The first results, and the errors recorded so far, stand unless the
+	// re-run completes
+	saved := cloneResults(results)
+	r.log("  Re-running %s with interrupts spread over the CPUs...", b.label)
+	r.runBenchmark(ctx, b, results)
+	if ctx.Err() != nil || !b.status(results).OK() {
+		*results = saved
+		return tuning
+	}
+	tuning.After = r.timeline[len(r.timeline)-1].IRQ
+
+	after := benchmarkMetrics(results, b.name)
+	for metric, value := range before {
+		m := types.TuningMetric{Metric: metric, Before: value, After: after[metric]}
+		if value != 0 {
+			m.ChangePercent = (m.After - value) / value * 100
+		}
+		tuning.Metrics = append(tuning.Metrics, m)
+	}
+	sort.Slice(tuning.Metrics, func(i, j int) bool { return tuning.Metrics[i].Metric < tuning.Metrics[j].Metric })
+	return tuning
+}
+
+// benchmarkMetrics returns the metrics of one benchmark, without durations
+func benchmarkMetrics(results *types.Results, name string) map[string]float64 {
+	metrics := make(map[string]float64)
+	for metric, value := range types.FlattenMetrics(results) {
+		if strings.HasPrefix(metric, name+".") && !strings.HasSuffix(metric, "duration_ns") {
+			metrics[metric] = value
+		}
+	}
+	return metrics
+}
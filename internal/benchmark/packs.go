@@ -0,0 +1,109 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vBenchmark/internal/forks"
+	"github.com/vBenchmark/internal/types"
+)
+
+// Pack is an optional set of benchmarks evaluating readiness for an upcoming
+// protocol upgrade. Packs are off by default and scored separately, so
+// enabling one never changes the core score.
+type Pack struct {
+	Name        string
+	Fork        string
+	Description string
+	benchmarks  func(duration time.Duration, verbose bool) []benchmark
+}
+
+// Packs lists the available fork benchmark packs
+var Packs = []Pack{
+	{
+		Name:        "pectra",
+		Fork:        "Pectra",
+		Description: "EIP-2537 BLS12-381 precompiles",
+		benchmarks: func(duration time.Duration, verbose bool) []benchmark {
+			return []benchmark{
+				{"fork.pectra", "fork", "Pectra BLS12-381 precompiles", func(ctx context.Context, res *types.Results) error {
+					result := &types.PectraResult{}
+					forkResults(res).Pectra = result
+					*result = forks.BenchmarkPectra(ctx, duration, verbose)
+					return nil
+				}, func(res *types.Results) *types.Status { return &forkResults(res).Pectra.Status }},
+			}
+		},
+	},
+	{
+		Name:        "fusaka",
+		Fork:        "Fusaka",
+		Description: "EIP-7594 PeerDAS blob erasure coding",
+		benchmarks: func(duration time.Duration, verbose bool) []benchmark {
+			return []benchmark{
+				{"fork.fusaka", "fork", "Fusaka PeerDAS blob extension", func(ctx context.Context, res *types.Results) error {
+					result := &types.FusakaResult{}
+					forkResults(res).Fusaka = result
+					*result = forks.BenchmarkFusaka(ctx, duration, verbose)
+					return nil
+				}, func(res *types.Results) *types.Status { return &forkResults(res).Fusaka.Status }},
+			}
+		},
+	},
+}
+
+// ParsePacks parses a comma-separated list of pack names, e.g. "pectra,fusaka".
+// "all" enables every pack.
+func ParsePacks(s string) ([]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if s == "all" {
+		names := make([]string, 0, len(Packs))
+		for _, p := range Packs {
+			names = append(names, p.Name)
+		}
+		return names, nil
+	}
+
+	var names []string
+	for _, part := range strings.Split(s, ",") {
+		name := strings.ToLower(strings.TrimSpace(part))
+		if findPack(name) == nil {
+			return nil, fmt.Errorf("unknown benchmark pack %q", part)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// findPack returns the pack with the given name, or nil
+func findPack(name string) *Pack {
+	for i := range Packs {
+		if Packs[i].Name == name {
+			return &Packs[i]
+		}
+	}
+	return nil
+}
+
+// packBenchmarks returns the benchmarks of all enabled packs
+func (r *Runner) packBenchmarks() []benchmark {
+	var list []benchmark
+	for _, name := range r.config.Packs {
+		if p := findPack(name); p != nil {
+			list = append(list, p.benchmarks(r.config.PackDuration, r.verbose)...)
+		}
+	}
+	return list
+}
+
+// forkResults returns the fork results, creating them on first use
+func forkResults(res *types.Results) *types.ForkResults {
+	if res.Forks == nil {
+		res.Forks = &types.ForkResults{}
+	}
+	return res.Forks
+}
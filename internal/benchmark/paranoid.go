@@ -0,0 +1,248 @@
+package benchmark
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+const (
+	// ParanoidMinProcs is the GOMAXPROCS -paranoid raises a run to, so the
+	// goroutines of parallel benchmarks and samplers interleave and race
+	// even on single-core machines
+	ParanoidMinProcs = 4
+	// paranoidLeakGrace is how long goroutines a benchmark started get to
+	// exit after it returned
+	paranoidLeakGrace = 2 * time.Second
+	// paranoidCancelAfter is how long the cancellation re-run of a
+	// benchmark runs before its context is cancelled
+	paranoidCancelAfter = 200 * time.Millisecond
+	// paranoidCancelLimit is how soon a cancelled benchmark must return
+	paranoidCancelLimit = 5 * time.Second
+	// paranoidMaxCreators caps the goroutine creators listed per leak
+	paranoidMaxCreators = 3
+)
+
+// RaceDetectorEnabled reports whether the binary was built with -race
+func RaceDetectorEnabled() bool {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return false
+	}
+	for _, s := range info.Settings {
+		if s.Key == "-race" {
+			return s.Value == "true"
+		}
+	}
+	return false
+}
+
+// checkBenchmark runs the invariant checks of -paranoid on a benchmark that
+// just ran: that the goroutines it started have exited, and that a re-run
+// cancelled shortly after it starts returns promptly, without panicking and
+// without leaking goroutines. The re-run writes into a discarded copy of
+// results.
+func (r *Runner) checkBenchmark(ctx context.Context, b benchmark, results *types.Results, before map[string]bool) []types.InvariantViolation {
+	var violations []types.InvariantViolation
+	if leak := waitGoroutines(before); leak != "" {
+		violations = append(violations, types.InvariantViolation{Benchmark: b.name, Check: "goroutine_leak", Detail: leak})
+	}
+
+	before = goroutineIDs()
+	scratch := cloneResults(results)
+	cancelCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan error, 1)
+	start := time.Now()
+	go func() {
+		done <- safeRun(cancelCtx, b, &scratch)
+	}()
+	timer := time.AfterFunc(paranoidCancelAfter, cancel)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		if err != nil && strings.HasPrefix(err.Error(), "panic:") {
+			violations = append(violations, types.InvariantViolation{Benchmark: b.name, Check: "cancellation", Detail: err.Error()})
+		}
+		if leak := waitGoroutines(before); leak != "" {
+			violations = append(violations, types.InvariantViolation{Benchmark: b.name, Check: "goroutine_leak", Detail: "cancelled re-run: " + leak})
+		}
+	case <-time.After(paranoidCancelAfter + paranoidCancelLimit):
+		violations = append(violations, types.InvariantViolation{
+			Benchmark: b.name,
+			Check:     "cancellation",
+			Detail:    fmt.Sprintf("still running %s after its context was cancelled", time.Since(start).Round(time.Millisecond)-paranoidCancelAfter),
+		})
+	case <-ctx.Done():
+		// The run itself was interrupted
+	}
+	for _, v := range violations {
+		r.log("    Paranoid: %s: %s", v.Check, v.Detail)
+	}
+	return violations
+}
+
+// checkValues finds measurements that no benchmark can produce: NaN or
+// infinite floats, which also fail the JSON report, negative rates and
+// negative durations
+func checkValues(results *types.Results) []types.InvariantViolation {
+	var violations []types.InvariantViolation
+	sections := []struct {
+		name  string
+		value any
+	}{{"cpu", results.CPU}, {"memory", results.Memory}, {"disk", results.Disk}, {"forks", results.Forks}}
+	for _, section := range sections {
+		walkValues(reflect.ValueOf(section.value), section.name, func(path string, v reflect.Value) {
+			var detail string
+			switch {
+			case v.Kind() == reflect.Float64 && (math.IsNaN(v.Float()) || math.IsInf(v.Float(), 0)):
+				detail = fmt.Sprintf("%s is %v", path, v.Float())
+			case v.Kind() == reflect.Float64 && v.Float() < 0 && isRate(path):
+				detail = fmt.Sprintf("%s is negative (%g)", path, v.Float())
+			case v.Type() == reflect.TypeOf(time.Duration(0)) && v.Int() < 0:
+				detail = fmt.Sprintf("%s is negative (%s)", path, time.Duration(v.Int()))
+			default:
+				return
+			}
+			benchmark, _, _ := strings.Cut(path, ".")
+			if parts := strings.SplitN(path, ".", 3); len(parts) > 2 {
+				benchmark = parts[0] + "." + parts[1]
+			}
+			violations = append(violations, types.InvariantViolation{Benchmark: benchmark, Check: "value", Detail: detail})
+		})
+	}
+	return violations
+}
+
+// isRate reports whether a metric path names a throughput
+func isRate(path string) bool {
+	for _, suffix := range []string{"_per_second", "_iops", "_mbps"} {
+		if strings.HasSuffix(path, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// walkValues calls fn with every float and integer leaf under v and its
+// dotted JSON path
+func walkValues(v reflect.Value, path string, fn func(path string, v reflect.Value)) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			walkValues(v.Elem(), path, fn)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			switch {
+			case name == "-":
+				continue
+			case field.Anonymous && name == "":
+				walkValues(v.Field(i), path, fn)
+				continue
+			case name == "":
+				name = field.Name
+			}
+			walkValues(v.Field(i), path+"."+name, fn)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walkValues(v.Index(i), fmt.Sprintf("%s.%d", path, i), fn)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			walkValues(iter.Value(), fmt.Sprintf("%s.%v", path, iter.Key()), fn)
+		}
+	case reflect.Float64, reflect.Int64:
+		fn(path, v)
+	}
+}
+
+// goroutineIDs returns the IDs of all running goroutines
+func goroutineIDs() map[string]bool {
+	ids := make(map[string]bool)
+	for _, g := range goroutineStacks() {
+		ids[goroutineID(g)] = true
+	}
+	return ids
+}
+
+// waitGoroutines waits up to paranoidLeakGrace for the goroutines started
+// since before to exit, and describes those left by their creators, or
+// returns "" when none are
+func waitGoroutines(before map[string]bool) string {
+	var leaked []string
+	for wait := time.Now().Add(paranoidLeakGrace); ; {
+		leaked = leaked[:0]
+		for _, g := range goroutineStacks() {
+			if !before[goroutineID(g)] {
+				leaked = append(leaked, g)
+			}
+		}
+		if len(leaked) == 0 || time.Now().After(wait) {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if len(leaked) == 0 {
+		return ""
+	}
+
+	counts := make(map[string]int)
+	var creators []string
+	for _, g := range leaked {
+		creator := "unknown"
+		if _, after, ok := strings.Cut(g, "\ncreated by "); ok {
+			creator, _, _ = strings.Cut(after, "\n")
+			creator, _, _ = strings.Cut(creator, " in goroutine")
+		}
+		if counts[creator] == 0 {
+			creators = append(creators, creator)
+		}
+		counts[creator]++
+	}
+	var parts []string
+	for i, creator := range creators {
+		if i == paranoidMaxCreators {
+			parts = append(parts, fmt.Sprintf("%d more", len(creators)-i))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%d by %s", counts[creator], creator))
+	}
+	return fmt.Sprintf("%d goroutine(s) still running %s after it returned, created %s", len(leaked), paranoidLeakGrace, strings.Join(parts, ", "))
+}
+
+// goroutineStacks returns the stack trace of every goroutine
+func goroutineStacks() []string {
+	buf := make([]byte, 1<<20)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	return strings.Split(string(bytes.TrimSpace(buf)), "\n\n")
+}
+
+// goroutineID returns the ID from a stack trace's "goroutine 7 [running]:"
+// header
+func goroutineID(stack string) string {
+	id, _, _ := strings.Cut(strings.TrimPrefix(stack, "goroutine "), " ")
+	return id
+}
@@ -0,0 +1,197 @@
+package benchmark
+
+import (
+	"context"
+
+	"github.com/vBenchmark/internal/cpu"
+	"github.com/vBenchmark/internal/disk"
+	"github.com/vBenchmark/internal/memory"
+	"github.com/vBenchmark/internal/replay"
+	"github.com/vBenchmark/internal/scenario"
+	"github.com/vBenchmark/internal/types"
+)
+
+// benchmarkCategory groups related benchmarks in the run order
+type benchmarkCategory struct {
+	name  string
+	title string
+}
+
+// categories lists benchmark categories in execution order
+var categories = []benchmarkCategory{
+	{name: "cpu", title: "CPU"},
+	{name: "memory", title: "Memory"},
+	{name: "disk", title: "Disk"},
+	{name: "fork", title: "Fork pack"},
+}
+
+// benchmark describes a single benchmark the runner can execute
+type benchmark struct {
+	name     string // Dotted identifier, e.g. "cpu.keccak"
+	category string
+	label    string
+	run      func(ctx context.Context, results *types.Results) error
+	status   func(results *types.Results) *types.Status
+}
+
+// benchmarks returns all benchmarks in execution order with their time budgets
+func (r *Runner) benchmarks() []benchmark {
+	cpuBudget := r.config.GetCPUTimeBudget()
+	memBudget := r.config.GetMemoryTimeBudget()
+	diskBudget := r.config.GetDiskTimeBudget()
+	testDir := r.config.TestDir
+
+	list := []benchmark{
+		{"cpu.keccak", "cpu", "Keccak256 hashing", func(ctx context.Context, res *types.Results) error {
+			res.CPU.Keccak = cpu.BenchmarkKeccak256(ctx, cpuBudget.Keccak256, r.verbose)
+			return nil
+		}, func(res *types.Results) *types.Status { return &res.CPU.Keccak.Status }},
+		{"cpu.ecdsa", "cpu", "ECDSA/secp256k1 signatures", func(ctx context.Context, res *types.Results) (err error) {
+			res.CPU.ECDSA, err = cpu.BenchmarkECDSA(ctx, cpuBudget.ECDSA, r.verbose)
+			return err
+		}, func(res *types.Results) *types.Status { return &res.CPU.ECDSA.Status }},
+		{"cpu.bls", "cpu", "BLS12-381 operations", func(ctx context.Context, res *types.Results) error {
+			res.CPU.BLS = cpu.BenchmarkBLS(ctx, cpuBudget.BLS, r.verbose)
+			return nil
+		}, func(res *types.Results) *types.Status { return &res.CPU.BLS.Status }},
+		{"cpu.bn256", "cpu", "BN256 pairing", func(ctx context.Context, res *types.Results) (err error) {
+			res.CPU.BN256, err = cpu.BenchmarkBN256(ctx, cpuBudget.BN256, r.verbose)
+			return err
+		}, func(res *types.Results) *types.Status { return &res.CPU.BN256.Status }},
+		{"cpu.rlp", "cpu", "RLP encoding/decoding", func(ctx context.Context, res *types.Results) (err error) {
+			res.CPU.RLP, err = cpu.BenchmarkRLP(ctx, cpuBudget.RLP, r.verbose)
+			return err
+		}, func(res *types.Results) *types.Status { return &res.CPU.RLP.Status }},
+		{"cpu.evm", "cpu", "EVM execution", func(ctx context.Context, res *types.Results) (err error) {
+			res.CPU.EVM, err = cpu.BenchmarkEVM(ctx, cpuBudget.EVM, r.verbose)
+			return err
+		}, func(res *types.Results) *types.Status { return &res.CPU.EVM.Status }},
+		{"cpu.sha256", "cpu", "SHA-256/BLAKE2b hashing", func(ctx context.Context, res *types.Results) (err error) {
+			res.CPU.SHA256, err = cpu.BenchmarkSHA256(ctx, cpuBudget.SHA256, r.verbose)
+			return err
+		}, func(res *types.Results) *types.Status { return &res.CPU.SHA256.Status }},
+		{"cpu.kzg", "cpu", "KZG blob commitments/proofs", func(ctx context.Context, res *types.Results) (err error) {
+			res.CPU.KZG, err = cpu.BenchmarkKZG(ctx, cpuBudget.KZG, r.verbose)
+			return err
+		}, func(res *types.Results) *types.Status { return &res.CPU.KZG.Status }},
+		{"cpu.parallel", "cpu", "Multi-core scaling", func(ctx context.Context, res *types.Results) (err error) {
+			res.CPU.Parallel, err = cpu.BenchmarkParallel(ctx, r.config.CPUWorkers, cpuBudget.Parallel, r.verbose)
+			return err
+		}, func(res *types.Results) *types.Status { return &res.CPU.Parallel.Status }},
+		{"memory.trie", "memory", "Merkle Patricia Trie simulation", func(ctx context.Context, res *types.Results) error {
+			res.Memory.Trie = memory.BenchmarkTrie(ctx, r.memoryLimit, memBudget.Trie, r.verbose)
+			return nil
+		}, func(res *types.Results) *types.Status { return &res.Memory.Trie.Status }},
+		{"memory.pool", "memory", "Object pool allocation", func(ctx context.Context, res *types.Results) error {
+			res.Memory.Pool = memory.BenchmarkPool(ctx, memBudget.Pool, r.verbose)
+			return nil
+		}, func(res *types.Results) *types.Status { return &res.Memory.Pool.Status }},
+		{"memory.state_cache", "memory", "State cache operations", func(ctx context.Context, res *types.Results) error {
+			res.Memory.StateCache = memory.BenchmarkStateCache(ctx, r.memoryLimit, memBudget.StateCache, r.verbose)
+			return nil
+		}, func(res *types.Results) *types.Status { return &res.Memory.StateCache.Status }},
+		{"memory.latency", "memory", "Memory latency (pointer chase)", func(ctx context.Context, res *types.Results) error {
+			res.Memory.Latency = memory.BenchmarkLatency(ctx, r.memoryLimit, memBudget.Latency, r.verbose)
+			return nil
+		}, func(res *types.Results) *types.Status { return &res.Memory.Latency.Status }},
+		{"memory.correctness", "memory", "Correctness cross-checks", func(ctx context.Context, res *types.Results) error {
+			res.Memory.Correctness = memory.CheckCorrectness(ctx, memBudget.Correctness, r.verbose)
+			return nil
+		}, func(res *types.Results) *types.Status { return &res.Memory.Correctness.Status }},
+		{"memory.mpt", "memory", "Merkle Patricia Trie (go-ethereum)", func(ctx context.Context, res *types.Results) (err error) {
+			res.Memory.MPT, err = memory.BenchmarkMPT(ctx, r.memoryLimit, testDir, r.config.MPTDisk, r.writes, memBudget.MPT, r.verbose)
+			return err
+		}, func(res *types.Results) *types.Status { return &res.Memory.MPT.Status }},
+		{"disk.sequential", "disk", "Sequential I/O", func(ctx context.Context, res *types.Results) (err error) {
+			res.Disk.Sequential, err = disk.BenchmarkSequential(ctx, testDir, r.writes, diskBudget.Sequential, res.Disk.DirectIO == "on", r.verbose)
+			return err
+		}, func(res *types.Results) *types.Status { return &res.Disk.Sequential.Status }},
+		{"disk.random", "disk", "Random 4K I/O", func(ctx context.Context, res *types.Results) (err error) {
+			res.Disk.Random, err = disk.BenchmarkRandom(ctx, r.files, r.writes, r.config.RandomFileSize, r.config.IOJobs, r.config.QueueDepths, diskBudget.Random, res.Disk.DirectIO == "on", r.verbose)
+			return err
+		}, func(res *types.Results) *types.Status { return &res.Disk.Random.Status }},
+		{"disk.mmap", "disk", "Mmap reads (freezer)", func(ctx context.Context, res *types.Results) (err error) {
+			res.Disk.Mmap, err = disk.BenchmarkMmap(ctx, testDir, r.writes, diskBudget.Mmap, r.verbose)
+			return err
+		}, func(res *types.Results) *types.Status { return &res.Disk.Mmap.Status }},
+		{"disk.batch", "disk", "Batch writes", func(ctx context.Context, res *types.Results) (err error) {
+			res.Disk.Batch, err = disk.BenchmarkBatch(ctx, testDir, r.writes, diskBudget.Batch, res.Disk.DirectIO == "on", r.verbose)
+			return err
+		}, func(res *types.Results) *types.Status { return &res.Disk.Batch.Status }},
+		{"disk.state_scheme", "disk", "State scheme (hash vs path)", func(ctx context.Context, res *types.Results) (err error) {
+			res.Disk.StateScheme, err = disk.BenchmarkStateScheme(ctx, r.files, r.writes, diskBudget.StateScheme, r.verbose)
+			return err
+		}, func(res *types.Results) *types.Status { return &res.Disk.StateScheme.Status }},
+		{"disk.blob", "disk", "Blob sidecar store", func(ctx context.Context, res *types.Results) (err error) {
+			res.Disk.Blob, err = disk.BenchmarkBlobStore(ctx, testDir, r.writes, diskBudget.Blob, r.verbose)
+			return err
+		}, func(res *types.Results) *types.Status { return &res.Disk.Blob.Status }},
+		{"disk.kvstore", "disk", "Key-value store (Pebble, LevelDB)", func(ctx context.Context, res *types.Results) (err error) {
+			res.Disk.KVStore, err = disk.BenchmarkKVStore(ctx, testDir, r.writes, diskBudget.KVStore, r.verbose)
+			return err
+		}, func(res *types.Results) *types.Status { return &res.Disk.KVStore.Status }},
+		{"disk.fsync", "disk", "Fsync latency", func(ctx context.Context, res *types.Results) (err error) {
+			res.Disk.Fsync, err = disk.BenchmarkFsync(ctx, testDir, r.writes, diskBudget.Fsync, r.verbose)
+			return err
+		}, func(res *types.Results) *types.Status { return &res.Disk.Fsync.Status }},
+		{"disk.slot", "disk", "Slot deadline pipeline", func(ctx context.Context, res *types.Results) (err error) {
+			res.Disk.Slot, err = scenario.BenchmarkSlot(ctx, testDir, r.writes, diskBudget.Slot, r.verbose)
+			return err
+		}, func(res *types.Results) *types.Status { return &res.Disk.Slot.Status }},
+		{"disk.contention", "disk", "Combined CPU, trie and disk load", func(ctx context.Context, res *types.Results) (err error) {
+			res.Disk.Contention, err = scenario.BenchmarkContention(ctx, testDir, r.writes, diskBudget.Contention, r.verbose)
+			return err
+		}, func(res *types.Results) *types.Status { return &res.Disk.Contention.Status }},
+		{"disk.trie_commit", "disk", "Trie commit to Pebble", func(ctx context.Context, res *types.Results) (err error) {
+			res.Disk.TrieCommit, err = disk.BenchmarkTrieCommit(ctx, testDir, r.writes, diskBudget.TrieCommit, r.verbose)
+			return err
+		}, func(res *types.Results) *types.Status { return &res.Disk.TrieCommit.Status }},
+	}
+	if r.config.CopyDest != "" {
+		list = append(list, benchmark{"disk.copy", "disk", "Backup/restore copy", func(ctx context.Context, res *types.Results) error {
+			result, err := disk.BenchmarkCopy(ctx, r.files, r.writes, r.config.CopyDest, diskBudget.Copy, r.verbose)
+			res.Disk.Copy = &result
+			return err
+		}, func(res *types.Results) *types.Status {
+			// Only present when a destination is set
+			if res.Disk.Copy == nil {
+				res.Disk.Copy = &types.CopyResult{}
+			}
+			return &res.Disk.Copy.Status
+		}}, benchmark{"disk.migration", "disk", "Datadir migration", func(ctx context.Context, res *types.Results) error {
+			result, err := disk.BenchmarkMigration(ctx, r.files, r.writes, r.config.CopyDest, diskBudget.Migration, r.verbose)
+			res.Disk.Migration = &result
+			return err
+		}, func(res *types.Results) *types.Status {
+			if res.Disk.Migration == nil {
+				res.Disk.Migration = &types.MigrationResult{}
+			}
+			return &res.Disk.Migration.Status
+		}})
+	}
+	if r.config.MemoryPressure {
+		list = append(list, benchmark{"memory.pressure", "memory", "Memory pressure and swap", func(ctx context.Context, res *types.Results) error {
+			result, err := memory.BenchmarkPressure(ctx, memBudget.Pressure, r.verbose)
+			res.Memory.Pressure = &result
+			return err
+		}, func(res *types.Results) *types.Status {
+			if res.Memory.Pressure == nil {
+				res.Memory.Pressure = &types.PressureResult{}
+			}
+			return &res.Memory.Pressure.Status
+		}})
+	}
+	if r.config.Replay != "" {
+		list = append(list, benchmark{"disk.replay", "disk", "Block import replay", func(ctx context.Context, res *types.Results) error {
+			result, err := replay.Benchmark(ctx, r.config.Replay, testDir, r.writes, r.verbose)
+			res.Disk.Replay = &result
+			return err
+		}, func(res *types.Results) *types.Status {
+			if res.Disk.Replay == nil {
+				res.Disk.Replay = &types.ReplayResult{}
+			}
+			return &res.Disk.Replay.Status
+		}})
+	}
+	return append(list, r.packBenchmarks()...)
+}
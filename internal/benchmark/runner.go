@@ -1,12 +1,19 @@
 package benchmark
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"runtime"
+	"slices"
+	"strings"
 	"time"
 
-	"github.com/vBenchmark/internal/cpu"
+	"github.com/vBenchmark/internal/deadline"
 	"github.com/vBenchmark/internal/disk"
 	"github.com/vBenchmark/internal/memory"
+	"github.com/vBenchmark/internal/system"
 	"github.com/vBenchmark/internal/types"
 )
 
@@ -15,6 +22,22 @@ type Runner struct {
 	config    *Config
 	StartTime time.Time
 	verbose   bool
+	logBuf    strings.Builder
+	timeline  []types.PhaseTiming
+	writes    *disk.WriteBudget
+	files     *disk.TestFiles
+	selection *Selection
+	// paranoid collects the invariant checks of -paranoid, nil without it
+	paranoid *types.ParanoidChecks
+
+	// Checkpoint, if set, is called with the results so far after every
+	// benchmark that finished, so they survive a crash later in the run
+	Checkpoint func(results *types.Results)
+
+	// Working-set limit of the memory benchmarks from available memory
+	// (0 = none), see memory.WorkingSetLimit
+	memoryAvailableMB int
+	memoryLimit       int64
 }
 
 // NewRunner creates a new benchmark runner
@@ -22,86 +45,372 @@ func NewRunner(config *Config) *Runner {
 	return &Runner{
 		config:  config,
 		verbose: config.Verbose,
+		writes:  newWriteBudget(config),
+		files:   disk.NewTestFiles(config.TestDir, config.KeepTestFiles),
 	}
 }
 
-// RunAll executes all benchmarks and returns results
-func (r *Runner) RunAll() *types.Results {
-	r.StartTime = time.Now()
+// newWriteBudget creates the write budget, watching the test volume
+func newWriteBudget(config *Config) *disk.WriteBudget {
+	writes := disk.NewWriteBudget(config.MaxWriteBytes)
+	writes.Watch(config.TestDir)
+	return writes
+}
+
+// RunAll executes the selected benchmarks (nil = all) and returns results.
+// Benchmarks left out of the selection are marked skipped. When ctx is
+// cancelled the running benchmark stops early, its partial measurements are
+// discarded and it and all remaining benchmarks are marked skipped.
+func (r *Runner) RunAll(ctx context.Context, selection *Selection) *types.Results {
+	// Repeated runs (-runs) share one start time and write budget; the
+	// timeline covers the latest run
+	if r.StartTime.IsZero() {
+		r.StartTime = time.Now()
+	}
+	r.timeline = nil
+	r.selection = selection
 	results := &types.Results{}
+	if r.config.Paranoid {
+		r.paranoid = &types.ParanoidChecks{RaceDetector: RaceDetectorEnabled(), GOMAXPROCS: runtime.GOMAXPROCS(0)}
+	}
+	deadline.Drain()
 
-	// Run CPU benchmarks
-	r.log("Running CPU benchmarks...")
-	results.CPU = r.runCPUBenchmarks()
+	// Watch for backup jobs and upgrades that would skew results
+	monitor := system.NewInterferenceMonitor(5 * time.Second)
+	monitor.Start()
 
-	// Run Memory benchmarks
-	r.log("Running Memory benchmarks...")
-	results.Memory = r.runMemoryBenchmarks()
+	// Sample temperature and clocks to catch throttling under sustained load
+	thermal := system.NewThermalMonitor(2 * time.Second)
+	thermal.Start()
 
-	// Run Disk benchmarks
-	r.log("Running Disk benchmarks...")
-	results.Disk = r.runDiskBenchmarks()
+	// Keep laptops and desktops from sleeping mid-run
+	sleep := system.NewSleepGuard(time.Second)
+	sleep.Start()
 
-	return results
-}
+	// Measure idle baseline before loading the system
+	if r.config.IdleDuration > 0 {
+		r.log("Measuring idle baseline (%s)...", r.config.IdleDuration)
+		r.track("idle", func() {
+			idle, err := system.MeasureIdle(ctx, r.config.IdleDuration)
+			if err != nil {
+				r.log("    Idle state unknown: %v", err)
+				idle.Error = err.Error()
+			}
+			if ctx.Err() == nil {
+				results.Idle = &idle
+			}
+		})
+	}
 
-// runCPUBenchmarks executes all CPU benchmarks
-func (r *Runner) runCPUBenchmarks() types.CPUResults {
-	budget := r.config.GetCPUTimeBudget()
-	results := types.CPUResults{}
+	// Time the loop bookkeeping so its share of the fastest metrics can be reported
+	var harness *types.HarnessOverhead
+	if (r.selected("cpu") || r.selected("memory")) && ctx.Err() == nil {
+		r.log("Calibrating harness overhead...")
+		r.track("calibration", func() {
+			harness = calibrateHarness(ctx)
+		})
+	}
 
-	r.log("  [1/4] Keccak256 hashing...")
-	results.Keccak = cpu.BenchmarkKeccak256(budget.Keccak256, r.verbose)
+	if r.selected("memory") {
+		results.Memory.GOGC = r.memoryGOGC()
+		results.Memory.BallastBytes = r.config.MemoryBallast
+		r.memoryAvailableMB, r.memoryLimit = memory.WorkingSetLimit()
+	}
+	if r.selected("disk") {
+		results.Disk.Filesystem = system.DetectFilesystem(r.config.TestDir)
+		if r.config.DirectIO {
+			results.Disk.DirectIO = "on"
+			if err := disk.CheckDirectIO(r.config.TestDir); err != nil {
+				results.Disk.DirectIO = err.Error()
+				r.log("Direct I/O disabled: %v", err)
+			}
+		}
+	}
 
-	r.log("  [2/4] ECDSA/secp256k1 signatures...")
-	results.ECDSA = cpu.BenchmarkECDSA(budget.ECDSA, r.verbose)
+	// Run CPU, Memory and Disk benchmarks
+	for _, category := range categories {
+		r.runCategory(ctx, category, results)
+	}
+	loops := deadline.Drain()
+	results.Memory.Guard = r.memoryGuard(results)
+
+	// Show how GC tuning changes the memory benchmarks
+	if len(r.config.GOGCSweep) > 0 && r.selected("memory") && ctx.Err() == nil {
+		r.log("Running GOGC sweep...")
+		results.GCSweep = r.sweepGOGC(ctx, r.config.GOGCSweep)
+		deadline.Drain() // Sweep loops ran under other GOGC values
+	}
 
-	r.log("  [3/4] BLS12-381 operations...")
-	results.BLS = cpu.BenchmarkBLS(budget.BLS, r.verbose)
+	// Re-run benchmarks with isolated deviations from the reference
+	if r.config.Reference != nil && r.config.AnomalySigma > 0 && ctx.Err() == nil {
+		results.Anomalies = r.recheckAnomalies(ctx, results)
+		maps.Copy(loops, deadline.Drain()) // Re-runs replace the first results
+	}
 
-	r.log("  [4/4] BN256 pairing...")
-	results.BN256 = cpu.BenchmarkBN256(budget.BN256, r.verbose)
+	// Spread interrupts concentrated on one CPU, re-running the benchmark
+	// if the spread is applied
+	if r.selected("disk") && ctx.Err() == nil {
+		results.IRQTuning = r.tuneIRQAffinity(ctx, results)
+	}
 
+	if harness != nil && ctx.Err() == nil {
+		harness.Metrics = r.harnessMetrics(harness, results, loops)
+		results.Harness = harness
+	}
+
+	results.Disk.Writes = types.WriteUsage{
+		LimitBytes:   r.writes.Limit(),
+		WrittenBytes: r.writes.Written(),
+		LimitReached: r.writes.Exhausted(),
+	}
+	if volumeErr := r.writes.VolumeErr(nil); volumeErr != nil {
+		results.Disk.Writes.VolumeError = volumeErr.Error()
+	}
+	if r.paranoid != nil {
+		r.paranoid.Violations = append(r.paranoid.Violations, checkValues(results)...)
+		results.Paranoid = r.paranoid
+	}
+	results.Timeline = r.timeline
+	results.Interference = r.attributeInterference(monitor.Stop())
+	results.Thermal = thermal.Stop(r.timeline)
+	results.Sleep = sleep.Stop(r.timeline)
+	results.Interrupted = ctx.Err() != nil
 	return results
 }
 
-// runMemoryBenchmarks executes all memory benchmarks
-func (r *Runner) runMemoryBenchmarks() types.MemoryResults {
-	budget := r.config.GetMemoryTimeBudget()
-	results := types.MemoryResults{}
+// runCategory executes all benchmarks in a category
+func (r *Runner) runCategory(ctx context.Context, category benchmarkCategory, results *types.Results) {
+	var selected []benchmark
+	for _, b := range r.benchmarks() {
+		if b.category != category.name {
+			continue
+		}
+		if r.selection.includes(b) {
+			selected = append(selected, b)
+		} else if b.category != "fork" {
+			// Fork pack results only exist once the pack has run
+			*b.status(results) = types.Status{Skipped: true}
+		}
+	}
+	if len(selected) == 0 {
+		return
+	}
+
+	if ctx.Err() == nil {
+		r.log("Running %s benchmarks...", category.title)
+	}
+	for i, b := range selected {
+		if ctx.Err() != nil {
+			markInterrupted(b, results)
+			continue
+		}
+		r.log("  [%d/%d] %s...", i+1, len(selected), b.label)
+		var goroutines map[string]bool
+		if r.paranoid != nil {
+			goroutines = goroutineIDs()
+		}
+		r.runBenchmark(ctx, b, results)
+		if r.paranoid != nil && ctx.Err() == nil && b.status(results).OK() {
+			r.paranoid.Benchmarks++
+			r.paranoid.Violations = append(r.paranoid.Violations, r.checkBenchmark(ctx, b, results, goroutines)...)
+		}
+		if r.Checkpoint != nil && ctx.Err() == nil {
+			results.Timeline = r.timeline
+			r.Checkpoint(results)
+		}
+	}
+}
 
-	r.log("  [1/3] Merkle Patricia Trie simulation...")
-	results.Trie = memory.BenchmarkTrie(budget.Trie, r.verbose)
+// runBenchmark executes a single benchmark, recording it on the timeline.
+// Errors, panics and hangs caught by the watchdog are recorded so the
+// remaining benchmarks still run.
+// Disk benchmarks are skipped once the write limit is reached, and one that
+// fills the test volume or finds it read-only is failed with its partial
+// result and the disk benchmarks after it skipped.
+func (r *Runner) runBenchmark(ctx context.Context, b benchmark, results *types.Results) {
+	// Interrupt load shows whether one core handling the storage interrupts
+	// held the disk benchmarks back
+	if b.category == "disk" {
+		irqBefore := system.TakeIRQSnapshot()
+		defer func() {
+			r.timeline[len(r.timeline)-1].IRQ = system.TakeIRQSnapshot().Since(irqBefore)
+		}()
+	}
+	r.track(b.name, func() {
+		if b.category == "memory" {
+			defer r.isolateMemoryGC()()
+		}
+		var err error
+		var volumeErr *disk.VolumeError
+		switch {
+		case b.category != "disk":
+			err = r.runWatched(ctx, b, results)
+		case r.writes.VolumeErr(nil) != nil:
+			// An earlier benchmark filled the volume or found it read-only
+			err = r.writes.VolumeErr(nil)
+		case r.writes.Exhausted():
+			err = disk.ErrWriteLimit
+		default:
+			err = r.runWatched(ctx, b, results)
+			// The volume filling up or turning read-only fails the benchmark,
+			// keeping what it measured, whatever its refused or failed writes
+			// made it return
+			volumeErr = r.writes.VolumeErr(err)
+		}
 
-	r.log("  [2/3] Object pool allocation...")
-	results.Pool = memory.BenchmarkPool(budget.Pool, r.verbose)
+		switch {
+		case ctx.Err() != nil:
+			r.log("    Interrupted")
+			markInterrupted(b, results)
+			// A benchmark that ignored the interrupt was abandoned; record it
+			// like a hang so it is not mistaken for a clean stop
+			if errors.As(err, new(*WatchdogError)) {
+				r.log("    Abandoned: %v", err)
+				results.Errors = append(results.Errors, types.BenchmarkError{
+					Benchmark: b.name,
+					Error:     err.Error(),
+					Reason:    "timeout",
+				})
+			}
+		case volumeErr != nil:
+			r.log("    Aborted: %v", volumeErr)
+			*b.status(results) = types.Status{Error: volumeErr.Error()}
+			results.Errors = append(results.Errors, types.BenchmarkError{
+				Benchmark: b.name,
+				Error:     volumeErr.Error(),
+				Reason:    volumeErr.Reason(),
+			})
+		case err == nil:
+		case errors.Is(err, disk.ErrWriteLimit), errors.As(err, new(*disk.VolumeError)):
+			r.log("    Skipped: %v", err)
+			*b.status(results) = types.Status{Skipped: true}
+		case errors.As(err, new(*WatchdogError)):
+			r.log("    Stopped by watchdog: %v", err)
+			*b.status(results) = types.Status{Error: err.Error()}
+			results.Errors = append(results.Errors, types.BenchmarkError{
+				Benchmark: b.name,
+				Error:     err.Error(),
+				Reason:    "timeout",
+			})
+		default:
+			r.log("    Error: %v", err)
+			*b.status(results) = types.Status{Error: err.Error()}
+			results.Errors = append(results.Errors, types.BenchmarkError{
+				Benchmark: b.name,
+				Error:     err.Error(),
+			})
+		}
+	})
+}
 
-	r.log("  [3/3] State cache operations...")
-	results.StateCache = memory.BenchmarkStateCache(budget.StateCache, r.verbose)
+// memoryGuard records the memory benchmarks whose working set was reduced to
+// fit in available memory, or returns nil when none was
+func (r *Runner) memoryGuard(results *types.Results) *types.MemoryGuard {
+	guard := &types.MemoryGuard{
+		AvailableMB: r.memoryAvailableMB,
+		LimitMB:     int(r.memoryLimit >> 20),
+	}
+	m := &results.Memory
+	for name, capped := range map[string]bool{
+		"memory.trie":        m.Trie.WorkingSetCapped,
+		"memory.state_cache": m.StateCache.WorkingSetCapped,
+		"memory.latency":     m.Latency.WorkingSetCapped,
+	} {
+		if capped {
+			guard.Capped = append(guard.Capped, name)
+		}
+	}
+	if len(guard.Capped) == 0 {
+		return nil
+	}
+	slices.Sort(guard.Capped)
+	return guard
+}
 
-	return results
+// selected reports whether any benchmark of the category is selected to run
+func (r *Runner) selected(category string) bool {
+	for _, b := range r.benchmarks() {
+		if b.category == category && r.selection.includes(b) {
+			return true
+		}
+	}
+	return false
 }
 
-// runDiskBenchmarks executes all disk benchmarks
-func (r *Runner) runDiskBenchmarks() types.DiskResults {
-	budget := r.config.GetDiskTimeBudget()
-	results := types.DiskResults{}
+// safeRun runs a benchmark, converting a panic into an error. The benchmark
+// writes into a copy of results that is only kept if it was not interrupted,
+// so a cut-short run never leaves partial measurements behind.
+func safeRun(ctx context.Context, b benchmark, results *types.Results) (err error) {
+	scratch := cloneResults(results)
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+		if ctx.Err() == nil {
+			*results = scratch
+		}
+	}()
+	return b.run(ctx, &scratch)
+}
 
-	r.log("  [1/3] Sequential I/O...")
-	results.Sequential = disk.BenchmarkSequential(r.config.TestDir, budget.Sequential, r.verbose)
+// cloneResults copies results so a benchmark can write into the copy
+// without touching the original
+func cloneResults(results *types.Results) types.Results {
+	clone := *results
+	if clone.Forks != nil {
+		forks := *clone.Forks
+		clone.Forks = &forks
+	}
+	return clone
+}
 
-	r.log("  [2/3] Random 4K I/O...")
-	results.Random = disk.BenchmarkRandom(r.config.TestDir, budget.Random, r.verbose)
+// markInterrupted marks a benchmark that was cut short or never started
+// because the run was cancelled as skipped
+func markInterrupted(b benchmark, results *types.Results) {
+	// Fork pack results only exist once the pack has run
+	if b.category != "fork" {
+		*b.status(results) = types.Status{Skipped: true}
+	}
+}
 
-	r.log("  [3/3] Batch writes...")
-	results.Batch = disk.BenchmarkBatch(r.config.TestDir, budget.Batch, r.verbose)
+// track runs a single benchmark and records its start and end time and the
+// CPU time it consumed
+func (r *Runner) track(name string, fn func()) {
+	before := system.TakeCPUSnapshot()
+	phase := types.PhaseTiming{Name: name, Start: time.Now()}
+	fn()
+	phase.End = time.Now()
+	phase.CPU = system.TakeCPUSnapshot().Since(before)
+	r.timeline = append(r.timeline, phase)
+}
 
-	return results
+// attributeInterference tags each detected background job with the
+// benchmark phases it overlapped
+func (r *Runner) attributeInterference(found []types.Interference) []types.Interference {
+	for i := range found {
+		if found[i].FirstSeen.IsZero() {
+			continue
+		}
+		for _, phase := range r.timeline {
+			if found[i].FirstSeen.After(phase.End) || found[i].LastSeen.Before(phase.Start) {
+				continue
+			}
+			found[i].Phases = append(found[i].Phases, phase.Name)
+		}
+	}
+	return found
 }
 
 // log prints a message if verbose mode is enabled or always for progress
 func (r *Runner) log(format string, args ...interface{}) {
-	fmt.Printf(format+"\n", args...)
+	line := fmt.Sprintf(format+"\n", args...)
+	r.logBuf.WriteString(line)
+	fmt.Print(line)
+}
+
+// Log returns all progress messages printed during the run
+func (r *Runner) Log() string {
+	return r.logBuf.String()
 }
 
 // Duration returns the total time elapsed since benchmark start
@@ -0,0 +1,100 @@
+package benchmark
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vBenchmark/internal/replay"
+)
+
+// Selection chooses which benchmarks run. Entries are categories ("disk") or
+// benchmark names ("disk.random"). A nil Selection runs every benchmark.
+type Selection struct {
+	only []string
+	skip []string
+}
+
+// SoakSuite is the reduced suite -soak loops when neither -only nor -skip is
+// given: two CPU benchmarks that show throttling, the state cache, and the
+// disk benchmarks that show write cliffs
+const SoakSuite = "cpu.keccak,cpu.bls,memory.state_cache,disk.sequential,disk.random,disk.batch"
+
+// ParseSelection parses comma-separated -only and -skip lists, e.g.
+// "cpu,disk.random" and "memory". Unknown names are an error.
+func ParseSelection(only, skip string) (*Selection, error) {
+	if only == "" && skip == "" {
+		return nil, nil
+	}
+
+	known := make(map[string]bool)
+	for _, c := range categories {
+		known[c.name] = true
+	}
+	for _, b := range allBenchmarks() {
+		known[b.name] = true
+	}
+
+	parse := func(s string) ([]string, error) {
+		var entries []string
+		for _, part := range strings.Split(s, ",") {
+			name := strings.ToLower(strings.TrimSpace(part))
+			if name == "" {
+				continue
+			}
+			if !known[name] {
+				return nil, fmt.Errorf("unknown benchmark or category %q", part)
+			}
+			entries = append(entries, name)
+		}
+		return entries, nil
+	}
+
+	sel := &Selection{}
+	var err error
+	if sel.only, err = parse(only); err != nil {
+		return nil, err
+	}
+	if sel.skip, err = parse(skip); err != nil {
+		return nil, err
+	}
+	return sel, nil
+}
+
+// includes reports whether the benchmark is selected to run
+func (s *Selection) includes(b benchmark) bool {
+	if s == nil {
+		return true
+	}
+	matches := func(entries []string) bool {
+		for _, e := range entries {
+			if e == b.name || e == b.category {
+				return true
+			}
+		}
+		return false
+	}
+	if len(s.only) > 0 && !matches(s.only) {
+		return false
+	}
+	return !matches(s.skip)
+}
+
+// Includes reports whether the named benchmark, e.g. "disk.random", is
+// selected to run
+func (s *Selection) Includes(name string) bool {
+	category, _, _ := strings.Cut(name, ".")
+	return s.includes(benchmark{name: name, category: category})
+}
+
+// allBenchmarks lists every benchmark, including optional ones and those of
+// all fork packs
+func allBenchmarks() []benchmark {
+	config := DefaultConfig()
+	config.CopyDest = config.TestDir
+	config.Replay = replay.Builtin
+	config.MemoryPressure = true
+	for _, p := range Packs {
+		config.Packs = append(config.Packs, p.Name)
+	}
+	return (&Runner{config: config}).benchmarks()
+}
@@ -0,0 +1,123 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+const (
+	// watchdogFactor is how many times its category's time budget a
+	// benchmark may run before the watchdog considers it hung
+	watchdogFactor = 3
+	// watchdogMinTimeout keeps short quick-mode budgets from cutting off
+	// one-time setup such as loading the KZG trusted setup
+	watchdogMinTimeout = time.Minute
+	// watchdogStall is how long a benchmark past its timeout may go without
+	// writing before it is stopped; preparing a large test file on slow
+	// storage can legitimately take longer than the timeout
+	watchdogStall = 10 * time.Second
+	// watchdogGrace is how long a stopped benchmark gets to return before it
+	// is abandoned
+	watchdogGrace = 5 * time.Second
+)
+
+// WatchdogError reports a benchmark stopped by the watchdog. Hung is set
+// when it did not return after being cancelled either, for example a read
+// stuck in the kernel behind a stalled USB bridge, and was abandoned.
+// Interrupted is set when the cancellation came from an interrupt rather
+// than the timeout; Timeout is then how long the benchmark had run.
+type WatchdogError struct {
+	Timeout     time.Duration
+	Hung        bool
+	Interrupted bool
+}
+
+func (e *WatchdogError) Error() string {
+	if e.Interrupted {
+		return fmt.Sprintf("hung: did not stop within %s of the interrupt after running %s, abandoned", watchdogGrace, e.Timeout.Round(time.Second))
+	}
+	if e.Hung {
+		return fmt.Sprintf("hung: still running after %s and did not stop when cancelled, abandoned", e.Timeout)
+	}
+	return fmt.Sprintf("timed out after %s", e.Timeout)
+}
+
+// watchdogTimeout returns how long a benchmark may run, or 0 for no limit
+func (r *Runner) watchdogTimeout(b benchmark) time.Duration {
+	var budget time.Duration
+	switch b.category {
+	case "cpu":
+		budget = r.config.CPUDuration
+	case "memory":
+		budget = r.config.MemoryDuration
+	case "disk":
+		// Replay imports the whole export, however long that takes
+		if b.name == "disk.replay" {
+			return 0
+		}
+		budget = r.config.DiskDuration
+	case "fork":
+		budget = r.config.PackDuration
+	}
+	return max(budget*watchdogFactor, watchdogMinTimeout)
+}
+
+// runWatched runs a benchmark with safeRun under the watchdog. A benchmark
+// that overruns its timeout without writing is cancelled, and abandoned if
+// it does not return; either way it fails with a WatchdogError and its
+// results are discarded, so the rest of the suite still runs. An interrupted
+// benchmark that does not return in time is abandoned the same way.
+func (r *Runner) runWatched(ctx context.Context, b benchmark, results *types.Results) error {
+	timeout := r.watchdogTimeout(b)
+	if timeout == 0 {
+		return safeRun(ctx, b, results)
+	}
+
+	// The benchmark writes into its own copy, which is only taken over once
+	// it returned; an abandoned benchmark may still write to it later
+	benchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	local := cloneResults(results)
+	done := make(chan error, 1)
+	go func() {
+		done <- safeRun(benchCtx, b, &local)
+	}()
+
+	start := time.Now()
+	deadline := start.Add(timeout)
+	ticker := time.NewTicker(watchdogStall)
+	defer ticker.Stop()
+	written := r.writes.Written()
+	for {
+		select {
+		case err := <-done:
+			*results = local
+			return err
+		case <-ctx.Done():
+			// Interrupted: give the benchmark the same grace to clean up
+			select {
+			case <-done:
+				return ctx.Err()
+			case <-time.After(watchdogGrace):
+				return &WatchdogError{Timeout: time.Since(start), Hung: true, Interrupted: true}
+			}
+		case <-ticker.C:
+			progress := r.writes.Written()
+			if time.Now().Before(deadline) || progress != written {
+				written = progress
+				continue
+			}
+		}
+
+		cancel()
+		select {
+		case <-done:
+			return &WatchdogError{Timeout: timeout}
+		case <-time.After(watchdogGrace):
+			return &WatchdogError{Timeout: timeout, Hung: true}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package benchmark
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Default disk write limits per storage type. SD cards wear out quickly and
+// their slow writes barely reach the limit on healthy cards anyway.
+const (
+	defaultMaxWriteSD    = 1 << 30  // 1 GB
+	defaultMaxWriteOther = 10 << 30 // 10 GB
+	defaultMaxWriteNVMe  = 64 << 30 // 64 GB, room to fill a RAM-sized random I/O file
+)
+
+// Random I/O working set bounds. The file must be well beyond RAM: on 8 GB
+// boards a 1 GB file stays in the page cache even after fadvise.
+const (
+	minRandomFileSize = 8 << 30 // 8 GB
+	ramMultiple       = 4
+)
+
+// DefaultRandomFileSize returns the random I/O working set for a system with
+// ramMB of memory: max(4×RAM, 8 GB), capped at half the free space in the
+// test directory (freeBytes, 0 if unknown)
+func DefaultRandomFileSize(ramMB int, freeBytes uint64) int64 {
+	size := max(int64(ramMB)*ramMultiple<<20, minRandomFileSize)
+	if freeBytes > 0 {
+		size = min(size, int64(freeBytes/2))
+	}
+	return size
+}
+
+// DefaultMaxWrite returns the disk write limit for a storage type as
+// reported by system detection ("nvme", "sd", "scsi")
+func DefaultMaxWrite(diskType string) int64 {
+	switch diskType {
+	case "sd":
+		return defaultMaxWriteSD
+	case "nvme":
+		return defaultMaxWriteNVMe
+	default:
+		return defaultMaxWriteOther
+	}
+}
+
+// ParseByteSize parses a size such as "10G", "512M" or "1.5T" into bytes.
+// Suffixes are binary (K = 1024); "0" and "unlimited" disable the limit.
+func ParseByteSize(size string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(size))
+	if s == "UNLIMITED" {
+		return 0, nil
+	}
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "B"), "I")
+
+	multiplier := int64(1)
+	if n := len(s); n > 0 {
+		switch s[n-1] {
+		case 'K':
+			multiplier = 1 << 10
+		case 'M':
+			multiplier = 1 << 20
+		case 'G':
+			multiplier = 1 << 30
+		case 'T':
+			multiplier = 1 << 40
+		}
+		if multiplier > 1 {
+			s = s[:n-1]
+		}
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid size %q", size)
+	}
+	return int64(v * float64(multiplier)), nil
+}
+
+// maxQueueDepth bounds the queue depth sweep; NVMe drives saturate well
+// below it and each outstanding request is a goroutine
+const maxQueueDepth = 256
+
+// ParseQueueDepths parses a comma-separated list of random read queue
+// depths, e.g. "1,8,32"
+func ParseQueueDepths(s string) ([]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var depths []int
+	for _, part := range strings.Split(s, ",") {
+		d, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || d < 1 || d > maxQueueDepth {
+			return nil, fmt.Errorf("invalid queue depth %q (want 1-%d)", part, maxQueueDepth)
+		}
+		depths = append(depths, d)
+	}
+	return depths, nil
+}
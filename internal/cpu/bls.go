@@ -1,15 +1,20 @@
 package cpu
 
 import (
+	"context"
 	"math/big"
 	"time"
 
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 
+	"github.com/vBenchmark/internal/fastrand"
 	"github.com/vBenchmark/internal/types"
 )
 
+// blsScalars is the number of distinct secret keys cycled through when signing
+const blsScalars = 64
+
 // BenchmarkBLS measures BLS12-381 operations performance
 // This tests the actual cryptographic operations used in Ethereum consensus layer
 // Reference: nimbus/beacon_chain/spec/crypto.nim, geth uses gnark-crypto
@@ -18,7 +23,7 @@ import (
 // - G1 scalar multiplication (signature generation)
 // - Pairing operations (signature verification)
 // - G2 point addition (signature aggregation)
-func BenchmarkBLS(duration time.Duration, verbose bool) types.BLSResult {
+func BenchmarkBLS(ctx context.Context, duration time.Duration, verbose bool) types.BLSResult {
 	// Get generator points
 	_, _, g1Gen, g2Gen := bls12381.Generators()
 
@@ -26,16 +31,22 @@ func BenchmarkBLS(duration time.Duration, verbose bool) types.BLSResult {
 	// BLS signing involves multiplying the hash-to-curve point by secret key
 	signDuration := duration / 4
 	var signCount uint64
+	// Random scalars (simulated secret keys) are prepared outside the timed
+	// loop so it measures the curve arithmetic rather than the RNG
+	rng := fastrand.New("cpu.bls")
+	scalars := make([]*big.Int, blsScalars)
+	for i := range scalars {
+		var scalar fr.Element
+		scalar.SetBytes(rng.Bytes(fr.Bytes))
+		scalars[i] = scalar.BigInt(new(big.Int))
+	}
 	start := time.Now()
 
-	var scalar fr.Element
 	var result bls12381.G1Affine
 
-	for time.Since(start) < signDuration {
-		// Generate random scalar (simulates secret key)
-		scalar.SetRandom()
+	for time.Since(start) < signDuration && ctx.Err() == nil {
 		// G1 scalar multiplication (core signing operation)
-		result.ScalarMultiplication(&g1Gen, scalar.BigInt(new(big.Int)))
+		result.ScalarMultiplication(&g1Gen, scalars[signCount%blsScalars])
 		signCount++
 	}
 	signElapsed := time.Since(start)
@@ -51,7 +62,7 @@ func BenchmarkBLS(duration time.Duration, verbose bool) types.BLSResult {
 	g1Points := []bls12381.G1Affine{g1Gen}
 	g2Points := []bls12381.G2Affine{g2Gen}
 
-	for time.Since(start) < verifyDuration {
+	for time.Since(start) < verifyDuration && ctx.Err() == nil {
 		// Pairing operation (core verification)
 		_, err := bls12381.Pair(g1Points, g2Points)
 		if err == nil {
@@ -70,7 +81,7 @@ func BenchmarkBLS(duration time.Duration, verbose bool) types.BLSResult {
 	var g2Jac bls12381.G2Jac
 	g2Jac.FromAffine(&g2Gen)
 
-	for time.Since(start) < aggDuration {
+	for time.Since(start) < aggDuration && ctx.Err() == nil {
 		// Simulate aggregating 64 signatures (typical committee size)
 		var aggResult bls12381.G2Jac
 		for i := 0; i < 64; i++ {
@@ -91,7 +102,7 @@ func BenchmarkBLS(duration time.Duration, verbose bool) types.BLSResult {
 	multiG1 := []bls12381.G1Affine{g1Gen, g1Gen, g1Gen, g1Gen}
 	multiG2 := []bls12381.G2Affine{g2Gen, g2Gen, g2Gen, g2Gen}
 
-	for time.Since(start) < batchDuration {
+	for time.Since(start) < batchDuration && ctx.Err() == nil {
 		// Multi-pairing (batch verification)
 		_, err := bls12381.Pair(multiG1, multiG2)
 		if err == nil {
@@ -1,30 +1,32 @@
 package cpu
 
 import (
-	"crypto/rand"
+	"context"
+	"fmt"
 	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
 
+	"github.com/vBenchmark/internal/fastrand"
 	"github.com/vBenchmark/internal/types"
 )
 
 // BenchmarkBN256 measures BN256 elliptic curve operations
 // These are used in EVM precompiled contracts for zkSNARK verification
 // Reference: geth/core/vm/contracts.go (bn256Add, bn256ScalarMul, bn256Pairing)
-func BenchmarkBN256(duration time.Duration, verbose bool) types.BN256Result {
+func BenchmarkBN256(ctx context.Context, duration time.Duration, verbose bool) (types.BN256Result, error) {
 	// Generate random test points
-	_, g1a, err := bn256.RandomG1(rand.Reader)
+	rng := fastrand.New("cpu.bn256")
+	_, g1a, err := bn256.RandomG1(rng)
 	if err != nil {
-		return types.BN256Result{Rating: "Error"}
+		return types.BN256Result{}, fmt.Errorf("failed to generate test points: %w", err)
 	}
-	_, g1b, _ := bn256.RandomG1(rand.Reader)
-	_, g2a, _ := bn256.RandomG2(rand.Reader)
+	_, g1b, _ := bn256.RandomG1(rng)
+	_, g2a, _ := bn256.RandomG2(rng)
 
 	// Generate random scalar for multiplication
-	scalar := make([]byte, 32)
-	rand.Read(scalar)
+	scalar := rng.Bytes(32)
 	scalarInt := new(big.Int).SetBytes(scalar)
 
 	// Phase 1: G1 point addition (precompile 0x06)
@@ -32,7 +34,7 @@ func BenchmarkBN256(duration time.Duration, verbose bool) types.BN256Result {
 	var addCount uint64
 	start := time.Now()
 
-	for time.Since(start) < addDuration {
+	for time.Since(start) < addDuration && ctx.Err() == nil {
 		result := new(bn256.G1)
 		result.Add(g1a, g1b)
 		addCount++
@@ -45,7 +47,7 @@ func BenchmarkBN256(duration time.Duration, verbose bool) types.BN256Result {
 	var mulCount uint64
 	start = time.Now()
 
-	for time.Since(start) < mulDuration {
+	for time.Since(start) < mulDuration && ctx.Err() == nil {
 		result := new(bn256.G1)
 		result.ScalarMult(g1a, scalarInt)
 		mulCount++
@@ -59,7 +61,7 @@ func BenchmarkBN256(duration time.Duration, verbose bool) types.BN256Result {
 	var pairCount uint64
 	start = time.Now()
 
-	for time.Since(start) < pairDuration {
+	for time.Since(start) < pairDuration && ctx.Err() == nil {
 		bn256.Pair(g1a, g2a)
 		pairCount++
 	}
@@ -74,7 +76,7 @@ func BenchmarkBN256(duration time.Duration, verbose bool) types.BN256Result {
 		PairingsPerSecond:     pairRate,
 		Duration:              totalDuration,
 		Rating:                rateBN256(pairRate),
-	}
+	}, nil
 }
 
 // rateBN256 provides a rating based on pairing operations per second
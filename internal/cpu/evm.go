@@ -0,0 +1,246 @@
+package cpu
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+
+	"github.com/vBenchmark/internal/evmcode"
+	"github.com/vBenchmark/internal/fastrand"
+	"github.com/vBenchmark/internal/types"
+)
+
+const (
+	evmGasLimit      = 30_000_000 // Per call, a mainnet block's worth
+	evmArithLoops    = 1000       // Iterations of the arithmetic loop per call
+	evmStorageSlots  = 32         // Slots read and rewritten per storage call
+	evmTokenHolders  = 1024       // ERC-20 recipients, cycled so slots stay cold per call
+	evmTransferValue = 1
+)
+
+var (
+	evmSender  = common.HexToAddress("0x00000000000000000000000000000000000e0a01")
+	evmArith   = common.HexToAddress("0x00000000000000000000000000000000000e0c01")
+	evmStorage = common.HexToAddress("0x00000000000000000000000000000000000e0c02")
+	evmToken   = common.HexToAddress("0x00000000000000000000000000000000000e0c03")
+)
+
+// BenchmarkEVM measures go-ethereum's EVM interpreter executing three
+// contracts against an in-memory state: an arithmetic loop, SLOAD/SSTORE
+// over committed storage, and ERC-20 transfers. Each call runs like a
+// transaction (fresh access list, cold slots) and is reverted afterwards so
+// every call does the same work and burns the same gas.
+// Reference: geth/core/state_transition.go, geth/core/vm/interpreter.go
+func BenchmarkEVM(ctx context.Context, duration time.Duration, verbose bool) (types.EVMResult, error) {
+	rng := fastrand.New("cpu.evm")
+	statedb, holders, err := newEVMState(rng)
+	if err != nil {
+		return types.EVMResult{}, fmt.Errorf("failed to set up EVM state: %w", err)
+	}
+
+	cfg := &runtime.Config{
+		ChainConfig: params.MainnetChainConfig,
+		Origin:      evmSender,
+		BlockNumber: big.NewInt(21_000_000),
+		Time:        *params.MainnetChainConfig.CancunTime,
+		Difficulty:  new(big.Int),
+		GasLimit:    evmGasLimit,
+		GasPrice:    big.NewInt(params.InitialBaseFee),
+		BaseFee:     big.NewInt(params.InitialBaseFee),
+		BlobBaseFee: big.NewInt(params.BlobTxMinBlobGasprice),
+		Random:      &common.Hash{},
+		State:       statedb,
+		GetHashFn:   func(n uint64) common.Hash { return common.Hash{} },
+	}
+	evm := runtime.NewEnv(cfg)
+	rules := cfg.ChainConfig.Rules(cfg.BlockNumber, true, cfg.Time)
+	precompiles := vm.ActivePrecompiles(rules)
+
+	// call executes one transaction-like call and reverts its state changes
+	call := func(to common.Address, input []byte) ([]byte, uint64, error) {
+		statedb.Prepare(rules, evmSender, common.Address{}, &to, precompiles, nil)
+		snapshot := statedb.Snapshot()
+		ret, leftOver, err := evm.Call(vm.AccountRef(evmSender), to, input, evmGasLimit, new(uint256.Int))
+		statedb.RevertToSnapshot(snapshot)
+		return ret, evmGasLimit - leftOver, err
+	}
+
+	inputs := make([][]byte, len(holders))
+	for i, holder := range holders {
+		inputs[i] = evmcode.TransferInput(holder, evmTransferValue)
+	}
+	if err := checkEVM(statedb, evm, rules, precompiles, holders[0], inputs[0]); err != nil {
+		return types.EVMResult{}, err
+	}
+
+	phase := func(d time.Duration, to common.Address, input func(n uint64) []byte) (calls, gas uint64, elapsed time.Duration, err error) {
+		start := time.Now()
+		for time.Since(start) < d && ctx.Err() == nil {
+			_, used, err := call(to, input(calls))
+			if err != nil {
+				return 0, 0, 0, err
+			}
+			gas += used
+			calls++
+		}
+		return calls, gas, time.Since(start), nil
+	}
+
+	// Phase 1: Arithmetic loop (ADD, MUL, MULMOD, SHR, XOR, MOD, jumps)
+	_, arithGas, arithElapsed, err := phase(duration*3/10, evmArith, func(uint64) []byte { return nil })
+	if err != nil {
+		return types.EVMResult{}, fmt.Errorf("arithmetic contract failed: %w", err)
+	}
+
+	// Phase 2: Storage (cold SLOAD, then SSTORE of a committed slot)
+	_, storageGas, storageElapsed, err := phase(duration*3/10, evmStorage, func(uint64) []byte { return nil })
+	if err != nil {
+		return types.EVMResult{}, fmt.Errorf("storage contract failed: %w", err)
+	}
+
+	// Phase 3: ERC-20 transfers (mapping lookups, balance updates, Transfer log)
+	transfers, transferGas, transferElapsed, err := phase(duration*4/10, evmToken, func(n uint64) []byte { return inputs[n%uint64(len(inputs))] })
+	if err != nil {
+		return types.EVMResult{}, fmt.Errorf("ERC-20 transfer failed: %w", err)
+	}
+
+	totalElapsed := arithElapsed + storageElapsed + transferElapsed
+	mgas := float64(arithGas+storageGas+transferGas) / 1e6 / totalElapsed.Seconds()
+
+	if verbose {
+		fmt.Printf("    EVM: %.1f Mgas/s overall, %.0f ERC-20 transfers/s\n", mgas, float64(transfers)/transferElapsed.Seconds())
+	}
+
+	return types.EVMResult{
+		MGasPerSecond:           mgas,
+		ArithmeticMGasPerSecond: float64(arithGas) / 1e6 / arithElapsed.Seconds(),
+		StorageMGasPerSecond:    float64(storageGas) / 1e6 / storageElapsed.Seconds(),
+		TransferMGasPerSecond:   float64(transferGas) / 1e6 / transferElapsed.Seconds(),
+		TransfersPerSecond:      float64(transfers) / transferElapsed.Seconds(),
+		Duration:                totalElapsed,
+		Rating:                  rateEVM(mgas),
+	}, nil
+}
+
+// newEVMState deploys the workload contracts with committed storage and
+// token balances, and returns the reopened state and the token holders
+func newEVMState(rng *fastrand.Source) (*state.StateDB, []common.Address, error) {
+	db := state.NewDatabaseForTesting()
+	statedb, err := state.New(gethtypes.EmptyRootHash, db)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	statedb.SetCode(evmArith, arithmeticProgram())
+	statedb.SetCode(evmStorage, storageProgram())
+	for i := uint64(0); i < evmStorageSlots; i++ {
+		statedb.SetState(evmStorage, common.BigToHash(new(big.Int).SetUint64(i)), common.BigToHash(new(big.Int).SetUint64(i+1)))
+	}
+
+	statedb.SetCode(evmToken, evmcode.ERC20())
+	statedb.SetState(evmToken, evmcode.BalanceSlot(evmSender), common.BigToHash(new(big.Int).Lsh(big.NewInt(1), 200)))
+	holders := make([]common.Address, evmTokenHolders)
+	for i := range holders {
+		rng.Read(holders[i][:])
+		balance := new(big.Int).SetUint64(rng.Uint64()>>16 + 1)
+		statedb.SetState(evmToken, evmcode.BalanceSlot(holders[i]), common.BigToHash(balance))
+	}
+
+	root, err := statedb.Commit(0, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	statedb, err = state.New(root, db)
+	return statedb, holders, err
+}
+
+// checkEVM runs one ERC-20 transfer and checks the return value and both
+// balances before reverting it, so a broken contract fails loudly instead of
+// reporting the gas of a revert
+func checkEVM(statedb *state.StateDB, evm *vm.EVM, rules params.Rules, precompiles []common.Address, holder common.Address, input []byte) error {
+	senderBefore := statedb.GetState(evmToken, evmcode.BalanceSlot(evmSender)).Big()
+	holderBefore := statedb.GetState(evmToken, evmcode.BalanceSlot(holder)).Big()
+
+	statedb.Prepare(rules, evmSender, common.Address{}, &evmToken, precompiles, nil)
+	snapshot := statedb.Snapshot()
+	defer statedb.RevertToSnapshot(snapshot)
+	ret, _, err := evm.Call(vm.AccountRef(evmSender), evmToken, input, evmGasLimit, new(uint256.Int))
+	if err != nil {
+		return fmt.Errorf("ERC-20 self-check failed: %w", err)
+	}
+
+	senderAfter := statedb.GetState(evmToken, evmcode.BalanceSlot(evmSender)).Big()
+	holderAfter := statedb.GetState(evmToken, evmcode.BalanceSlot(holder)).Big()
+	value := big.NewInt(evmTransferValue)
+	switch {
+	case new(big.Int).SetBytes(ret).Cmp(big.NewInt(1)) != 0:
+		return fmt.Errorf("ERC-20 self-check failed: transfer returned %x", ret)
+	case new(big.Int).Sub(senderBefore, senderAfter).Cmp(value) != 0,
+		new(big.Int).Sub(holderAfter, holderBefore).Cmp(value) != 0:
+		return fmt.Errorf("ERC-20 self-check failed: balances did not move by %d", evmTransferValue)
+	}
+	return nil
+}
+
+// arithmeticProgram loops evmArithLoops times over a mix of 256-bit
+// arithmetic and returns the accumulator
+func arithmeticProgram() []byte {
+	mul := crypto.Keccak256([]byte("ethbench.evm.mul"))
+	mod := crypto.Keccak256([]byte("ethbench.evm.mod"))
+	p := evmcode.NewProgram()
+	p.Push(nil).PushUint(evmArithLoops) // [acc, i]
+	p.Jumpdest("loop")
+	p.Op(vm.DUP1, vm.ISZERO).PushLabel("done").Op(vm.JUMPI)
+	p.Op(vm.SWAP1).Push(mul).Op(vm.MUL, vm.DUP2, vm.ADD)    // x = acc*mul + i
+	p.Op(vm.DUP1).PushUint(7).Op(vm.SHR, vm.XOR)            // x ^= x >> 7
+	p.Push(mod).Op(vm.DUP2, vm.DUP3, vm.MULMOD, vm.ADD)     // x += x*x % mod
+	p.Op(vm.DUP1).PushUint(13).Op(vm.SWAP1, vm.MOD, vm.ADD) // x += x % 13
+	p.Op(vm.SWAP1).PushUint(1).Op(vm.SWAP1, vm.SUB)         // [x, i-1]
+	p.PushLabel("loop").Op(vm.JUMP)
+	p.Jumpdest("done")
+	p.Op(vm.POP).Push(nil).Op(vm.MSTORE).PushUint(32).Push(nil).Op(vm.RETURN)
+	return p.Bytecode()
+}
+
+// storageProgram increments slots evmStorageSlots-1 down to 0
+func storageProgram() []byte {
+	p := evmcode.NewProgram()
+	p.PushUint(evmStorageSlots) // [i]
+	p.Jumpdest("loop")
+	p.Op(vm.DUP1, vm.ISZERO).PushLabel("done").Op(vm.JUMPI)
+	p.PushUint(1).Op(vm.SWAP1, vm.SUB)  // [i-1]
+	p.Op(vm.DUP1, vm.SLOAD).PushUint(1) // [i, v, 1]
+	p.Op(vm.ADD, vm.DUP2, vm.SSTORE)    // slot[i] = v + 1
+	p.PushLabel("loop").Op(vm.JUMP)
+	p.Jumpdest("done")
+	p.Op(vm.STOP)
+	return p.Bytecode()
+}
+
+// rateEVM provides a rating based on overall interpreter throughput. Cold
+// storage access is charged its mainnet gas but served from memory, so these
+// figures are well above what a node reading state from disk sustains.
+func rateEVM(mgasPerSecond float64) string {
+	switch {
+	case mgasPerSecond >= 800:
+		return "Excellent"
+	case mgasPerSecond >= 400:
+		return "Good"
+	case mgasPerSecond >= 200:
+		return "Adequate"
+	case mgasPerSecond >= 100:
+		return "Marginal"
+	default:
+		return "Poor"
+	}
+}
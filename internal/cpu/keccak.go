@@ -2,12 +2,14 @@
 package cpu
 
 import (
-	"crypto/rand"
+	"context"
 	"sync"
 	"time"
 
 	"golang.org/x/crypto/sha3"
 
+	"github.com/vBenchmark/internal/deadline"
+	"github.com/vBenchmark/internal/fastrand"
 	"github.com/vBenchmark/internal/types"
 )
 
@@ -21,7 +23,7 @@ var hasherPool = sync.Pool{
 
 // BenchmarkKeccak256 measures Keccak256 hashing performance
 // This is critical for state trie operations and transaction hashing
-func BenchmarkKeccak256(duration time.Duration, verbose bool) types.KeccakResult {
+func BenchmarkKeccak256(ctx context.Context, duration time.Duration, verbose bool) types.KeccakResult {
 	// Input sizes matching Ethereum data patterns:
 	// - 32 bytes: hash of hash (common in tries)
 	// - 64 bytes: two concatenated hashes
@@ -30,18 +32,18 @@ func BenchmarkKeccak256(duration time.Duration, verbose bool) types.KeccakResult
 	inputSizes := []int{32, 64, 128, 550}
 
 	// Pre-generate test data
+	rng := fastrand.New("cpu.keccak")
 	testData := make([][]byte, len(inputSizes))
 	for i, size := range inputSizes {
-		testData[i] = make([]byte, size)
-		rand.Read(testData[i])
+		testData[i] = rng.Bytes(size)
 	}
 
 	var totalHashes uint64
 	var totalBytes uint64
 	output := make([]byte, 32)
 
-	start := time.Now()
-	for time.Since(start) < duration {
+	loop := deadline.Start(ctx, "cpu.keccak.hashes_per_second", duration)
+	for loop.Next() {
 		for i, data := range testData {
 			// Get hasher from pool (like Geth does)
 			hasher := hasherPool.Get().(sha3.ShakeHash)
@@ -55,7 +57,7 @@ func BenchmarkKeccak256(duration time.Duration, verbose bool) types.KeccakResult
 		}
 	}
 
-	elapsed := time.Since(start)
+	elapsed := loop.Elapsed()
 	hashesPerSec := float64(totalHashes) / elapsed.Seconds()
 	dataMB := float64(totalBytes) / (1024 * 1024)
 
@@ -0,0 +1,109 @@
+package cpu
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+
+	"github.com/vBenchmark/internal/fastrand"
+	"github.com/vBenchmark/internal/types"
+	"github.com/vBenchmark/internal/workload"
+)
+
+// Blob verification workload (EIP-4844)
+const (
+	kzgBlobs          = 4 // Distinct blobs cycled through so no result is reused
+	fieldElementBytes = 32
+)
+
+// BenchmarkKZG measures the KZG work post-Deneb nodes do for every blob:
+// computing a commitment (block building, blob transactions in the pool) and
+// verifying a blob proof against its commitment (gossip and block import)
+// Reference: geth/crypto/kzg4844 (go-kzg-4844 backend)
+func BenchmarkKZG(ctx context.Context, duration time.Duration, verbose bool) (types.KZGResult, error) {
+	blobs := make([]*kzg4844.Blob, kzgBlobs)
+	commitments := make([]kzg4844.Commitment, kzgBlobs)
+	proofs := make([]kzg4844.Proof, kzgBlobs)
+
+	// The first call loads the trusted setup, so prepare outside the timed phases
+	rng := fastrand.New("cpu.kzg")
+	for i := range blobs {
+		blobs[i] = randomBlob(rng)
+		commitment, err := kzg4844.BlobToCommitment(blobs[i])
+		if err != nil {
+			return types.KZGResult{}, fmt.Errorf("failed to compute blob commitment: %w", err)
+		}
+		proof, err := kzg4844.ComputeBlobProof(blobs[i], commitment)
+		if err != nil {
+			return types.KZGResult{}, fmt.Errorf("failed to compute blob proof: %w", err)
+		}
+		commitments[i], proofs[i] = commitment, proof
+	}
+
+	// Phase 1: Blob commitments
+	commitDuration := duration * 2 / 5
+	var commits uint64
+	start := time.Now()
+	for time.Since(start) < commitDuration && ctx.Err() == nil {
+		if _, err := kzg4844.BlobToCommitment(blobs[commits%kzgBlobs]); err != nil {
+			return types.KZGResult{}, fmt.Errorf("failed to compute blob commitment: %w", err)
+		}
+		commits++
+	}
+	commitElapsed := time.Since(start)
+
+	// Phase 2: Blob proof verification
+	verifyDuration := duration * 3 / 5
+	var verifies uint64
+	start = time.Now()
+	for time.Since(start) < verifyDuration && ctx.Err() == nil {
+		i := verifies % kzgBlobs
+		if err := kzg4844.VerifyBlobProof(blobs[i], commitments[i], proofs[i]); err != nil {
+			return types.KZGResult{}, fmt.Errorf("failed to verify blob proof: %w", err)
+		}
+		verifies++
+	}
+	verifyElapsed := time.Since(start)
+
+	verifiesPerSec := float64(verifies) / verifyElapsed.Seconds()
+	// Realtime factor against full blocks of the workload profile
+	maxBlobs := workload.Current.Blobs.MaxPerBlock
+	realtime := verifiesPerSec / (float64(maxBlobs) / float64(workload.Current.Block.SlotSeconds))
+
+	return types.KZGResult{
+		CommitmentsPerSecond:   float64(commits) / commitElapsed.Seconds(),
+		VerificationsPerSecond: verifiesPerSec,
+		RealtimeFactor:         realtime,
+		Duration:               commitElapsed + verifyElapsed,
+		Rating:                 rateKZG(verifiesPerSec),
+	}, nil
+}
+
+// randomBlob returns a blob of random field elements. The top byte of each
+// element is cleared so it stays below the BLS12-381 scalar field modulus.
+func randomBlob(rng *fastrand.Source) *kzg4844.Blob {
+	blob := new(kzg4844.Blob)
+	rng.Read(blob[:])
+	for i := 0; i < len(blob); i += fieldElementBytes {
+		blob[i] = 0
+	}
+	return blob
+}
+
+// rateKZG provides a rating based on blob proof verifications per second
+func rateKZG(verifiesPerSec float64) string {
+	switch {
+	case verifiesPerSec >= 500:
+		return "Excellent"
+	case verifiesPerSec >= 200:
+		return "Good"
+	case verifiesPerSec >= 100:
+		return "Adequate"
+	case verifiesPerSec >= 40:
+		return "Marginal"
+	default:
+		return "Poor"
+	}
+}
@@ -0,0 +1,161 @@
+package cpu
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/vBenchmark/internal/fastrand"
+	"github.com/vBenchmark/internal/types"
+)
+
+// parallelOp creates a per-worker operation closure; each worker gets its
+// own state so workers never contend on shared data
+type parallelOp struct {
+	name    string
+	newWork func() func()
+}
+
+// BenchmarkParallel runs the core operation of each CPU benchmark on one
+// goroutine and then on all workers, reporting throughput and scaling
+// efficiency. Real clients verify signatures and hash tries in parallel.
+func BenchmarkParallel(ctx context.Context, workers int, duration time.Duration, verbose bool) (types.CPUParallelResult, error) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	ops, err := parallelOps()
+	if err != nil {
+		return types.CPUParallelResult{Workers: workers}, err
+	}
+	opDuration := duration / time.Duration(len(ops))
+	result := types.CPUParallelResult{Workers: workers}
+
+	// Ideal speedup is bounded by the number of cores
+	ideal := workers
+	if cores := runtime.NumCPU(); cores < ideal {
+		ideal = cores
+	}
+
+	var efficiencySum float64
+	for _, op := range ops {
+		single := runWorkers(ctx, 1, opDuration/2, op.newWork)
+		multi := runWorkers(ctx, workers, opDuration/2, op.newWork)
+
+		point := types.ParallelScaling{
+			Operation:      op.name,
+			SingleCoreRate: single,
+			AllCoreRate:    multi,
+		}
+		if single > 0 {
+			point.Speedup = multi / single
+			point.Efficiency = point.Speedup / float64(ideal) * 100
+		}
+		efficiencySum += point.Efficiency
+		result.Operations = append(result.Operations, point)
+		result.Duration += opDuration
+	}
+	result.ScalingEfficiency = efficiencySum / float64(len(ops))
+	result.Rating = rateParallel(result.ScalingEfficiency)
+
+	return result, nil
+}
+
+// parallelOps prepares the core operation of each CPU benchmark
+func parallelOps() ([]parallelOp, error) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	pubKeyBytes := crypto.FromECDSAPub(privateKey.Public().(*ecdsa.PublicKey))
+	rng := fastrand.New("cpu.parallel")
+	message := rng.Bytes(32)
+	signature, err := crypto.Sign(message, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign message: %w", err)
+	}
+
+	_, _, g1Gen, g2Gen := bls12381.Generators()
+	_, bnG1, _ := bn256.RandomG1(rng)
+	_, bnG2, _ := bn256.RandomG2(rng)
+
+	return []parallelOp{
+		{"keccak256", func() func() {
+			data := rng.Bytes(128)
+			output := make([]byte, 32)
+			hasher := sha3.NewLegacyKeccak256().(sha3.ShakeHash)
+			return func() {
+				hasher.Reset()
+				hasher.Write(data)
+				hasher.Read(output)
+			}
+		}},
+		{"ecdsa_verify", func() func() {
+			return func() {
+				crypto.VerifySignature(pubKeyBytes, message, signature[:64])
+			}
+		}},
+		{"bls_pairing", func() func() {
+			g1 := []bls12381.G1Affine{g1Gen}
+			g2 := []bls12381.G2Affine{g2Gen}
+			return func() {
+				bls12381.Pair(g1, g2)
+			}
+		}},
+		{"bn256_pairing", func() func() {
+			return func() {
+				bn256.Pair(bnG1, bnG2)
+			}
+		}},
+	}, nil
+}
+
+// runWorkers runs work on the given number of goroutines for duration and
+// returns the combined operations per second
+func runWorkers(ctx context.Context, workers int, duration time.Duration, newWork func() func()) float64 {
+	var total atomic.Uint64
+	var wg sync.WaitGroup
+
+	start := time.Now()
+	for w := 0; w < workers; w++ {
+		work := newWork()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var count uint64
+			for time.Since(start) < duration && ctx.Err() == nil {
+				work()
+				count++
+			}
+			total.Add(count)
+		}()
+	}
+	wg.Wait()
+
+	return float64(total.Load()) / time.Since(start).Seconds()
+}
+
+// rateParallel provides a rating based on average scaling efficiency
+func rateParallel(efficiency float64) string {
+	switch {
+	case efficiency >= 90:
+		return "Excellent"
+	case efficiency >= 75:
+		return "Good"
+	case efficiency >= 60:
+		return "Adequate"
+	case efficiency >= 40:
+		return "Marginal"
+	default:
+		return "Poor"
+	}
+}
@@ -0,0 +1,182 @@
+package cpu
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/vBenchmark/internal/deadline"
+	"github.com/vBenchmark/internal/fastrand"
+	"github.com/vBenchmark/internal/types"
+	"github.com/vBenchmark/internal/workload"
+)
+
+// BenchmarkRLP measures RLP serialization of transactions, headers and
+// receipts, which Geth does for every block it imports, stores and serves
+// Reference: geth/rlp/encode.go, geth/core/types/transaction.go
+func BenchmarkRLP(ctx context.Context, duration time.Duration, verbose bool) (types.RLPResult, error) {
+	rng := fastrand.New("cpu.rlp")
+	tx, err := syntheticTransaction(rng)
+	if err != nil {
+		return types.RLPResult{}, err
+	}
+	objects := []struct {
+		value  any
+		decode func([]byte) error
+	}{
+		{tx, func(b []byte) error { return rlp.DecodeBytes(b, new(gethtypes.Transaction)) }},
+		{syntheticHeader(rng), func(b []byte) error { return rlp.DecodeBytes(b, new(gethtypes.Header)) }},
+		{syntheticReceipt(rng), func(b []byte) error { return rlp.DecodeBytes(b, new(gethtypes.Receipt)) }},
+	}
+
+	// Pre-encode once so the decode phase has valid input
+	encoded := make([][]byte, len(objects))
+	for i, obj := range objects {
+		if encoded[i], err = rlp.EncodeToBytes(obj.value); err != nil {
+			return types.RLPResult{}, fmt.Errorf("failed to encode %T: %w", obj.value, err)
+		}
+	}
+
+	// Phase 1: Encoding (block propagation, database writes)
+	encodeDuration := duration / 2
+	var encodes, encodedBytes uint64
+	loop := deadline.Start(ctx, "cpu.rlp.encodes_per_second", encodeDuration)
+	for loop.Next() {
+		for _, obj := range objects {
+			b, err := rlp.EncodeToBytes(obj.value)
+			if err != nil {
+				return types.RLPResult{}, fmt.Errorf("failed to encode %T: %w", obj.value, err)
+			}
+			encodes++
+			encodedBytes += uint64(len(b))
+		}
+	}
+	encodeElapsed := loop.Elapsed()
+
+	// Phase 2: Decoding (block import, database reads)
+	decodeDuration := duration / 2
+	var decodes uint64
+	loop = deadline.Start(ctx, "cpu.rlp.decodes_per_second", decodeDuration)
+	for loop.Next() {
+		for i, obj := range objects {
+			if err := obj.decode(encoded[i]); err != nil {
+				return types.RLPResult{}, fmt.Errorf("failed to decode %T: %w", obj.value, err)
+			}
+			decodes++
+		}
+	}
+	decodeElapsed := loop.Elapsed()
+
+	encodesPerSec := float64(encodes) / encodeElapsed.Seconds()
+	decodesPerSec := float64(decodes) / decodeElapsed.Seconds()
+
+	return types.RLPResult{
+		EncodesPerSecond: encodesPerSec,
+		DecodesPerSecond: decodesPerSec,
+		EncodeMBPerSec:   float64(encodedBytes) / encodeElapsed.Seconds() / (1024 * 1024),
+		Duration:         encodeElapsed + decodeElapsed,
+		Rating:           rateRLP((encodesPerSec + decodesPerSec) / 2),
+	}, nil
+}
+
+// syntheticTransaction returns a signed EIP-1559 token transfer
+func syntheticTransaction(rng *fastrand.Source) (*gethtypes.Transaction, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	to := common.BytesToAddress(rng.Bytes(20))
+	data := rng.Bytes(68) // ERC-20 transfer(address,uint256) calldata
+	chainID := big.NewInt(1)
+	tx, err := gethtypes.SignNewTx(key, gethtypes.LatestSignerForChainID(chainID), &gethtypes.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     42,
+		GasTipCap: big.NewInt(1_000_000_000),
+		GasFeeCap: big.NewInt(30_000_000_000),
+		Gas:       65_000,
+		To:        &to,
+		Value:     big.NewInt(0),
+		Data:      data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// blobGasPerBlob is the blob gas each blob consumes (EIP-4844 GAS_PER_BLOB)
+const blobGasPerBlob = 1 << 17
+
+// syntheticHeader returns a block header shaped like the workload profile's
+// mainnet blocks
+func syntheticHeader(rng *fastrand.Source) *gethtypes.Header {
+	block := workload.Current.Block
+	blobGasUsed, excessBlobGas := uint64(workload.Current.Blobs.TargetPerBlock)*blobGasPerBlob, uint64(0)
+	withdrawalsHash := common.BytesToHash(rng.Bytes(32))
+	beaconRoot := common.BytesToHash(rng.Bytes(32))
+	return &gethtypes.Header{
+		ParentHash:       common.BytesToHash(rng.Bytes(32)),
+		UncleHash:        gethtypes.EmptyUncleHash,
+		Coinbase:         common.BytesToAddress(rng.Bytes(20)),
+		Root:             common.BytesToHash(rng.Bytes(32)),
+		TxHash:           common.BytesToHash(rng.Bytes(32)),
+		ReceiptHash:      common.BytesToHash(rng.Bytes(32)),
+		Bloom:            gethtypes.BytesToBloom(rng.Bytes(gethtypes.BloomByteLength)),
+		Difficulty:       big.NewInt(0),
+		Number:           big.NewInt(block.Number),
+		GasLimit:         block.GasLimit,
+		GasUsed:          block.GasUsed,
+		Time:             1_730_000_000,
+		Extra:            rng.Bytes(16),
+		MixDigest:        common.BytesToHash(rng.Bytes(32)),
+		BaseFee:          big.NewInt(block.BaseFeeWei),
+		WithdrawalsHash:  &withdrawalsHash,
+		BlobGasUsed:      &blobGasUsed,
+		ExcessBlobGas:    &excessBlobGas,
+		ParentBeaconRoot: &beaconRoot,
+	}
+}
+
+// syntheticReceipt returns a successful receipt with two ERC-20 style logs
+func syntheticReceipt(rng *fastrand.Source) *gethtypes.Receipt {
+	receipt := &gethtypes.Receipt{
+		Type:              gethtypes.DynamicFeeTxType,
+		Status:            gethtypes.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 7_500_000,
+	}
+	for i := 0; i < 2; i++ {
+		receipt.Logs = append(receipt.Logs, &gethtypes.Log{
+			Address: common.BytesToAddress(rng.Bytes(20)),
+			Topics: []common.Hash{
+				common.BytesToHash(rng.Bytes(32)),
+				common.BytesToHash(rng.Bytes(32)),
+				common.BytesToHash(rng.Bytes(32)),
+			},
+			Data: rng.Bytes(64),
+		})
+	}
+	receipt.Bloom = gethtypes.CreateBloom(gethtypes.Receipts{receipt})
+	return receipt
+}
+
+// rateRLP provides a rating based on average encode/decode operations per second
+func rateRLP(opsPerSec float64) string {
+	switch {
+	case opsPerSec >= 400000:
+		return "Excellent"
+	case opsPerSec >= 200000:
+		return "Good"
+	case opsPerSec >= 100000:
+		return "Adequate"
+	case opsPerSec >= 50000:
+		return "Marginal"
+	default:
+		return "Poor"
+	}
+}
@@ -1,37 +1,38 @@
 package cpu
 
 import (
+	"context"
 	"crypto/ecdsa"
-	"crypto/rand"
+	"fmt"
 	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
 
+	"github.com/vBenchmark/internal/fastrand"
 	"github.com/vBenchmark/internal/types"
 )
 
 // BenchmarkECDSA measures ECDSA/secp256k1 performance
 // This is critical for transaction signature verification
 // Reference: geth/crypto/crypto.go, geth/crypto/signature_cgo.go
-func BenchmarkECDSA(duration time.Duration, verbose bool) types.ECDSAResult {
+func BenchmarkECDSA(ctx context.Context, duration time.Duration, verbose bool) (types.ECDSAResult, error) {
 	// Generate test key pair
 	privateKey, err := crypto.GenerateKey()
 	if err != nil {
-		return types.ECDSAResult{Rating: "Error"}
+		return types.ECDSAResult{}, fmt.Errorf("failed to generate key: %w", err)
 	}
 	publicKey := privateKey.Public().(*ecdsa.PublicKey)
 	pubKeyBytes := crypto.FromECDSAPub(publicKey)
 
 	// Test message (typical transaction hash - 32 bytes)
-	message := make([]byte, 32)
-	rand.Read(message)
+	message := fastrand.New("cpu.ecdsa").Bytes(32)
 
 	// Phase 1: Signature generation
 	signDuration := duration / 3
 	var signCount uint64
 	start := time.Now()
 
-	for time.Since(start) < signDuration {
+	for time.Since(start) < signDuration && ctx.Err() == nil {
 		_, err := crypto.Sign(message, privateKey)
 		if err == nil {
 			signCount++
@@ -48,7 +49,7 @@ func BenchmarkECDSA(duration time.Duration, verbose bool) types.ECDSAResult {
 	var verifyCount uint64
 	start = time.Now()
 
-	for time.Since(start) < verifyDuration {
+	for time.Since(start) < verifyDuration && ctx.Err() == nil {
 		// VerifySignature expects 64-byte signature (R||S without recovery byte)
 		if crypto.VerifySignature(pubKeyBytes, message, signature[:64]) {
 			verifyCount++
@@ -63,7 +64,7 @@ func BenchmarkECDSA(duration time.Duration, verbose bool) types.ECDSAResult {
 	var recoverCount uint64
 	start = time.Now()
 
-	for time.Since(start) < recoverDuration {
+	for time.Since(start) < recoverDuration && ctx.Err() == nil {
 		_, err := crypto.Ecrecover(message, signature)
 		if err == nil {
 			recoverCount++
@@ -80,7 +81,7 @@ func BenchmarkECDSA(duration time.Duration, verbose bool) types.ECDSAResult {
 		RecoveriesPerSecond:    recoverRate,
 		Duration:               totalDuration,
 		Rating:                 rateECDSA(verifyRate, recoverRate),
-	}
+	}, nil
 }
 
 // rateECDSA provides a rating based on verification and recovery rates
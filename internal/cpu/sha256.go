@@ -0,0 +1,181 @@
+package cpu
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/sys/cpu"
+
+	"github.com/vBenchmark/internal/deadline"
+	"github.com/vBenchmark/internal/fastrand"
+	"github.com/vBenchmark/internal/types"
+)
+
+// bulkInputSize matches a large SSZ list being merkleized or a gossip
+// message being hashed for its message-id
+const bulkInputSize = 16 * 1024
+
+// BenchmarkSHA256 measures the hashing the consensus layer depends on:
+// SHA-256 over 64-byte inputs (one merkle node, two 32-byte children) as in
+// hash_tree_root, bulk SHA-256 throughput, the same bulk hash in portable
+// software to show what the ARMv8/SHA-NI extensions contribute, and BLAKE2b
+// throughput (EIP-152 precompile, libp2p).
+// Reference: nimbus/vendor/nim-ssz-serialization, consensus-specs ssz/merkle-proofs.md
+func BenchmarkSHA256(ctx context.Context, duration time.Duration, verbose bool) (types.SHA256Result, error) {
+	rng := fastrand.New("cpu.sha256")
+	node := rng.Bytes(64)
+	bulk := rng.Bytes(bulkInputSize)
+
+	// The software baseline is only meaningful if it is actually SHA-256
+	if want, got := sha256.Sum256(bulk), softwareSHA256(bulk); !bytes.Equal(want[:], got[:]) {
+		return types.SHA256Result{}, errors.New("software SHA-256 does not match crypto/sha256")
+	}
+
+	// Phase 1: Merkle node hashing (hash_tree_root)
+	var nodes uint64
+	nodeDuration := duration * 2 / 5
+	loop := deadline.Start(ctx, "cpu.sha256.node_hashes_per_second", nodeDuration)
+	for loop.Next() {
+		sum := sha256.Sum256(node)
+		copy(node[:32], sum[:])
+		nodes++
+	}
+	nodeElapsed := loop.Elapsed()
+
+	bulkRate := func(d time.Duration, hash func([]byte)) (float64, time.Duration) {
+		var n uint64
+		start := time.Now()
+		for time.Since(start) < d && ctx.Err() == nil {
+			hash(bulk)
+			n++
+		}
+		elapsed := time.Since(start)
+		return float64(n*bulkInputSize) / elapsed.Seconds() / (1024 * 1024), elapsed
+	}
+
+	// Phase 2: Bulk SHA-256 with whatever acceleration Go's crypto/sha256 uses
+	bulkMBps, bulkElapsed := bulkRate(duration*3/10, func(b []byte) { sha256.Sum256(b) })
+
+	// Phase 3: Bulk SHA-256 in portable software
+	softMBps, softElapsed := bulkRate(duration*3/20, func(b []byte) { softwareSHA256(b) })
+
+	// Phase 4: Bulk BLAKE2b
+	blakeMBps, blakeElapsed := bulkRate(duration*3/20, func(b []byte) { blake2b.Sum256(b) })
+
+	nodesPerSec := float64(nodes) / nodeElapsed.Seconds()
+	result := types.SHA256Result{
+		NodeHashesPerSecond: nodesPerSec,
+		MBPerSecond:         bulkMBps,
+		SoftwareMBPerSecond: softMBps,
+		BLAKE2bMBPerSecond:  blakeMBps,
+		HardwareAccelerated: sha256Accelerated(),
+		Duration:            nodeElapsed + bulkElapsed + softElapsed + blakeElapsed,
+		Rating:              rateSHA256(nodesPerSec),
+	}
+	if softMBps > 0 {
+		result.AccelerationSpeedup = bulkMBps / softMBps
+	}
+	return result, nil
+}
+
+// sha256Accelerated reports whether the CPU has SHA-256 instructions that
+// Go's crypto/sha256 uses (ARMv8 crypto extensions or x86 SHA-NI)
+func sha256Accelerated() bool {
+	switch runtime.GOARCH {
+	case "arm64":
+		return cpu.ARM64.HasSHA2
+	case "amd64":
+		data, err := os.ReadFile("/proc/cpuinfo")
+		return err == nil && strings.Contains(string(data), " sha_ni")
+	}
+	return false
+}
+
+// sha256K holds the SHA-256 round constants
+var sha256K = [64]uint32{
+	0x428a2f98, 0x71374491, 0xb5c0fbcf, 0xe9b5dba5, 0x3956c25b, 0x59f111f1, 0x923f82a4, 0xab1c5ed5,
+	0xd807aa98, 0x12835b01, 0x243185be, 0x550c7dc3, 0x72be5d74, 0x80deb1fe, 0x9bdc06a7, 0xc19bf174,
+	0xe49b69c1, 0xefbe4786, 0x0fc19dc6, 0x240ca1cc, 0x2de92c6f, 0x4a7484aa, 0x5cb0a9dc, 0x76f988da,
+	0x983e5152, 0xa831c66d, 0xb00327c8, 0xbf597fc7, 0xc6e00bf3, 0xd5a79147, 0x06ca6351, 0x14292967,
+	0x27b70a85, 0x2e1b2138, 0x4d2c6dfc, 0x53380d13, 0x650a7354, 0x766a0abb, 0x81c2c92e, 0x92722c85,
+	0xa2bfe8a1, 0xa81a664b, 0xc24b8b70, 0xc76c51a3, 0xd192e819, 0xd6990624, 0xf40e3585, 0x106aa070,
+	0x19a4c116, 0x1e376c08, 0x2748774c, 0x34b0bcb5, 0x391c0cb3, 0x4ed8aa4a, 0x5b9cca4f, 0x682e6ff3,
+	0x748f82ee, 0x78a5636f, 0x84c87814, 0x8cc70208, 0x90befffa, 0xa4506ceb, 0xbef9a3f7, 0xc67178f2,
+}
+
+// softwareSHA256 is a portable SHA-256 (FIPS 180-4) without any CPU
+// extensions, the speed a board without crypto instructions gets
+func softwareSHA256(data []byte) [32]byte {
+	h := [8]uint32{0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a, 0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19}
+
+	// Pad to a multiple of 64 bytes with the bit length at the end
+	padded := make([]byte, 0, len(data)+72)
+	padded = append(padded, data...)
+	padded = append(padded, 0x80)
+	for len(padded)%64 != 56 {
+		padded = append(padded, 0)
+	}
+	padded = binary.BigEndian.AppendUint64(padded, uint64(len(data))*8)
+
+	var w [64]uint32
+	for p := padded; len(p) >= 64; p = p[64:] {
+		for i := 0; i < 16; i++ {
+			w[i] = binary.BigEndian.Uint32(p[i*4:])
+		}
+		for i := 16; i < 64; i++ {
+			s0 := bits.RotateLeft32(w[i-15], -7) ^ bits.RotateLeft32(w[i-15], -18) ^ (w[i-15] >> 3)
+			s1 := bits.RotateLeft32(w[i-2], -17) ^ bits.RotateLeft32(w[i-2], -19) ^ (w[i-2] >> 10)
+			w[i] = w[i-16] + s0 + w[i-7] + s1
+		}
+
+		a, b, c, d, e, f, g, hh := h[0], h[1], h[2], h[3], h[4], h[5], h[6], h[7]
+		for i := 0; i < 64; i++ {
+			s1 := bits.RotateLeft32(e, -6) ^ bits.RotateLeft32(e, -11) ^ bits.RotateLeft32(e, -25)
+			ch := (e & f) ^ (^e & g)
+			t1 := hh + s1 + ch + sha256K[i] + w[i]
+			s0 := bits.RotateLeft32(a, -2) ^ bits.RotateLeft32(a, -13) ^ bits.RotateLeft32(a, -22)
+			maj := (a & b) ^ (a & c) ^ (b & c)
+			t2 := s0 + maj
+			hh, g, f, e, d, c, b, a = g, f, e, d+t1, c, b, a, t1+t2
+		}
+		h[0] += a
+		h[1] += b
+		h[2] += c
+		h[3] += d
+		h[4] += e
+		h[5] += f
+		h[6] += g
+		h[7] += hh
+	}
+
+	var out [32]byte
+	for i, v := range h {
+		binary.BigEndian.PutUint32(out[i*4:], v)
+	}
+	return out
+}
+
+// rateSHA256 provides a rating based on merkle node hashes per second
+func rateSHA256(nodesPerSec float64) string {
+	switch {
+	case nodesPerSec >= 3000000:
+		return "Excellent"
+	case nodesPerSec >= 1500000:
+		return "Good"
+	case nodesPerSec >= 750000:
+		return "Adequate"
+	case nodesPerSec >= 400000:
+		return "Marginal"
+	default:
+		return "Poor"
+	}
+}
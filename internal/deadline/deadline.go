@@ -0,0 +1,137 @@
+// Package deadline runs benchmark loops until their time budget is spent
+// without reading the clock on every iteration. On fast machines a
+// time.Since call costs as much as a short Keccak hash, so hot loops check
+// the deadline once per batch and size the batch to the loop's own speed.
+package deadline
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// checkInterval is the target time between two deadline checks. It bounds
+// how far a loop overshoots its budget and how late it notices cancellation.
+const checkInterval = 100 * time.Microsecond
+
+// Stats describes how a finished loop spent its time
+type Stats struct {
+	Iterations uint64
+	Checks     uint64 // Clock reads and cancellation checks
+	Elapsed    time.Duration
+}
+
+// ChecksPerIteration is the fraction of iterations that read the clock
+func (s Stats) ChecksPerIteration() float64 {
+	if s.Iterations == 0 {
+		return 0
+	}
+	return float64(s.Checks) / float64(s.Iterations)
+}
+
+// DutyCycle is the share of the loop's time left for the measured work once
+// checkCost per deadline check is taken out
+func (s Stats) DutyCycle(checkCost time.Duration) float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return max(1-float64(s.Checks)*float64(checkCost)/float64(s.Elapsed), 0)
+}
+
+// Loop is a deadline-bounded benchmark loop
+type Loop struct {
+	ctx      context.Context
+	metric   string
+	duration time.Duration
+	start    time.Time
+	last     time.Duration // Elapsed time at the previous check
+	batch    uint64        // Iterations between checks
+	left     uint64        // Iterations until the next check
+	stats    Stats
+	done     bool
+}
+
+// Start begins a loop that runs for d or until ctx is cancelled. When metric
+// is not empty the loop's stats are recorded under it once it finishes.
+func Start(ctx context.Context, metric string, d time.Duration) *Loop {
+	return &Loop{ctx: ctx, metric: metric, duration: d, start: time.Now(), batch: 1}
+}
+
+// Next reports whether another iteration should run. The deadline is checked
+// every batch iterations; the batch doubles while checks come more often than
+// checkInterval and halves when a batch runs long.
+func (l *Loop) Next() bool {
+	if l.done {
+		return false
+	}
+	if l.left > 0 {
+		l.left--
+		l.stats.Iterations++
+		return true
+	}
+
+	l.stats.Checks++
+	now := time.Since(l.start)
+	if now >= l.duration || l.ctx.Err() != nil {
+		l.finish(now)
+		return false
+	}
+	switch span := now - l.last; {
+	case span < checkInterval/2:
+		l.batch *= 2
+	case span > checkInterval*2 && l.batch > 1:
+		l.batch /= 2
+	}
+	l.last = now
+	l.left = l.batch - 1
+	l.stats.Iterations++
+	return true
+}
+
+// Stop ends the loop before its deadline, as when a working-set cap is
+// reached; Next returns false from then on
+func (l *Loop) Stop() {
+	if !l.done {
+		l.finish(time.Since(l.start))
+	}
+}
+
+// Elapsed returns the loop's run time, up to now if it has not finished
+func (l *Loop) Elapsed() time.Duration {
+	if l.done {
+		return l.stats.Elapsed
+	}
+	return time.Since(l.start)
+}
+
+// Stats returns the loop's iteration and check counts so far
+func (l *Loop) Stats() Stats {
+	s := l.stats
+	s.Elapsed = l.Elapsed()
+	return s
+}
+
+func (l *Loop) finish(elapsed time.Duration) {
+	l.done = true
+	l.stats.Elapsed = elapsed
+	if l.metric != "" {
+		mu.Lock()
+		recorded[l.metric] = l.stats
+		mu.Unlock()
+	}
+}
+
+var (
+	mu       sync.Mutex
+	recorded = make(map[string]Stats)
+)
+
+// Drain returns the stats of loops finished since the last call, keyed by
+// metric, and forgets them
+func Drain() map[string]Stats {
+	mu.Lock()
+	defer mu.Unlock()
+	stats := recorded
+	recorded = make(map[string]Stats)
+	return stats
+}
@@ -0,0 +1,128 @@
+package disk
+
+import (
+	"context"
+	"fmt"
+	mathrand "math/rand"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vBenchmark/internal/fastrand"
+	"github.com/vBenchmark/internal/types"
+	"github.com/vBenchmark/internal/workload"
+)
+
+// Blob sidecar workload (EIP-4844)
+const blobSize = 128 * 1024 // One blob
+
+// BenchmarkBlobStore measures the blob sidecar write/read/prune cycle
+// Consensus clients persist every blob for ~18 days and prune the oldest
+// slot as each new one arrives.
+// Reference: nimbus/beacon_chain/beacon_chain_db.nim putBlobSidecar()
+func BenchmarkBlobStore(ctx context.Context, testDir string, budget *WriteBudget, duration time.Duration, verbose bool) (types.BlobResult, error) {
+	// Full blocks of the workload profile
+	blobs := workload.Current.Blobs
+	blobsPerBlock, blobReadsPerBlock, blobRetainedSlots := blobs.MaxPerBlock, blobs.ReadsPerBlock, blobs.RetainedSlots
+
+	blobDir := filepath.Join(testDir, "ethbench_blobs")
+	defer os.RemoveAll(blobDir)
+
+	if err := os.MkdirAll(blobDir, 0755); err != nil {
+		return types.BlobResult{}, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	src := fastrand.New("disk.blob")
+	blob := src.Bytes(blobSize)
+	readBuf := make([]byte, blobSize)
+	rng := mathrand.New(src)
+
+	blobPath := func(slot, index int) string {
+		return filepath.Join(blobDir, fmt.Sprintf("%08d_%d.ssz", slot, index))
+	}
+
+	var slots int
+	var written, read uint64
+	var writeTime, readTime, pruneTime time.Duration
+	var prunes uint64
+
+	start := time.Now()
+	for time.Since(start) < duration && ctx.Err() == nil && budget.Take(blobsPerBlock*blobSize) {
+		// Write all sidecars of the new block durably
+		opStart := time.Now()
+		for i := 0; i < blobsPerBlock; i++ {
+			f, err := os.Create(blobPath(slots, i))
+			if err != nil {
+				return types.BlobResult{}, fmt.Errorf("failed to write blob sidecar: %w", err)
+			}
+			n, _ := f.Write(blob)
+			f.Sync()
+			f.Close()
+			written += uint64(n)
+		}
+		writeTime += time.Since(opStart)
+
+		// Serve sidecars of random retained slots to peers
+		opStart = time.Now()
+		for i := 0; i < blobReadsPerBlock; i++ {
+			oldest := slots - blobRetainedSlots + 1
+			if oldest < 0 {
+				oldest = 0
+			}
+			slot := oldest + rng.Intn(slots-oldest+1)
+			f, err := os.Open(blobPath(slot, rng.Intn(blobsPerBlock)))
+			if err != nil {
+				continue
+			}
+			n, _ := f.Read(readBuf)
+			f.Close()
+			read += uint64(n)
+		}
+		readTime += time.Since(opStart)
+
+		// Prune the slot that fell out of the retention window
+		if expired := slots - blobRetainedSlots; expired >= 0 {
+			opStart = time.Now()
+			for i := 0; i < blobsPerBlock; i++ {
+				os.Remove(blobPath(expired, i))
+			}
+			pruneTime += time.Since(opStart)
+			prunes++
+		}
+		slots++
+	}
+	elapsed := time.Since(start)
+	if slots == 0 && budget.Exhausted() {
+		return types.BlobResult{}, ErrWriteLimit
+	}
+
+	blocksPerSec := float64(slots) / elapsed.Seconds()
+	result := types.BlobResult{
+		BlocksPerSecond: blocksPerSec,
+		RealtimeFactor:  blocksPerSec * float64(workload.Current.Block.SlotSeconds),
+		WriteMBps:       float64(written) / writeTime.Seconds() / (1024 * 1024),
+		ReadMBps:        float64(read) / readTime.Seconds() / (1024 * 1024),
+		Duration:        elapsed,
+		Rating:          rateBlob(blocksPerSec),
+	}
+	if prunes > 0 {
+		result.AvgPruneLatencyMs = float64(pruneTime.Microseconds()) / float64(prunes) / 1000
+	}
+	return result, nil
+}
+
+// rateBlob provides a rating based on blob blocks processed per second
+func rateBlob(blocksPerSec float64) string {
+	switch {
+	case blocksPerSec >= 50:
+		return "Excellent"
+	case blocksPerSec >= 20:
+		return "Good"
+	case blocksPerSec >= 5:
+		return "Adequate"
+	case blocksPerSec >= 1:
+		return "Marginal"
+	default:
+		return "Poor"
+	}
+}
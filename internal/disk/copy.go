@@ -0,0 +1,160 @@
+package disk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vBenchmark/internal/fastrand"
+	"github.com/vBenchmark/internal/types"
+)
+
+// Copy workload: a large file copied to a backup location and back
+const (
+	copyFileSize    = 1024 * 1024 * 1024 // Largest test file
+	copyMinFileSize = 64 * 1024 * 1024
+	copyChunk       = 4 * 1024 * 1024
+	datadirBytes    = 1000 * 1000 * 1000 * 1000 // ~1 TB Geth + consensus client datadir
+)
+
+// BenchmarkCopy measures large-file copy throughput from the test directory
+// to destDir (backup) and back (restore), e.g. NVMe to a USB backup disk,
+// and estimates how long copying a ~1 TB datadir each way would take.
+// Each copy runs for at most half the duration; its result includes the
+// final sync.
+func BenchmarkCopy(ctx context.Context, files *TestFiles, budget *WriteBudget, destDir string, duration time.Duration, verbose bool) (types.CopyResult, error) {
+	// The source file and both copies all count against the write budget
+	fileSize := int64(copyFileSize)
+	if remaining := budget.Remaining(); remaining >= 0 && remaining/3 < fileSize {
+		fileSize = remaining / 3 &^ (copyChunk - 1)
+		if fileSize < copyMinFileSize {
+			return types.CopyResult{}, ErrWriteLimit
+		}
+	}
+
+	src, err := files.Prepare("ethbench_copy_source.dat", fileSize, func(f *os.File) error {
+		rng := fastrand.New("disk.copy")
+		chunk := make([]byte, copyChunk)
+		for offset := int64(0); offset < fileSize; offset += copyChunk {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if !budget.Take(copyChunk) {
+				return ErrWriteLimit
+			}
+			rng.Read(chunk)
+			if _, err := f.WriteAt(chunk, offset); err != nil {
+				return fmt.Errorf("failed to fill test file: %w", err)
+			}
+		}
+		return f.Sync()
+	})
+	if err != nil {
+		return types.CopyResult{}, err
+	}
+	defer files.Release(src)
+
+	// Phase 1: Backup to the destination
+	backupPath := filepath.Join(destDir, "ethbench_copy_backup.dat")
+	defer os.Remove(backupPath)
+	backupMBps, backupElapsed, err := timedCopy(ctx, src, backupPath, budget, duration/2)
+	if err != nil {
+		return types.CopyResult{}, fmt.Errorf("backup copy failed: %w", err)
+	}
+
+	// Phase 2: Restore from the destination
+	backup, err := os.Open(backupPath)
+	if err != nil {
+		return types.CopyResult{}, fmt.Errorf("failed to open backup copy: %w", err)
+	}
+	defer backup.Close()
+	restorePath := filepath.Join(files.dir, "ethbench_copy_restore.dat")
+	defer os.Remove(restorePath)
+	restoreMBps, restoreElapsed, err := timedCopy(ctx, backup, restorePath, budget, duration/2)
+	if err != nil {
+		return types.CopyResult{}, fmt.Errorf("restore copy failed: %w", err)
+	}
+
+	hours := func(mbps float64) float64 {
+		return datadirBytes / (mbps * 1024 * 1024) / 3600
+	}
+	return types.CopyResult{
+		Destination:  destDir,
+		TestFileMB:   float64(fileSize) / (1024 * 1024),
+		BackupMBps:   backupMBps,
+		RestoreMBps:  restoreMBps,
+		DatadirGB:    datadirBytes / 1e9,
+		BackupHours:  hours(backupMBps),
+		RestoreHours: hours(restoreMBps),
+		Duration:     backupElapsed + restoreElapsed,
+		Rating:       rateCopy(min(backupMBps, restoreMBps)),
+	}, nil
+}
+
+// timedCopy copies src to a new file at dstPath from cold cache until the
+// end of src or the duration runs out, then syncs. It returns MB/s and the
+// time taken, including the sync.
+func timedCopy(ctx context.Context, src *os.File, dstPath string, budget *WriteBudget, duration time.Duration) (float64, time.Duration, error) {
+	info, err := src.Stat()
+	if err != nil {
+		return 0, 0, err
+	}
+	fadviseDontNeed(int(src.Fd()), info.Size())
+
+	dst, err := os.OpenFile(dstPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to create copy: %w", err)
+	}
+	defer dst.Close()
+
+	buf := make([]byte, copyChunk)
+	var copied int64
+	start := time.Now()
+	for time.Since(start) < duration && ctx.Err() == nil {
+		n, err := src.ReadAt(buf, copied)
+		if n > 0 {
+			if !budget.Take(n) {
+				break
+			}
+			if _, err := dst.Write(buf[:n]); err != nil {
+				return 0, 0, fmt.Errorf("failed to write copy: %w", err)
+			}
+			copied += int64(n)
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to read source: %w", err)
+		}
+	}
+	if err := dst.Sync(); err != nil {
+		return 0, 0, fmt.Errorf("failed to sync copy: %w", err)
+	}
+	elapsed := time.Since(start)
+
+	if copied == 0 {
+		return 0, 0, ErrWriteLimit
+	}
+	return float64(copied) / elapsed.Seconds() / (1024 * 1024), elapsed, nil
+}
+
+// rateCopy provides a rating based on the slower copy direction
+func rateCopy(mbps float64) string {
+	switch {
+	case mbps >= 500:
+		return "Excellent"
+	case mbps >= 200:
+		return "Good"
+	case mbps >= 100:
+		return "Adequate"
+	case mbps >= 40:
+		return "Marginal"
+	default:
+		return "Poor"
+	}
+}
@@ -0,0 +1,56 @@
+package disk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+// directAlign is the buffer address, length and file offset alignment
+// O_DIRECT requires; 4096 covers both 512-byte and 4K logical sectors
+const directAlign = 4096
+
+// alignedBuffer returns a size-byte buffer whose address is a multiple of
+// directAlign, usable with both cached and O_DIRECT file handles
+func alignedBuffer(size int) []byte {
+	buf := make([]byte, size+directAlign)
+	shift := 0
+	if rem := int(uintptr(unsafe.Pointer(&buf[0])) % directAlign); rem != 0 {
+		shift = directAlign - rem
+	}
+	return buf[shift : shift+size : shift+size]
+}
+
+// alignUp rounds n up to a multiple of directAlign
+func alignUp(n int) int {
+	return (n + directAlign - 1) &^ (directAlign - 1)
+}
+
+// openDirect opens path with O_DIRECT added to flag, so reads and writes
+// bypass the page cache instead of relying on fadvise to evict it
+func openDirect(path string, flag int) (*os.File, error) {
+	return os.OpenFile(path, flag|syscall.O_DIRECT, 0644)
+}
+
+// CheckDirectIO reports whether the filesystem holding dir accepts O_DIRECT
+// reads and writes; tmpfs before Linux 6.6 and some FUSE filesystems do not
+func CheckDirectIO(dir string) error {
+	path := filepath.Join(dir, testFilePrefix+"direct_probe.dat")
+	defer os.Remove(path)
+
+	f, err := openDirect(path, os.O_CREATE|os.O_RDWR|os.O_TRUNC)
+	if err != nil {
+		return fmt.Errorf("O_DIRECT not supported: %w", err)
+	}
+	defer f.Close()
+	buf := alignedBuffer(directAlign)
+	if _, err := f.WriteAt(buf, 0); err != nil {
+		return fmt.Errorf("O_DIRECT write failed: %w", err)
+	}
+	if _, err := f.ReadAt(buf, 0); err != nil {
+		return fmt.Errorf("O_DIRECT read failed: %w", err)
+	}
+	return nil
+}
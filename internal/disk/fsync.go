@@ -0,0 +1,97 @@
+package disk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/vBenchmark/internal/fastrand"
+	"github.com/vBenchmark/internal/types"
+)
+
+// Fsync workload: one filesystem block written and synced per iteration,
+// cycling through a small file like a write-ahead log
+const (
+	fsyncBlockSize = 4096
+	fsyncFileSize  = 64 * 1024 * 1024
+)
+
+// BenchmarkFsync measures fsync tail latency. Block commitment waits on the
+// slowest sync, so p99/p999 matter more than average throughput.
+// Reference: geth/ethdb/pebble/pebble.go (WAL sync on batch commit)
+func BenchmarkFsync(ctx context.Context, testDir string, budget *WriteBudget, duration time.Duration, verbose bool) (types.FsyncResult, error) {
+	testFile := filepath.Join(testDir, "ethbench_fsync_test.dat")
+	defer os.Remove(testFile)
+
+	f, err := os.OpenFile(testFile, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0644)
+	if err != nil {
+		return types.FsyncResult{}, fmt.Errorf("failed to create test file: %w", err)
+	}
+	defer f.Close()
+
+	block := fastrand.New("disk.fsync").Bytes(fsyncBlockSize)
+
+	var latencies []time.Duration
+	var offset int64
+
+	start := time.Now()
+	for time.Since(start) < duration && ctx.Err() == nil && budget.Take(fsyncBlockSize) {
+		opStart := time.Now()
+		if _, err := f.WriteAt(block, offset); err != nil {
+			return types.FsyncResult{}, fmt.Errorf("failed to write block: %w", err)
+		}
+		if err := f.Sync(); err != nil {
+			return types.FsyncResult{}, fmt.Errorf("failed to sync: %w", err)
+		}
+		latencies = append(latencies, time.Since(opStart))
+
+		offset = (offset + fsyncBlockSize) % fsyncFileSize
+	}
+	elapsed := time.Since(start)
+
+	if len(latencies) == 0 {
+		if budget.Exhausted() {
+			return types.FsyncResult{}, ErrWriteLimit
+		}
+		return types.FsyncResult{Duration: elapsed, Rating: "Poor"}, nil
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	p99 := latencyPercentile(latencies, 99)
+	return types.FsyncResult{
+		Count:          uint64(len(latencies)),
+		SyncsPerSecond: float64(len(latencies)) / elapsed.Seconds(),
+		P50LatencyMs:   latencyPercentile(latencies, 50),
+		P95LatencyMs:   latencyPercentile(latencies, 95),
+		P99LatencyMs:   p99,
+		P999LatencyMs:  latencyPercentile(latencies, 99.9),
+		MaxLatencyMs:   float64(latencies[len(latencies)-1].Microseconds()) / 1000,
+		Duration:       elapsed,
+		Rating:         rateFsync(p99),
+	}, nil
+}
+
+// latencyPercentile returns the p-th percentile of sorted latencies in milliseconds
+func latencyPercentile(sorted []time.Duration, p float64) float64 {
+	idx := int(float64(len(sorted)-1) * p / 100)
+	return float64(sorted[idx].Microseconds()) / 1000
+}
+
+// rateFsync provides a rating based on p99 fsync latency
+func rateFsync(p99Ms float64) string {
+	switch {
+	case p99Ms <= 1:
+		return "Excellent"
+	case p99Ms <= 5:
+		return "Good"
+	case p99Ms <= 20:
+		return "Adequate"
+	case p99Ms <= 50:
+		return "Marginal"
+	default:
+		return "Poor"
+	}
+}
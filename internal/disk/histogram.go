@@ -0,0 +1,80 @@
+package disk
+
+import (
+	"math/bits"
+	"time"
+)
+
+// Latency histogram layout: values below histogramLinear microseconds get
+// their own bucket, larger ones histogramSub buckets per power of two, so
+// every bucket is within ~1.5% of the values it holds (HDR histogram style)
+const (
+	histogramLinear = 128
+	histogramSub    = histogramLinear / 2
+)
+
+// latencyHistogram records operation latencies in constant memory with
+// bounded relative error, so long runs keep every stall without storing
+// each sample
+type latencyHistogram struct {
+	counts []uint64
+	count  uint64
+	max    time.Duration
+}
+
+// Record adds one latency sample
+func (h *latencyHistogram) Record(d time.Duration) {
+	idx := histogramIndex(uint64(max(d.Microseconds(), 0)))
+	if idx >= len(h.counts) {
+		h.counts = append(h.counts, make([]uint64, idx+1-len(h.counts))...)
+	}
+	h.counts[idx]++
+	h.count++
+	h.max = max(h.max, d)
+}
+
+// Count returns the number of recorded samples
+func (h *latencyHistogram) Count() uint64 {
+	return h.count
+}
+
+// MaxMs returns the exact largest sample in milliseconds
+func (h *latencyHistogram) MaxMs() float64 {
+	return float64(h.max.Microseconds()) / 1000
+}
+
+// PercentileMs returns the p-th percentile in milliseconds, reported as the
+// upper edge of its bucket and never above the recorded maximum
+func (h *latencyHistogram) PercentileMs(p float64) float64 {
+	if h.count == 0 {
+		return 0
+	}
+	rank := uint64(float64(h.count-1)*p/100) + 1
+	var seen uint64
+	for idx, n := range h.counts {
+		seen += n
+		if seen >= rank {
+			return min(float64(histogramUpper(idx))/1000, h.MaxMs())
+		}
+	}
+	return h.MaxMs()
+}
+
+// histogramIndex returns the bucket for a value in microseconds
+func histogramIndex(us uint64) int {
+	if us < histogramLinear {
+		return int(us)
+	}
+	shift := bits.Len64(us) - bits.Len64(histogramLinear-1)
+	return histogramLinear + (shift-1)*histogramSub + int(us>>shift) - histogramSub
+}
+
+// histogramUpper returns the largest value in microseconds a bucket holds
+func histogramUpper(idx int) uint64 {
+	if idx < histogramLinear {
+		return uint64(idx)
+	}
+	shift := (idx-histogramLinear)/histogramSub + 1
+	top := uint64((idx-histogramLinear)%histogramSub + histogramSub)
+	return (top+1)<<shift - 1
+}
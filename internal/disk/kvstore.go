@@ -0,0 +1,190 @@
+package disk
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/ethereum/go-ethereum/ethdb/pebble"
+
+	"github.com/vBenchmark/internal/fastrand"
+	"github.com/vBenchmark/internal/types"
+	"github.com/vBenchmark/internal/workload"
+)
+
+// Key-value workload modelled on Geth state and snapshot writes
+const (
+	kvSampleEvery = 16 // Keep one in N written keys for the read phase
+	kvCacheMB     = 64
+	kvHandles     = 256
+)
+
+// BenchmarkKVStore runs a compaction-heavy key-value workload against
+// Geth's actual storage engines, Pebble (the default) and LevelDB, opened
+// through Geth's own ethdb wrappers with Geth's tuning.
+// Reference: geth/ethdb/pebble/pebble.go, geth/ethdb/leveldb/leveldb.go
+func BenchmarkKVStore(ctx context.Context, testDir string, budget *WriteBudget, duration time.Duration, verbose bool) (types.KVStoreResult, error) {
+	engineDuration := duration / 2
+
+	pebbleResult := benchmarkEngine(ctx, testDir, "pebble", budget, engineDuration, func(dir string) (ethdb.KeyValueStore, error) {
+		return pebble.New(dir, kvCacheMB, kvHandles, "", false)
+	})
+	levelResult := benchmarkEngine(ctx, testDir, "leveldb", budget, engineDuration, func(dir string) (ethdb.KeyValueStore, error) {
+		return leveldb.New(dir, kvCacheMB, kvHandles, "", false)
+	})
+
+	result := types.KVStoreResult{
+		Pebble:   pebbleResult,
+		LevelDB:  levelResult,
+		Duration: pebbleResult.Duration + levelResult.Duration,
+	}
+	// Rating follows Pebble, Geth's default engine
+	switch pebbleResult.Error {
+	case "":
+	case ErrWriteLimit.Error():
+		return result, ErrWriteLimit
+	default:
+		return result, fmt.Errorf("pebble: %s", pebbleResult.Error)
+	}
+	result.Rating = rateKVStore(pebbleResult.WritesPerSecond)
+	// LevelDB running out of write budget still leaves a valid Pebble result
+	if levelResult.Error != "" && levelResult.Error != ErrWriteLimit.Error() {
+		return result, fmt.Errorf("leveldb: %s", levelResult.Error)
+	}
+	return result, nil
+}
+
+// benchmarkEngine runs write, random read and iterator scan phases on one engine
+func benchmarkEngine(ctx context.Context, testDir, engine string, budget *WriteBudget, duration time.Duration, open func(dir string) (ethdb.KeyValueStore, error)) types.KVEngineResult {
+	dir := filepath.Join(testDir, "ethbench_kv_"+engine)
+	os.RemoveAll(dir)
+	defer os.RemoveAll(dir)
+
+	db, err := open(dir)
+	if err != nil {
+		return types.KVEngineResult{Engine: engine, Error: err.Error()}
+	}
+	defer db.Close()
+
+	result := types.KVEngineResult{Engine: engine}
+	kv := workload.Current.KVStore
+
+	// Phase 1: Batched random writes, driving memtable flushes and compactions
+	writeDuration := duration / 2
+	// Values are flat state entries, accounts and storage slots of realistic sizes
+	rng := fastrand.New("disk.kvstore")
+	entries := workload.NewGenerator(rng)
+	keyBuf := make([]byte, kv.BatchSize*kv.KeySize)
+	value := rng.Bytes(entries.MaxStateEntrySize())
+	var samples [][]byte
+	var written, writtenBytes uint64
+	ioBefore := readProcessWriteBytes()
+
+	start := time.Now()
+	for time.Since(start) < writeDuration && ctx.Err() == nil {
+		rng.Read(keyBuf)
+		batch := db.NewBatch()
+		batchBytes, sampled := 0, len(samples)
+		for i := 0; i < kv.BatchSize; i++ {
+			key := keyBuf[i*kv.KeySize : (i+1)*kv.KeySize]
+			size := entries.StateEntrySize()
+			batch.Put(key, value[:size])
+			batchBytes += kv.KeySize + size
+			if (written+uint64(i))%kvSampleEvery == 0 {
+				samples = append(samples, append([]byte(nil), key...))
+			}
+		}
+		if !budget.Take(batchBytes) {
+			samples = samples[:sampled]
+			break
+		}
+		if err := batch.Write(); err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		written += uint64(kv.BatchSize)
+		writtenBytes += uint64(batchBytes)
+	}
+	writeElapsed := time.Since(start)
+	if written == 0 && budget.Exhausted() {
+		result.Error = ErrWriteLimit.Error()
+		return result
+	}
+	result.WritesPerSecond = float64(written) / writeElapsed.Seconds()
+	if ioAfter := readProcessWriteBytes(); ioAfter > ioBefore && written > 0 {
+		result.WriteAmplification = float64(ioAfter-ioBefore) / float64(writtenBytes)
+	}
+
+	// Phase 2: Random point reads of existing keys
+	readDuration := duration / 4
+	var reads uint64
+	start = time.Now()
+	for time.Since(start) < readDuration && ctx.Err() == nil && len(samples) > 0 {
+		db.Get(samples[reads%uint64(len(samples))])
+		reads++
+	}
+	readElapsed := time.Since(start)
+	result.ReadsPerSecond = float64(reads) / readElapsed.Seconds()
+
+	// Phase 3: Iterator scans from random positions (snapshot generation, sync serving)
+	scanDuration := duration / 4
+	var scanned uint64
+	startKey := make([]byte, kv.KeySize)
+	start = time.Now()
+	for time.Since(start) < scanDuration && ctx.Err() == nil {
+		rng.Read(startKey)
+		it := db.NewIterator(nil, startKey)
+		for n := 0; n < kv.ScanLength && it.Next(); n++ {
+			_ = it.Value()
+			scanned++
+		}
+		it.Release()
+	}
+	scanElapsed := time.Since(start)
+	result.ScanKeysPerSecond = float64(scanned) / scanElapsed.Seconds()
+
+	result.Duration = writeElapsed + readElapsed + scanElapsed
+	return result
+}
+
+// readProcessWriteBytes returns bytes this process caused to be written to
+// storage, or 0 if I/O accounting is unavailable
+func readProcessWriteBytes() uint64 {
+	f, err := os.Open("/proc/self/io")
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "write_bytes: "); ok {
+			n, _ := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+			return n
+		}
+	}
+	return 0
+}
+
+// rateKVStore provides a rating based on Pebble batched write throughput
+func rateKVStore(writesPerSec float64) string {
+	switch {
+	case writesPerSec >= 300000:
+		return "Excellent"
+	case writesPerSec >= 150000:
+		return "Good"
+	case writesPerSec >= 75000:
+		return "Adequate"
+	case writesPerSec >= 30000:
+		return "Marginal"
+	default:
+		return "Poor"
+	}
+}
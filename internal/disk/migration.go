@@ -0,0 +1,218 @@
+package disk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/vBenchmark/internal/fastrand"
+	"github.com/vBenchmark/internal/types"
+)
+
+// Migration workload: a directory of small files and one large file, copied
+// to the destination like cp -r or rsync would
+const (
+	migrationSmallFile  = 64 * 1024
+	migrationSmallCount = 2048
+	migrationLargeFile  = 256 * 1024 * 1024
+)
+
+// datadirLayout approximates how a client's mainnet datadir is split into files
+type datadirLayout struct {
+	client string
+	parts  []datadirPart
+}
+
+// datadirPart is a share of a datadir stored in files of one typical size
+type datadirPart struct {
+	bytes    float64
+	fileSize float64
+}
+
+// datadirLayouts lists the clients migration time is estimated for
+var datadirLayouts = []datadirLayout{
+	// Pebble SST files plus the freezer's 2 GB ancient segments
+	{"Geth", []datadirPart{{700e9, 2 << 20}, {500e9, 2 << 30}}},
+	// RocksDB column families with 64 MB SST files
+	{"Nethermind", []datadirPart{{1100e9, 64 << 20}}},
+	// Snapshot segments plus one large MDBX database file
+	{"Erigon", []datadirPart{{650e9, 512 << 20}, {350e9, 350e9}}},
+}
+
+// BenchmarkMigration simulates moving a client datadir from the test
+// directory to destDir. Copying thousands of small files measures the
+// per-file cost (open, create, metadata), copying one large file the raw
+// throughput; together they give the migration time of each client layout.
+func BenchmarkMigration(ctx context.Context, files *TestFiles, budget *WriteBudget, destDir string, duration time.Duration, verbose bool) (types.MigrationResult, error) {
+	// Sources and copies both count against the write budget
+	if remaining := budget.Remaining(); remaining >= 0 && remaining < 2*(migrationSmallFile*migrationSmallCount+migrationLargeFile) {
+		return types.MigrationResult{}, ErrWriteLimit
+	}
+
+	// Phase 1: Small files
+	srcDir := filepath.Join(files.dir, "ethbench_migration_src")
+	dstDir := filepath.Join(destDir, "ethbench_migration_dst")
+	defer os.RemoveAll(srcDir)
+	defer os.RemoveAll(dstDir)
+	if err := writeSmallFiles(ctx, srcDir, budget); err != nil {
+		return types.MigrationResult{}, err
+	}
+	if err := os.MkdirAll(dstDir, 0755); err != nil {
+		return types.MigrationResult{}, fmt.Errorf("failed to create migration directory: %w", err)
+	}
+
+	buf := make([]byte, migrationSmallFile)
+	var copied int
+	start := time.Now()
+	for copied < migrationSmallCount && time.Since(start) < duration/2 && ctx.Err() == nil {
+		name := fmt.Sprintf("%06d.ldb", copied)
+		if err := copySmallFile(filepath.Join(srcDir, name), filepath.Join(dstDir, name), buf, budget); err != nil {
+			return types.MigrationResult{}, err
+		}
+		copied++
+	}
+	if err := syncFS(dstDir); err != nil {
+		return types.MigrationResult{}, err
+	}
+	smallElapsed := time.Since(start)
+
+	// Phase 2: One large file
+	large, err := files.Prepare("ethbench_migration_large.dat", migrationLargeFile, func(f *os.File) error {
+		rng := fastrand.New("disk.migration.large")
+		chunk := make([]byte, copyChunk)
+		for offset := int64(0); offset < migrationLargeFile; offset += copyChunk {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if !budget.Take(copyChunk) {
+				return ErrWriteLimit
+			}
+			rng.Read(chunk)
+			if _, err := f.WriteAt(chunk, offset); err != nil {
+				return fmt.Errorf("failed to fill test file: %w", err)
+			}
+		}
+		return f.Sync()
+	})
+	if err != nil {
+		return types.MigrationResult{}, err
+	}
+	defer files.Release(large)
+	largeMBps, largeElapsed, err := timedCopy(ctx, large, filepath.Join(dstDir, "large.dat"), budget, duration/2)
+	if err != nil {
+		return types.MigrationResult{}, fmt.Errorf("large file copy failed: %w", err)
+	}
+
+	// Per-file cost beyond moving the bytes themselves
+	smallPerFile := smallElapsed.Seconds() / float64(copied)
+	largeBps := largeMBps * 1024 * 1024
+	overhead := max(smallPerFile-migrationSmallFile/largeBps, 0)
+
+	result := types.MigrationResult{
+		Destination:         destDir,
+		SmallFilesPerSecond: float64(copied) / smallElapsed.Seconds(),
+		SmallMBps:           float64(copied*migrationSmallFile) / smallElapsed.Seconds() / (1024 * 1024),
+		LargeMBps:           largeMBps,
+		PerFileOverheadMs:   overhead * 1000,
+		Duration:            smallElapsed + largeElapsed,
+	}
+	for _, layout := range datadirLayouts {
+		estimate := types.MigrationEstimate{Client: layout.client}
+		var seconds float64
+		for _, part := range layout.parts {
+			n := part.bytes / part.fileSize
+			estimate.DatadirGB += part.bytes / 1e9
+			estimate.Files += int64(n)
+			seconds += n*overhead + part.bytes/largeBps
+		}
+		estimate.Hours = seconds / 3600
+		result.Estimates = append(result.Estimates, estimate)
+	}
+	result.Rating = rateMigration(result.Estimates[0].Hours)
+	return result, nil
+}
+
+// writeSmallFiles creates the small source files and drops them from the
+// page cache so the copy reads from the device
+func writeSmallFiles(ctx context.Context, dir string, budget *WriteBudget) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create migration directory: %w", err)
+	}
+	rng := fastrand.New("disk.migration.small")
+	data := make([]byte, migrationSmallFile)
+	for i := 0; i < migrationSmallCount; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !budget.Take(migrationSmallFile) {
+			return ErrWriteLimit
+		}
+		rng.Read(data)
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("%06d.ldb", i)), data, 0644); err != nil {
+			return fmt.Errorf("failed to write migration file: %w", err)
+		}
+	}
+	if err := syncFS(dir); err != nil {
+		return err
+	}
+	for i := 0; i < migrationSmallCount; i++ {
+		if f, err := os.Open(filepath.Join(dir, fmt.Sprintf("%06d.ldb", i))); err == nil {
+			fadviseDontNeed(int(f.Fd()), migrationSmallFile)
+			f.Close()
+		}
+	}
+	return nil
+}
+
+// copySmallFile copies one small file the way cp does, without a sync
+func copySmallFile(src, dst string, buf []byte, budget *WriteBudget) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open migration file: %w", err)
+	}
+	defer in.Close()
+	n, err := in.Read(buf)
+	if err != nil {
+		return fmt.Errorf("failed to read migration file: %w", err)
+	}
+	if !budget.Take(n) {
+		return ErrWriteLimit
+	}
+	if err := os.WriteFile(dst, buf[:n], 0644); err != nil {
+		return fmt.Errorf("failed to copy migration file: %w", err)
+	}
+	return nil
+}
+
+// syncFS flushes the filesystem holding dir, so copied files are on the device
+func syncFS(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	if err := unix.Syncfs(int(d.Fd())); err != nil {
+		return fmt.Errorf("failed to sync filesystem: %w", err)
+	}
+	return nil
+}
+
+// rateMigration provides a rating based on the estimated Geth datadir move time
+func rateMigration(hours float64) string {
+	switch {
+	case hours <= 2:
+		return "Excellent"
+	case hours <= 4:
+		return "Good"
+	case hours <= 8:
+		return "Adequate"
+	case hours <= 16:
+		return "Marginal"
+	default:
+		return "Poor"
+	}
+}
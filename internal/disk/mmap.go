@@ -0,0 +1,169 @@
+package disk
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	mathrand "math/rand"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/vBenchmark/internal/fastrand"
+	"github.com/vBenchmark/internal/types"
+)
+
+// Freezer workload: geth's ancient store keeps immutable headers, bodies
+// and receipts in flat files of up to 2 GB that are read through mmap
+const (
+	mmapFileSize    = 1024 * 1024 * 1024
+	mmapMinFileSize = 64 * 1024 * 1024
+	mmapFillChunk   = 1024 * 1024
+)
+
+// mmapSink keeps the compiler from dropping the loads that fault pages in
+var mmapSink byte
+
+// BenchmarkMmap measures reads through a memory mapping, where every first
+// touch of a page is a page fault served from the device. A sequential pass
+// reads the file front to back as a freezer export or sync does; random
+// single-page touches follow as serving old blocks and receipts to peers does.
+// Reference: geth/core/rawdb/freezer_table.go
+func BenchmarkMmap(ctx context.Context, testDir string, budget *WriteBudget, duration time.Duration, verbose bool) (types.MmapResult, error) {
+	// Shrink the file to the write budget rather than map unwritten holes,
+	// which fault in zero pages without touching the media
+	fileSize := int64(mmapFileSize)
+	if remaining := budget.Remaining(); remaining >= 0 && remaining < fileSize {
+		fileSize = remaining &^ (mmapFillChunk - 1)
+		if fileSize < mmapMinFileSize {
+			return types.MmapResult{}, ErrWriteLimit
+		}
+	}
+
+	testFile := filepath.Join(testDir, "ethbench_mmap_test.dat")
+	defer os.Remove(testFile)
+	f, err := os.OpenFile(testFile, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0644)
+	if err != nil {
+		return types.MmapResult{}, fmt.Errorf("failed to create test file: %w", err)
+	}
+	defer f.Close()
+
+	// Stamp every page with its offset so no two pages are identical
+	chunk := fastrand.New("disk.mmap").Bytes(mmapFillChunk)
+	for offset := int64(0); offset < fileSize; offset += mmapFillChunk {
+		if err := ctx.Err(); err != nil {
+			return types.MmapResult{}, err
+		}
+		if !budget.Take(mmapFillChunk) {
+			return types.MmapResult{}, ErrWriteLimit
+		}
+		for b := 0; b < mmapFillChunk; b += 4096 {
+			binary.LittleEndian.PutUint64(chunk[b:], uint64(offset)+uint64(b))
+		}
+		if _, err := f.WriteAt(chunk, offset); err != nil {
+			return types.MmapResult{}, fmt.Errorf("failed to fill test file: %w", err)
+		}
+	}
+	if err := f.Sync(); err != nil {
+		return types.MmapResult{}, fmt.Errorf("failed to sync test file: %w", err)
+	}
+
+	pageSize := os.Getpagesize()
+	faultsBefore := majorFaults()
+
+	// Phase 1: Sequential reads through the mapping, remapped with a cold
+	// page cache whenever the end is reached
+	seqDuration := duration * 2 / 5
+	var seqBytes int64
+	seqStart := time.Now()
+	for time.Since(seqStart) < seqDuration && ctx.Err() == nil {
+		data, err := mapFile(f, fileSize, syscall.MADV_SEQUENTIAL)
+		if err != nil {
+			return types.MmapResult{}, err
+		}
+		offset := 0
+		for ; offset < len(data); offset += pageSize {
+			mmapSink ^= data[offset]
+			if offset%(256*pageSize) == 0 && (time.Since(seqStart) >= seqDuration || ctx.Err() != nil) {
+				break
+			}
+		}
+		seqBytes += int64(offset)
+		syscall.Munmap(data)
+	}
+	seqElapsed := time.Since(seqStart)
+	if verbose {
+		fmt.Printf("    Sequential: %.2f MB/s\n", float64(seqBytes)/seqElapsed.Seconds()/(1024*1024))
+	}
+
+	// Phase 2: Random single-page touches
+	data, err := mapFile(f, fileSize, syscall.MADV_RANDOM)
+	if err != nil {
+		return types.MmapResult{}, err
+	}
+	defer syscall.Munmap(data)
+	rng := mathrand.New(fastrand.New("disk.mmap.random"))
+	pages := len(data) / pageSize
+	var reads uint64
+	var latency time.Duration
+	randomStart := time.Now()
+	for time.Since(randomStart) < duration-seqDuration && ctx.Err() == nil {
+		offset := rng.Intn(pages) * pageSize
+		opStart := time.Now()
+		mmapSink ^= data[offset]
+		latency += time.Since(opStart)
+		reads++
+	}
+	randomElapsed := time.Since(randomStart)
+
+	result := types.MmapResult{
+		SequentialMBps:       float64(seqBytes) / seqElapsed.Seconds() / (1024 * 1024),
+		RandomReadsPerSecond: float64(reads) / randomElapsed.Seconds(),
+		MajorFaults:          majorFaults() - faultsBefore,
+		TestFileMB:           float64(fileSize) / (1024 * 1024),
+		Duration:             seqElapsed + randomElapsed,
+	}
+	if reads > 0 {
+		result.AvgRandomLatencyUs = float64(latency.Microseconds()) / float64(reads)
+	}
+	result.Rating = rateMmap(result.RandomReadsPerSecond)
+	return result, nil
+}
+
+// mapFile drops the file from the page cache and maps it read-only with the
+// given access advice, so the first touch of each page reads the device
+func mapFile(f *os.File, size int64, advice int) ([]byte, error) {
+	fadviseDontNeed(int(f.Fd()), size)
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map test file: %w", err)
+	}
+	syscall.Madvise(data, advice)
+	return data, nil
+}
+
+// majorFaults returns the process's page faults that needed device I/O
+func majorFaults() uint64 {
+	var usage syscall.Rusage
+	if syscall.Getrusage(syscall.RUSAGE_SELF, &usage) != nil {
+		return 0
+	}
+	return uint64(usage.Majflt)
+}
+
+// rateMmap provides a rating based on random page-fault reads per second
+func rateMmap(readsPerSecond float64) string {
+	switch {
+	case readsPerSecond >= 50000:
+		return "Excellent"
+	case readsPerSecond >= 20000:
+		return "Good"
+	case readsPerSecond >= 10000:
+		return "Adequate"
+	case readsPerSecond >= 5000:
+		return "Marginal"
+	default:
+		return "Poor"
+	}
+}
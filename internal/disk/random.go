@@ -1,120 +1,326 @@
 package disk
 
 import (
-	"crypto/rand"
+	"context"
+	"encoding/binary"
+	"fmt"
 	mathrand "math/rand"
 	"os"
-	"path/filepath"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/vBenchmark/internal/fastrand"
 	"github.com/vBenchmark/internal/types"
 )
 
+// fallbackRandomFileSize is the random I/O working set used when the caller
+// passes none; ethbench itself sizes it with benchmark.DefaultRandomFileSize
+const fallbackRandomFileSize = 1024 * 1024 * 1024
+
 // BenchmarkRandom measures random 4K I/O performance
 // This simulates trie node lookups during EVM execution
 // Reference: geth/trie/trie.go resolveAndTrack()
-func BenchmarkRandom(testDir string, duration time.Duration, verbose bool) types.RandomResult {
-	const blockSize = 4096                 // 4KB - typical trie node size
-	const fileSize = 1024 * 1024 * 1024    // 1GB test file - larger than typical cache
-
-	testFile := filepath.Join(testDir, "ethbench_random_test.dat")
-	defer os.Remove(testFile)
+// fileSize is the working set; it should exceed RAM so the page cache cannot
+// absorb the reads (0 = fallbackRandomFileSize). With jobs > 1 the phases are
+// repeated from that many goroutines and the aggregate IOPS reported too.
+// Reads are repeated at each of depths outstanding requests, one goroutine
+// per request, to show drives that only reach their IOPS at higher queue
+// depths. With direct set, the QD1 phases are also run through an O_DIRECT
+// handle.
+func BenchmarkRandom(ctx context.Context, files *TestFiles, budget *WriteBudget, fileSize int64, jobs int, depths []int, duration time.Duration, direct, verbose bool) (types.RandomResult, error) {
+	const blockSize = 4096 // 4KB - typical trie node size
+	const minFileSize = 64 * 1024 * 1024
+	const fillChunk = 1024 * 1024
 
-	// Create and populate test file
-	f, err := os.OpenFile(testFile, os.O_CREATE|os.O_RDWR, 0644)
-	if err != nil {
-		return types.RandomResult{Rating: "Error: " + err.Error()}
+	if fileSize <= 0 {
+		fileSize = fallbackRandomFileSize
 	}
+	fileSize &^= fillChunk - 1
+	requested := fileSize
 
-	// Pre-allocate the file
-	if err := f.Truncate(fileSize); err != nil {
-		f.Close()
-		return types.RandomResult{Rating: "Error: " + err.Error()}
+	// Every block must hold written data: reads of unwritten extents are
+	// answered by the filesystem without touching the media. Shrink the file
+	// rather than leave holes when the write budget cannot cover a full fill.
+	if remaining := budget.Remaining(); remaining >= 0 && remaining/2 < fileSize {
+		fileSize = remaining / 2 &^ (fillChunk - 1)
+		if verbose {
+			fmt.Printf("    Write limit shrinks the test file from %d MB to %d MB\n", requested>>20, fileSize>>20)
+		}
+		if fileSize < minFileSize {
+			return types.RandomResult{}, ErrWriteLimit
+		}
 	}
 
-	// Fill with random data at intervals to ensure file is actually allocated
-	data := make([]byte, blockSize)
-	for offset := int64(0); offset < fileSize; offset += 4 * 1024 * 1024 { // Every 4MB
-		rand.Read(data)
-		f.WriteAt(data, offset)
+	// Create and populate test file, or reuse one prepared by an earlier run
+	f, err := files.Prepare("ethbench_random_test.dat", fileSize, func(f *os.File) error {
+		// Allocate real extents up front; filesystems without fallocate
+		// allocate them during the fill instead
+		if err := syscall.Fallocate(int(f.Fd()), 0, 0, fileSize); err != nil && err != syscall.EOPNOTSUPP {
+			return fmt.Errorf("failed to allocate test file: %w", err)
+		}
+
+		// Stamp each 4K block with its offset so no two blocks are identical
+		// (defeating controller deduplication) without generating gigabytes
+		// of random data
+		chunk := fastrand.New("disk.random.fill").Bytes(fillChunk)
+		for offset := int64(0); offset < fileSize; offset += fillChunk {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if !budget.Take(fillChunk) {
+				return ErrWriteLimit
+			}
+			for b := 0; b < fillChunk; b += blockSize {
+				binary.LittleEndian.PutUint64(chunk[b:], uint64(offset)+uint64(b))
+			}
+			if _, err := f.WriteAt(chunk, offset); err != nil {
+				return fmt.Errorf("failed to fill test file: %w", err)
+			}
+		}
+		return f.Sync()
+	})
+	if err != nil {
+		return types.RandomResult{}, err
 	}
-	f.Sync()
+	defer files.Release(f)
 
 	numBlocks := fileSize / blockSize
-	rng := mathrand.New(mathrand.NewSource(time.Now().UnixNano()))
 
 	// Drop page cache before reading
 	fd := int(f.Fd())
-	syscall.Syscall6(syscall.SYS_FADVISE64, uintptr(fd), 0, uintptr(fileSize), uintptr(4), 0, 0) // POSIX_FADV_DONTNEED = 4
+	fadviseDontNeed(fd, fileSize)
 
-	// Phase 1: Random reads (simulates trie lookups)
-	readDuration := duration * 3 / 5
-	var readOps uint64
-	var totalReadLatency time.Duration
+	// O_DIRECT phases take half the time when enabled
+	var directDuration time.Duration
+	if direct {
+		directDuration = duration / 2
+		duration -= directDuration
+	}
 
-	readStart := time.Now()
-	for time.Since(readStart) < readDuration {
-		// Truly random offset within file
-		blockNum := rng.Int63n(int64(numBlocks))
-		offset := blockNum * blockSize
+	// The queue depth sweep takes half of the rest, split evenly by depth
+	var sweepDuration time.Duration
+	if len(depths) > 0 {
+		sweepDuration = duration / 2
+		duration -= sweepDuration
+	}
 
-		opStart := time.Now()
-		_, err := f.ReadAt(data, offset)
-		totalReadLatency += time.Since(opStart)
+	// With concurrent jobs, half the time goes to the QD1 phases and half to
+	// the same phases driven from several goroutines
+	qd1Duration := duration
+	if jobs > 1 {
+		qd1Duration = duration / 2
+	}
 
-		if err == nil {
-			readOps++
-		}
+	// A quarter of the QD1 time goes to the mixed phase
+	mixedDuration := qd1Duration / 4
+	qd1Duration -= mixedDuration
+
+	// Phase 1: Random reads at QD1 (simulates trie lookups)
+	read := randomIO(ctx, f, budget, numBlocks, 1, qd1Duration*3/5, readOnly, "read")
+
+	// Phase 2: Random writes with sync at QD1 (simulates dirty node flushes)
+	write := randomIO(ctx, f, budget, numBlocks, 1, qd1Duration*2/5, writeOnly, "write")
+	f.Sync()
+	if write.ops == 0 && budget.Exhausted() {
+		return types.RandomResult{}, ErrWriteLimit
 	}
-	readElapsed := time.Since(readStart)
-	readIOPS := float64(readOps) / readElapsed.Seconds()
 
-	// Phase 2: Random writes with sync (simulates dirty node flushes)
-	writeDuration := duration * 2 / 5
-	var writeOps uint64
-	var totalWriteLatency time.Duration
+	// Phase 2b: 70/30 reads and writes interleaved in one loop, as trie
+	// reads compete with compaction and flushes on a running node
+	fadviseDontNeed(fd, fileSize)
+	mixed := randomIO(ctx, f, budget, numBlocks, 1, mixedDuration, mixedWrites, "mixed")
+	f.Sync()
 
-	writeStart := time.Now()
-	for time.Since(writeStart) < writeDuration {
-		// Truly random offset within file
-		blockNum := rng.Int63n(int64(numBlocks))
-		offset := blockNum * blockSize
+	readIOPS := read.iops()
+	writeIOPS := write.iops()
 
-		rand.Read(data)
+	// Calculate average latency across all operations; none complete when
+	// the run is cancelled right away
+	var avgLatencyUs float64
+	if n := read.ops + write.ops; n > 0 {
+		avgLatencyUs = float64((read.latency + write.latency).Microseconds()) / float64(n)
+	}
 
-		opStart := time.Now()
-		_, err := f.WriteAt(data, offset)
-		// Sync periodically to measure real write latency (every 100 ops)
-		if writeOps%100 == 99 {
-			f.Sync()
+	result := types.RandomResult{
+		ReadIOPS:     readIOPS,
+		WriteIOPS:    writeIOPS,
+		AvgLatencyUs: avgLatencyUs,
+		TestFileMB:   float64(fileSize) / (1024 * 1024),
+		Jobs:         1,
+		Duration:     read.elapsed + write.elapsed + mixed.elapsed,
+		Rating:       rateRandom(readIOPS, writeIOPS),
+	}
+	if fileSize < requested {
+		result.RequestedFileMB = float64(requested) / (1024 * 1024)
+	}
+	if mixed.ops > 0 {
+		result.MixedIOPS = mixed.iops()
+		result.MixedWritePercent = mixedWrites
+		if reads := mixed.ops - mixed.writes; reads > 0 {
+			result.MixedReadLatencyUs = float64((mixed.latency - mixed.writeLatency).Microseconds()) / float64(reads)
 		}
-		totalWriteLatency += time.Since(opStart)
+		if mixed.writes > 0 {
+			result.MixedWriteLatencyUs = float64(mixed.writeLatency.Microseconds()) / float64(mixed.writes)
+		}
+	}
+
+	// Phases 3-4: The same reads and writes from concurrent jobs, as clients
+	// resolve trie nodes and flush from many goroutines at once
+	if jobs > 1 {
+		fadviseDontNeed(fd, fileSize)
+		concurrentRead := randomIO(ctx, f, budget, numBlocks, jobs, (duration-qd1Duration)*3/5, readOnly, "read")
+		concurrentWrite := randomIO(ctx, f, budget, numBlocks, jobs, (duration-qd1Duration)*2/5, writeOnly, "write")
+		f.Sync()
 
-		if err == nil {
-			writeOps++
+		result.Jobs = jobs
+		result.ConcurrentReadIOPS = concurrentRead.iops()
+		result.ConcurrentWriteIOPS = concurrentWrite.iops()
+		result.Duration += concurrentRead.elapsed + concurrentWrite.elapsed
+	}
+
+	// Reads at each queue depth, as a node resolving trie nodes for many
+	// transactions in parallel keeps the drive's queues filled
+	for _, depth := range depths {
+		if ctx.Err() != nil {
+			break
+		}
+		fadviseDontNeed(fd, fileSize)
+		reads := randomIO(ctx, f, budget, numBlocks, depth, sweepDuration/time.Duration(len(depths)), readOnly, "qd.read")
+		if verbose {
+			fmt.Printf("    QD%d: %.0f read IOPS\n", depth, reads.iops())
+		}
+		qd := types.QueueDepthResult{
+			Depth:    depth,
+			ReadIOPS: reads.iops(),
+			ReadMBps: reads.iops() * blockSize / (1024 * 1024),
 		}
+		if reads.ops > 0 {
+			qd.AvgLatencyUs = float64(reads.latency.Microseconds()) / float64(reads.ops)
+		}
+		result.QueueDepths = append(result.QueueDepths, qd)
+		result.Duration += reads.elapsed
 	}
-	f.Sync()
-	f.Close()
 
-	writeElapsed := time.Since(writeStart)
-	writeIOPS := float64(writeOps) / writeElapsed.Seconds()
+	// Phases 5-6: QD1 again with the page cache bypassed by O_DIRECT rather
+	// than dropped by fadvise
+	if direct {
+		df, err := openDirect(f.Name(), os.O_RDWR)
+		if err != nil {
+			return result, fmt.Errorf("direct I/O: %w", err)
+		}
+		directRead := randomIO(ctx, df, budget, numBlocks, 1, directDuration*3/5, readOnly, "direct.read")
+		directWrite := randomIO(ctx, df, budget, numBlocks, 1, directDuration*2/5, writeOnly, "direct.write")
+		df.Sync()
+		df.Close()
 
-	// Calculate average latency across all operations
-	totalOps := readOps + writeOps
-	totalLatency := totalReadLatency + totalWriteLatency
-	avgLatencyUs := float64(totalLatency.Microseconds()) / float64(totalOps)
+		result.DirectReadIOPS = directRead.iops()
+		result.DirectWriteIOPS = directWrite.iops()
+		result.Duration += directRead.elapsed + directWrite.elapsed
+	}
 
-	totalDuration := readElapsed + writeElapsed
+	return result, nil
+}
 
-	return types.RandomResult{
-		ReadIOPS:     readIOPS,
-		WriteIOPS:    writeIOPS,
-		AvgLatencyUs: avgLatencyUs,
-		Duration:     totalDuration,
-		Rating:       rateRandom(readIOPS, writeIOPS),
+// randomIOStats aggregates the operations of one random I/O phase
+type randomIOStats struct {
+	ops     uint64
+	latency time.Duration
+	elapsed time.Duration
+	// writes and writeLatency are the share of ops and latency that were
+	// writes; the rest were reads
+	writes       uint64
+	writeLatency time.Duration
+}
+
+// iops returns the aggregate operations per second across all jobs
+func (s randomIOStats) iops() float64 {
+	if s.elapsed <= 0 {
+		return 0
 	}
+	return float64(s.ops) / s.elapsed.Seconds()
+}
+
+// Operation mixes for randomIO, as the percentage of operations that write
+const (
+	readOnly  = 0
+	writeOnly = 100
+	// mixedWrites matches fio's randrw with rwmixread=70: trie reads
+	// interleaved with compaction and flush writes
+	mixedWrites = 30
+)
+
+// randomIO issues random 4K operations from jobs goroutines, each with its
+// own buffer and offset sequence, for duration. writePercent of them are
+// writes, the rest reads. Writers sync every 100 writes to measure real
+// write latency. phase names the offset sequence.
+func randomIO(ctx context.Context, f *os.File, budget *WriteBudget, numBlocks int64, jobs int, duration time.Duration, writePercent int, phase string) randomIOStats {
+	const blockSize = 4096
+
+	var (
+		mu    sync.Mutex
+		total randomIOStats
+		wg    sync.WaitGroup
+	)
+	// Each phase and job count draws its own offsets, so a phase never
+	// replays blocks an earlier one left in the page cache
+	start := time.Now()
+	for j := 0; j < jobs; j++ {
+		wg.Add(1)
+		go func(job int) {
+			defer wg.Done()
+			src := fastrand.New(fmt.Sprintf("disk.random.%s.%d.%d", phase, jobs, job))
+			rng := mathrand.New(src)
+			data := alignedBuffer(blockSize)
+			var stats randomIOStats
+
+			for time.Since(start) < duration && ctx.Err() == nil {
+				write := writePercent == writeOnly || (writePercent > readOnly && rng.Intn(100) < writePercent)
+				if write && !budget.Take(blockSize) {
+					break
+				}
+				// Truly random offset within file
+				offset := rng.Int63n(numBlocks) * blockSize
+
+				var err error
+				opStart := time.Now()
+				if write {
+					src.Read(data)
+					_, err = f.WriteAt(data, offset)
+					if stats.writes%100 == 99 {
+						f.Sync()
+					}
+				} else {
+					_, err = f.ReadAt(data, offset)
+				}
+				opLatency := time.Since(opStart)
+
+				if err == nil {
+					stats.ops++
+					stats.latency += opLatency
+					if write {
+						stats.writes++
+						stats.writeLatency += opLatency
+					}
+				}
+			}
+
+			mu.Lock()
+			total.ops += stats.ops
+			total.latency += stats.latency
+			total.writes += stats.writes
+			total.writeLatency += stats.writeLatency
+			mu.Unlock()
+		}(j)
+	}
+	wg.Wait()
+	total.elapsed = time.Since(start)
+	return total
+}
+
+// fadviseDontNeed drops the file's pages from the page cache
+func fadviseDontNeed(fd int, size int64) {
+	syscall.Syscall6(syscall.SYS_FADVISE64, uintptr(fd), 0, uintptr(size), uintptr(4), 0, 0) // POSIX_FADV_DONTNEED = 4
 }
 
 // rateRandom provides a rating based on random I/O performance
@@ -2,24 +2,68 @@
 package disk
 
 import (
-	"crypto/rand"
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
-	"syscall"
 	"time"
 
+	"github.com/vBenchmark/internal/fastrand"
 	"github.com/vBenchmark/internal/types"
 )
 
 // BenchmarkSequential measures sequential I/O performance
 // This simulates state sync and snapshot operations
-func BenchmarkSequential(testDir string, duration time.Duration, verbose bool) types.SequentialResult {
+// With direct set, half the time repeats the test with O_DIRECT, whose
+// numbers show the device without any page cache help.
+func BenchmarkSequential(ctx context.Context, testDir string, budget *WriteBudget, duration time.Duration, direct, verbose bool) (types.SequentialResult, error) {
+	passDuration := duration
+	if direct {
+		passDuration = duration / 2
+	}
+
+	writeSpeed, readSpeed, elapsed, err := sequentialPass(ctx, filepath.Join(testDir, "ethbench_seq_test.dat"), budget, passDuration, false)
+	if err != nil {
+		return types.SequentialResult{WriteSpeedMBps: writeSpeed}, err
+	}
+	result := types.SequentialResult{
+		WriteSpeedMBps: writeSpeed,
+		ReadSpeedMBps:  readSpeed,
+		Duration:       elapsed,
+		Rating:         rateSequential(writeSpeed, readSpeed),
+	}
+
+	if direct {
+		writeSpeed, readSpeed, elapsed, err := sequentialPass(ctx, filepath.Join(testDir, "ethbench_seq_direct.dat"), budget, duration-passDuration, true)
+		if errors.Is(err, ErrWriteLimit) {
+			return result, nil
+		}
+		if err != nil {
+			return result, fmt.Errorf("direct I/O: %w", err)
+		}
+		result.DirectWriteSpeedMBps = writeSpeed
+		result.DirectReadSpeedMBps = readSpeed
+		result.Duration += elapsed
+	}
+	return result, nil
+}
+
+// sequentialPass writes a test file in 128 KB and 1 MB blocks for half the
+// duration and reads it back for the other half. Without direct, the page
+// cache is dropped with fadvise before and between reads.
+func sequentialPass(ctx context.Context, testFile string, budget *WriteBudget, duration time.Duration, direct bool) (writeSpeed, readSpeed float64, elapsed time.Duration, err error) {
 	// Block sizes matching Ethereum data patterns:
 	// - 128KB: LevelDB SST file writes
 	// - 1MB: State snapshot chunks
 	blockSizes := []int{128 * 1024, 1024 * 1024}
 
-	testFile := filepath.Join(testDir, "ethbench_seq_test.dat")
+	open := os.OpenFile
+	stream := "disk.sequential"
+	if direct {
+		open = func(name string, flag int, _ os.FileMode) (*os.File, error) { return openDirect(name, flag) }
+		stream = "disk.sequential.direct"
+	}
 	defer os.Remove(testFile)
 
 	// Phase 1: Sequential writes with sync
@@ -27,17 +71,21 @@ func BenchmarkSequential(testDir string, duration time.Duration, verbose bool) t
 	var totalWritten uint64
 	writeStart := time.Now()
 
-	f, err := os.OpenFile(testFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	f, err := open(testFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
 	if err != nil {
-		return types.SequentialResult{Rating: "Error: " + err.Error()}
+		return 0, 0, 0, fmt.Errorf("failed to create test file: %w", err)
 	}
 
-	// Pre-allocate buffer to avoid GC during benchmark
-	buffer := make([]byte, 1024*1024)
-	rand.Read(buffer)
+	// Pre-allocate buffer to avoid GC during benchmark; O_DIRECT needs it aligned
+	buffer := alignedBuffer(1024 * 1024)
+	copy(buffer, fastrand.New(stream).Bytes(len(buffer)))
 
-	for time.Since(writeStart) < writeDuration {
+writeLoop:
+	for time.Since(writeStart) < writeDuration && ctx.Err() == nil {
 		for _, blockSize := range blockSizes {
+			if !budget.Take(blockSize) {
+				break writeLoop
+			}
 			data := buffer[:blockSize]
 			n, err := f.Write(data)
 			if err != nil {
@@ -48,37 +96,41 @@ func BenchmarkSequential(testDir string, duration time.Duration, verbose bool) t
 	}
 	f.Sync()
 	f.Close()
+	if totalWritten == 0 && budget.Exhausted() {
+		return 0, 0, 0, ErrWriteLimit
+	}
 
 	writeElapsed := time.Since(writeStart)
-	writeSpeed := float64(totalWritten) / writeElapsed.Seconds() / (1024 * 1024)
+	writeSpeed = float64(totalWritten) / writeElapsed.Seconds() / (1024 * 1024)
 
 	// Phase 2: Sequential reads - bypass page cache
 	readDuration := duration / 2
 	var totalRead uint64
 
-	f, err = os.OpenFile(testFile, os.O_RDONLY, 0)
+	f, err = open(testFile, os.O_RDONLY, 0)
 	if err != nil {
-		return types.SequentialResult{
-			WriteSpeedMBps: writeSpeed,
-			Rating:         "Error: " + err.Error(),
-		}
+		return writeSpeed, 0, writeElapsed, fmt.Errorf("failed to open test file for reading: %w", err)
 	}
 
 	// Drop page cache for this file using fadvise
 	fd := int(f.Fd())
 	fileInfo, _ := f.Stat()
 	fileSize := fileInfo.Size()
-	syscall.Syscall6(syscall.SYS_FADVISE64, uintptr(fd), 0, uintptr(fileSize), uintptr(4), 0, 0) // POSIX_FADV_DONTNEED = 4
+	if !direct {
+		fadviseDontNeed(fd, fileSize)
+	}
 
 	readStart := time.Now()
-	readBuffer := make([]byte, 1024*1024) // 1MB read buffer
+	readBuffer := alignedBuffer(1024 * 1024) // 1MB read buffer
 
-	for time.Since(readStart) < readDuration {
+	for time.Since(readStart) < readDuration && ctx.Err() == nil {
 		n, err := f.Read(readBuffer)
 		if err != nil {
 			// Loop back to start of file, drop cache again
 			f.Seek(0, 0)
-			syscall.Syscall6(syscall.SYS_FADVISE64, uintptr(fd), 0, uintptr(fileSize), uintptr(4), 0, 0)
+			if !direct {
+				fadviseDontNeed(fd, fileSize)
+			}
 			continue
 		}
 		totalRead += uint64(n)
@@ -86,16 +138,9 @@ func BenchmarkSequential(testDir string, duration time.Duration, verbose bool) t
 	f.Close()
 
 	readElapsed := time.Since(readStart)
-	readSpeed := float64(totalRead) / readElapsed.Seconds() / (1024 * 1024)
-
-	totalDuration := writeElapsed + readElapsed
+	readSpeed = float64(totalRead) / readElapsed.Seconds() / (1024 * 1024)
 
-	return types.SequentialResult{
-		WriteSpeedMBps: writeSpeed,
-		ReadSpeedMBps:  readSpeed,
-		Duration:       totalDuration,
-		Rating:         rateSequential(writeSpeed, readSpeed),
-	}
+	return writeSpeed, readSpeed, writeElapsed + readElapsed, nil
 }
 
 // rateSequential provides a rating based on sequential I/O speeds
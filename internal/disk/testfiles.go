@@ -0,0 +1,162 @@
+package disk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Test files are named ethbench_* so leftovers from crashed runs can be found
+const testFilePrefix = "ethbench_"
+
+// manifestSuffix marks the sidecar describing a prepared test file that is
+// kept for reuse. Files without one are leftovers from an interrupted run.
+const manifestSuffix = ".manifest"
+
+// checksumStride is the distance between the 4 KB blocks hashed to validate
+// a prepared file; hashing samples keeps validation fast on slow storage
+const checksumStride = 64 * 1024 * 1024
+
+// testFileManifest records what a prepared test file should contain
+type testFileManifest struct {
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"`
+}
+
+// TestFiles manages the large prepared files the disk benchmarks read from.
+// A prepared file whose size and checksum still match is reused instead of
+// being rewritten, saving time and flash wear on repeated runs.
+type TestFiles struct {
+	dir  string
+	keep bool
+}
+
+// NewTestFiles creates a manager for dir. With keep set, prepared files are
+// left in place after the run for the next one to reuse.
+func NewTestFiles(dir string, keep bool) *TestFiles {
+	return &TestFiles{dir: dir, keep: keep}
+}
+
+// Prepare opens the named test file for reading and writing, reusing it when
+// a valid manifest matches its size and contents. Otherwise the file is
+// recreated at size and populated by fill. The manifest is removed while the
+// file is in use, so a crash mid-run leaves an orphan rather than a file
+// that would be trusted next time.
+func (t *TestFiles) Prepare(name string, size int64, fill func(f *os.File) error) (*os.File, error) {
+	path := filepath.Join(t.dir, name)
+	manifestPath := path + manifestSuffix
+
+	if manifest, err := readManifest(manifestPath); err == nil && manifest.Size == size {
+		os.Remove(manifestPath)
+		if f, err := os.OpenFile(path, os.O_RDWR, 0644); err == nil {
+			if sum, err := sampleChecksum(f, size); err == nil && sum == manifest.Checksum {
+				return f, nil
+			}
+			f.Close()
+		}
+	}
+	os.Remove(manifestPath)
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create test file: %w", err)
+	}
+	if err := fill(f); err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, err
+	}
+	return f, nil
+}
+
+// Release closes a file returned by Prepare. The file is kept with a fresh
+// manifest when the manager keeps test files, and removed otherwise.
+func (t *TestFiles) Release(f *os.File) {
+	path := f.Name()
+	if t.keep {
+		f.Sync()
+		if info, err := f.Stat(); err == nil {
+			if sum, err := sampleChecksum(f, info.Size()); err == nil {
+				data, _ := json.Marshal(testFileManifest{Size: info.Size(), Checksum: sum})
+				if os.WriteFile(path+manifestSuffix, data, 0644) == nil {
+					f.Close()
+					return
+				}
+			}
+		}
+	}
+	f.Close()
+	os.Remove(path)
+}
+
+// CleanOrphans removes test files and directories left behind by crashed or
+// interrupted runs. Prepared files with a manifest are kept for reuse.
+func CleanOrphans(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read test directory: %w", err)
+	}
+
+	kept := make(map[string]bool)
+	for _, e := range entries {
+		if name, ok := strings.CutSuffix(e.Name(), manifestSuffix); ok {
+			kept[name] = true
+		}
+	}
+
+	var removed []string
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasPrefix(name, testFilePrefix) || kept[name] {
+			continue
+		}
+		// Manifests whose data file is gone are orphans too
+		if base, ok := strings.CutSuffix(name, manifestSuffix); ok {
+			if _, err := os.Stat(filepath.Join(dir, base)); err == nil {
+				continue
+			}
+		}
+		if err := os.RemoveAll(filepath.Join(dir, name)); err == nil {
+			removed = append(removed, name)
+		}
+	}
+	return removed, nil
+}
+
+// readManifest loads the manifest of a prepared test file
+func readManifest(path string) (testFileManifest, error) {
+	var m testFileManifest
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return m, err
+	}
+	err = json.Unmarshal(data, &m)
+	return m, err
+}
+
+// sampleChecksum hashes the file size and a 4 KB block every checksumStride
+func sampleChecksum(f *os.File, size int64) (string, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	if info.Size() != size {
+		return "", fmt.Errorf("size mismatch: %d != %d", info.Size(), size)
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%d", size)
+	block := make([]byte, 4096)
+	for offset := int64(0); offset < size; offset += checksumStride {
+		n, err := f.ReadAt(block, offset)
+		if err != nil && n == 0 {
+			return "", err
+		}
+		h.Write(block[:n])
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
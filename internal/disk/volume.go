@@ -0,0 +1,115 @@
+package disk
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// The watched volume is checked after this many bytes or this long since the
+// last check, whichever comes first
+const (
+	volumeCheckBytes    = 64 * 1024 * 1024
+	volumeCheckInterval = time.Second
+	// Writes stop once free space drops below 5% of the volume or 1 GB,
+	// whichever is smaller, leaving room for the node and the system
+	volumeReservePercent = 5
+	volumeMaxReserve     = 1024 * 1024 * 1024
+)
+
+// VolumeError reports that the volume under test filled up or turned
+// read-only (for example ext4 remounting after I/O errors) while the disk
+// benchmarks were writing
+type VolumeError struct {
+	Dir       string
+	ReadOnly  bool
+	FreeBytes int64 // -1 if unknown
+}
+
+func (e *VolumeError) Error() string {
+	if e.ReadOnly {
+		return fmt.Sprintf("volume of %s turned read-only", e.Dir)
+	}
+	if e.FreeBytes < 0 {
+		return fmt.Sprintf("volume of %s is full", e.Dir)
+	}
+	return fmt.Sprintf("volume of %s nearly full (%d MB free)", e.Dir, e.FreeBytes/(1024*1024))
+}
+
+// Reason returns a stable identifier for reports: "read_only" or "volume_full"
+func (e *VolumeError) Reason() string {
+	if e.ReadOnly {
+		return "read_only"
+	}
+	return "volume_full"
+}
+
+// Watch makes the budget check the free space and mount state of the volume
+// holding dir as writes are taken. Once the volume nears full or turns
+// read-only, all further writes are refused and VolumeErr reports why.
+// Must be called before the budget is shared.
+func (b *WriteBudget) Watch(dir string) {
+	b.dir = dir
+}
+
+// VolumeErr returns the volume error that stopped writes, if any. A write
+// error err that shows the volume is full or read-only, as ENOSPC or EROFS,
+// stops further writes too and is returned as a volume error.
+func (b *WriteBudget) VolumeErr(err error) *VolumeError {
+	if b == nil {
+		return nil
+	}
+	if stopped := b.stopped.Load(); stopped != nil {
+		return stopped
+	}
+	var readOnly bool
+	switch {
+	case errors.Is(err, syscall.EROFS):
+		readOnly = true
+	case errors.Is(err, syscall.ENOSPC), errors.Is(err, syscall.EDQUOT):
+	default:
+		return nil
+	}
+	volumeErr := &VolumeError{Dir: b.dir, ReadOnly: readOnly, FreeBytes: -1}
+	var pathErr *os.PathError
+	if errors.As(err, &pathErr) {
+		volumeErr.Dir = pathErr.Path
+	}
+	b.stopped.CompareAndSwap(nil, volumeErr)
+	return b.stopped.Load()
+}
+
+// checkVolume stats the watched volume when enough bytes or time have passed
+// since the last check, and stops writes if it nears full or turns read-only
+func (b *WriteBudget) checkVolume(n int) {
+	if b.dir == "" {
+		return
+	}
+	now := time.Now().UnixNano()
+	last := b.lastCheck.Load()
+	if b.sinceCheck.Add(int64(n)) < volumeCheckBytes && now-last < int64(volumeCheckInterval) {
+		return
+	}
+	// One writer checks at a time; the others carry on
+	if !b.lastCheck.CompareAndSwap(last, now) {
+		return
+	}
+	b.sinceCheck.Store(0)
+
+	var st unix.Statfs_t
+	if unix.Statfs(b.dir, &st) != nil {
+		return
+	}
+	free := int64(st.Bavail) * st.Bsize
+	reserve := min(int64(st.Blocks)*st.Bsize*volumeReservePercent/100, volumeMaxReserve)
+	switch {
+	case st.Flags&unix.ST_RDONLY != 0:
+		b.stopped.CompareAndSwap(nil, &VolumeError{Dir: b.dir, ReadOnly: true, FreeBytes: free})
+	case free < reserve:
+		b.stopped.CompareAndSwap(nil, &VolumeError{Dir: b.dir, FreeBytes: free})
+	}
+}
@@ -0,0 +1,85 @@
+package disk
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrWriteLimit is returned when the write budget ran out before a benchmark
+// could take a measurement
+var ErrWriteLimit = errors.New("write limit reached")
+
+// WriteBudget caps the total bytes the disk benchmarks write, so fragile
+// flash storage is not worn by tens of GB of test data. A nil budget or a
+// zero limit is unlimited. Safe for concurrent use.
+type WriteBudget struct {
+	limit   int64
+	written atomic.Int64
+	reached atomic.Bool
+
+	// Volume watch, see Watch
+	dir        string
+	sinceCheck atomic.Int64
+	lastCheck  atomic.Int64 // Unix nanoseconds
+	stopped    atomic.Pointer[VolumeError]
+}
+
+// NewWriteBudget creates a budget of limit bytes (0 = unlimited)
+func NewWriteBudget(limit int64) *WriteBudget {
+	return &WriteBudget{limit: limit}
+}
+
+// Take reserves n bytes for a write and reports whether the write may go
+// ahead. Writes are refused once the watched volume nears full or turns
+// read-only.
+func (b *WriteBudget) Take(n int) bool {
+	if b == nil {
+		return true
+	}
+	if b.checkVolume(n); b.stopped.Load() != nil {
+		return false
+	}
+	if b.limit <= 0 {
+		b.written.Add(int64(n))
+		return true
+	}
+	for {
+		written := b.written.Load()
+		if written+int64(n) > b.limit {
+			b.reached.Store(true)
+			return false
+		}
+		if b.written.CompareAndSwap(written, written+int64(n)) {
+			return true
+		}
+	}
+}
+
+// Exhausted reports whether a write was refused because the limit was reached
+func (b *WriteBudget) Exhausted() bool {
+	return b != nil && b.reached.Load()
+}
+
+// Written returns the bytes reserved so far
+func (b *WriteBudget) Written() int64 {
+	if b == nil {
+		return 0
+	}
+	return b.written.Load()
+}
+
+// Remaining returns the bytes left in the budget, or -1 if unlimited
+func (b *WriteBudget) Remaining() int64 {
+	if b == nil || b.limit <= 0 {
+		return -1
+	}
+	return max(b.limit-b.written.Load(), 0)
+}
+
+// Limit returns the configured limit in bytes (0 = unlimited)
+func (b *WriteBudget) Limit() int64 {
+	if b == nil {
+		return 0
+	}
+	return b.limit
+}
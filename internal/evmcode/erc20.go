@@ -0,0 +1,60 @@
+package evmcode
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// TransferSelector is the ABI selector of transfer(address,uint256)
+	TransferSelector = crypto.Keccak256([]byte("transfer(address,uint256)"))[:4]
+	// TransferTopic is the Transfer(address,address,uint256) event signature
+	TransferTopic = crypto.Keccak256([]byte("Transfer(address,address,uint256)"))
+)
+
+// ERC20 is the runtime code of a minimal ERC-20 token that only
+// implements transfer(address,uint256), laid out like Solidity output:
+// selector dispatch, balances mapping at slot 0, a balance check, both
+// balance updates, the Transfer event and an ABI-encoded true
+func ERC20() []byte {
+	p := NewProgram()
+	p.Push(nil).Op(vm.CALLDATALOAD).PushUint(0xe0).Op(vm.SHR)
+	p.Push(TransferSelector).Op(vm.EQ).PushLabel("transfer").Op(vm.JUMPI)
+	p.Push(nil).Op(vm.DUP1, vm.REVERT)
+
+	p.Jumpdest("transfer")
+	p.Op(vm.CALLVALUE).PushLabel("revert").Op(vm.JUMPI)              // Non-payable
+	p.Op(vm.CALLER).Push(nil).Op(vm.MSTORE)                          // mem[0:32] = sender
+	p.Push(nil).PushUint(32).Op(vm.MSTORE)                           // mem[32:64] = slot 0
+	p.PushUint(64).Push(nil).Op(vm.KECCAK256)                        // [senderSlot]
+	p.Op(vm.DUP1, vm.SLOAD).PushUint(0x24).Op(vm.CALLDATALOAD)       // [senderSlot, bal, value]
+	p.Op(vm.DUP1, vm.DUP3, vm.LT).PushLabel("revert").Op(vm.JUMPI)   // bal < value
+	p.Op(vm.DUP1, vm.DUP3, vm.SUB, vm.DUP4, vm.SSTORE)               // balances[sender] = bal - value
+	p.PushUint(4).Op(vm.CALLDATALOAD).Push(nil).Op(vm.MSTORE)        // mem[0:32] = to
+	p.PushUint(64).Push(nil).Op(vm.KECCAK256)                        // [.., value, toSlot]
+	p.Op(vm.DUP1, vm.SLOAD, vm.DUP3, vm.ADD, vm.SWAP1, vm.SSTORE)    // balances[to] += value
+	p.Op(vm.DUP1).Push(nil).Op(vm.MSTORE)                            // mem[0:32] = value
+	p.PushUint(4).Op(vm.CALLDATALOAD, vm.CALLER).Push(TransferTopic) // topics: to, from, signature
+	p.PushUint(32).Push(nil).Op(vm.LOG3)                             // Transfer(from, to, value)
+	p.PushUint(1).Push(nil).Op(vm.MSTORE).PushUint(32).Push(nil).Op(vm.RETURN)
+
+	p.Jumpdest("revert")
+	p.Push(nil).Op(vm.DUP1, vm.REVERT)
+	return p.Bytecode()
+}
+
+// BalanceSlot returns the storage slot of balances[addr] for a Solidity
+// mapping declared first in the contract: keccak256(pad(addr) . pad(0))
+func BalanceSlot(addr common.Address) common.Hash {
+	return crypto.Keccak256Hash(common.LeftPadBytes(addr[:], 32), make([]byte, 32))
+}
+
+// TransferInput ABI-encodes transfer(to, value)
+func TransferInput(to common.Address, value uint64) []byte {
+	input := append([]byte{}, TransferSelector...)
+	input = append(input, common.LeftPadBytes(to[:], 32)...)
+	return append(input, common.LeftPadBytes(new(big.Int).SetUint64(value).Bytes(), 32)...)
+}
@@ -0,0 +1,71 @@
+// Package evmcode assembles the EVM bytecode the benchmarks execute
+package evmcode
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// Program assembles EVM bytecode with named jump targets
+type Program struct {
+	code   []byte
+	labels map[string]int
+	refs   map[int]string // Offsets of PUSH2 immediates that hold a label
+}
+
+// NewProgram returns an empty program
+func NewProgram() *Program {
+	return &Program{labels: make(map[string]int), refs: make(map[int]string)}
+}
+
+// Op appends opcodes without immediates
+func (p *Program) Op(ops ...vm.OpCode) *Program {
+	for _, o := range ops {
+		p.code = append(p.code, byte(o))
+	}
+	return p
+}
+
+// Push appends the shortest PUSH of a big-endian value, PUSH0 for zero
+func (p *Program) Push(value []byte) *Program {
+	value = bytes.TrimLeft(value, "\x00")
+	if len(value) == 0 {
+		return p.Op(vm.PUSH0)
+	}
+	p.code = append(p.code, byte(vm.PUSH1)+byte(len(value)-1))
+	p.code = append(p.code, value...)
+	return p
+}
+
+// PushUint appends the shortest PUSH of v
+func (p *Program) PushUint(v uint64) *Program {
+	return p.Push(binary.BigEndian.AppendUint64(nil, v))
+}
+
+// PushLabel appends a PUSH2 of a jump target resolved by Bytecode
+func (p *Program) PushLabel(label string) *Program {
+	p.code = append(p.code, byte(vm.PUSH2))
+	p.refs[len(p.code)] = label
+	p.code = append(p.code, 0, 0)
+	return p
+}
+
+// Jumpdest marks a jump target
+func (p *Program) Jumpdest(label string) *Program {
+	p.labels[label] = len(p.code)
+	return p.Op(vm.JUMPDEST)
+}
+
+// Bytecode resolves labels and returns the assembled code
+func (p *Program) Bytecode() []byte {
+	for offset, label := range p.refs {
+		target, ok := p.labels[label]
+		if !ok {
+			panic("evmcode: undefined label " + label)
+		}
+		binary.BigEndian.PutUint16(p.code[offset:], uint16(target))
+	}
+	return p.code
+}
@@ -0,0 +1,88 @@
+// Package fastrand provides a fast, seeded generator for benchmark payload
+// data. crypto/rand costs more per byte than some of the operations being
+// measured, and a fixed seed makes every run write and hash the same data.
+package fastrand
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+)
+
+// Source is a xoshiro256** generator. It also implements math/rand.Source64.
+// It is not cryptographically secure and not safe for concurrent use; give
+// each goroutine its own stream.
+type Source struct {
+	s [4]uint64
+}
+
+// New returns a generator seeded from the stream name, e.g. "disk.random",
+// so each benchmark draws its own reproducible sequence and files written
+// by different benchmarks never share content
+func New(stream string) *Source {
+	h := fnv.New64a()
+	h.Write([]byte(stream))
+	r := &Source{}
+	r.Seed(int64(h.Sum64()))
+	return r
+}
+
+// Seed resets the state from a 64-bit seed, expanded with SplitMix64 as the
+// xoshiro authors recommend
+func (r *Source) Seed(seed int64) {
+	x := uint64(seed)
+	for i := range r.s {
+		x += 0x9e3779b97f4a7c15
+		z := x
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		r.s[i] = z ^ (z >> 31)
+	}
+}
+
+// Uint64 returns the next 64 random bits
+func (r *Source) Uint64() uint64 {
+	s := &r.s
+	result := rotl(s[1]*5, 7) * 9
+	t := s[1] << 17
+	s[2] ^= s[0]
+	s[3] ^= s[1]
+	s[1] ^= s[2]
+	s[0] ^= s[3]
+	s[2] ^= t
+	s[3] = rotl(s[3], 45)
+	return result
+}
+
+// Int63 returns a non-negative 63-bit integer
+func (r *Source) Int63() int64 {
+	return int64(r.Uint64() >> 1)
+}
+
+// Read fills p with random bytes. It never fails, so it can stand in for
+// crypto/rand.Read and rand.Reader.
+func (r *Source) Read(p []byte) (int, error) {
+	n := len(p)
+	for len(p) >= 8 {
+		binary.LittleEndian.PutUint64(p, r.Uint64())
+		p = p[8:]
+	}
+	if len(p) > 0 {
+		v := r.Uint64()
+		for i := range p {
+			p[i] = byte(v)
+			v >>= 8
+		}
+	}
+	return n, nil
+}
+
+// Bytes returns n random bytes
+func (r *Source) Bytes(n int) []byte {
+	b := make([]byte, n)
+	r.Read(b)
+	return b
+}
+
+func rotl(x uint64, k uint) uint64 {
+	return (x << k) | (x >> (64 - k))
+}
@@ -0,0 +1,79 @@
+package forks
+
+import (
+	"context"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+
+	"github.com/vBenchmark/internal/fastrand"
+	"github.com/vBenchmark/internal/types"
+	"github.com/vBenchmark/internal/workload"
+)
+
+// PeerDAS parameters from EIP-7594
+const (
+	fieldElementsPerBlob = 4096
+	extendedBlobElements = 2 * fieldElementsPerBlob // Reed-Solomon rate 1/2
+)
+
+// BenchmarkFusaka measures PeerDAS erasure coding from Fusaka (EIP-7594).
+// Nodes custodying all columns extend each blob from 4096 to 8192 field
+// elements: an inverse FFT to coefficient form, then an FFT over the doubled
+// domain.
+// Reference: geth/crypto/kzg4844 (cell computation via go-eth-kzg)
+func BenchmarkFusaka(ctx context.Context, duration time.Duration, verbose bool) types.FusakaResult {
+	blobDomain := fft.NewDomain(fieldElementsPerBlob)
+	extDomain := fft.NewDomain(extendedBlobElements)
+
+	blob := make([]fr.Element, fieldElementsPerBlob)
+	rng := fastrand.New("fork.fusaka")
+	for i := range blob {
+		blob[i].SetBytes(rng.Bytes(fr.Bytes))
+	}
+	poly := make([]fr.Element, extendedBlobElements)
+
+	var extensions uint64
+	start := time.Now()
+	for time.Since(start) < duration && ctx.Err() == nil {
+		copy(poly, blob)
+		for i := fieldElementsPerBlob; i < extendedBlobElements; i++ {
+			poly[i].SetZero()
+		}
+		blobDomain.FFTInverse(poly[:fieldElementsPerBlob], fft.DIF, fft.WithNbTasks(1))
+		fft.BitReverse(poly[:fieldElementsPerBlob])
+		extDomain.FFT(poly, fft.DIF, fft.WithNbTasks(1))
+		extensions++
+	}
+	elapsed := time.Since(start)
+
+	rate := float64(extensions) / elapsed.Seconds()
+	maxBlobs := workload.Current.Blobs.MaxPerBlock
+	realtime := rate / (float64(maxBlobs) / float64(workload.Current.Block.SlotSeconds))
+
+	return types.FusakaResult{
+		BlobExtensionsPerSecond: rate,
+		MaxBlobsPerSlot:         maxBlobs,
+		RealtimeFactor:          realtime,
+		Duration:                elapsed,
+		Rating:                  rateFusaka(realtime),
+	}
+}
+
+// rateFusaka provides a rating based on how far ahead of real-time blob
+// extension runs at the maximum blob count
+func rateFusaka(realtime float64) string {
+	switch {
+	case realtime >= 50:
+		return "Excellent"
+	case realtime >= 25:
+		return "Good"
+	case realtime >= 10:
+		return "Adequate"
+	case realtime >= 5:
+		return "Marginal"
+	default:
+		return "Poor"
+	}
+}
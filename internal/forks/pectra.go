@@ -0,0 +1,119 @@
+// Package forks provides optional benchmarks for upcoming protocol upgrades
+package forks
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+
+	"github.com/vBenchmark/internal/fastrand"
+	"github.com/vBenchmark/internal/types"
+)
+
+// EIP-2537 gas schedule for the operations measured
+const (
+	msmPoints    = 128
+	msmGas       = msmPoints * 12000 * 519 / 1000 // G1 MSM with discount for 128 points
+	pairingPairs = 2
+	pairingGas   = 32600*pairingPairs + 37700
+	mapToG1Gas   = 5500
+)
+
+// BenchmarkPectra measures the BLS12-381 precompiles added by EIP-2537 in
+// Pectra: G1 multi-scalar multiplication, pairing check and field-to-curve
+// mapping. Throughput is reported as precompile gas per second so it can be
+// compared against block gas targets.
+// Reference: geth/core/vm/contracts.go (bls12381G1MultiExp, bls12381Pairing)
+func BenchmarkPectra(ctx context.Context, duration time.Duration, verbose bool) types.PectraResult {
+	_, _, g1Gen, g2Gen := bls12381.Generators()
+
+	// Phase 1: G1 MSM over 128 points
+	points := make([]bls12381.G1Affine, msmPoints)
+	scalars := make([]fr.Element, msmPoints)
+	rng := fastrand.New("fork.pectra")
+	var s fr.Element
+	for i := range points {
+		s.SetBytes(rng.Bytes(fr.Bytes))
+		points[i].ScalarMultiplication(&g1Gen, s.BigInt(new(big.Int)))
+		scalars[i].SetBytes(rng.Bytes(fr.Bytes))
+	}
+
+	msmDuration := duration * 2 / 5
+	var msmCount uint64
+	var msmResult bls12381.G1Affine
+	start := time.Now()
+	for time.Since(start) < msmDuration && ctx.Err() == nil {
+		// Single task: precompiles execute on the EVM goroutine
+		msmResult.MultiExp(points, scalars, ecc.MultiExpConfig{NbTasks: 1})
+		msmCount++
+	}
+	msmElapsed := time.Since(start)
+
+	// Phase 2: Pairing check with two pairs, e(P, Q) * e(-P, Q) == 1
+	var negG1 bls12381.G1Affine
+	negG1.Neg(&g1Gen)
+	g1Points := []bls12381.G1Affine{g1Gen, negG1}
+	g2Points := []bls12381.G2Affine{g2Gen, g2Gen}
+
+	pairingDuration := duration * 2 / 5
+	var pairingCount uint64
+	start = time.Now()
+	for time.Since(start) < pairingDuration && ctx.Err() == nil {
+		if ok, err := bls12381.PairingCheck(g1Points, g2Points); err == nil && ok {
+			pairingCount++
+		}
+	}
+	pairingElapsed := time.Since(start)
+
+	// Phase 3: Map field element to G1
+	mapDuration := duration / 5
+	var mapCount uint64
+	var u fp.Element
+	u.SetBytes(rng.Bytes(fp.Bytes))
+	start = time.Now()
+	for time.Since(start) < mapDuration && ctx.Err() == nil {
+		p := bls12381.MapToG1(u)
+		u.SetBytes(p.X.Marshal())
+		mapCount++
+	}
+	mapElapsed := time.Since(start)
+
+	msmRate := float64(msmCount) / msmElapsed.Seconds()
+	pairingRate := float64(pairingCount) / pairingElapsed.Seconds()
+	mapRate := float64(mapCount) / mapElapsed.Seconds()
+
+	// Average gas throughput across the three precompiles
+	totalGas := float64(msmCount)*msmGas + float64(pairingCount)*pairingGas + float64(mapCount)*mapToG1Gas
+	totalElapsed := msmElapsed + pairingElapsed + mapElapsed
+	mgasPerSec := totalGas / totalElapsed.Seconds() / 1e6
+
+	return types.PectraResult{
+		G1MSMPerSecond:         msmRate,
+		PairingChecksPerSecond: pairingRate,
+		MapToG1PerSecond:       mapRate,
+		MGasPerSecond:          mgasPerSec,
+		Duration:               totalElapsed,
+		Rating:                 ratePectra(mgasPerSec),
+	}
+}
+
+// ratePectra provides a rating based on precompile gas throughput
+func ratePectra(mgasPerSec float64) string {
+	switch {
+	case mgasPerSec >= 80:
+		return "Excellent"
+	case mgasPerSec >= 40:
+		return "Good"
+	case mgasPerSec >= 20:
+		return "Adequate"
+	case mgasPerSec >= 10:
+		return "Marginal"
+	default:
+		return "Poor"
+	}
+}
@@ -0,0 +1,153 @@
+// Package history keeps a local record of benchmark runs, one JSON line per
+// run, so slow degradation of the same hardware shows across months
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vBenchmark/internal/report"
+)
+
+// FileName is the history file in the ethbench directory of the home
+// directory
+const FileName = "history.jsonl"
+
+// DefaultPath returns ~/.ethbench/history.jsonl, or "" without a home
+// directory
+func DefaultPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return ""
+	}
+	return filepath.Join(home, ".ethbench", FileName)
+}
+
+// Entry is one run in the history
+type Entry struct {
+	Timestamp       time.Time `json:"timestamp"`
+	Version         string    `json:"version"`
+	WorkloadVersion string    `json:"workload_version"`
+	ScoringProfile  string    `json:"scoring_profile"`
+	Hostname        string    `json:"hostname"`
+	// Fingerprint is the hardware fingerprint, empty in runs recorded before
+	// it was added
+	Fingerprint string `json:"fingerprint,omitempty"`
+	Model       string `json:"model"`
+	DiskModel   string `json:"disk_model"`
+	DiskType    string `json:"disk_type"`
+	// TestDir is the absolute directory the disk benchmarks ran in; runs on
+	// different disks are not trended together
+	TestDir string `json:"test_dir"`
+	Quick   bool   `json:"quick"`
+	// Tags are the run's -tags labels
+	Tags map[string]string `json:"tags,omitempty"`
+	// Metrics holds the completed score and benchmark metrics by dotted
+	// report path, e.g. "summary.total_score" or "disk.random.read_iops"
+	Metrics map[string]float64 `json:"metrics"`
+}
+
+// NewEntry records a finished report run on testDir
+func NewEntry(r *report.Report, testDir string, quick bool) Entry {
+	e := Entry{
+		Timestamp:       r.Metadata.Timestamp,
+		Version:         r.Metadata.Version,
+		WorkloadVersion: r.Metadata.WorkloadVersion,
+		ScoringProfile:  r.Metadata.ScoringProfile,
+		TestDir:         testDir,
+		Quick:           quick,
+		Tags:            r.Metadata.Tags,
+		Metrics:         r.Metrics(),
+	}
+	if abs, err := filepath.Abs(testDir); err == nil {
+		e.TestDir = abs
+	}
+	if sys := r.System; sys != nil {
+		e.Hostname = sys.Hostname
+		e.Fingerprint = sys.Fingerprint
+		e.Model = sys.RPiModel
+		if e.Model == "" {
+			e.Model = sys.CPUModel
+		}
+		e.DiskModel = sys.DiskModel
+		e.DiskType = sys.DiskType
+	}
+	return e
+}
+
+// Append adds the entry to the history file at path, creating the file and
+// its directory if needed
+func Append(path string, e Entry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode history entry: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	// One write per entry, so an interrupted append leaves at most one
+	// partial line, which Load skips
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to sync history: %w", err)
+	}
+	return f.Close()
+}
+
+// Load reads the history file in the order the runs were appended. Lines
+// that do not parse, such as one cut short by a crash, are skipped and
+// counted.
+func Load(path string) (entries []Entry, skipped int, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read history: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			skipped++
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to read history: %w", err)
+	}
+	return entries, skipped, nil
+}
+
+// Comparable reports whether two runs measured the same setup: the same
+// machine, disk, workload and suite length
+func (e Entry) Comparable(other Entry) bool {
+	return e.SameMachine(other) && e.TestDir == other.TestDir &&
+		e.WorkloadVersion == other.WorkloadVersion && e.Quick == other.Quick
+}
+
+// SameMachine reports whether two runs were on the same physical machine:
+// by hardware fingerprint, so a renamed host keeps its history, or by
+// hostname when a run predates fingerprints
+func (e Entry) SameMachine(other Entry) bool {
+	if e.Fingerprint != "" && other.Fingerprint != "" {
+		return e.Fingerprint == other.Fingerprint
+	}
+	return e.Hostname == other.Hostname
+}
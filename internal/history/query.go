@@ -0,0 +1,167 @@
+package history
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vBenchmark/internal/report"
+)
+
+// Filter selects the runs of a query; zero fields match every run
+type Filter struct {
+	// Tags must all be set on a run, with the same values
+	Tags map[string]string
+	// Host matches the hostname or the hardware fingerprint
+	Host string
+	// DiskType matches the disk type, e.g. "nvme"
+	DiskType string
+	// Suite is "quick" or "full"
+	Suite string
+	// ScoringProfile matches the profile the scores were computed with
+	ScoringProfile string
+	// Since drops the runs before it
+	Since time.Time
+}
+
+// Match reports whether the run passes every filter
+func (f Filter) Match(e Entry) bool {
+	for key, value := range f.Tags {
+		if got, ok := e.Tags[key]; !ok || got != value {
+			return false
+		}
+	}
+	if f.Host != "" && e.Hostname != f.Host && e.Fingerprint != f.Host {
+		return false
+	}
+	if f.DiskType != "" && e.DiskType != f.DiskType {
+		return false
+	}
+	if f.Suite != "" && suite(e.Quick) != f.Suite {
+		return false
+	}
+	if f.ScoringProfile != "" && e.ScoringProfile != f.ScoringProfile {
+		return false
+	}
+	return f.Since.IsZero() || !e.Timestamp.Before(f.Since)
+}
+
+// Select returns the runs matching the filter, in history order
+func Select(entries []Entry, f Filter) []Entry {
+	var matched []Entry
+	for _, e := range entries {
+		if f.Match(e) {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
+
+// Aggregate summarizes one metric over the runs of a query
+type Aggregate struct {
+	Metric string
+	// Runs is the number of selected runs that recorded the metric
+	Runs   int
+	Mean   float64
+	Min    float64
+	Max    float64
+	StdDev float64
+}
+
+// Aggregates computes the mean, range and sample standard deviation of every
+// metric starting with prefix ("" for all) over runs, sorted by metric
+func Aggregates(runs []Entry, prefix string) []Aggregate {
+	values := make(map[string][]float64)
+	for _, e := range runs {
+		for metric, value := range e.Metrics {
+			if strings.HasPrefix(metric, prefix) {
+				values[metric] = append(values[metric], value)
+			}
+		}
+	}
+
+	aggregates := make([]Aggregate, 0, len(values))
+	for metric, v := range values {
+		a := Aggregate{Metric: metric, Runs: len(v), Min: v[0], Max: v[0]}
+		var sum float64
+		for _, x := range v {
+			sum += x
+			a.Min = math.Min(a.Min, x)
+			a.Max = math.Max(a.Max, x)
+		}
+		a.Mean = sum / float64(len(v))
+		if len(v) > 1 {
+			var ss float64
+			for _, x := range v {
+				ss += (x - a.Mean) * (x - a.Mean)
+			}
+			a.StdDev = math.Sqrt(ss / float64(len(v)-1))
+		}
+		aggregates = append(aggregates, a)
+	}
+	sort.Slice(aggregates, func(i, j int) bool { return aggregates[i].Metric < aggregates[j].Metric })
+	return aggregates
+}
+
+// FormatQuery renders the last selected runs and the aggregates of the
+// metrics starting with prefix over all of them
+func FormatQuery(path string, total int, runs []Entry, last int, prefix string) string {
+	var sb strings.Builder
+
+	sb.WriteString(strings.Repeat("=", 80) + "\n")
+	sb.WriteString("                      ETHEREUM NODE BENCHMARK QUERY\n")
+	sb.WriteString(strings.Repeat("=", 80) + "\n\n")
+	sb.WriteString(fmt.Sprintf("  File:           %s (%d runs)\n", path, total))
+	sb.WriteString(fmt.Sprintf("  Matched:        %d runs\n", len(runs)))
+	if len(runs) == 0 {
+		return sb.String()
+	}
+
+	shown := runs
+	if last > 0 && len(shown) > last {
+		shown = shown[len(shown)-last:]
+	}
+	sb.WriteString(fmt.Sprintf("\nRUNS (latest %d)\n", len(shown)))
+	sb.WriteString(fmt.Sprintf("  %-16s %-16s %-6s %-6s %6s  %s\n", "Date", "Host", "Disk", "Suite", "Total", "Tags"))
+	sb.WriteString("  " + strings.Repeat("-", 92) + "\n")
+	for _, e := range shown {
+		sb.WriteString(fmt.Sprintf("  %-16s %-16s %-6s %-6s %6s  %s\n",
+			e.Timestamp.Local().Format("2006-01-02 15:04"), truncate(e.Hostname, 16), e.DiskType, suite(e.Quick),
+			score(e, "summary.total_score"), report.FormatTags(e.Tags)))
+	}
+
+	aggregates := Aggregates(runs, prefix)
+	sb.WriteString("\nMETRICS\n")
+	sb.WriteString(fmt.Sprintf("  %-44s %4s %11s %11s %11s %11s\n", "Metric", "Runs", "Mean", "Min", "Max", "Std Dev"))
+	sb.WriteString("  " + strings.Repeat("-", 92) + "\n")
+	for _, a := range aggregates {
+		sb.WriteString(fmt.Sprintf("  %-44s %4d %11.2f %11.2f %11.2f %11.2f\n",
+			a.Metric, a.Runs, a.Mean, a.Min, a.Max, a.StdDev))
+	}
+	if len(aggregates) == 0 {
+		sb.WriteString(fmt.Sprintf("  No metrics start with %q\n", prefix))
+	}
+	// Scores from different profiles are not comparable
+	scored := strings.HasPrefix("summary.", prefix) || strings.HasPrefix(prefix, "summary.")
+	if profiles := scoringProfiles(runs); scored && len(profiles) > 1 {
+		sb.WriteString(fmt.Sprintf("\n  Warning: the runs were scored with %d profiles (%s); pass -scoring to average comparable scores\n",
+			len(profiles), strings.Join(profiles, ", ")))
+	}
+	return sb.String()
+}
+
+// scoringProfiles lists the distinct scoring profiles of runs, sorted
+func scoringProfiles(runs []Entry) []string {
+	seen := make(map[string]bool)
+	var profiles []string
+	for _, e := range runs {
+		if !seen[e.ScoringProfile] {
+			seen[e.ScoringProfile] = true
+			profiles = append(profiles, e.ScoringProfile)
+		}
+	}
+	sort.Strings(profiles)
+	return profiles
+}
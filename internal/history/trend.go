@@ -0,0 +1,205 @@
+package history
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Scores that fell at least degradedPercent from the first comparable run
+// over at least degradedMinRuns runs, and keep falling, are flagged
+const (
+	degradedPercent = 10.0
+	degradedMinRuns = 3
+	// minSlopeSpan is the shortest period a monthly slope is fitted over;
+	// runs minutes apart only measure noise
+	minSlopeSpan = 7 * 24 * time.Hour
+)
+
+// Trend is the course of one metric over comparable runs
+type Trend struct {
+	Metric string
+	Runs   int
+	First  float64
+	Latest float64
+	// ChangePercent is the latest value relative to the first, NaN when the
+	// first is zero
+	ChangePercent float64
+	// PerMonthPercent is the least-squares slope over 30 days relative to
+	// the mean, NaN when the runs span less than a week
+	PerMonthPercent float64
+	// Degrading is set for scores, where higher is always better, that fell
+	// since the first run and are still falling
+	Degrading bool
+}
+
+// Trends computes the trend of every metric starting with prefix ("" for
+// all) over runs, which must be comparable and in time order. Scores are
+// only trended over runs scored with the latest run's profile.
+func Trends(runs []Entry, prefix string) []Trend {
+	if len(runs) == 0 {
+		return nil
+	}
+	scoring := runs[len(runs)-1].ScoringProfile
+	type sample struct {
+		at    time.Time
+		value float64
+	}
+	samples := make(map[string][]sample)
+	for _, e := range runs {
+		for metric, value := range e.Metrics {
+			if !strings.HasPrefix(metric, prefix) {
+				continue
+			}
+			if strings.HasPrefix(metric, "summary.") && e.ScoringProfile != scoring {
+				continue
+			}
+			samples[metric] = append(samples[metric], sample{e.Timestamp, value})
+		}
+	}
+
+	names := make([]string, 0, len(samples))
+	for metric := range samples {
+		names = append(names, metric)
+	}
+	sort.Strings(names)
+
+	trends := make([]Trend, 0, len(names))
+	for _, metric := range names {
+		s := samples[metric]
+		t := Trend{
+			Metric:          metric,
+			Runs:            len(s),
+			First:           s[0].value,
+			Latest:          s[len(s)-1].value,
+			ChangePercent:   math.NaN(),
+			PerMonthPercent: math.NaN(),
+		}
+		if t.First != 0 {
+			t.ChangePercent = (t.Latest - t.First) / math.Abs(t.First) * 100
+		}
+
+		// Least-squares fit of value over days since the first run
+		var sumX, sumY float64
+		for _, p := range s {
+			sumX += p.at.Sub(s[0].at).Hours() / 24
+			sumY += p.value
+		}
+		n := float64(len(s))
+		meanX, meanY := sumX/n, sumY/n
+		var sxx, sxy float64
+		for _, p := range s {
+			dx := p.at.Sub(s[0].at).Hours()/24 - meanX
+			sxx += dx * dx
+			sxy += dx * (p.value - meanY)
+		}
+		if s[len(s)-1].at.Sub(s[0].at) >= minSlopeSpan && meanY != 0 {
+			t.PerMonthPercent = sxy / sxx * 30 / math.Abs(meanY) * 100
+		}
+
+		t.Degrading = strings.HasPrefix(metric, "summary.") && t.Runs >= degradedMinRuns &&
+			t.ChangePercent <= -degradedPercent && t.PerMonthPercent < 0
+		trends = append(trends, t)
+	}
+	return trends
+}
+
+// FormatHistory renders the last runs of the history and the trends of the
+// metrics starting with prefix over the runs comparable with the latest
+func FormatHistory(path string, entries []Entry, last int, prefix string) string {
+	var sb strings.Builder
+
+	sb.WriteString(strings.Repeat("=", 80) + "\n")
+	sb.WriteString("                     ETHEREUM NODE BENCHMARK HISTORY\n")
+	sb.WriteString(strings.Repeat("=", 80) + "\n\n")
+	sb.WriteString(fmt.Sprintf("  File:           %s (%d runs)\n", path, len(entries)))
+	if len(entries) == 0 {
+		return sb.String()
+	}
+
+	shown := entries
+	if last > 0 && len(shown) > last {
+		shown = shown[len(shown)-last:]
+	}
+	sb.WriteString(fmt.Sprintf("\nRUNS (latest %d)\n", len(shown)))
+	sb.WriteString(fmt.Sprintf("  %-16s %-16s %-6s %-6s %6s %6s %6s %6s  %s\n", "Date", "Host", "Disk", "Suite", "Total", "CPU", "Memory", "Disk", "Test Dir"))
+	sb.WriteString("  " + strings.Repeat("-", 92) + "\n")
+	for _, e := range shown {
+		sb.WriteString(fmt.Sprintf("  %-16s %-16s %-6s %-6s %6s %6s %6s %6s  %s\n",
+			e.Timestamp.Local().Format("2006-01-02 15:04"), truncate(e.Hostname, 16), e.DiskType, suite(e.Quick),
+			score(e, "summary.total_score"), score(e, "summary.cpu_score"), score(e, "summary.memory_score"), score(e, "summary.disk_score"),
+			e.TestDir))
+	}
+
+	latest := entries[len(entries)-1]
+	var runs []Entry
+	for _, e := range entries {
+		if e.Comparable(latest) {
+			runs = append(runs, e)
+		}
+	}
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].Timestamp.Before(runs[j].Timestamp) })
+
+	sb.WriteString("\nTRENDS\n")
+	sb.WriteString(fmt.Sprintf("  Runs:           %d comparable with the latest (%s, %s suite)\n", len(runs), latest.Hostname, suite(latest.Quick)))
+	sb.WriteString(fmt.Sprintf("  Test Dir:       %s\n", latest.TestDir))
+	sb.WriteString(fmt.Sprintf("  Period:         %s to %s\n", runs[0].Timestamp.Local().Format("2006-01-02"), latest.Timestamp.Local().Format("2006-01-02")))
+	if len(runs) < 2 {
+		sb.WriteString("\n  A trend needs at least two comparable runs.\n")
+		return sb.String()
+	}
+
+	trends := Trends(runs, prefix)
+	sb.WriteString(fmt.Sprintf("\n  %-44s %4s %11s %11s %8s %8s\n", "Metric", "Runs", "First", "Latest", "Change", "Per 30d"))
+	sb.WriteString("  " + strings.Repeat("-", 92) + "\n")
+	for _, t := range trends {
+		sb.WriteString(fmt.Sprintf("  %-44s %4d %11.2f %11.2f %8s %8s\n",
+			t.Metric, t.Runs, t.First, t.Latest, percent(t.ChangePercent), percent(t.PerMonthPercent)))
+	}
+	if len(trends) == 0 {
+		sb.WriteString(fmt.Sprintf("  No metrics start with %q\n", prefix))
+	}
+	sb.WriteString("\n  Change is the latest run against the first; Per 30d is the fitted slope relative to the mean\n")
+	for _, t := range trends {
+		if t.Degrading {
+			sb.WriteString(fmt.Sprintf("  Warning: %s fell %.1f%% since %s and is still falling; check cooling, the power supply and the disk's SMART data\n",
+				t.Metric, -t.ChangePercent, runs[0].Timestamp.Local().Format("2006-01-02")))
+		}
+	}
+	return sb.String()
+}
+
+// score formats a score metric of the entry, or "-" if it was not scored
+func score(e Entry, metric string) string {
+	value, ok := e.Metrics[metric]
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f", value)
+}
+
+// suite names the benchmark suite length of a run
+func suite(quick bool) string {
+	if quick {
+		return "quick"
+	}
+	return "full"
+}
+
+// percent formats a signed percentage, or "n/a" for NaN
+func percent(p float64) string {
+	if math.IsNaN(p) {
+		return "n/a"
+	}
+	return fmt.Sprintf("%+.1f%%", p)
+}
+
+// truncate shortens s to n characters
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
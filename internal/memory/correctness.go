@@ -0,0 +1,194 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	mathrand "math/rand"
+	"sort"
+	"time"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	decredecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	cloudflare "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	google "github.com/ethereum/go-ethereum/crypto/bn256/google"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// maxRecordedFailures limits how many failure descriptions are kept
+const maxRecordedFailures = 10
+
+// correctnessCheck runs one randomized operation and compares the result
+// against an independent computation, returning an error on mismatch
+type correctnessCheck struct {
+	name string
+	run  func(rng *mathrand.Rand) error
+}
+
+// CheckCorrectness runs thousands of tiny crypto and trie operations, each
+// cross-checked against a second implementation or an algebraic identity.
+// Unstable RAM, overclocks or under-voltage show up here as wrong results
+// long before they visibly reduce throughput.
+func CheckCorrectness(ctx context.Context, duration time.Duration, verbose bool) types.CorrectnessResult {
+	checks := []correctnessCheck{
+		{"keccak256", checkKeccak},
+		{"secp256k1", checkSecp256k1},
+		{"bn256", checkBN256},
+		{"bls12381", checkBLSBilinearity},
+		{"trie_root", checkTrieRoot},
+	}
+
+	rng := mathrand.New(mathrand.NewSource(time.Now().UnixNano()))
+	result := types.CorrectnessResult{}
+
+	start := time.Now()
+	for i := 0; time.Since(start) < duration && ctx.Err() == nil; i++ {
+		check := checks[i%len(checks)]
+		result.Checks++
+		if err := check.run(rng); err != nil {
+			result.Failures++
+			if len(result.FailedChecks) < maxRecordedFailures {
+				result.FailedChecks = append(result.FailedChecks, check.name+": "+err.Error())
+			}
+		}
+	}
+	result.Duration = time.Since(start)
+
+	result.Rating = "Pass"
+	if result.Failures > 0 {
+		result.Rating = "Fail"
+	}
+	return result
+}
+
+// checkKeccak compares a one-shot hash with one fed in random-sized chunks
+func checkKeccak(rng *mathrand.Rand) error {
+	data := make([]byte, 1+rng.Intn(1024))
+	rng.Read(data)
+
+	oneShot := crypto.Keccak256(data)
+
+	hasher := sha3.NewLegacyKeccak256()
+	for rest := data; len(rest) > 0; {
+		n := 1 + rng.Intn(len(rest))
+		hasher.Write(rest[:n])
+		rest = rest[n:]
+	}
+	if chunked := hasher.Sum(nil); !bytes.Equal(oneShot, chunked) {
+		return fmt.Errorf("one-shot %x != chunked %x", oneShot, chunked)
+	}
+	return nil
+}
+
+// checkSecp256k1 signs with go-ethereum and recovers the key with decred
+func checkSecp256k1(rng *mathrand.Rand) error {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return err
+	}
+	hash := make([]byte, 32)
+	rng.Read(hash)
+
+	sig, err := crypto.Sign(hash, key)
+	if err != nil {
+		return err
+	}
+
+	// decred expects [V+27 || R || S] instead of [R || S || V]
+	compact := make([]byte, 65)
+	compact[0] = sig[64] + 27
+	copy(compact[1:], sig[:64])
+	pub, _, err := decredecdsa.RecoverCompact(compact, hash)
+	if err != nil {
+		return fmt.Errorf("decred recovery failed: %w", err)
+	}
+
+	expected := crypto.FromECDSAPub(&key.PublicKey)
+	if recovered := pub.SerializeUncompressed(); !bytes.Equal(expected, recovered) {
+		return fmt.Errorf("recovered key %x != %x", recovered, expected)
+	}
+
+	// Recover the private key scalar independently and compare public keys
+	priv := secp256k1.PrivKeyFromBytes(crypto.FromECDSA(key))
+	if derived := priv.PubKey().SerializeUncompressed(); !bytes.Equal(expected, derived) {
+		return fmt.Errorf("derived key %x != %x", derived, expected)
+	}
+	return nil
+}
+
+// checkBN256 compares G1 scalar multiplication in the cloudflare and google
+// BN256 implementations
+func checkBN256(rng *mathrand.Rand) error {
+	k := new(big.Int).Rand(rng, cloudflare.Order)
+
+	a := new(cloudflare.G1).ScalarBaseMult(k).Marshal()
+	b := new(google.G1).ScalarBaseMult(k).Marshal()
+	if !bytes.Equal(a, b) {
+		return fmt.Errorf("cloudflare %x != google %x", a, b)
+	}
+	return nil
+}
+
+// checkBLSBilinearity verifies e(aP, Q) == e(P, aQ) on BLS12-381
+func checkBLSBilinearity(rng *mathrand.Rand) error {
+	_, _, g1Gen, g2Gen := bls12381.Generators()
+
+	var s fr.Element
+	s.SetRandom()
+	a := s.BigInt(new(big.Int))
+
+	var aP bls12381.G1Affine
+	var aQ bls12381.G2Affine
+	aP.ScalarMultiplication(&g1Gen, a)
+	aQ.ScalarMultiplication(&g2Gen, a)
+
+	left, err := bls12381.Pair([]bls12381.G1Affine{aP}, []bls12381.G2Affine{g2Gen})
+	if err != nil {
+		return err
+	}
+	right, err := bls12381.Pair([]bls12381.G1Affine{g1Gen}, []bls12381.G2Affine{aQ})
+	if err != nil {
+		return err
+	}
+	if !left.Equal(&right) {
+		return fmt.Errorf("pairing is not bilinear")
+	}
+	return nil
+}
+
+// checkTrieRoot compares the root of a random key set computed by Geth's
+// Merkle Patricia Trie and its independent StackTrie implementation
+func checkTrieRoot(rng *mathrand.Rand) error {
+	n := 16 + rng.Intn(64)
+	keys := make([][]byte, n)
+	for i := range keys {
+		keys[i] = make([]byte, 32)
+		rng.Read(keys[i])
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+
+	full := trie.NewEmpty(triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	stack := trie.NewStackTrie(nil)
+	for _, key := range keys {
+		value := key[:8+rng.Intn(24)]
+		if err := full.Update(key, value); err != nil {
+			return err
+		}
+		if err := stack.Update(key, value); err != nil {
+			return err
+		}
+	}
+	if a, b := full.Hash(), stack.Hash(); a != b {
+		return fmt.Errorf("trie root %x != stack trie root %x", a, b)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// gcPhase forces a collection before a benchmark phase, so garbage left by
+// earlier phases is not collected on its time, and counts the collections
+// and pause time the phase itself causes
+type gcPhase struct {
+	name   string
+	before runtime.MemStats
+}
+
+// startGCPhase collects the heap and starts counting; call it before the
+// phase's timer starts
+func startGCPhase(name string) *gcPhase {
+	runtime.GC()
+	p := &gcPhase{name: name}
+	runtime.ReadMemStats(&p.before)
+	return p
+}
+
+// stop returns the collections since startGCPhase, with pause time as a
+// share of the phase's timed duration
+func (p *gcPhase) stop(elapsed time.Duration) types.GCPhase {
+	var after runtime.MemStats
+	runtime.ReadMemStats(&after)
+	phase := types.GCPhase{
+		Phase:        p.name,
+		NumGC:        after.NumGC - p.before.NumGC,
+		GCPauseTotal: time.Duration(after.PauseTotalNs - p.before.PauseTotalNs),
+	}
+	if elapsed > 0 {
+		phase.PausePercent = float64(phase.GCPauseTotal) / float64(elapsed) * 100
+	}
+	return phase
+}
@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"unsafe"
+
+	"github.com/vBenchmark/internal/workload"
+)
+
+// guardReserveMB is memory left to the OS and the rest of ethbench before
+// the working-set limit is applied
+const guardReserveMB = 256
+
+// WorkingSetLimit returns MemAvailable and the bytes the trie, state cache
+// and latency benchmarks may hold live without risking the OOM killer on
+// small boards: three quarters of what is available after a reserve, halved
+// because the Go heap grows to twice the live data at GOGC=100. The limit is
+// 0 when available memory is unknown.
+func WorkingSetLimit() (availableMB int, limit int64) {
+	availableMB, _ = readMemAvailable()
+	if availableMB == 0 {
+		return 0, 0
+	}
+	usableMB := max((availableMB-guardReserveMB)*3/4, 0)
+	return availableMB, int64(usableMB) << 20 / 2
+}
+
+// trieNodeBytes estimates the heap held per trie insert: the leaf, its share
+// of the fullNodes created by splits, its value, key and index entries
+func trieNodeBytes() int64 {
+	node := int64(unsafe.Sizeof(simulatedNode{}))
+	return node*3/2 + 96 + int64(workload.Current.Dataset.AccountRLPSize.Mean())
+}
+
+// mptNodeBytes estimates the heap held per go-ethereum trie insert: the
+// shortNode leaf with its hex key, its share of the fullNodes above it, the
+// key kept for reads, and the encoded nodes in an in-memory database. The
+// value is held twice, in the trie and in its encoded leaf.
+func mptNodeBytes() int64 {
+	return 900 + 2*int64(workload.Current.Dataset.AccountRLPSize.Mean())
+}
+
+// stateObjectBytes estimates the heap held per cached account: the object,
+// its data and code, and its storage slots held in a map and a key slice
+func stateObjectBytes() int64 {
+	ds := &workload.Current.Dataset
+	object := int64(unsafe.Sizeof(stateObject{})) + 3*48 + 64
+	slot := int64(64+32) * 2 // Map entry with overhead, plus its key copy
+	return object + int64(ds.AccountRLPSize.Mean()+ds.ContractCodeSize.Mean()) + int64(ds.StorageSlots.Mean())*slot
+}
@@ -0,0 +1,134 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/vBenchmark/internal/deadline"
+	"github.com/vBenchmark/internal/fastrand"
+	"github.com/vBenchmark/internal/types"
+)
+
+// cacheLine is the stride between chased elements, so every access touches
+// a different cache line
+const cacheLine = 64
+
+// latencyWorkingSets spans typical L1 (32-64 KB), L2 (512 KB-2 MB), L3 and
+// DRAM sizes of ARM SoCs and desktop CPUs
+var latencyWorkingSets = []int64{
+	16 << 10, 32 << 10, 64 << 10, 128 << 10, 256 << 10, 512 << 10,
+	1 << 20, 2 << 20, 4 << 20, 8 << 20, 16 << 20, 32 << 20, 64 << 20, 128 << 20,
+}
+
+// chasesPerIteration is how many dependent loads run between deadline checks
+const chasesPerIteration = 256
+
+// BenchmarkLatency measures random-access memory latency with a
+// pointer chase: each load's address depends on the previous load, so the
+// CPU cannot overlap them and every access costs the full latency of the
+// cache level the working set fits in. Trie traversal follows pointers the
+// same way, which is why it tracks latency rather than bandwidth.
+// Working sets larger than maxBytes (0 = no limit) are left out and the
+// result is marked as capped.
+func BenchmarkLatency(ctx context.Context, maxBytes int64, duration time.Duration, verbose bool) types.LatencyResult {
+	rng := fastrand.New("memory.latency")
+	sizes := latencyWorkingSets
+	var capped bool
+	for maxBytes > 0 && len(sizes) > 1 && chaseChainBytes(sizes[len(sizes)-1]) > maxBytes {
+		sizes = sizes[:len(sizes)-1]
+		capped = true
+	}
+	stepDuration := duration / time.Duration(len(sizes))
+	points := make([]types.LatencyPoint, 0, len(sizes))
+	var total time.Duration
+
+	for _, size := range sizes {
+		if ctx.Err() != nil {
+			break
+		}
+		chain := newChaseChain(rng, size)
+
+		var chases uint64
+		next := uint64(0)
+		loop := deadline.Start(ctx, "", stepDuration)
+		for loop.Next() {
+			for i := 0; i < chasesPerIteration; i++ {
+				next = chain[next]
+			}
+			chases += chasesPerIteration
+		}
+		elapsed := loop.Elapsed()
+		total += elapsed
+		latencySink = next
+
+		if chases == 0 {
+			continue
+		}
+		points = append(points, types.LatencyPoint{
+			WorkingSetKB: size >> 10,
+			LatencyNs:    float64(elapsed.Nanoseconds()) / float64(chases),
+		})
+	}
+
+	result := types.LatencyResult{
+		Points:           points,
+		WorkingSetCapped: capped,
+		Duration:         total,
+	}
+	if len(points) > 0 {
+		result.L1LatencyNs = points[0].LatencyNs
+		result.DRAMLatencyNs = points[len(points)-1].LatencyNs
+	}
+	result.Rating = rateLatency(result.DRAMLatencyNs)
+	return result
+}
+
+// latencySink keeps the chase result live so the loop is not optimised away
+var latencySink uint64
+
+// newChaseChain returns a buffer of size bytes holding one random cycle
+// through all its cache lines: the first word of each line is the index of
+// the next line's first word. Linking the lines in shuffled order makes a
+// single cycle, so the chase visits every line before repeating.
+func newChaseChain(rng *fastrand.Source, size int64) []uint64 {
+	const stride = cacheLine / 8
+	lines := int(size / cacheLine)
+	order := make([]int, lines)
+	for i := range order {
+		order[i] = i
+	}
+	for i := lines - 1; i > 0; i-- {
+		j := int(rng.Uint64() % uint64(i+1))
+		order[i], order[j] = order[j], order[i]
+	}
+
+	chain := make([]uint64, lines*stride)
+	for i, line := range order {
+		chain[line*stride] = uint64(order[(i+1)%lines] * stride)
+	}
+	return chain
+}
+
+// chaseChainBytes returns the memory newChaseChain allocates for a working
+// set of size bytes: the chain plus the shuffled line order
+func chaseChainBytes(size int64) int64 {
+	return size + size/cacheLine*8
+}
+
+// rateLatency provides a rating based on DRAM latency
+func rateLatency(dramNs float64) string {
+	switch {
+	case dramNs <= 0:
+		return "Poor"
+	case dramNs < 90:
+		return "Excellent"
+	case dramNs < 120:
+		return "Good"
+	case dramNs < 160:
+		return "Adequate"
+	case dramNs < 220:
+		return "Marginal"
+	default:
+		return "Poor"
+	}
+}
@@ -0,0 +1,231 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/pebble"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/trie/trienode"
+	"github.com/ethereum/go-ethereum/triedb"
+
+	"github.com/vBenchmark/internal/deadline"
+	"github.com/vBenchmark/internal/disk"
+	"github.com/vBenchmark/internal/fastrand"
+	"github.com/vBenchmark/internal/types"
+	"github.com/vBenchmark/internal/workload"
+)
+
+const (
+	// mptUpdatesPerBlock is the accounts a block changes: the sender and
+	// recipient of each of ~300 transactions
+	mptUpdatesPerBlock = 600
+	// Pebble cache and file handles for the on-disk node database
+	mptCacheMB = 64
+	mptHandles = 256
+)
+
+// BenchmarkMPT runs go-ethereum's trie.Trie over a hash-scheme triedb:
+// inserts into an empty trie, a commit of all its nodes, gets that resolve
+// nodes from the database, and blocks of account updates that are hashed
+// and committed. With onDisk the phases are repeated on a Pebble database
+// in testDir, so node reads and writes reach the storage.
+// Reference: geth/trie/trie.go, geth/triedb/hashdb/database.go
+func BenchmarkMPT(ctx context.Context, maxBytes int64, testDir string, onDisk bool, budget *disk.WriteBudget, duration time.Duration, verbose bool) (types.MPTResult, error) {
+	backendDuration := duration
+	if onDisk {
+		backendDuration = duration / 2
+	}
+
+	var result types.MPTResult
+	memory, err := benchmarkMPTBackend(ctx, "memory", "memory", rawdb.NewMemoryDatabase(), maxBytes, nil, backendDuration, verbose)
+	if err != nil {
+		return result, err
+	}
+	result.Memory = memory
+	result.Duration = memory.Duration
+
+	if onDisk {
+		dir := filepath.Join(testDir, "ethbench_mpt_pebble")
+		os.RemoveAll(dir)
+		defer os.RemoveAll(dir)
+		kv, err := pebble.New(dir, mptCacheMB, mptHandles, "", false)
+		if err != nil {
+			return result, fmt.Errorf("failed to open pebble: %w", err)
+		}
+		db := rawdb.NewDatabase(kv)
+		defer db.Close()
+		pebbleResult, err := benchmarkMPTBackend(ctx, "disk", "pebble", db, maxBytes, budget, backendDuration, verbose)
+		if err != nil {
+			return result, err
+		}
+		result.Disk = &pebbleResult
+		result.Duration += pebbleResult.Duration
+	}
+	result.Rating = rateMPT(memory.InsertsPerSecond, memory.GetsPerSecond)
+	return result, nil
+}
+
+// benchmarkMPTBackend runs the trie phases on one node database, reported
+// under field. Writes are charged to budget when it is set.
+func benchmarkMPTBackend(ctx context.Context, field, backend string, diskdb ethdb.Database, maxBytes int64, budget *disk.WriteBudget, duration time.Duration, verbose bool) (types.MPTBackendResult, error) {
+	result := types.MPTBackendResult{Backend: backend, UpdatesPerBlock: mptUpdatesPerBlock}
+	db := triedb.NewDatabase(diskdb, triedb.HashDefaults)
+	defer db.Close()
+	rng := fastrand.New("memory.mpt")
+	accounts := workload.NewGenerator(rng)
+	metric := "memory.mpt." + field
+
+	// Phase 1: Inserts of accounts keyed by address hash, as in the state trie
+	var maxInserts int
+	if maxBytes > 0 {
+		maxInserts = int(maxBytes / mptNodeBytes())
+	}
+	tr := trie.NewEmpty(db)
+	var keys [][]byte
+	loop := deadline.Start(ctx, metric+".inserts_per_second", duration*3/10)
+	for loop.Next() {
+		if maxInserts > 0 && len(keys) >= maxInserts {
+			result.WorkingSetCapped = true
+			loop.Stop()
+			break
+		}
+		key := crypto.Keccak256(rng.Bytes(20))
+		tr.MustUpdate(key, rng.Bytes(accounts.Account().RLPSize))
+		keys = append(keys, key)
+	}
+	insertElapsed := loop.Elapsed()
+	if len(keys) == 0 {
+		return result, ctx.Err()
+	}
+	result.Keys = len(keys)
+	result.InsertsPerSecond = float64(len(keys)) / insertElapsed.Seconds()
+
+	// Initial commit: hash the whole trie and write every node
+	start := time.Now()
+	root, nodes := tr.Commit(false)
+	if _, err := commitMPT(db, root, ethtypes.EmptyRootHash, 0, nodes, budget); err != nil {
+		return result, err
+	}
+	commitElapsed := time.Since(start)
+	result.InitialCommitMs = float64(commitElapsed.Microseconds()) / 1000
+
+	// Phase 2: Gets from the committed trie; 80% existing accounts, 20%
+	// absent ones that end at an empty slot or another leaf
+	tr, err := trie.New(trie.TrieID(root), db)
+	if err != nil {
+		return result, fmt.Errorf("failed to open trie: %w", err)
+	}
+	var gets uint64
+	loop = deadline.Start(ctx, metric+".gets_per_second", duration*3/10)
+	for loop.Next() {
+		key := keys[rng.Uint64()%uint64(len(keys))]
+		if gets%5 == 4 {
+			key = rng.Bytes(32)
+		}
+		if _, err := tr.Get(key); err != nil {
+			return result, fmt.Errorf("failed to get key: %w", err)
+		}
+		gets++
+	}
+	getElapsed := loop.Elapsed()
+	result.GetsPerSecond = float64(gets) / getElapsed.Seconds()
+
+	// Phase 3: Blocks of account updates, each hashed, committed and the
+	// trie reopened at the new root as the next block's state
+	var blocks int
+	var hashTime, commitTime time.Duration
+	var nodeCount int
+	start = time.Now()
+	blockDuration := duration * 4 / 10
+	for ctx.Err() == nil && time.Since(start) < blockDuration {
+		for i := 0; i < mptUpdatesPerBlock; i++ {
+			tr.MustUpdate(keys[rng.Uint64()%uint64(len(keys))], rng.Bytes(accounts.Account().RLPSize))
+		}
+		hashStart := time.Now()
+		tr.Hash()
+		commitStart := time.Now()
+		newRoot, nodes := tr.Commit(false)
+		written, err := commitMPT(db, newRoot, root, uint64(blocks+1), nodes, budget)
+		if err != nil {
+			if blocks > 0 && errors.Is(err, disk.ErrWriteLimit) {
+				break
+			}
+			return result, err
+		}
+		committed := time.Now()
+		root = newRoot
+		if tr, err = trie.New(trie.TrieID(root), db); err != nil {
+			return result, fmt.Errorf("failed to open trie: %w", err)
+		}
+		hashTime += commitStart.Sub(hashStart)
+		commitTime += committed.Sub(commitStart)
+		nodeCount += written
+		blocks++
+	}
+	blockElapsed := time.Since(start)
+	if blocks > 0 {
+		result.BlocksPerSecond = float64(blocks) / blockElapsed.Seconds()
+		result.AvgHashMs = float64(hashTime.Microseconds()) / 1000 / float64(blocks)
+		result.AvgCommitMs = float64(commitTime.Microseconds()) / 1000 / float64(blocks)
+		result.AvgNodesPerBlock = float64(nodeCount) / float64(blocks)
+	}
+	result.Duration = insertElapsed + commitElapsed + getElapsed + blockElapsed
+
+	if verbose {
+		fmt.Printf("    %s: %d keys, %.0f inserts/sec, %.0f gets/sec, %.1f blocks/sec (hash %.2f ms, commit %.2f ms)\n",
+			backend, result.Keys, result.InsertsPerSecond, result.GetsPerSecond, result.BlocksPerSecond, result.AvgHashMs, result.AvgCommitMs)
+	}
+	return result, nil
+}
+
+// commitMPT hands a trie's dirty nodes to the node database and flushes them
+// to its key-value store, returning the number of nodes written
+func commitMPT(db *triedb.Database, root, parent common.Hash, block uint64, nodes *trienode.NodeSet, budget *disk.WriteBudget) (int, error) {
+	if nodes == nil {
+		return 0, nil
+	}
+	if budget != nil {
+		var size int
+		for _, n := range nodes.Nodes {
+			size += common.HashLength + len(n.Blob)
+		}
+		if !budget.Take(size) {
+			return 0, disk.ErrWriteLimit
+		}
+	}
+	if err := db.Update(root, parent, block, trienode.NewWithNodeSet(nodes), nil); err != nil {
+		return 0, fmt.Errorf("failed to update trie database: %w", err)
+	}
+	if err := db.Commit(root, false); err != nil {
+		return 0, fmt.Errorf("failed to commit trie database: %w", err)
+	}
+	return len(nodes.Nodes), nil
+}
+
+// rateMPT provides a rating based on the real trie's insert and get rates,
+// weighted like rateTrie
+func rateMPT(insertRate, getRate float64) string {
+	score := insertRate*0.4 + getRate*0.6
+	switch {
+	case score >= 200000:
+		return "Excellent"
+	case score >= 100000:
+		return "Good"
+	case score >= 50000:
+		return "Adequate"
+	case score >= 25000:
+		return "Marginal"
+	default:
+		return "Poor"
+	}
+}
@@ -1,10 +1,12 @@
 package memory
 
 import (
-	"crypto/rand"
+	"context"
 	"sync"
 	"time"
 
+	"github.com/vBenchmark/internal/deadline"
+	"github.com/vBenchmark/internal/fastrand"
 	"github.com/vBenchmark/internal/types"
 )
 
@@ -44,7 +46,7 @@ func newStackPool() *stackPool {
 // BenchmarkPool measures object pool allocation performance
 // This simulates EVM memory management patterns
 // Reference: geth/core/vm/memory.go, geth/core/vm/stack.go
-func BenchmarkPool(duration time.Duration, verbose bool) types.PoolResult {
+func BenchmarkPool(ctx context.Context, duration time.Duration, verbose bool) types.PoolResult {
 	memPool := newMemoryPool()
 	stPool := newStackPool()
 
@@ -52,8 +54,10 @@ func BenchmarkPool(duration time.Duration, verbose bool) types.PoolResult {
 	var totalBytes uint64
 
 	// Simulate EVM contract execution memory patterns
-	start := time.Now()
-	for time.Since(start) < duration {
+	rng := fastrand.New("memory.pool")
+	gc := startGCPhase("alloc_reuse")
+	loop := deadline.Start(ctx, "memory.pool.reuses_per_second", duration)
+	for loop.Next() {
 		// Get memory from pool
 		mem := memPool.pool.Get().([]byte)
 		stack := stPool.pool.Get().([][32]byte)
@@ -75,7 +79,7 @@ func BenchmarkPool(duration time.Duration, verbose bool) types.PoolResult {
 		// Simulate some memory operations (like MSTORE)
 		if len(mem) >= 32 {
 			for i := 0; i < len(mem)-32; i += 32 {
-				rand.Read(mem[i : i+4]) // Partial fill to save time
+				rng.Read(mem[i : i+4]) // Partial fill to save time
 			}
 		}
 
@@ -96,7 +100,8 @@ func BenchmarkPool(duration time.Duration, verbose bool) types.PoolResult {
 		stPool.pool.Put(stack[:0])
 	}
 
-	elapsed := time.Since(start)
+	elapsed := loop.Elapsed()
+	gcPhase := gc.stop(elapsed)
 	totalOps := allocCount + reuseCount
 
 	return types.PoolResult{
@@ -105,6 +110,7 @@ func BenchmarkPool(duration time.Duration, verbose bool) types.PoolResult {
 		MemoryChurnMB:        float64(totalBytes) / (1024 * 1024),
 		Duration:             elapsed,
 		Rating:               ratePool(float64(totalOps) / elapsed.Seconds()),
+		GC:                   []types.GCPhase{gcPhase},
 	}
 }
 
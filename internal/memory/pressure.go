@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// pressureChunk is the allocation unit of the memory-pressure test
+const pressureChunk = 64 << 20
+
+// BenchmarkPressure allocates toward the RAM limit and then re-reads the
+// oldest allocations, which the kernel has pushed to swap first, to measure
+// how fast a node recovers pages swapped out during sync. With swap enabled
+// it allocates past the available memory by up to a quarter; without swap
+// it stays at three quarters of it so the OOM killer is never triggered.
+// Half the duration bounds the fill, the rest is spent re-reading.
+func BenchmarkPressure(ctx context.Context, duration time.Duration, verbose bool) (types.PressureResult, error) {
+	availableMB, swapFreeMB := readMemAvailable()
+	if availableMB == 0 {
+		return types.PressureResult{}, errors.New("available memory unknown: /proc/meminfo unreadable")
+	}
+	targetMB := availableMB * 3 / 4
+	if swapFreeMB > 0 {
+		targetMB = availableMB + min(swapFreeMB/2, availableMB/4)
+	}
+
+	pageSize := os.Getpagesize()
+	swapInBefore, swapOutBefore := readSwapPages()
+	defer debug.FreeOSMemory()
+
+	// Fill: touch every page so the memory is really committed
+	var chunks [][]byte
+	start := time.Now()
+	for (len(chunks)+1)*(pressureChunk>>20) <= targetMB && time.Since(start) < duration/2 && ctx.Err() == nil {
+		chunk := make([]byte, pressureChunk)
+		for i := 0; i < len(chunk); i += pageSize {
+			chunk[i] = byte(len(chunks))
+		}
+		chunks = append(chunks, chunk)
+	}
+	fillElapsed := time.Since(start)
+	allocatedMB := len(chunks) * (pressureChunk >> 20)
+
+	// Re-read oldest first, the order swapped-out pages come back in when a
+	// client walks its caches again
+	var retouchedMB int
+	var maxStall time.Duration
+	start = time.Now()
+	for i := 0; time.Since(start) < duration/2 && ctx.Err() == nil && len(chunks) > 0; i++ {
+		chunk := chunks[i%len(chunks)]
+		chunkStart := time.Now()
+		var sum byte
+		for j := 0; j < len(chunk); j += pageSize {
+			sum += chunk[j]
+		}
+		chunk[0] = sum
+		maxStall = max(maxStall, time.Since(chunkStart))
+		retouchedMB += pressureChunk >> 20
+	}
+	retouchElapsed := time.Since(start)
+
+	swapInAfter, swapOutAfter := readSwapPages()
+	chunks = nil
+	runtime.GC()
+
+	result := types.PressureResult{
+		AvailableMB: availableMB,
+		TargetMB:    targetMB,
+		AllocatedMB: allocatedMB,
+		SwapOutMB:   float64((swapOutAfter-swapOutBefore)*uint64(pageSize)) / (1024 * 1024),
+		SwapInMB:    float64((swapInAfter-swapInBefore)*uint64(pageSize)) / (1024 * 1024),
+		MaxStallMs:  float64(maxStall.Microseconds()) / 1000,
+		Duration:    fillElapsed + retouchElapsed,
+	}
+	if fillElapsed > 0 {
+		result.FillMBPerSec = float64(allocatedMB) / fillElapsed.Seconds()
+	}
+	if retouchElapsed > 0 {
+		result.RetouchMBPerSec = float64(retouchedMB) / retouchElapsed.Seconds()
+	}
+	result.Rating = ratePressure(result.RetouchMBPerSec)
+	return result, nil
+}
+
+// readMemAvailable returns MemAvailable and SwapFree from /proc/meminfo in MB
+func readMemAvailable() (availableMB, swapFreeMB int) {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, 0
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		kb, _ := strconv.Atoi(fields[1])
+		switch fields[0] {
+		case "MemAvailable:":
+			availableMB = kb / 1024
+		case "SwapFree:":
+			swapFreeMB = kb / 1024
+		}
+	}
+	return availableMB, swapFreeMB
+}
+
+// readSwapPages returns the pages swapped in and out since boot
+func readSwapPages() (in, out uint64) {
+	f, err := os.Open("/proc/vmstat")
+	if err != nil {
+		return 0, 0
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		name, value, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			continue
+		}
+		switch name {
+		case "pswpin":
+			in, _ = strconv.ParseUint(value, 10, 64)
+		case "pswpout":
+			out, _ = strconv.ParseUint(value, 10, 64)
+		}
+	}
+	return in, out
+}
+
+// ratePressure provides a rating based on re-read throughput under memory
+// pressure: zram stays near RAM speed, NVMe swap is usable, SD card swap
+// stalls the node
+func ratePressure(retouchMBPerSec float64) string {
+	switch {
+	case retouchMBPerSec >= 1000:
+		return "Excellent"
+	case retouchMBPerSec >= 300:
+		return "Good"
+	case retouchMBPerSec >= 100:
+		return "Adequate"
+	case retouchMBPerSec >= 30:
+		return "Marginal"
+	default:
+		return "Poor"
+	}
+}
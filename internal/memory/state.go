@@ -1,10 +1,13 @@
 package memory
 
 import (
-	"crypto/rand"
+	"context"
 	"time"
 
+	"github.com/vBenchmark/internal/deadline"
+	"github.com/vBenchmark/internal/fastrand"
 	"github.com/vBenchmark/internal/types"
+	"github.com/vBenchmark/internal/workload"
 )
 
 // stateObject simulates Geth's state object caching
@@ -12,6 +15,7 @@ import (
 type stateObject struct {
 	address        [20]byte
 	data           []byte
+	code           []byte
 	originStorage  map[[32]byte][32]byte // Original values
 	dirtyStorage   map[[32]byte][32]byte // Modified values
 	pendingStorage map[[32]byte][32]byte // Pending commit
@@ -21,31 +25,45 @@ type stateObject struct {
 // BenchmarkStateCache measures state access patterns
 // This simulates account and storage caching in Geth
 // Reference: geth/core/state/state_object.go
-func BenchmarkStateCache(duration time.Duration, verbose bool) types.StateCacheResult {
+// The cache holds fewer accounts than the workload profile when they would
+// take more than maxBytes (0 = no limit), and the result is marked as capped.
+func BenchmarkStateCache(ctx context.Context, maxBytes int64, duration time.Duration, verbose bool) types.StateCacheResult {
 	// Pre-populate cache with realistic state data
-	// Simulating ~10000 accounts typical for a busy block
+	// Simulating the accounts touched by a busy block, with account, code
+	// and storage sizes drawn from the workload profile's distributions
+	accounts := workload.Current.State.Accounts
+	var capped bool
+	if maxBytes > 0 && int64(accounts)*stateObjectBytes() > maxBytes {
+		accounts = max(int(maxBytes/stateObjectBytes()), 1)
+		capped = true
+	}
+	rng := fastrand.New("memory.state_cache")
+	gen := workload.NewGenerator(rng)
 	cache := make(map[[20]byte]*stateObject)
-	addresses := make([][20]byte, 0, 10000)
+	addresses := make([][20]byte, 0, accounts)
 
-	for i := 0; i < 10000; i++ {
+	for i := 0; i < accounts; i++ {
 		var addr [20]byte
-		rand.Read(addr[:])
+		rng.Read(addr[:])
 
+		account := gen.Account()
 		obj := &stateObject{
 			address:        addr,
-			data:           make([]byte, 100),
-			originStorage:  make(map[[32]byte][32]byte),
+			data:           make([]byte, account.RLPSize),
+			code:           make([]byte, account.CodeSize),
+			originStorage:  make(map[[32]byte][32]byte, account.StorageSlots),
 			dirtyStorage:   make(map[[32]byte][32]byte),
 			pendingStorage: make(map[[32]byte][32]byte),
-			storageKeys:    make([][32]byte, 0, 50),
+			storageKeys:    make([][32]byte, 0, account.StorageSlots),
 		}
-		rand.Read(obj.data)
+		rng.Read(obj.data)
+		rng.Read(obj.code)
 
-		// Pre-populate storage slots (typical contract state)
-		for j := 0; j < 50; j++ {
+		// Pre-populate storage slots (contract accounts only)
+		for j := 0; j < account.StorageSlots; j++ {
 			var key, val [32]byte
-			rand.Read(key[:])
-			rand.Read(val[:])
+			rng.Read(key[:])
+			rng.Read(val[:])
 			obj.originStorage[key] = val
 			obj.storageKeys = append(obj.storageKeys, key) // Store keys for this object
 		}
@@ -56,9 +74,12 @@ func BenchmarkStateCache(duration time.Duration, verbose bool) types.StateCacheR
 
 	var hits, misses uint64
 	var totalBytes uint64
+	missBytes := uint64(workload.Current.Dataset.AccountRLPSize.Mean())
 
-	start := time.Now()
-	for time.Since(start) < duration {
+	// Collect the garbage of populating the cache before timing lookups
+	gc := startGCPhase("lookup")
+	loop := deadline.Start(ctx, "memory.state_cache.cache_hits_per_second", duration)
+	for loop.Next() {
 		// 80% cache hits (typical during block processing)
 		// This simulates the pattern where most accessed accounts are already cached
 		opIndex := hits + misses
@@ -68,6 +89,13 @@ func BenchmarkStateCache(duration time.Duration, verbose bool) types.StateCacheR
 			addr := addresses[idx]
 			obj := cache[addr]
 
+			// Externally owned accounts only have their account data
+			if len(obj.storageKeys) == 0 {
+				hits++
+				totalBytes += uint64(len(obj.data))
+				continue
+			}
+
 			// Use a key that belongs to THIS object
 			keyIdx := int(opIndex) % len(obj.storageKeys)
 			key := obj.storageKeys[keyIdx]
@@ -93,18 +121,19 @@ func BenchmarkStateCache(duration time.Duration, verbose bool) types.StateCacheR
 		} else {
 			// Cache miss - simulate new account access (20%)
 			var newAddr [20]byte
-			rand.Read(newAddr[:])
+			rng.Read(newAddr[:])
 			_, exists := cache[newAddr]
 			if !exists {
 				misses++
 			} else {
 				hits++ // Rare case where random address matches
 			}
-			totalBytes += 100 // Account data size
+			totalBytes += missBytes
 		}
 	}
 
-	elapsed := time.Since(start)
+	elapsed := loop.Elapsed()
+	gcPhase := gc.stop(elapsed)
 	total := hits + misses
 	hitRatio := float64(hits) / float64(total)
 
@@ -115,6 +144,8 @@ func BenchmarkStateCache(duration time.Duration, verbose bool) types.StateCacheR
 		ThroughputMBPerSec:   float64(totalBytes) / elapsed.Seconds() / (1024 * 1024),
 		Duration:             elapsed,
 		Rating:               rateStateCache(float64(hits) / elapsed.Seconds()),
+		GC:                   []types.GCPhase{gcPhase},
+		WorkingSetCapped:     capped,
 	}
 }
 
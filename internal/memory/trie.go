@@ -2,14 +2,17 @@
 package memory
 
 import (
-	"crypto/rand"
+	"context"
 	"runtime"
 	"sync"
 	"time"
 
 	"golang.org/x/crypto/sha3"
 
+	"github.com/vBenchmark/internal/deadline"
+	"github.com/vBenchmark/internal/fastrand"
 	"github.com/vBenchmark/internal/types"
+	"github.com/vBenchmark/internal/workload"
 )
 
 // hasher simulates Geth's hasher structure
@@ -35,6 +38,7 @@ type simulatedNode struct {
 	hash     [32]byte
 	children [17]*simulatedNode // 16 children + value (fullNode pattern)
 	key      []byte
+	path     [32]byte // Keccak256 of key, the path it is stored under
 	value    []byte
 	dirty    bool
 }
@@ -42,25 +46,40 @@ type simulatedNode struct {
 // BenchmarkTrie measures Merkle Patricia Trie operations
 // This simulates state storage patterns in Geth
 // Reference: geth/trie/trie.go
-func BenchmarkTrie(duration time.Duration, verbose bool) types.TrieResult {
+// Inserts stop early once the trie would hold more than maxBytes (0 = no
+// limit), and the result is marked as capped.
+func BenchmarkTrie(ctx context.Context, maxBytes int64, duration time.Duration, verbose bool) types.TrieResult {
+	state := workload.Current.State
+	rng := fastrand.New("memory.trie")
+	accounts := workload.NewGenerator(rng)
 	nodes := make(map[[20]byte]*simulatedNode)
-	nodeKeys := make([][20]byte, 0, 10000)
+	nodeKeys := make([][20]byte, 0, state.Accounts)
+	root := &simulatedNode{}
 
 	var memBefore, memAfter runtime.MemStats
 	runtime.ReadMemStats(&memBefore)
 
 	// Phase 1: Trie insertions (simulates state updates during block processing)
-	insertDuration := duration * 2 / 5
+	var gcPhases []types.GCPhase
+	insertDuration := duration * 3 / 10
 	var insertCount uint64
-	start := time.Now()
-
-	for time.Since(start) < insertDuration {
+	var maxInserts uint64
+	if maxBytes > 0 {
+		maxInserts = uint64(maxBytes / trieNodeBytes())
+	}
+	var capped bool
+	gc := startGCPhase("insert")
+	loop := deadline.Start(ctx, "memory.trie.inserts_per_second", insertDuration)
+	for loop.Next() {
+		if maxInserts > 0 && insertCount >= maxInserts {
+			capped = true
+			loop.Stop()
+			break
+		}
 		// Simulate account address (20 bytes) -> account data
 		var key [20]byte
-		rand.Read(key[:])
-
-		value := make([]byte, 100) // Typical account RLP size
-		rand.Read(value)
+		rng.Read(key[:])
+		value := rng.Bytes(accounts.Account().RLPSize)
 
 		node := &simulatedNode{
 			key:   key[:],
@@ -75,39 +94,60 @@ func BenchmarkTrie(duration time.Duration, verbose bool) types.TrieResult {
 		h.sha.Write(key[:])
 		h.sha.Write(value)
 		h.sha.Read(node.hash[:])
+		h.sha.Reset()
+		h.sha.Write(key[:])
+		h.sha.Read(node.path[:])
 		trieHasherPool.Put(h)
 
+		trieInsert(root, node)
 		nodes[key] = node
 		nodeKeys = append(nodeKeys, key)
 		insertCount++
 	}
-	insertElapsed := time.Since(start)
+	insertElapsed := loop.Elapsed()
+	gcPhases = append(gcPhases, gc.stop(insertElapsed))
 	insertRate := float64(insertCount) / insertElapsed.Seconds()
 
 	// Phase 2: Trie lookups (simulates state reads during EVM execution)
-	lookupDuration := duration * 2 / 5
+	// Reference: geth/trie/secure_trie.go GetAccount() and trie.go get()
+	lookupDuration := duration * 3 / 10
 	var lookupCount uint64
-	start = time.Now()
-
+	gc = startGCPhase("lookup")
+	loop = deadline.Start(ctx, "memory.trie.lookups_per_second", lookupDuration)
 	if len(nodeKeys) > 0 {
-		for time.Since(start) < lookupDuration {
-			// Random access pattern (simulates SLOAD operations)
-			idx := int(lookupCount) % len(nodeKeys)
-			key := nodeKeys[idx]
-			_ = nodes[key]
+		h := trieHasherPool.Get().(*hasher)
+		var key [20]byte
+		var path [32]byte
+		for loop.Next() {
+			// 80% existing accounts, 20% accounts not in the trie, which
+			// traverse until they reach an empty slot or a different leaf
+			if lookupCount%5 < 4 {
+				key = nodeKeys[int(lookupCount)%len(nodeKeys)]
+			} else {
+				rng.Read(key[:])
+			}
+
+			// Geth's state trie is keyed by the hash of the address
+			h.sha.Reset()
+			h.sha.Write(key[:])
+			h.sha.Read(path[:])
+			_ = trieGet(root, &path)
 			lookupCount++
 		}
+		trieHasherPool.Put(h)
 	}
-	lookupElapsed := time.Since(start)
+	lookupElapsed := loop.Elapsed()
+	gcPhases = append(gcPhases, gc.stop(lookupElapsed))
 	lookupRate := float64(lookupCount) / lookupElapsed.Seconds()
 
 	// Phase 3: Root hash computation (simulates block commitment)
 	// Reference: geth/trie/trie.go hashRoot()
 	hashDuration := duration / 5
 	var hashCount uint64
-	start = time.Now()
+	gc = startGCPhase("hash")
+	start := time.Now()
 
-	for time.Since(start) < hashDuration {
+	for time.Since(start) < hashDuration && ctx.Err() == nil {
 		// Simulate parallel hashing like Geth when unhashed >= 100
 		h := trieHasherPool.Get().(*hasher)
 		for _, node := range nodes {
@@ -126,30 +166,192 @@ func BenchmarkTrie(duration time.Duration, verbose bool) types.TrieResult {
 		hashCount++
 	}
 	hashElapsed := time.Since(start)
+	gcPhases = append(gcPhases, gc.stop(hashElapsed))
 	hashRate := float64(hashCount) / hashElapsed.Seconds()
 
+	// Phase 4: Parallel commit (simulates Geth hashing root subtries concurrently)
+	// Reference: geth/trie/hasher.go hashFullNodeChildren()
+	parallelDuration := duration / 5
+	gc = startGCPhase("parallel_commit")
+	start = time.Now()
+	parallelCommit := benchmarkParallelCommit(ctx, nodes, parallelDuration)
+	parallelElapsed := time.Since(start)
+	gcPhases = append(gcPhases, gc.stop(parallelElapsed))
+
 	runtime.ReadMemStats(&memAfter)
 	peakMemMB := float64(memAfter.Alloc-memBefore.Alloc) / (1024 * 1024)
 	if peakMemMB < 0 {
 		peakMemMB = float64(memAfter.Alloc) / (1024 * 1024)
 	}
 
-	totalDuration := insertElapsed + lookupElapsed + hashElapsed
+	totalDuration := insertElapsed + lookupElapsed + hashElapsed + parallelElapsed
 
-	return types.TrieResult{
+	result := types.TrieResult{
 		InsertsPerSecond: insertRate,
 		LookupsPerSecond: lookupRate,
 		HashesPerSecond:  hashRate,
 		PeakMemoryMB:     peakMemMB,
+		ParallelCommit:   parallelCommit,
+		GC:               gcPhases,
+		WorkingSetCapped: capped,
 		Duration:         totalDuration,
 		Rating:           rateTrie(insertRate, lookupRate),
 	}
+	if n := len(parallelCommit); n > 0 {
+		result.ParallelEfficiency = parallelCommit[n-1].Efficiency
+	}
+	return result
+}
+
+// trieInsert stores a leaf under its hashed path, splitting a leaf it meets
+// into a fullNode until the two paths diverge. Extension nodes are not
+// modelled, so every level of a shared prefix is its own fullNode.
+func trieInsert(root, leaf *simulatedNode) {
+	n := root
+	for depth := 0; depth < 2*len(leaf.path); depth++ {
+		i := pathNibble(&leaf.path, depth)
+		child := n.children[i]
+		switch {
+		case child == nil:
+			n.children[i] = leaf
+			return
+		case child.value == nil:
+			n = child
+		case child.path == leaf.path:
+			n.children[i] = leaf
+			return
+		default:
+			// Push the existing leaf down one level and keep descending
+			branch := &simulatedNode{dirty: true}
+			branch.children[pathNibble(&child.path, depth+1)] = child
+			n.children[i] = branch
+			n = branch
+		}
+	}
+}
+
+// trieGet walks the hashed path from the root and returns the leaf stored
+// under it, or nil if the path ends at an empty slot or another leaf
+func trieGet(root *simulatedNode, path *[32]byte) *simulatedNode {
+	n := root
+	for depth := 0; depth < 2*len(path); depth++ {
+		n = n.children[pathNibble(path, depth)]
+		switch {
+		case n == nil:
+			return nil
+		case n.value != nil:
+			if n.path != *path {
+				return nil
+			}
+			return n
+		}
+	}
+	return nil
+}
+
+// pathNibble returns the nibble of path at the given depth
+func pathNibble(path *[32]byte, depth int) byte {
+	b := path[depth/2]
+	if depth%2 == 0 {
+		return b >> 4
+	}
+	return b & 0x0f
+}
+
+// parallelWorkerCounts are the worker counts measured in the parallel commit phase
+var parallelWorkerCounts = []int{1, 4, 8, 16}
+
+// benchmarkParallelCommit splits the trie into 16 subtries by the first key
+// nibble, like the children of Geth's root fullNode, and measures commit
+// rate when hashing them across different numbers of goroutines
+func benchmarkParallelCommit(ctx context.Context, nodes map[[20]byte]*simulatedNode, duration time.Duration) []types.ParallelCommitPoint {
+	var subtries [16][]*simulatedNode
+	for key, node := range nodes {
+		nibble := key[0] >> 4
+		subtries[nibble] = append(subtries[nibble], node)
+	}
+
+	points := make([]types.ParallelCommitPoint, 0, len(parallelWorkerCounts))
+	stepDuration := duration / time.Duration(len(parallelWorkerCounts))
+	var baseRate float64
+
+	for _, workers := range parallelWorkerCounts {
+		var commitCount uint64
+		start := time.Now()
+		for time.Since(start) < stepDuration && ctx.Err() == nil {
+			commitSubtries(&subtries, workers)
+			commitCount++
+		}
+		rate := float64(commitCount) / time.Since(start).Seconds()
+
+		if workers == 1 {
+			baseRate = rate
+		}
+		point := types.ParallelCommitPoint{
+			Workers:          workers,
+			CommitsPerSecond: rate,
+		}
+		if baseRate > 0 {
+			point.Speedup = rate / baseRate
+			// Ideal speedup is bounded by the number of cores
+			ideal := workers
+			if cores := runtime.NumCPU(); cores < ideal {
+				ideal = cores
+			}
+			point.Efficiency = point.Speedup / float64(ideal) * 100
+		}
+		points = append(points, point)
+	}
+
+	return points
+}
+
+// commitSubtries hashes all subtries using the given number of workers and
+// then combines the subtrie roots into the root hash
+func commitSubtries(subtries *[16][]*simulatedNode, workers int) [32]byte {
+	var roots [16][32]byte
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			h := trieHasherPool.Get().(*hasher)
+			defer trieHasherPool.Put(h)
+
+			for i := w; i < len(subtries); i += workers {
+				// Each node belongs to exactly one subtrie, so no locking is needed
+				for _, node := range subtries[i] {
+					h.sha.Reset()
+					h.sha.Write(node.key)
+					h.sha.Write(node.value)
+					h.sha.Read(node.hash[:])
+				}
+				h.sha.Reset()
+				for _, node := range subtries[i] {
+					h.sha.Write(node.hash[:])
+				}
+				h.sha.Read(roots[i][:])
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	h := trieHasherPool.Get().(*hasher)
+	defer trieHasherPool.Put(h)
+	h.sha.Reset()
+	for i := range roots {
+		h.sha.Write(roots[i][:])
+	}
+	var root [32]byte
+	h.sha.Read(root[:])
+	return root
 }
 
 // rateTrie provides a rating based on insert and lookup rates
 func rateTrie(insertRate, lookupRate float64) string {
 	// Weight lookups higher as they're more common
-	score := insertRate*0.4 + lookupRate*0.001*0.6 // Scale lookup rate down
+	score := insertRate*0.4 + lookupRate*0.01*0.6 // Scale lookup rate down
 
 	switch {
 	case score >= 50000:
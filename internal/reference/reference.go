@@ -0,0 +1,115 @@
+// Package reference provides embedded reference results for known hardware
+package reference
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/vBenchmark/internal/system"
+)
+
+//go:embed reference.json
+var referenceData []byte
+
+// DefaultComparison is the profile reports are compared against unless
+// another is chosen: the Raspberry Pi 5 with NVMe the benchmark targets
+const DefaultComparison = "rpi5-nvme"
+
+// Range describes the expected distribution of a metric on a hardware class
+type Range struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stddev"`
+}
+
+// Profile holds reference ranges for one hardware class
+type Profile struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Model       string           `json:"model"`
+	DiskType    string           `json:"disk_type"`
+	Metrics     map[string]Range `json:"metrics"`
+}
+
+// Database is the embedded collection of reference profiles
+type Database struct {
+	Version  string    `json:"version"`
+	Note     string    `json:"note"`
+	Profiles []Profile `json:"profiles"`
+}
+
+// Load parses the embedded reference database
+func Load() (*Database, error) {
+	var db Database
+	if err := json.Unmarshal(referenceData, &db); err != nil {
+		return nil, fmt.Errorf("failed to parse reference database: %w", err)
+	}
+	return &db, nil
+}
+
+// Find returns the profile with the given name
+func (db *Database) Find(name string) (*Profile, error) {
+	for i := range db.Profiles {
+		if db.Profiles[i].Name == name {
+			return &db.Profiles[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown reference profile %q (available: %s)", name, strings.Join(db.Names(), ", "))
+}
+
+// Names lists the available profile names
+func (db *Database) Names() []string {
+	names := make([]string, len(db.Profiles))
+	for i, p := range db.Profiles {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// Match returns the profile for the detected hardware, or nil if unknown.
+// Profiles without a model, such as virtual machines, are never matched.
+func (db *Database) Match(info *system.Info) *Profile {
+	if info == nil {
+		return nil
+	}
+	model := info.RPiModel
+	if model == "" {
+		model = info.CPUModel
+	}
+	for i := range db.Profiles {
+		p := &db.Profiles[i]
+		if p.Model != "" && strings.Contains(model, p.Model) && p.DiskType == info.DiskType {
+			return p
+		}
+	}
+	return nil
+}
+
+// Deviation returns how many standard deviations value lies from the
+// reference mean; ok is false when the profile has no range for the metric
+func (p *Profile) Deviation(metric string, value float64) (sigma float64, ok bool) {
+	r, ok := p.Metrics[metric]
+	if !ok || r.StdDev <= 0 {
+		return 0, false
+	}
+	return (value - r.Mean) / r.StdDev, true
+}
+
+// Mean returns the reference mean of a metric; ok is false when the profile
+// has no range for it
+func (p *Profile) Mean(metric string) (mean float64, ok bool) {
+	r, ok := p.Metrics[metric]
+	return r.Mean, ok && r.Mean > 0
+}
+
+// Percentile estimates the percentile (0-100) of value within the hardware
+// class, assuming results are normally distributed around the reference mean
+func (p *Profile) Percentile(metric string, value float64) (percentile float64, ok bool) {
+	sigma, ok := p.Deviation(metric, value)
+	if !ok {
+		return 0, false
+	}
+	return 50 * (1 + math.Erf(sigma/math.Sqrt2)), true
+}
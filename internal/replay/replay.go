@@ -0,0 +1,219 @@
+// Package replay imports a segment of blocks through go-ethereum's
+// core.BlockChain, the same code path Geth runs during full sync, and
+// measures the import rate in blocks and Mgas per second
+package replay
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethdb/pebble"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/vBenchmark/internal/disk"
+	"github.com/vBenchmark/internal/types"
+)
+
+// Builtin selects the generated block segment instead of a file
+const Builtin = "builtin"
+
+const (
+	importBatch = 256 // Blocks per InsertChain call, between budget checks
+	dbCacheMB   = 512 // Geth's default database cache share
+	dbHandles   = 512
+)
+
+// knownGenesis lists the networks whose exported blocks can be replayed
+var knownGenesis = []struct {
+	name    string
+	hash    common.Hash
+	genesis func() *core.Genesis
+}{
+	{"mainnet", params.MainnetGenesisHash, core.DefaultGenesisBlock},
+	{"holesky", params.HoleskyGenesisHash, core.DefaultHoleskyGenesisBlock},
+	{"sepolia", params.SepoliaGenesisHash, core.DefaultSepoliaGenesisBlock},
+}
+
+// Benchmark imports the blocks of source, a file written by `geth export`
+// (optionally gzipped) or Builtin, into a fresh Pebble database under
+// testDir. Only block import is timed; reading the file and generating the
+// builtin segment are not.
+func Benchmark(ctx context.Context, source, testDir string, budget *disk.WriteBudget, verbose bool) (types.ReplayResult, error) {
+	result := types.ReplayResult{Source: source}
+	if source != Builtin {
+		result.Source = filepath.Base(source)
+	}
+	var (
+		genesis *core.Genesis
+		blocks  []*gethtypes.Block
+		err     error
+	)
+	if source == Builtin {
+		result.Chain = "synthetic"
+		genesis, blocks, err = generate()
+	} else {
+		result.Chain, genesis, blocks, err = readBlocks(source)
+	}
+	if err != nil {
+		return result, err
+	}
+	if verbose {
+		fmt.Printf("    Replaying %d %s blocks (%d-%d)\n", len(blocks), result.Chain, blocks[0].NumberU64(), blocks[len(blocks)-1].NumberU64())
+	}
+
+	dir := filepath.Join(testDir, "ethbench_replay")
+	os.RemoveAll(dir)
+	defer os.RemoveAll(dir)
+	kv, err := pebble.New(dir, dbCacheMB, dbHandles, "", false)
+	if err != nil {
+		return result, fmt.Errorf("failed to open database: %w", err)
+	}
+	db := rawdb.NewDatabase(kv)
+	defer db.Close()
+
+	engine := beacon.New(ethash.NewFaker())
+	bc, err := core.NewBlockChain(db, core.DefaultCacheConfigWithScheme(rawdb.PathScheme), genesis, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to initialise chain: %w", err)
+	}
+	defer bc.Stop()
+	stop := context.AfterFunc(ctx, bc.StopInsert)
+	defer stop()
+
+	result.FirstBlock = blocks[0].NumberU64()
+	size := dirSize(dir)
+	for len(blocks) > 0 && ctx.Err() == nil {
+		batch := blocks[:min(importBatch, len(blocks))]
+		blocks = blocks[len(batch):]
+
+		start := time.Now()
+		n, err := bc.InsertChain(batch)
+		result.Duration += time.Since(start)
+		for _, b := range batch[:n] {
+			result.Blocks++
+			result.Transactions += uint64(len(b.Transactions()))
+			result.GasUsed += b.GasUsed()
+			result.LastBlock = b.NumberU64()
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return result, fmt.Errorf("failed to import block %d: %w", batch[n].NumberU64(), err)
+		}
+
+		// Charge what the database grew by against the write limit
+		grown := dirSize(dir)
+		if grown > size && !budget.Take(int(grown-size)) {
+			break
+		}
+		size = grown
+	}
+	if result.Blocks == 0 {
+		if budget.Exhausted() {
+			return result, disk.ErrWriteLimit
+		}
+		return result, ctx.Err()
+	}
+
+	seconds := result.Duration.Seconds()
+	result.BlocksPerSecond = float64(result.Blocks) / seconds
+	result.MGasPerSecond = float64(result.GasUsed) / 1e6 / seconds
+	result.TxPerSecond = float64(result.Transactions) / seconds
+	result.Rating = rateReplay(result.MGasPerSecond)
+	return result, nil
+}
+
+// readBlocks decodes an RLP block export and finds the network it starts
+// from. Replaying needs the parent state, so the export must start at
+// block 1 (or the genesis block) of a known network.
+func readBlocks(path string) (string, *core.Genesis, []*gethtypes.Block, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to open block file: %w", err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("failed to read block file: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	var blocks []*gethtypes.Block
+	stream := rlp.NewStream(r, 0)
+	for {
+		var b gethtypes.Block
+		if err := stream.Decode(&b); err == io.EOF {
+			break
+		} else if err != nil {
+			return "", nil, nil, fmt.Errorf("failed to decode block %d of %s: %w", len(blocks), path, err)
+		}
+		if b.NumberU64() == 0 {
+			continue // Exports may include the genesis block, which is never imported
+		}
+		blocks = append(blocks, &b)
+	}
+	if len(blocks) == 0 {
+		return "", nil, nil, fmt.Errorf("%s contains no blocks", path)
+	}
+
+	first := blocks[0]
+	for _, g := range knownGenesis {
+		if first.NumberU64() == 1 && first.ParentHash() == g.hash {
+			return g.name, g.genesis(), blocks, nil
+		}
+	}
+	return "", nil, nil, fmt.Errorf("%s starts at block %d; replay needs an export starting at block 1 of mainnet, holesky or sepolia, e.g. geth export blocks.rlp 1 20000",
+		path, first.NumberU64())
+}
+
+// dirSize returns the total size of the files under dir
+func dirSize(dir string) int64 {
+	var size int64
+	filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
+
+// rateReplay provides a rating based on block import throughput, comparable
+// to the mgasps figure in Geth's "Imported new chain segment" log lines
+func rateReplay(mgasPerSecond float64) string {
+	switch {
+	case mgasPerSecond >= 100:
+		return "Excellent"
+	case mgasPerSecond >= 50:
+		return "Good"
+	case mgasPerSecond >= 25:
+		return "Adequate"
+	case mgasPerSecond >= 10:
+		return "Marginal"
+	default:
+		return "Poor"
+	}
+}
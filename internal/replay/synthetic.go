@@ -0,0 +1,111 @@
+package replay
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/vBenchmark/internal/evmcode"
+	"github.com/vBenchmark/internal/fastrand"
+)
+
+// Shape of the builtin segment: Cancun blocks of about 12 Mgas, roughly a
+// mainnet block, mixing plain transfers with ERC-20 token transfers
+const (
+	syntheticBlocks      = 32
+	syntheticSenders     = 64
+	ethTransfers         = 100 // Per block
+	tokenTransfers       = 200 // Per block
+	tokenTransferGas     = 80_000
+	syntheticGasLimit    = 30_000_000
+	syntheticBalance     = 1_000_000 // ETH per sender
+	syntheticTokenSupply = 1 << 60   // Token balance per sender
+)
+
+// syntheticToken is the address of the ERC-20 contract in the builtin segment
+var syntheticToken = common.HexToAddress("0x00000000000000000000000000000000000e0c20")
+
+// generate builds the builtin segment: a genesis funding the senders and
+// deploying an ERC-20 token, and blocks of signed transactions on top of it.
+// Keys and recipients are derived from fixed seeds, so every run replays the
+// same blocks.
+func generate() (*core.Genesis, []*gethtypes.Block, error) {
+	config := *params.MergedTestChainConfig
+	config.ChainID = big.NewInt(1337)
+	config.PragueTime = nil // Not final in this go-ethereum release
+
+	rng := fastrand.New("replay.synthetic")
+	keys := make([]*ecdsa.PrivateKey, syntheticSenders)
+	alloc := gethtypes.GenesisAlloc{}
+	tokenStorage := make(map[common.Hash]common.Hash)
+	for i := range keys {
+		key, err := crypto.ToECDSA(crypto.Keccak256([]byte(fmt.Sprintf("ethbench.replay.sender.%d", i))))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to derive sender key: %w", err)
+		}
+		keys[i] = key
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+		alloc[addr] = gethtypes.Account{Balance: new(big.Int).Mul(big.NewInt(syntheticBalance), big.NewInt(params.Ether))}
+		tokenStorage[evmcode.BalanceSlot(addr)] = common.BigToHash(big.NewInt(syntheticTokenSupply))
+	}
+	alloc[syntheticToken] = gethtypes.Account{Code: evmcode.ERC20(), Storage: tokenStorage, Balance: new(big.Int)}
+
+	genesis := &core.Genesis{
+		Config:     &config,
+		GasLimit:   syntheticGasLimit,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		Difficulty: new(big.Int),
+		Alloc:      alloc,
+	}
+	signer := gethtypes.LatestSigner(&config)
+
+	var genErr error
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, beacon.New(ethash.NewFaker()), syntheticBlocks, func(n int, b *core.BlockGen) {
+		if genErr != nil {
+			return
+		}
+		b.SetPoS()
+		tip := big.NewInt(params.GWei)
+		feeCap := new(big.Int).Add(new(big.Int).Mul(b.BaseFee(), big.NewInt(2)), tip)
+		send := func(i int, to common.Address, value *big.Int, gas uint64, data []byte) {
+			key := keys[i%len(keys)]
+			tx, err := gethtypes.SignNewTx(key, signer, &gethtypes.DynamicFeeTx{
+				ChainID:   config.ChainID,
+				Nonce:     b.TxNonce(crypto.PubkeyToAddress(key.PublicKey)),
+				GasTipCap: tip,
+				GasFeeCap: feeCap,
+				Gas:       gas,
+				To:        &to,
+				Value:     value,
+				Data:      data,
+			})
+			if err != nil {
+				genErr = err
+				return
+			}
+			b.AddTx(tx)
+		}
+
+		var to common.Address
+		for i := 0; i < ethTransfers && genErr == nil; i++ {
+			rng.Read(to[:])
+			send(i, to, big.NewInt(params.GWei), params.TxGas, nil)
+		}
+		for i := 0; i < tokenTransfers && genErr == nil; i++ {
+			rng.Read(to[:])
+			send(i, syntheticToken, new(big.Int), tokenTransferGas, evmcode.TransferInput(to, 1))
+		}
+	})
+	if genErr != nil {
+		return nil, nil, fmt.Errorf("failed to sign transaction: %w", genErr)
+	}
+	return genesis, blocks, nil
+}
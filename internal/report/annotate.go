@@ -0,0 +1,167 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// Annotations holds external sensor readings merged onto the benchmark timeline
+type Annotations struct {
+	Source  string         `json:"source"`
+	Columns []string       `json:"columns"`
+	Samples int            `json:"samples"`
+	Phases  []PhaseSensors `json:"phases"`
+}
+
+// PhaseSensors summarizes sensor readings taken while one benchmark ran
+type PhaseSensors struct {
+	Phase   string                  `json:"phase"`
+	Samples int                     `json:"samples"`
+	Values  map[string]SensorValues `json:"values"`
+}
+
+// SensorValues holds aggregate readings for a single sensor column
+type SensorValues struct {
+	Mean float64 `json:"mean"`
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+}
+
+// sensorSample is a single timestamped row from the annotation file
+type sensorSample struct {
+	time   time.Time
+	values map[string]float64
+}
+
+// timestampLayouts are the accepted formats for the first CSV column
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+}
+
+// LoadAnnotations reads a sensor CSV file and merges it onto the timeline.
+// The first column must be a timestamp (RFC3339, "YYYY-MM-DD HH:MM:SS" local
+// time, or Unix seconds); all other columns are treated as numeric sensors.
+func LoadAnnotations(path string, timeline []types.PhaseTiming) (*Annotations, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open annotation file: %w", err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read annotation header: %w", err)
+	}
+	if len(header) < 2 {
+		return nil, fmt.Errorf("annotation file needs a timestamp column and at least one sensor column")
+	}
+	columns := make([]string, 0, len(header)-1)
+	for _, col := range header[1:] {
+		columns = append(columns, strings.TrimSpace(col))
+	}
+
+	var samples []sensorSample
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read annotation row: %w", err)
+		}
+
+		ts, err := parseTimestamp(record[0])
+		if err != nil {
+			continue // Skip rows we cannot place on the timeline
+		}
+
+		sample := sensorSample{time: ts, values: make(map[string]float64)}
+		for i, cell := range record[1:] {
+			if i >= len(columns) {
+				break
+			}
+			v, err := strconv.ParseFloat(strings.TrimSpace(cell), 64)
+			if err == nil {
+				sample.values[columns[i]] = v
+			}
+		}
+		samples = append(samples, sample)
+	}
+
+	return &Annotations{
+		Source:  path,
+		Columns: columns,
+		Samples: len(samples),
+		Phases:  mergeSamples(timeline, samples, columns),
+	}, nil
+}
+
+// parseTimestamp parses a timestamp in any of the supported layouts
+func parseTimestamp(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if secs, err := strconv.ParseFloat(s, 64); err == nil {
+		whole, frac := math.Modf(secs)
+		return time.Unix(int64(whole), int64(frac*1e9)), nil
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized timestamp %q", s)
+}
+
+// mergeSamples aggregates sensor samples into the benchmark phase they fall in
+func mergeSamples(timeline []types.PhaseTiming, samples []sensorSample, columns []string) []PhaseSensors {
+	phases := make([]PhaseSensors, 0, len(timeline))
+	for _, phase := range timeline {
+		ps := PhaseSensors{
+			Phase:  phase.Name,
+			Values: make(map[string]SensorValues),
+		}
+		counts := make(map[string]int)
+
+		for _, sample := range samples {
+			if sample.time.Before(phase.Start) || sample.time.After(phase.End) {
+				continue
+			}
+			ps.Samples++
+			for _, col := range columns {
+				v, ok := sample.values[col]
+				if !ok {
+					continue
+				}
+				agg, seen := ps.Values[col]
+				if !seen {
+					agg = SensorValues{Min: v, Max: v}
+				}
+				agg.Mean += v
+				agg.Min = math.Min(agg.Min, v)
+				agg.Max = math.Max(agg.Max, v)
+				ps.Values[col] = agg
+				counts[col]++
+			}
+		}
+
+		for col, agg := range ps.Values {
+			agg.Mean /= float64(counts[col])
+			ps.Values[col] = agg
+		}
+		phases = append(phases, ps)
+	}
+	return phases
+}
@@ -0,0 +1,118 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// Rates of late head votes and missed attestations above which the node,
+// rather than network-wide conditions, is the likely cause
+const (
+	lateHeadRate = 0.05
+	missedRate   = 0.02
+)
+
+// CorrelateAttestations matches missed and late attestations with the
+// bottlenecks in a benchmark report of the node's machine and returns
+// remediation advice. Without a report only the duty pattern is explained.
+func CorrelateAttestations(perf *types.AttestationPerformance, r *Report) []string {
+	if perf.Duties == 0 {
+		return nil
+	}
+	var advice []string
+	headRate := float64(perf.HeadMissed) / float64(perf.Duties)
+	missRate := float64(perf.Missed) / float64(perf.Duties)
+	if headRate <= lateHeadRate && missRate <= missedRate {
+		return append(advice, fmt.Sprintf("Attestation performance is healthy (%.1f%% effectiveness); no hardware changes needed.", perf.EffectivenessPercent))
+	}
+
+	// Whole epochs without a single timely attestation mean the node was down
+	var offline []string
+	for _, e := range perf.Epochs {
+		if e.Duties > 0 && e.Missed == e.Duties {
+			offline = append(offline, fmt.Sprint(e.Epoch))
+		}
+	}
+	if len(offline) > 0 {
+		advice = append(advice, fmt.Sprintf("All attestations were missed in epoch(s) %s, so the node was down or not synced. Check the client logs for restarts.", strings.Join(offline, ", ")))
+		if r != nil && r.System != nil && len(r.System.Swap) == 0 && r.System.RAMTotalMB <= 8192 {
+			advice = append(advice, fmt.Sprintf("With %d MB of RAM and no swap, an OOM kill is the most likely cause of the downtime; enable zram swap or lower the client caches.", r.System.RAMTotalMB))
+		}
+		if r != nil && r.Memory.Correctness.Failures > 0 {
+			advice = append(advice, "The benchmark found wrong computation results; crashes from unstable RAM, overclock or power can cause the downtime.")
+		}
+	}
+
+	if headRate > lateHeadRate {
+		advice = append(advice, fmt.Sprintf("%.1f%% of attestations voted late or for the wrong head, which usually means blocks were imported after the 4 second attestation deadline.", headRate*100))
+		advice = append(advice, headVoteCauses(r)...)
+	}
+	if missRate > missedRate && len(offline) == 0 {
+		advice = append(advice, fmt.Sprintf("%.1f%% of attestations were not included in time while the node was up. Check peer count and network latency; a saturated upload link delays attestation broadcast.", missRate*100))
+	}
+	return advice
+}
+
+// headVoteCauses names the benchmark results that slow block import
+func headVoteCauses(r *Report) []string {
+	if r == nil {
+		return []string{"Run ethbench on the node's machine and pass its report with -report to find the bottleneck."}
+	}
+	var causes []string
+	if fsync := r.Disk.Fsync; fsync.OK() && fsync.P99LatencyMs > 20 {
+		causes = append(causes, fmt.Sprintf("fsync p99 latency is %.1f ms; block commits stall on storage. Use an NVMe SSD with power-loss protection.", fsync.P99LatencyMs))
+	}
+	if batch := r.Disk.Batch; batch.OK() && batch.MaxBatchLatencyMs >= 1000 {
+		causes = append(causes, fmt.Sprintf("A batch commit stalled for %.1f s during the benchmark; stalls like this delay block import past the deadline.", batch.MaxBatchLatencyMs/1000))
+	}
+	if random := r.Disk.Random; random.OK() && random.ReadIOPS < 10000 {
+		causes = append(causes, fmt.Sprintf("Random reads reach only %.0f IOPS; state access during block execution is slow. An NVMe SSD fixes this.", random.ReadIOPS))
+	}
+	if bls := r.CPU.BLS; bls.OK() && bls.VerificationsPerSecond < 100 {
+		causes = append(causes, fmt.Sprintf("BLS verification runs at %.0f/sec; attestation and block signature checks lag.", bls.VerificationsPerSecond))
+	}
+	if r.Thermal != nil && !r.Thermal.Stable {
+		causes = append(causes, fmt.Sprintf("The CPU throttled during the benchmark (max %.1f°C); improve cooling so block import runs at full clock.", r.Thermal.MaxTemperatureC))
+	}
+	if len(causes) == 0 {
+		causes = append(causes, "The benchmark shows no hardware bottleneck. Check clock sync (chrony or timesyncd), peer count and network latency.")
+	}
+	return causes
+}
+
+// FormatAttestations renders the attestation summary, the epochs with
+// problems and the advice
+func FormatAttestations(perf *types.AttestationPerformance, advice []string) string {
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat("=", 80) + "\n")
+	sb.WriteString("                       ATTESTATION PERFORMANCE\n")
+	sb.WriteString(strings.Repeat("=", 80) + "\n\n")
+	sb.WriteString(fmt.Sprintf("  Beacon Node:    %s\n", perf.Endpoint))
+	sb.WriteString(fmt.Sprintf("  Epochs:         %d-%d, %d validators, %d duties\n", perf.FirstEpoch, perf.LastEpoch, perf.Validators, perf.Duties))
+	sb.WriteString(fmt.Sprintf("  Effectiveness:  %.1f%%\n", perf.EffectivenessPercent))
+	sb.WriteString(fmt.Sprintf("  Missed:         %d\n", perf.Missed))
+	sb.WriteString(fmt.Sprintf("  Late Head:      %d\n", perf.HeadMissed))
+	sb.WriteString(fmt.Sprintf("  Missed Target:  %d\n", perf.TargetMissed))
+
+	var header bool
+	for _, e := range perf.Epochs {
+		if e.Missed == 0 && e.HeadMissed == 0 {
+			continue
+		}
+		if !header {
+			sb.WriteString(fmt.Sprintf("\n  %-10s %8s %8s %10s\n", "Epoch", "Duties", "Missed", "Late Head"))
+			header = true
+		}
+		sb.WriteString(fmt.Sprintf("  %-10d %8d %8d %10d\n", e.Epoch, e.Duties, e.Missed, e.HeadMissed))
+	}
+
+	if len(advice) > 0 {
+		sb.WriteString("\nRecommendations:\n")
+		for _, a := range advice {
+			sb.WriteString(fmt.Sprintf("  - %s\n", a))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,100 @@
+package report
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/vBenchmark/internal/baseline"
+	"github.com/vBenchmark/internal/types"
+)
+
+// BaselineCheck holds the result of checking a run against a baseline profile
+type BaselineCheck struct {
+	Profile     string           `json:"profile"`
+	Description string           `json:"description"`
+	Passed      bool             `json:"passed"`
+	Checks      []ThresholdCheck `json:"checks"`
+}
+
+// ThresholdCheck holds the outcome for one metric of a baseline profile
+type ThresholdCheck struct {
+	Metric   string  `json:"metric"`
+	Required string  `json:"required"`
+	Value    float64 `json:"value"`
+	Measured bool    `json:"measured"`
+	Passed   bool    `json:"passed"`
+}
+
+// CheckBaseline compares the results with the minimums of a baseline
+// profile. Metrics of failed, skipped or unselected benchmarks count as a
+// failure, since the machine could not be shown to meet them.
+func (r *Report) CheckBaseline(profile *baseline.Profile) {
+	if profile == nil {
+		return
+	}
+	tree := reportTree(r)
+	metrics := types.FlattenMetrics(tree)
+
+	check := &BaselineCheck{
+		Profile:     profile.Name,
+		Description: profile.Description,
+		Passed:      true,
+	}
+	for _, metric := range profile.Metrics() {
+		threshold := profile.Thresholds[metric]
+		value, ok := metrics[metric]
+		measured := ok && completed(tree, metric)
+		c := ThresholdCheck{
+			Metric:   metric,
+			Required: threshold.String(),
+			Value:    value,
+			Measured: measured,
+			Passed:   measured && threshold.Passes(value),
+		}
+		check.Passed = check.Passed && c.Passed
+		check.Checks = append(check.Checks, c)
+	}
+	r.Baseline = check
+}
+
+// Failed returns the checks that did not pass
+func (c *BaselineCheck) Failed() []ThresholdCheck {
+	var failed []ThresholdCheck
+	for _, check := range c.Checks {
+		if !check.Passed {
+			failed = append(failed, check)
+		}
+	}
+	return failed
+}
+
+// reportTree returns the report as a generic JSON tree
+func reportTree(r *Report) map[string]any {
+	var tree map[string]any
+	data, err := json.Marshal(r)
+	if err != nil {
+		return tree
+	}
+	json.Unmarshal(data, &tree)
+	return tree
+}
+
+// completed reports whether no result along the metric's path was marked
+// failed or skipped
+func completed(tree map[string]any, metric string) bool {
+	node := tree
+	for _, key := range strings.Split(metric, ".") {
+		if skipped, _ := node["skipped"].(bool); skipped {
+			return false
+		}
+		if e, _ := node["error"].(string); e != "" {
+			return false
+		}
+		child, ok := node[key].(map[string]any)
+		if !ok {
+			return true
+		}
+		node = child
+	}
+	return true
+}
@@ -0,0 +1,169 @@
+package report
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// redacted replaces identifying values in support bundles
+const redacted = "[REDACTED]"
+
+// truncatedNote ends an entry cut short by the bundle size cap
+const truncatedNote = "\n[truncated: bundle size cap reached]\n"
+
+// BundleFile is a single named entry in a support bundle
+type BundleFile struct {
+	Name string
+	Data []byte
+}
+
+// BundleOptions controls support bundle generation
+type BundleOptions struct {
+	// MaxSizeBytes caps the total size of the bundle entries before
+	// compression, truncation notes included; tar headers are not counted
+	MaxSizeBytes int64
+	// Log holds the benchmark progress log
+	Log string
+	// Dmesg holds the kernel log excerpt
+	Dmesg string
+	// Files are raw sample files saved with the report, such as the soak
+	// time series, added under their base names
+	Files []string
+}
+
+// SaveBundle packages the report, logs and system details into a redacted
+// .tar.zst support bundle suitable for sharing in help channels
+func SaveBundle(r *Report, outputDir string, opts BundleOptions) (string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	reportJSON, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal report: %w", err)
+	}
+	systemJSON, err := json.MarshalIndent(r.System, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal system info: %w", err)
+	}
+
+	// Most important files first so the size cap truncates the least useful
+	// data: the raw samples, which can run to megabytes, go last
+	files := []BundleFile{
+		{Name: "report.json", Data: reportJSON},
+		{Name: "report.txt", Data: []byte(FormatText(r))},
+		{Name: "system.json", Data: systemJSON},
+		{Name: "benchmark.log", Data: []byte(opts.Log)},
+		{Name: "dmesg.txt", Data: []byte(opts.Dmesg)},
+	}
+	for _, path := range opts.Files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			data = []byte(fmt.Sprintf("[unreadable: %v]\n", err))
+		}
+		files = append(files, BundleFile{Name: filepath.Base(path), Data: data})
+	}
+
+	secrets := bundleSecrets(r)
+
+	timestamp := time.Now().Format("2006-01-02_15-04-05")
+	filename := fmt.Sprintf("ethbench-bundle-%s.tar.zst", timestamp)
+	path := filepath.Join(outputDir, filename)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create bundle file: %w", err)
+	}
+	defer f.Close()
+
+	zw, err := zstd.NewWriter(f)
+	if err != nil {
+		return "", fmt.Errorf("failed to create zstd writer: %w", err)
+	}
+	tw := tar.NewWriter(zw)
+
+	remaining := opts.MaxSizeBytes
+	for _, file := range files {
+		data := redact(file.Data, secrets)
+		if opts.MaxSizeBytes > 0 {
+			if remaining <= 0 {
+				break
+			}
+			if int64(len(data)) > remaining {
+				// The note counts against the cap; without room for it the
+				// entry is cut bare
+				if keep := remaining - int64(len(truncatedNote)); keep > 0 {
+					data = append(data[:keep:keep], truncatedNote...)
+				} else {
+					data = data[:remaining]
+				}
+			}
+			remaining -= int64(len(data))
+		}
+
+		header := &tar.Header{
+			Name:    file.Name,
+			Mode:    0644,
+			Size:    int64(len(data)),
+			ModTime: time.Now(),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return "", fmt.Errorf("failed to write bundle entry %s: %w", file.Name, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return "", fmt.Errorf("failed to write bundle entry %s: %w", file.Name, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return "", fmt.Errorf("failed to finalize bundle archive: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return "", fmt.Errorf("failed to finalize bundle compression: %w", err)
+	}
+
+	return path, nil
+}
+
+// bundleSecrets collects identifying values that must not leave the machine
+func bundleSecrets(r *Report) []string {
+	var secrets []string
+	if r.System == nil {
+		return secrets
+	}
+	// The fingerprint is derived from the serials and links runs to the machine
+	for _, s := range []string{r.System.SerialNumber, r.System.DiskSerial, r.System.Hostname, r.System.Fingerprint} {
+		if s != "" && s != "unknown" {
+			secrets = append(secrets, s)
+		}
+	}
+	if data, err := os.ReadFile("/etc/machine-id"); err == nil {
+		if id := strings.TrimSpace(string(data)); id != "" {
+			secrets = append(secrets, id)
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil && home != "/" {
+		secrets = append(secrets, home)
+	}
+	return secrets
+}
+
+// redact replaces every occurrence of the given secrets with a placeholder
+func redact(data []byte, secrets []string) []byte {
+	s := string(data)
+	for _, secret := range secrets {
+		// Very short values would mangle unrelated text
+		if len(secret) < 4 {
+			continue
+		}
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return []byte(s)
+}
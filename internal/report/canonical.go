@@ -0,0 +1,141 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// canonicalPrecision is the number of decimals for floats in canonical JSON
+const canonicalPrecision = 2
+
+// volatileKeys are timestamp fields stripped in deterministic mode
+var volatileKeys = map[string]bool{
+	"timestamp":  true,
+	"start":      true,
+	"end":        true,
+	"first_seen": true,
+	"last_seen":  true,
+}
+
+// FormatCanonicalJSON generates diff-friendly JSON with sorted keys and fixed
+// float precision. When deterministic is set, timestamps are stripped too.
+func FormatCanonicalJSON(r *Report, deterministic bool) (string, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal report: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var tree any
+	if err := decoder.Decode(&tree); err != nil {
+		return "", fmt.Errorf("failed to decode report: %w", err)
+	}
+
+	var sb strings.Builder
+	writeCanonical(&sb, tree, 0, deterministic)
+	sb.WriteString("\n")
+	return sb.String(), nil
+}
+
+// SaveCanonicalJSON saves the report as canonical JSON. Deterministic reports
+// use a fixed filename so they can be committed and diffed in git.
+func SaveCanonicalJSON(r *Report, outputDir string, deterministic bool) (string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	filename := "ethbench-report.json"
+	if !deterministic {
+		filename = fmt.Sprintf("ethbench-%s.json", time.Now().Format("2006-01-02_15-04-05"))
+	}
+	path := filepath.Join(outputDir, filename)
+
+	data, err := FormatCanonicalJSON(r, deterministic)
+	if err != nil {
+		return "", err
+	}
+	if err := writeFileAtomic(path, []byte(data)); err != nil {
+		return "", fmt.Errorf("failed to write report file: %w", err)
+	}
+	return path, nil
+}
+
+// writeCanonical writes a decoded JSON value with sorted keys and two-space indentation
+func writeCanonical(sb *strings.Builder, v any, depth int, deterministic bool) {
+	indent := strings.Repeat("  ", depth+1)
+	closing := strings.Repeat("  ", depth)
+
+	switch val := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			if deterministic && volatileKeys[k] {
+				continue
+			}
+			keys = append(keys, k)
+		}
+		if len(keys) == 0 {
+			sb.WriteString("{}")
+			return
+		}
+		sort.Strings(keys)
+
+		sb.WriteString("{\n")
+		for i, k := range keys {
+			key, _ := json.Marshal(k)
+			sb.WriteString(indent)
+			sb.Write(key)
+			sb.WriteString(": ")
+			writeCanonical(sb, val[k], depth+1, deterministic)
+			if i < len(keys)-1 {
+				sb.WriteString(",")
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString(closing + "}")
+
+	case []any:
+		if len(val) == 0 {
+			sb.WriteString("[]")
+			return
+		}
+		sb.WriteString("[\n")
+		for i, item := range val {
+			sb.WriteString(indent)
+			writeCanonical(sb, item, depth+1, deterministic)
+			if i < len(val)-1 {
+				sb.WriteString(",")
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString(closing + "]")
+
+	case json.Number:
+		sb.WriteString(canonicalNumber(val))
+
+	default:
+		data, _ := json.Marshal(val)
+		sb.Write(data)
+	}
+}
+
+// canonicalNumber keeps integers as-is and rounds floats to a fixed precision
+func canonicalNumber(n json.Number) string {
+	s := n.String()
+	if !strings.ContainsAny(s, ".eE") {
+		return s
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return s
+	}
+	return strconv.FormatFloat(f, 'f', canonicalPrecision, 64)
+}
@@ -0,0 +1,275 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// comparedSections lists the report sections whose metrics are compared
+var comparedSections = []string{"summary.", "cpu.", "memory.", "disk.", "forks.", "thermal."}
+
+// Comparison holds metric deltas between two saved reports
+type Comparison struct {
+	OldPath      string
+	NewPath      string
+	OldTimestamp string
+	NewTimestamp string
+	OldWorkload  string
+	NewWorkload  string
+	OldScoring   string
+	NewScoring   string
+	Rescored     bool // Both reports were re-scored with one profile
+	Scores       []MetricDelta
+	Metrics      []MetricDelta
+}
+
+// MetricDelta holds the change of a single metric between two reports
+type MetricDelta struct {
+	Metric  string
+	Old     float64
+	New     float64
+	Delta   float64
+	Percent float64 // NaN when the old value is zero
+	Missing string  // "old" or "new" when the metric exists in only one report
+}
+
+// CompareReports loads two JSON reports and computes per-metric deltas.
+// With a scoring profile both reports are re-scored with it; otherwise the
+// scores they were saved with are compared.
+func CompareReports(oldPath, newPath string, scoring *ScoringProfile) (*Comparison, error) {
+	oldTree, err := loadReportTree(oldPath)
+	if err != nil {
+		return nil, err
+	}
+	newTree, err := loadReportTree(newPath)
+	if err != nil {
+		return nil, err
+	}
+	if scoring != nil {
+		if err := rescoreTree(oldTree, scoring); err != nil {
+			return nil, fmt.Errorf("failed to re-score %s: %w", oldPath, err)
+		}
+		if err := rescoreTree(newTree, scoring); err != nil {
+			return nil, fmt.Errorf("failed to re-score %s: %w", newPath, err)
+		}
+	}
+
+	c := &Comparison{
+		OldPath:      oldPath,
+		NewPath:      newPath,
+		OldTimestamp: reportTimestamp(oldTree),
+		NewTimestamp: reportTimestamp(newTree),
+		OldWorkload:  reportWorkload(oldTree),
+		NewWorkload:  reportWorkload(newTree),
+		OldScoring:   reportScoring(oldTree),
+		NewScoring:   reportScoring(newTree),
+		Rescored:     scoring != nil,
+	}
+
+	oldMetrics := comparableMetrics(oldTree)
+	newMetrics := comparableMetrics(newTree)
+
+	keys := make(map[string]bool)
+	for k := range oldMetrics {
+		keys[k] = true
+	}
+	for k := range newMetrics {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	for _, metric := range sorted {
+		oldValue, inOld := oldMetrics[metric]
+		newValue, inNew := newMetrics[metric]
+		d := MetricDelta{Metric: metric, Old: oldValue, New: newValue, Percent: math.NaN()}
+		switch {
+		case !inOld:
+			d.Missing = "old"
+		case !inNew:
+			d.Missing = "new"
+		default:
+			d.Delta = newValue - oldValue
+			if oldValue != 0 {
+				d.Percent = d.Delta / math.Abs(oldValue) * 100
+			}
+		}
+
+		if strings.HasPrefix(metric, "summary.") {
+			c.Scores = append(c.Scores, d)
+		} else {
+			c.Metrics = append(c.Metrics, d)
+		}
+	}
+	return c, nil
+}
+
+// loadReportTree reads a saved report as a generic JSON tree
+func loadReportTree(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+	var tree map[string]any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, fmt.Errorf("failed to parse report %s: %w", path, err)
+	}
+	return tree, nil
+}
+
+// reportTimestamp returns the metadata timestamp of a report tree, if any
+func reportTimestamp(tree map[string]any) string {
+	if meta, ok := tree["metadata"].(map[string]any); ok {
+		if ts, ok := meta["timestamp"].(string); ok {
+			return ts
+		}
+	}
+	return ""
+}
+
+// reportWorkload returns the workload profile version of a report tree.
+// Reports from before workload profiles were versioned return "".
+func reportWorkload(tree map[string]any) string {
+	if meta, ok := tree["metadata"].(map[string]any); ok {
+		if v, ok := meta["workload_version"].(string); ok {
+			return v
+		}
+	}
+	return ""
+}
+
+// reportScoring returns the scoring profile a report tree was scored with.
+// Reports from before scoring profiles were recorded use the profile of
+// their schema version.
+func reportScoring(tree map[string]any) string {
+	meta, _ := tree["metadata"].(map[string]any)
+	if name, ok := meta["scoring_profile"].(string); ok && name != "" {
+		return name
+	}
+	version, _ := meta["schema_version"].(float64)
+	return scoringProfileForSchema(int(version)).Name
+}
+
+// rescoreTree replaces the summary of a report tree with scores computed by
+// the given profile and records the profile in its metadata
+func rescoreTree(tree map[string]any, scoring *ScoringProfile) error {
+	data, err := json.Marshal(tree)
+	if err != nil {
+		return err
+	}
+	var results types.Results
+	if err := json.Unmarshal(data, &results); err != nil {
+		return err
+	}
+	if data, err = json.Marshal(calculateSummary(&results, scoring)); err != nil {
+		return err
+	}
+	var summary map[string]any
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return err
+	}
+	tree["summary"] = summary
+	if meta, ok := tree["metadata"].(map[string]any); ok {
+		meta["scoring_profile"] = scoring.Name
+	}
+	return nil
+}
+
+// comparableMetrics returns the benchmark and score metrics of a report tree
+func comparableMetrics(tree map[string]any) map[string]float64 {
+	metrics := make(map[string]float64)
+	for key, value := range types.FlattenMetrics(tree) {
+		if strings.HasSuffix(key, "duration_ns") {
+			continue
+		}
+		for _, section := range comparedSections {
+			if strings.HasPrefix(key, section) {
+				metrics[key] = value
+				break
+			}
+		}
+	}
+	return metrics
+}
+
+// FormatComparison renders a side-by-side delta table
+func FormatComparison(c *Comparison) string {
+	var sb strings.Builder
+
+	sb.WriteString(strings.Repeat("=", 80) + "\n")
+	sb.WriteString("                    ETHEREUM NODE BENCHMARK COMPARISON\n")
+	sb.WriteString(strings.Repeat("=", 80) + "\n\n")
+	sb.WriteString(fmt.Sprintf("  Old:            %s %s\n", c.OldPath, c.OldTimestamp))
+	sb.WriteString(fmt.Sprintf("  New:            %s %s\n", c.NewPath, c.NewTimestamp))
+	switch {
+	case c.Rescored:
+		sb.WriteString(fmt.Sprintf("  Scoring:        both re-scored with %s\n", c.NewScoring))
+	case c.OldScoring != c.NewScoring:
+		sb.WriteString(fmt.Sprintf("\n  Note: the reports were scored with different profiles (%s vs %s);\n", c.OldScoring, c.NewScoring))
+		sb.WriteString("  re-run with -scoring to compare scores on the same scale.\n")
+	}
+	if c.OldWorkload != c.NewWorkload {
+		sb.WriteString(fmt.Sprintf("\n  Note: the reports use different workload profiles (%s vs %s);\n", versionOrUnknown(c.OldWorkload), versionOrUnknown(c.NewWorkload)))
+		sb.WriteString("  some metric changes come from the workload rather than the hardware.\n")
+	}
+
+	sb.WriteString("\nSCORES\n")
+	writeDeltaTable(&sb, c.Scores, "summary.")
+
+	sb.WriteString("\nMETRICS\n")
+	writeDeltaTable(&sb, c.Metrics, "")
+
+	return sb.String()
+}
+
+// writeDeltaTable writes one row per metric with absolute and percent change
+func writeDeltaTable(sb *strings.Builder, deltas []MetricDelta, trimPrefix string) {
+	sb.WriteString(fmt.Sprintf("  %-44s %12s %12s %12s %8s\n", "Metric", "Old", "New", "Change", "%"))
+	sb.WriteString("  " + strings.Repeat("-", 92) + "\n")
+	for _, d := range deltas {
+		name := strings.TrimPrefix(d.Metric, trimPrefix)
+		switch d.Missing {
+		case "old":
+			sb.WriteString(fmt.Sprintf("  %-44s %12s %12.2f %12s %8s\n", name, "-", d.New, "new", ""))
+		case "new":
+			sb.WriteString(fmt.Sprintf("  %-44s %12.2f %12s %12s %8s\n", name, d.Old, "-", "removed", ""))
+		default:
+			percent := "n/a"
+			if !math.IsNaN(d.Percent) {
+				percent = fmt.Sprintf("%+.1f%%", d.Percent)
+			}
+			sb.WriteString(fmt.Sprintf("  %-44s %12.2f %12.2f %+12.2f %8s\n", name, d.Old, d.New, d.Delta, percent))
+		}
+	}
+}
+
+// versionOrUnknown returns v, or "unversioned" for reports without a version
+func versionOrUnknown(v string) string {
+	if v == "" {
+		return "unversioned"
+	}
+	return v
+}
+
+// Metrics returns the score and benchmark metrics of the report that
+// completed, by dotted JSON path, as compared between reports. Scores of
+// categories that did not run are left out.
+func (r *Report) Metrics() map[string]float64 {
+	tree := reportTree(r)
+	metrics := comparableMetrics(tree)
+	for metric := range metrics {
+		if !completed(tree, metric) || !r.Summary.scored(metric) {
+			delete(metrics, metric)
+		}
+	}
+	return metrics
+}
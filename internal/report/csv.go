@@ -0,0 +1,102 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// csvIdentityColumns are the text fields leading each CSV row, so rows from
+// many machines can be told apart and grouped in a spreadsheet
+var csvIdentityColumns = []string{
+	"metadata.timestamp",
+	"metadata.version",
+	"metadata.workload_version",
+	"metadata.scoring_profile",
+	"system.hostname",
+	"system.serial_number",
+	"system.fingerprint",
+	"system.rpi_model",
+	"system.cpu_model",
+	"system.disk_model",
+	"system.disk_type",
+	"verdict.execution_client",
+	"verdict.consensus_client",
+}
+
+// FormatCSV flattens the report into a header and a single row: the
+// identity columns, then every numeric metric under its dotted JSON path in
+// sorted order. Metrics of failed or skipped benchmarks are left empty.
+func FormatCSV(r *Report) (string, error) {
+	tree := reportTree(r)
+	metrics := types.FlattenMetrics(tree)
+	names := make([]string, 0, len(metrics))
+	for name := range metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	header := append([]string{}, csvIdentityColumns...)
+	row := make([]string, 0, len(header)+len(names))
+	for _, column := range csvIdentityColumns {
+		row = append(row, stringField(tree, column))
+	}
+	for _, name := range names {
+		header = append(header, name)
+		value := ""
+		if completed(tree, name) {
+			value = strconv.FormatFloat(metrics[name], 'f', -1, 64)
+		}
+		row = append(row, value)
+	}
+
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	w.Write(header)
+	w.Write(row)
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return sb.String(), nil
+}
+
+// stringField returns the text value at a dotted path of a report tree
+func stringField(tree map[string]any, path string) string {
+	node := tree
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		child, ok := node[key].(map[string]any)
+		if !ok {
+			return ""
+		}
+		node = child
+	}
+	value, _ := node[keys[len(keys)-1]].(string)
+	return value
+}
+
+// SaveCSV saves the report as a CSV file with timestamp in filename
+func SaveCSV(r *Report, outputDir string) (string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	data, err := FormatCSV(r)
+	if err != nil {
+		return "", err
+	}
+	timestamp := time.Now().Format("2006-01-02_15-04-05")
+	path := filepath.Join(outputDir, fmt.Sprintf("ethbench-%s.csv", timestamp))
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		return "", fmt.Errorf("failed to write CSV report: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,152 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// DurationCheck shows how each metric moves as the benchmark durations grow,
+// to pick the shortest durations that still converge on a hardware class
+type DurationCheck struct {
+	Scales    []float64 // Multipliers of the default time budgets, ascending
+	Tolerance float64   // Percent from the longest run's value that counts as converged
+	Metrics   []DurationSensitivity
+}
+
+// DurationSensitivity holds one metric's value at every duration scale
+type DurationSensitivity struct {
+	Metric string
+	Values []float64 // One per scale; NaN when the metric is missing at that scale
+	// SpreadPercent is the range of the values relative to the longest run
+	SpreadPercent float64
+	// ConvergedScale is the shortest scale from which every value is within
+	// the tolerance of the longest run; 0 when only the longest run is
+	ConvergedScale float64
+}
+
+// CheckDurations compares the results of the same suite run at each scale.
+// Metrics that are identical at every scale, such as worker counts, are
+// left out.
+func CheckDurations(scales []float64, runs []*types.Results, tolerance float64) *DurationCheck {
+	samples := make(map[string][]float64)
+	for i, results := range runs {
+		metrics := comparableMetrics(map[string]any{
+			"cpu":    results.CPU,
+			"memory": results.Memory,
+			"disk":   results.Disk,
+			"forks":  results.Forks,
+		})
+		for metric, value := range metrics {
+			if samples[metric] == nil {
+				samples[metric] = make([]float64, len(runs))
+				for j := range samples[metric] {
+					samples[metric][j] = math.NaN()
+				}
+			}
+			samples[metric][i] = value
+		}
+	}
+
+	names := make([]string, 0, len(samples))
+	for metric, values := range samples {
+		if !constant(values) {
+			names = append(names, metric)
+		}
+	}
+	sort.Strings(names)
+
+	check := &DurationCheck{Scales: scales, Tolerance: tolerance}
+	for _, metric := range names {
+		check.Metrics = append(check.Metrics, durationSensitivity(metric, scales, samples[metric], tolerance))
+	}
+	return check
+}
+
+// durationSensitivity measures how far the values drift from the longest run
+func durationSensitivity(metric string, scales, values []float64, tolerance float64) DurationSensitivity {
+	s := DurationSensitivity{Metric: metric, Values: values, SpreadPercent: math.NaN()}
+	final := values[len(values)-1]
+	if math.IsNaN(final) || final == 0 {
+		return s
+	}
+
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			low, high = min(low, v), max(high, v)
+		}
+	}
+	s.SpreadPercent = (high - low) / math.Abs(final) * 100
+
+	// Walk back from the longest run while values stay within tolerance
+	for i := len(values) - 2; i >= 0; i-- {
+		if math.IsNaN(values[i]) || math.Abs(values[i]-final)/math.Abs(final)*100 > tolerance {
+			break
+		}
+		s.ConvergedScale = scales[i]
+	}
+	return s
+}
+
+// constant reports whether all present values are equal
+func constant(values []float64) bool {
+	first := math.NaN()
+	for _, v := range values {
+		switch {
+		case math.IsNaN(v):
+		case math.IsNaN(first):
+			first = v
+		case v != first:
+			return false
+		}
+	}
+	return true
+}
+
+// FormatDurationCheck renders one row per metric with its value at every
+// scale and the scale it converges from
+func FormatDurationCheck(c *DurationCheck) string {
+	var sb strings.Builder
+
+	sb.WriteString(strings.Repeat("=", 80) + "\n")
+	sb.WriteString("                    BENCHMARK DURATION SENSITIVITY\n")
+	sb.WriteString(strings.Repeat("=", 80) + "\n\n")
+	sb.WriteString(fmt.Sprintf("  Converged:      within %.1f%% of the longest run from that scale on\n\n", c.Tolerance))
+
+	sb.WriteString(fmt.Sprintf("  %-44s", "Metric"))
+	for _, scale := range c.Scales {
+		sb.WriteString(fmt.Sprintf(" %12s", formatScale(scale)))
+	}
+	sb.WriteString(fmt.Sprintf(" %8s %10s\n", "Spread", "Converged"))
+	sb.WriteString("  " + strings.Repeat("-", 44+13*len(c.Scales)+20) + "\n")
+
+	for _, m := range c.Metrics {
+		sb.WriteString(fmt.Sprintf("  %-44s", m.Metric))
+		for _, v := range m.Values {
+			if math.IsNaN(v) {
+				sb.WriteString(fmt.Sprintf(" %12s", "-"))
+			} else {
+				sb.WriteString(fmt.Sprintf(" %12.2f", v))
+			}
+		}
+		spread := "n/a"
+		if !math.IsNaN(m.SpreadPercent) {
+			spread = fmt.Sprintf("%.1f%%", m.SpreadPercent)
+		}
+		converged := "longest"
+		if m.ConvergedScale > 0 {
+			converged = formatScale(m.ConvergedScale)
+		}
+		sb.WriteString(fmt.Sprintf(" %8s %10s\n", spread, converged))
+	}
+	return sb.String()
+}
+
+// formatScale formats a duration multiplier, e.g. "x0.25"
+func formatScale(scale float64) string {
+	return fmt.Sprintf("x%g", scale)
+}
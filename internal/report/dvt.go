@@ -0,0 +1,148 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vBenchmark/internal/system"
+	"github.com/vBenchmark/internal/types"
+)
+
+// dvtMiddleware is a distributed validator client with its approximate
+// resident memory and CPU use on mainnet, for a cluster of up to a few
+// hundred validators
+type dvtMiddleware struct {
+	name     string
+	ramMB    int
+	cpuCores float64
+}
+
+// dvtMiddlewareTable lists the middleware a DVT operator runs beside the
+// execution and consensus clients. ssv-node keeps the operator network's
+// QBFT state and verifies RSA-signed messages, so it needs more than charon.
+var dvtMiddlewareTable = []dvtMiddleware{
+	{"charon", 500, 0.25},
+	{"ssv-node", 1000, 0.5},
+}
+
+// dvtNodeRAMMB is the memory an execution and a consensus client use
+// together with default caches
+const dvtNodeRAMMB = 7000
+
+// Latency budget between cluster nodes. Each duty goes through QBFT
+// consensus and a partial signature exchange, together about two round
+// trips, before the validator client may sign.
+const (
+	clusterLatencyWarnMs = 100
+	clusterLatencyMaxMs  = 250
+)
+
+// partialSigCPUShare is the share of one core partial signature
+// verification may take when sizing the validator count
+const partialSigCPUShare = 0.10
+
+// ClusterAssessment checks a distributed validator node: memory for the
+// middleware, signature capacity for the cluster and the latency to the
+// other operators' nodes
+type ClusterAssessment struct {
+	// Size counts the cluster's nodes including this one, from the probed
+	// peers; 0 when no peers were given
+	Size       int                  `json:"size,omitempty"`
+	Middleware []MiddlewareFit      `json:"middleware"`
+	Peers      []system.PeerLatency `json:"peers,omitempty"`
+	// SlowestMs is the highest average connect time to a reachable peer
+	SlowestMs float64 `json:"slowest_ms,omitempty"`
+	// Latency rates SlowestMs against the budget: good, fair or poor;
+	// empty when no peer was reached
+	Latency string `json:"latency,omitempty"`
+	// MaxValidators is how many validators this node can verify partial
+	// signatures for within a tenth of a core
+	MaxValidators int `json:"max_validators,omitempty"`
+}
+
+// MiddlewareFit tells whether one middleware fits in memory beside the
+// execution and consensus clients
+type MiddlewareFit struct {
+	Name     string  `json:"name"`
+	RAMMB    int     `json:"ram_mb"`
+	CPUCores float64 `json:"cpu_cores"`
+	// NodeRAMMB is what the RAM leaves the execution and consensus clients
+	NodeRAMMB int  `json:"node_ram_mb"`
+	Fits      bool `json:"fits"`
+}
+
+// assessCluster builds the cluster assessment for the dvt deployment and
+// the recommendations it leads to
+func assessCluster(sysInfo *system.Info, results *types.Results) (*ClusterAssessment, []string) {
+	if sysInfo == nil {
+		return nil, nil
+	}
+	cluster := &ClusterAssessment{Peers: sysInfo.ClusterPeers}
+	var recommendations []string
+
+	var tight []string
+	for _, mw := range dvtMiddlewareTable {
+		fit := MiddlewareFit{
+			Name:      mw.name,
+			RAMMB:     mw.ramMB,
+			CPUCores:  mw.cpuCores,
+			NodeRAMMB: sysInfo.RAMTotalMB - mw.ramMB,
+		}
+		fit.Fits = fit.NodeRAMMB >= dvtNodeRAMMB
+		cluster.Middleware = append(cluster.Middleware, fit)
+		if !fit.Fits {
+			tight = append(tight, fmt.Sprintf("%s (%d MB left)", mw.name, fit.NodeRAMMB))
+		}
+	}
+	if len(tight) > 0 && sysInfo.RAMTotalMB > 0 {
+		recommendations = append(recommendations,
+			fmt.Sprintf("With %d MB of RAM, the execution and consensus clients get less than the ~%d MB they need beside %s. Use 16 GB of RAM or lower the client caches.", sysInfo.RAMTotalMB, dvtNodeRAMMB, strings.Join(tight, " or ")),
+		)
+	}
+
+	// Every operator's partial signature is verified for every duty; count
+	// one attestation per validator and epoch
+	size := 4
+	if len(cluster.Peers) > 0 {
+		cluster.Size = len(cluster.Peers) + 1
+		size = cluster.Size
+	}
+	if bls := results.CPU.BLS; bls.OK() {
+		cluster.MaxValidators = int(bls.VerificationsPerSecond * 32 * 12 * partialSigCPUShare / float64(size))
+	}
+
+	if len(cluster.Peers) == 0 {
+		recommendations = append(recommendations,
+			"No cluster peers were given, so the latency budget between operators is unchecked. List the other nodes' p2p addresses with -cluster-peers (config key cluster_peers), e.g. charon's port 3610.",
+		)
+		return cluster, recommendations
+	}
+	var slowest string
+	for _, p := range cluster.Peers {
+		if p.Connected == 0 {
+			recommendations = append(recommendations,
+				fmt.Sprintf("Could not connect to cluster peer %s (%s). Duties only complete while a threshold of operators is online; check the peer's p2p port and firewall.", p.Peer, p.Error),
+			)
+			continue
+		}
+		if p.AvgMs > cluster.SlowestMs {
+			cluster.SlowestMs, slowest = p.AvgMs, p.Peer
+		}
+	}
+	switch {
+	case slowest == "":
+	case cluster.SlowestMs >= clusterLatencyMaxMs:
+		cluster.Latency = "poor"
+		recommendations = append(recommendations,
+			fmt.Sprintf("Cluster peer %s is %.0f ms away. Consensus between operators delays every duty by about %.0f ms, so attestations risk missing their inclusion slot; run the cluster's nodes within %d ms of each other.", slowest, cluster.SlowestMs, 2*cluster.SlowestMs, clusterLatencyWarnMs),
+		)
+	case cluster.SlowestMs >= clusterLatencyWarnMs:
+		cluster.Latency = "fair"
+		recommendations = append(recommendations,
+			fmt.Sprintf("Cluster peer %s is %.0f ms away, adding about %.0f ms to every duty. Attestations stay timely, but leave little margin for a slow block or a round change.", slowest, cluster.SlowestMs, 2*cluster.SlowestMs),
+		)
+	default:
+		cluster.Latency = "good"
+	}
+	return cluster, recommendations
+}
@@ -0,0 +1,236 @@
+package report
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// EIP712AttestationSchema names the signed summary format, versioned like
+// the hardware profile. The typed data itself is versioned by its domain.
+const (
+	EIP712AttestationSchema  = "ethbench/eip712-attestation"
+	EIP712AttestationVersion = 1
+)
+
+// eip712DomainName and eip712DomainVersion identify ethbench attestations
+// to a registry; the version changes with the message type
+const (
+	eip712DomainName    = "ethbench"
+	eip712DomainVersion = "1"
+)
+
+// eip712PrimaryType is the signed message type
+const eip712PrimaryType = "BenchmarkAttestation"
+
+// eip712MessageType lists the fields of the signed message in encoding
+// order. Scores are 0-100; hardwareHash commits to the attested hardware.
+var eip712MessageType = []apitypes.Type{
+	{Name: "ethbenchVersion", Type: "string"},
+	{Name: "workloadVersion", Type: "string"},
+	{Name: "scoringProfile", Type: "string"},
+	{Name: "timestamp", Type: "uint64"},
+	{Name: "totalScore", Type: "uint8"},
+	{Name: "cpuScore", Type: "uint8"},
+	{Name: "memoryScore", Type: "uint8"},
+	{Name: "diskScore", Type: "uint8"},
+	{Name: "partial", Type: "bool"},
+	{Name: "hardwareHash", Type: "bytes32"},
+}
+
+// EIP712Attestation is the report summary as EIP-712 typed data, for
+// registries of node hardware claims. Without a key it is left for a wallet
+// to sign with eth_signTypedData_v4; with one, Signer and Signature are set.
+type EIP712Attestation struct {
+	Schema        string `json:"schema"`
+	SchemaVersion int    `json:"schema_version"`
+	// TypedData is the eth_signTypedData_v4 payload
+	TypedData EIP712TypedData `json:"typed_data"`
+	// Digest is the EIP-712 hash the signature is made over
+	Digest string `json:"digest"`
+	// Hardware is what the message's hardwareHash commits to: the keccak256
+	// of this object's compact JSON, with the keys in the order written
+	Hardware  AttestedHardware `json:"hardware"`
+	Signer    string           `json:"signer,omitempty"`
+	Signature string           `json:"signature,omitempty"`
+}
+
+// EIP712TypedData is typed data in the JSON layout wallets take, leaving
+// out the domain fields that are not set
+type EIP712TypedData struct {
+	Types       apitypes.Types `json:"types"`
+	PrimaryType string         `json:"primaryType"`
+	Domain      EIP712Domain   `json:"domain"`
+	Message     map[string]any `json:"message"`
+}
+
+// EIP712Domain separates ethbench attestations from other typed data. A
+// registry contract verifying signatures on-chain fixes the chain ID and
+// its own address.
+type EIP712Domain struct {
+	Name              string `json:"name"`
+	Version           string `json:"version"`
+	ChainID           int64  `json:"chainId,omitempty"`
+	VerifyingContract string `json:"verifyingContract,omitempty"`
+}
+
+// AttestedHardware identifies the benchmarked machine without its hostname
+// or serial numbers
+type AttestedHardware struct {
+	CPUModel     string `json:"cpu_model"`
+	Architecture string `json:"architecture"`
+	CPUCores     int    `json:"cpu_cores"`
+	RAMMB        int    `json:"ram_mb"`
+	DiskModel    string `json:"disk_model"`
+	DiskType     string `json:"disk_type"`
+	Board        string `json:"board,omitempty"`
+}
+
+// NewEIP712Domain checks and builds the domain for a chain ID (0 to leave it
+// out) and an optional registry contract address
+func NewEIP712Domain(chainID int64, contract string) (EIP712Domain, error) {
+	domain := EIP712Domain{Name: eip712DomainName, Version: eip712DomainVersion}
+	if chainID < 0 {
+		return domain, fmt.Errorf("chain ID %d is negative", chainID)
+	}
+	domain.ChainID = chainID
+	if contract != "" {
+		if !common.IsHexAddress(contract) {
+			return domain, fmt.Errorf("%q is not a contract address", contract)
+		}
+		domain.VerifyingContract = common.HexToAddress(contract).Hex()
+	}
+	return domain, nil
+}
+
+// LoadSigningKey reads a secp256k1 private key in hex, as wallets export
+// it, from a file
+func LoadSigningKey(path string) (*ecdsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("%s does not hold a hex private key: %w", path, err)
+	}
+	return key, nil
+}
+
+// NewEIP712Attestation builds the typed data of the report summary and,
+// when key is given, signs it
+func NewEIP712Attestation(r *Report, domain EIP712Domain, key *ecdsa.PrivateKey) (*EIP712Attestation, error) {
+	a := &EIP712Attestation{
+		Schema:        EIP712AttestationSchema,
+		SchemaVersion: EIP712AttestationVersion,
+	}
+	if s := r.System; s != nil {
+		a.Hardware = AttestedHardware{
+			CPUModel:     s.CPUModel,
+			Architecture: s.Architecture,
+			CPUCores:     s.CPUCores,
+			RAMMB:        s.RAMTotalMB,
+			DiskModel:    s.DiskModel,
+			DiskType:     s.DiskType,
+			Board:        s.RPiModel,
+		}
+	}
+	// Without HTML escaping the hash matches JSON.stringify of the object
+	var hardware bytes.Buffer
+	enc := json.NewEncoder(&hardware)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(a.Hardware); err != nil {
+		return nil, fmt.Errorf("failed to marshal attested hardware: %w", err)
+	}
+
+	domainType := []apitypes.Type{
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+	}
+	if domain.ChainID > 0 {
+		domainType = append(domainType, apitypes.Type{Name: "chainId", Type: "uint256"})
+	}
+	if domain.VerifyingContract != "" {
+		domainType = append(domainType, apitypes.Type{Name: "verifyingContract", Type: "address"})
+	}
+	a.TypedData = EIP712TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain":    domainType,
+			eip712PrimaryType: eip712MessageType,
+		},
+		PrimaryType: eip712PrimaryType,
+		Domain:      domain,
+		Message: map[string]any{
+			"ethbenchVersion": r.Metadata.Version,
+			"workloadVersion": r.Metadata.WorkloadVersion,
+			"scoringProfile":  r.Metadata.ScoringProfile,
+			"timestamp":       big.NewInt(r.Metadata.Timestamp.Unix()),
+			"totalScore":      big.NewInt(int64(r.Summary.TotalScore)),
+			"cpuScore":        big.NewInt(int64(r.Summary.CPUScore)),
+			"memoryScore":     big.NewInt(int64(r.Summary.MemoryScore)),
+			"diskScore":       big.NewInt(int64(r.Summary.DiskScore)),
+			"partial":         r.Summary.Partial || r.Metadata.Incomplete,
+			"hardwareHash":    hexutil.Encode(crypto.Keccak256(bytes.TrimSuffix(hardware.Bytes(), []byte("\n")))),
+		},
+	}
+
+	typed := apitypes.TypedData{
+		Types:       a.TypedData.Types,
+		PrimaryType: eip712PrimaryType,
+		Domain: apitypes.TypedDataDomain{
+			Name:              domain.Name,
+			Version:           domain.Version,
+			VerifyingContract: domain.VerifyingContract,
+		},
+		Message: a.TypedData.Message,
+	}
+	if domain.ChainID > 0 {
+		typed.Domain.ChainId = math.NewHexOrDecimal256(domain.ChainID)
+	}
+	digest, _, err := apitypes.TypedDataAndHash(typed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash typed data: %w", err)
+	}
+	a.Digest = hexutil.Encode(digest)
+
+	if key != nil {
+		sig, err := crypto.Sign(digest, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign attestation: %w", err)
+		}
+		// Wallets and ecrecover take v as 27 or 28
+		sig[crypto.RecoveryIDOffset] += 27
+		a.Signer = crypto.PubkeyToAddress(key.PublicKey).Hex()
+		a.Signature = hexutil.Encode(sig)
+	}
+	return a, nil
+}
+
+// SaveEIP712Attestation saves the attestation as a JSON file with timestamp
+// in filename
+func SaveEIP712Attestation(a *EIP712Attestation, outputDir string) (string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal attestation: %w", err)
+	}
+	path := filepath.Join(outputDir, fmt.Sprintf("ethbench-eip712-%s.json", time.Now().Format("2006-01-02_15-04-05")))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write attestation: %w", err)
+	}
+	return path, nil
+}
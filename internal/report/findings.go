@@ -0,0 +1,174 @@
+package report
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/vBenchmark/internal/system"
+	"github.com/vBenchmark/internal/types"
+)
+
+// Finding is a specific hardware misconfiguration detected from system
+// details and measured results
+type Finding struct {
+	Severity  string `json:"severity"` // "warning" or "info"
+	Component string `json:"component"`
+	Message   string `json:"message"`
+}
+
+// detectMisconfigurations compares measured results against what the
+// detected components should deliver and explains likely causes
+func detectMisconfigurations(sys *system.Info, results *types.Results) []Finding {
+	var findings []Finding
+	if sys == nil {
+		return findings
+	}
+	isPi5 := strings.Contains(sys.RPiModel, "Raspberry Pi 5")
+
+	// CPU governor and frequency caps
+	if sys.CPUGovernor == "powersave" {
+		findings = append(findings, Finding{"warning", "cpu",
+			fmt.Sprintf("CPU locked at %d MHz — powersave governor active. Set the governor to \"performance\" or \"ondemand\".", sys.CPUFreqMHz)})
+	}
+	if sys.CPUMaxFreqMHz > 0 && sys.CPUScalingMaxMHz > 0 && sys.CPUScalingMaxMHz < sys.CPUMaxFreqMHz {
+		findings = append(findings, Finding{"warning", "cpu",
+			fmt.Sprintf("CPU frequency capped at %d MHz of %d MHz — check scaling_max_freq and arm_freq in config.txt.", sys.CPUScalingMaxMHz, sys.CPUMaxFreqMHz)})
+	}
+
+	// Under-voltage and throttling reported by the firmware
+	if flags, err := strconv.ParseUint(strings.TrimPrefix(sys.ThrottledFlags, "0x"), 16, 32); err == nil && flags != 0 {
+		if flags&0x10001 != 0 {
+			findings = append(findings, Finding{"warning", "power",
+				"Under-voltage detected — use the official 27W USB-C power supply and a short, thick cable."})
+		}
+		if flags&0xE000E != 0 {
+			findings = append(findings, Finding{"warning", "thermal",
+				"CPU frequency capping or throttling occurred — improve cooling (active cooler or fan case)."})
+		}
+	}
+
+	// PCIe link negotiated for NVMe
+	if sys.PCIeLinkSpeed != "" {
+		gen := pcieGeneration(sys.PCIeLinkSpeed)
+		maxGen := pcieGeneration(sys.PCIeMaxLinkSpeed)
+		switch {
+		case gen == 1:
+			findings = append(findings, Finding{"warning", "storage",
+				fmt.Sprintf("NVMe negotiated at PCIe gen1 (%s) — check config.txt (dtparam=pciex1) and the FPC ribbon cable.", sys.PCIeLinkSpeed)})
+		case isPi5 && gen == 2:
+			findings = append(findings, Finding{"info", "storage",
+				"NVMe running at PCIe gen2. dtparam=pciex1_gen=3 in config.txt can nearly double throughput (not officially certified)."})
+		case maxGen > gen:
+			findings = append(findings, Finding{"warning", "storage",
+				fmt.Sprintf("NVMe negotiated at PCIe gen%d but supports gen%d — check slot, risers and BIOS/firmware settings.", gen, maxGen)})
+		}
+	}
+
+	// USB storage plugged into a USB 2.0 port
+	if sys.USBStorageSpeedMb > 0 && sys.USBStorageSpeedMb <= 480 {
+		findings = append(findings, Finding{"warning", "storage",
+			fmt.Sprintf("USB storage connected at %d Mbps (USB 2.0) — use a blue USB 3.0 port and a UASP-capable enclosure.", sys.USBStorageSpeedMb)})
+	}
+
+	// Measured storage speed far below what the device type delivers
+	readSpeed := results.Disk.Sequential.ReadSpeedMBps
+	switch sys.DiskType {
+	case "nvme":
+		if readSpeed > 0 && readSpeed < 150 {
+			findings = append(findings, Finding{"warning", "storage",
+				fmt.Sprintf("NVMe sequential reads of %.0f MB/s are far below expected — check PCIe link, HAT and drive power.", readSpeed)})
+		}
+	case "sd":
+		if readSpeed > 0 && readSpeed < 40 && isPi5 {
+			findings = append(findings, Finding{"warning", "storage",
+				fmt.Sprintf("SD card reads of %.0f MB/s — the card is not running in SDR104 mode or is a low-grade card (use an A2-rated card).", readSpeed)})
+		}
+	}
+
+	// Validator traffic over Wi-Fi, or a degraded wired link
+	if link := sys.Network; link != nil {
+		probe := link.Probe
+		switch {
+		case link.Type == "wifi":
+			evidence := "latency not measured"
+			if probe != nil && probe.Received > 1 {
+				evidence = fmt.Sprintf("gateway jitter %.1f ms, peak %.1f ms, %.0f%% lost", probe.JitterMs, probe.MaxRTTMs, probe.LossPercent)
+			}
+			if link.WiFi != nil && link.WiFi.SignalDBm != 0 {
+				evidence += fmt.Sprintf(", signal %d dBm", link.WiFi.SignalDBm)
+			}
+			findings = append(findings, Finding{"warning", "network",
+				fmt.Sprintf("Node traffic runs over Wi-Fi (%s: %s) — latency spikes and dropouts cause late attestations and missed proposals. Use wired Ethernet.", link.Interface, evidence)})
+			for _, nic := range sys.NetworkInterfaces {
+				if nic.Type == "ethernet" && nic.State == "up" && nic.Master == "" && !link.Pinned {
+					findings = append(findings, Finding{"warning", "network",
+						fmt.Sprintf("%s is connected, but the default route uses %s — give %s the lower route metric, or disable Wi-Fi.", nic.Name, link.Interface, nic.Name)})
+					break
+				}
+			}
+		case link.Type == "ethernet" && link.SpeedMbps > 0 && link.SpeedMbps < 1000:
+			findings = append(findings, Finding{"info", "network",
+				fmt.Sprintf("Ethernet negotiated at %d Mbps — check the cable (Cat5e or better) and the switch port.", link.SpeedMbps)})
+		case link.Type == "ethernet" && probe != nil && probe.Received > 1 && probe.JitterMs >= 5:
+			findings = append(findings, Finding{"warning", "network",
+				fmt.Sprintf("Wired link shows %.1f ms jitter to the gateway (peak %.1f ms) — check for powerline adapters, a congested switch or a busy router.", probe.JitterMs, probe.MaxRTTMs)})
+		}
+
+		// Loss on the way out, and an MTU the upstream path cannot carry
+		for _, path := range link.Paths {
+			if echo := path.Echo; echo != nil && echo.Received > 0 && echo.LossPercent >= 3 {
+				findings = append(findings, Finding{"warning", "network",
+					fmt.Sprintf("%.0f%% of echoes to %s were lost — gossip retransmits and peers drop a lossy node, so blocks and attestations arrive late. Check the uplink and router load.", echo.LossPercent, path.Endpoint)})
+				break
+			}
+		}
+		for _, path := range link.Paths {
+			if path.Reduced() {
+				findings = append(findings, Finding{"info", "network",
+					fmt.Sprintf("Path MTU to %s is %d, below the %d of %s (PPPoE or a tunnel) — make sure the router clamps the TCP MSS, or large devp2p and libp2p packets stall.", path.Endpoint, path.MTU, path.InterfaceMTU, link.Interface)})
+				break
+			}
+		}
+	}
+
+	// One core saturated handling the storage interrupts
+	var worst *types.PhaseTiming
+	for i, p := range results.Timeline {
+		if p.IRQ != nil && p.IRQ.Saturated && (worst == nil || p.IRQ.BusiestCPUPercent > worst.IRQ.BusiestCPUPercent) {
+			worst = &results.Timeline[i]
+		}
+	}
+	if worst != nil {
+		irq := worst.IRQ
+		message := fmt.Sprintf("CPU %d spent %.0f%% of %s handling interrupts, capping I/O throughput", irq.BusiestCPU, irq.BusiestCPUPercent, worst.Name)
+		if irq.TopSource != "" {
+			message += fmt.Sprintf(" (mostly %s on CPU %d)", irq.TopSource, irq.TopSourceCPU)
+		}
+		if irq.TopSourceIRQ != "" {
+			message += fmt.Sprintf(" — spread interrupts with irqbalance or /proc/irq/%s/smp_affinity.", irq.TopSourceIRQ)
+		} else {
+			message += " — spread interrupts with irqbalance."
+		}
+		findings = append(findings, Finding{"warning", "storage", message})
+	}
+
+	return findings
+}
+
+// pcieGeneration maps a link speed like "5.0 GT/s PCIe" to its PCIe generation
+func pcieGeneration(speed string) int {
+	switch {
+	case strings.HasPrefix(speed, "2.5"):
+		return 1
+	case strings.HasPrefix(speed, "5.0"):
+		return 2
+	case strings.HasPrefix(speed, "8.0"):
+		return 3
+	case strings.HasPrefix(speed, "16.0"):
+		return 4
+	case strings.HasPrefix(speed, "32.0"):
+		return 5
+	}
+	return 0
+}
@@ -0,0 +1,288 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FormatHTML generates a self-contained single-file HTML report. Charts are
+// inline SVG so the file opens in any browser without network access.
+func FormatHTML(r *Report) (string, error) {
+	data := htmlData{
+		R:     r,
+		Radar: template.HTML(radarChart(scoreAxes(r))),
+	}
+	for _, s := range reportSections(r) {
+		data.Sections = append(data.Sections, htmlSection{
+			section: s,
+			Chart:   template.HTML(barChart(s.Components)),
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// SaveHTML saves the report as an HTML file with timestamp in filename
+func SaveHTML(r *Report, outputDir string) (string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	timestamp := time.Now().Format("2006-01-02_15-04-05")
+	path := filepath.Join(outputDir, fmt.Sprintf("ethbench-%s.html", timestamp))
+
+	html, err := FormatHTML(r)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(html), 0644); err != nil {
+		return "", fmt.Errorf("failed to write HTML report: %w", err)
+	}
+	return path, nil
+}
+
+type htmlData struct {
+	R        *Report
+	Radar    template.HTML
+	Sections []htmlSection
+}
+
+type htmlSection struct {
+	section
+	Chart template.HTML
+}
+
+// scoreAxes returns the radar chart axes: the category scores followed by
+// any fork pack scores
+func scoreAxes(r *Report) []scoreComponent {
+	axes := []scoreComponent{
+		{"CPU", float64(r.Summary.CPUScore), 0, true},
+		{"Memory", float64(r.Summary.MemoryScore), 0, true},
+		{"Disk", float64(r.Summary.DiskScore), 0, true},
+	}
+	for _, s := range reportSections(r) {
+		if s.Score < 0 {
+			for _, c := range s.Components {
+				if c.ok {
+					axes = append(axes, c)
+				}
+			}
+		}
+	}
+	return axes
+}
+
+// scoreColor maps a 0-100 score to the verdict colour bands
+func scoreColor(score float64) string {
+	switch {
+	case score >= 80:
+		return "#2e9d4f"
+	case score >= 60:
+		return "#d9a21b"
+	case score >= 40:
+		return "#e0702a"
+	default:
+		return "#c9372c"
+	}
+}
+
+// radarChart draws the scores as an SVG radar chart with rings at 25/50/75/100
+func radarChart(axes []scoreComponent) string {
+	const size, center, radius = 380.0, 190.0, 100.0
+	n := len(axes)
+	point := func(i int, value float64) (float64, float64) {
+		angle := -math.Pi/2 + 2*math.Pi*float64(i)/float64(n)
+		return center + radius*value/100*math.Cos(angle), center + radius*value/100*math.Sin(angle)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<svg class="radar" viewBox="0 0 %.0f %.0f" width="%.0f" height="%.0f" role="img" aria-label="Score radar chart">`, size, size, size, size))
+	for _, ring := range []float64{25, 50, 75, 100} {
+		pts := make([]string, n)
+		for i := range axes {
+			x, y := point(i, ring)
+			pts[i] = fmt.Sprintf("%.1f,%.1f", x, y)
+		}
+		sb.WriteString(fmt.Sprintf(`<polygon points="%s" fill="none" stroke="#d0d4da"/>`, strings.Join(pts, " ")))
+	}
+
+	pts := make([]string, n)
+	for i, axis := range axes {
+		x, y := point(i, 100)
+		sb.WriteString(fmt.Sprintf(`<line x1="%.0f" y1="%.0f" x2="%.1f" y2="%.1f" stroke="#d0d4da"/>`, center, center, x, y))
+		lx, ly := point(i, 122)
+		anchor := "middle"
+		switch {
+		case lx < center-5:
+			anchor = "end"
+		case lx > center+5:
+			anchor = "start"
+		}
+		sb.WriteString(fmt.Sprintf(`<text x="%.1f" y="%.1f" text-anchor="%s" dominant-baseline="middle">%s %.0f</text>`,
+			lx, ly, anchor, template.HTMLEscapeString(axis.label), axis.score))
+		px, py := point(i, axis.score)
+		pts[i] = fmt.Sprintf("%.1f,%.1f", px, py)
+	}
+	sb.WriteString(fmt.Sprintf(`<polygon points="%s" fill="#3b6fd6" fill-opacity="0.35" stroke="#3b6fd6" stroke-width="2"/>`, strings.Join(pts, " ")))
+	sb.WriteString(`</svg>`)
+	return sb.String()
+}
+
+// barChart draws one horizontal 0-100 bar per scored benchmark
+func barChart(components []scoreComponent) string {
+	const labelWidth, barWidth, rowHeight = 110.0, 240.0, 26.0
+	width := labelWidth + barWidth + 50
+	height := rowHeight*float64(len(components)) + 4
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<svg class="bars" viewBox="0 0 %.0f %.0f" width="%.0f" height="%.0f" role="img" aria-label="Benchmark scores">`, width, height, width, height))
+	for i, c := range components {
+		y := float64(i)*rowHeight + 2
+		label := template.HTMLEscapeString(c.label)
+		sb.WriteString(fmt.Sprintf(`<text x="%.0f" y="%.1f" text-anchor="end" dominant-baseline="middle">%s</text>`, labelWidth-8, y+rowHeight/2-2, label))
+		sb.WriteString(fmt.Sprintf(`<rect x="%.0f" y="%.1f" width="%.0f" height="%.0f" fill="#eef0f3" rx="3"/>`, labelWidth, y, barWidth, rowHeight-6))
+		if !c.ok {
+			sb.WriteString(fmt.Sprintf(`<text x="%.0f" y="%.1f" dominant-baseline="middle" fill="#888">not scored</text>`, labelWidth+6, y+rowHeight/2-2))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf(`<rect x="%.0f" y="%.1f" width="%.1f" height="%.0f" fill="%s" rx="3"/>`, labelWidth, y, barWidth*c.score/100, rowHeight-6, scoreColor(c.score)))
+		sb.WriteString(fmt.Sprintf(`<text x="%.0f" y="%.1f" dominant-baseline="middle">%.0f</text>`, labelWidth+barWidth+6, y+rowHeight/2-2, c.score))
+	}
+	sb.WriteString(`</svg>`)
+	return sb.String()
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"color": func(score int) template.CSS { return template.CSS(scoreColor(float64(score))) },
+	"date":  func(t time.Time) string { return t.Format("2006-01-02 15:04:05") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>ethbench report - {{.R.System.Hostname}} - {{date .R.Metadata.Timestamp}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Roboto, sans-serif; margin: 0; background: #f6f7f9; color: #1f2328; }
+main { max-width: 960px; margin: 0 auto; padding: 24px; }
+h1 { margin-bottom: 4px; }
+h2 { border-bottom: 2px solid #d0d4da; padding-bottom: 4px; margin-top: 32px; }
+.muted { color: #667; }
+.card { background: #fff; border-radius: 8px; padding: 16px 20px; margin: 12px 0; box-shadow: 0 1px 2px rgba(0,0,0,.08); }
+.summary { display: flex; flex-wrap: wrap; gap: 24px; align-items: center; }
+.score { font-size: 48px; font-weight: 700; }
+.badge { display: inline-block; padding: 2px 10px; border-radius: 12px; color: #fff; font-weight: 600; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 6px 8px; border-bottom: 1px solid #eef0f3; }
+th { color: #667; font-weight: 600; }
+td.error { color: #c9372c; }
+svg text { font-size: 12px; fill: #1f2328; }
+.warning { color: #c9372c; }
+</style>
+</head>
+<body>
+<main>
+<h1>Ethereum Node Benchmark Report</h1>
+<div class="muted">Generated {{date .R.Metadata.Timestamp}} by ethbench {{.R.Metadata.Version}} (workload {{.R.Metadata.WorkloadVersion}}, scoring {{.R.Metadata.ScoringProfile}}) in {{.R.Metadata.Duration}}</div>
+
+<h2>Summary</h2>
+<div class="card summary">
+  {{.Radar}}
+  <div>
+    <div class="score" style="color: {{color .R.Summary.TotalScore}}">{{.R.Summary.TotalScore}}/100</div>
+    <div>CPU {{.R.Summary.CPUScore}} &middot; Memory {{.R.Summary.MemoryScore}} &middot; Disk {{.R.Summary.DiskScore}}</div>
+    {{- if .R.Summary.Partial}}
+    <div class="warning">Partial: failed or skipped benchmarks excluded from scoring</div>
+    {{- end}}
+    <p>Execution client: <strong>{{.R.Verdict.ExecutionClient}}</strong><br>
+    Consensus client: <strong>{{.R.Verdict.ConsensusClient}}</strong></p>
+  </div>
+</div>
+{{- if .R.Verdict.Recommendations}}
+<div class="card">
+  <strong>Recommendations</strong>
+  <ul>
+  {{- range .R.Verdict.Recommendations}}
+    <li>{{.}}</li>
+  {{- end}}
+  </ul>
+</div>
+{{- end}}
+{{- if .R.Findings}}
+<div class="card">
+  <strong>Configuration Findings</strong>
+  <ul>
+  {{- range .R.Findings}}
+    <li><strong>{{.Component}}</strong> ({{.Severity}}): {{.Message}}</li>
+  {{- end}}
+  </ul>
+</div>
+{{- end}}
+
+<h2>System</h2>
+<div class="card">
+<table>
+  <tr><th>Hostname</th><td>{{.R.System.Hostname}}</td></tr>
+  {{- if .R.System.Fingerprint}}
+  <tr><th>Fingerprint</th><td>{{.R.System.Fingerprint}}</td></tr>
+  {{- end}}
+  <tr><th>OS</th><td>{{.R.System.OS}} {{.R.System.OSVersion}} ({{.R.System.Architecture}})</td></tr>
+  <tr><th>CPU</th><td>{{.R.System.CPUModel}} ({{.R.System.CPUCores}} cores)</td></tr>
+  <tr><th>RAM</th><td>{{.R.System.RAMTotalMB}} MB</td></tr>
+  <tr><th>Storage</th><td>{{.R.System.DiskModel}}</td></tr>
+  {{- if .R.System.RPiModel}}
+  <tr><th>Model</th><td>{{.R.System.RPiModel}}</td></tr>
+  {{- end}}
+  {{- if .R.System.KernelVersion}}
+  <tr><th>Kernel</th><td>{{.R.System.KernelVersion}}</td></tr>
+  {{- end}}
+</table>
+</div>
+{{- with .R.Thermal}}
+<div class="card">
+  <strong>Thermal Stability</strong>:
+  {{if .Stable}}stable{{else}}<span class="warning">throttling occurred, scores are affected</span>{{end}}
+  &middot; max {{printf "%.1f" .MaxTemperatureC}}&deg;C &middot; {{.ThrottleEvents}} throttle events, {{.FreqDrops}} frequency drops
+</div>
+{{- end}}
+{{range .Sections}}
+<h2>{{.Title}}{{if ge .Score 0}} <span class="badge" style="background: {{color .Score}}">{{.Score}}/100</span>{{end}}</h2>
+<div class="card">
+  {{- if .Components}}
+  {{.Chart}}
+  {{- end}}
+  <table>
+    <tr><th>Benchmark</th><th>Result</th><th>Rating</th></tr>
+    {{- range .Rows}}
+    {{- if .Error}}
+    <tr><td>{{.Name}}</td><td class="error" colspan="2">{{.Error}}</td></tr>
+    {{- else}}
+    <tr><td>{{.Name}}</td><td>{{.Value}}</td><td>{{.Rating}}</td></tr>
+    {{- end}}
+    {{- end}}
+  </table>
+</div>
+{{end}}
+{{- if .R.Errors}}
+<h2>Errors</h2>
+<div class="card">
+  <table>
+  {{- range .R.Errors}}
+    <tr><td>{{.Benchmark}}</td><td class="error">{{.Error}}</td></tr>
+  {{- end}}
+  </table>
+</div>
+{{- end}}
+</main>
+</body>
+</html>
+`))
@@ -0,0 +1,190 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vBenchmark/internal/system"
+)
+
+// HardwareProfileSchema names the hardware profile format. Its version is
+// kept apart from the report schema: fields are only added within a version,
+// and a version bump means a field was renamed, removed or changed meaning.
+const (
+	HardwareProfileSchema  = "ethbench/hardware-profile"
+	HardwareProfileVersion = 1
+)
+
+// HardwareProfile is a compact summary of the measured machine for clients
+// to derive defaults from, such as cache sizes, verification parallelism and
+// database sync settings. Zero or missing values were not measured.
+type HardwareProfile struct {
+	Schema          string         `json:"schema"`
+	SchemaVersion   int            `json:"schema_version"`
+	EthbenchVersion string         `json:"ethbench_version"`
+	Timestamp       time.Time      `json:"timestamp"`
+	CPU             ProfileCPU     `json:"cpu"`
+	Memory          ProfileMemory  `json:"memory"`
+	Storage         ProfileStorage `json:"storage"`
+	Crypto          ProfileCrypto  `json:"crypto"`
+}
+
+// ProfileCPU describes the processor and the clock it sustained under load
+type ProfileCPU struct {
+	Model        string `json:"model"`
+	Architecture string `json:"architecture"`
+	Cores        int    `json:"cores"`
+	MaxFreqMHz   int    `json:"max_freq_mhz,omitempty"`
+	// SustainedFreqMHz is the lowest clock seen during the run; below
+	// MaxFreqMHz when the CPU throttled
+	SustainedFreqMHz int      `json:"sustained_freq_mhz,omitempty"`
+	Throttled        bool     `json:"throttled"`
+	Features         []string `json:"features,omitempty"`
+	// ParallelSpeedup is the all-core over single-core throughput ratio
+	ParallelSpeedup float64 `json:"parallel_speedup,omitempty"`
+}
+
+// ProfileMemory describes RAM and swap
+type ProfileMemory struct {
+	TotalMB       int     `json:"total_mb"`
+	SwapMB        int     `json:"swap_mb"`
+	Zram          bool    `json:"zram"`
+	DRAMLatencyNs float64 `json:"dram_latency_ns,omitempty"`
+}
+
+// ProfileStorage describes the disk the benchmarks ran on
+type ProfileStorage struct {
+	Type         string  `json:"type"` // "nvme", "sd", "scsi"
+	Filesystem   string  `json:"filesystem,omitempty"`
+	SeqReadMBps  float64 `json:"seq_read_mbps,omitempty"`
+	SeqWriteMBps float64 `json:"seq_write_mbps,omitempty"`
+	// Random 4K IOPS at QD1, and reads by queue depth when swept
+	RandReadIOPS     float64         `json:"rand_read_iops,omitempty"`
+	RandWriteIOPS    float64         `json:"rand_write_iops,omitempty"`
+	RandReadIOPSByQD map[int]float64 `json:"rand_read_iops_by_qd,omitempty"`
+	FsyncP50Ms       float64         `json:"fsync_p50_ms,omitempty"`
+	FsyncP99Ms       float64         `json:"fsync_p99_ms,omitempty"`
+	BatchP99Ms       float64         `json:"batch_p99_ms,omitempty"`
+	BestSyncMode     string          `json:"best_sync_mode,omitempty"`
+}
+
+// ProfileCrypto holds single-core verification rates, which bound block
+// and attestation processing
+type ProfileCrypto struct {
+	KeccakHashesPerSec float64 `json:"keccak_hashes_per_sec,omitempty"`
+	ECDSARecoverPerSec float64 `json:"ecdsa_recover_per_sec,omitempty"`
+	BLSVerifyPerSec    float64 `json:"bls_verify_per_sec,omitempty"`
+	KZGVerifyPerSec    float64 `json:"kzg_verify_per_sec,omitempty"`
+}
+
+// NewHardwareProfile extracts the hardware profile from a report, leaving
+// out benchmarks that failed or were skipped
+func NewHardwareProfile(r *Report) *HardwareProfile {
+	p := &HardwareProfile{
+		Schema:          HardwareProfileSchema,
+		SchemaVersion:   HardwareProfileVersion,
+		EthbenchVersion: r.Metadata.Version,
+		Timestamp:       r.Metadata.Timestamp,
+	}
+	if s := r.System; s != nil {
+		p.CPU = ProfileCPU{
+			Model:        s.CPUModel,
+			Architecture: s.Architecture,
+			Cores:        s.CPUCores,
+			MaxFreqMHz:   maxCoreFreqMHz(s),
+			Features:     s.CPUFeatures,
+		}
+		p.Memory.TotalMB = s.RAMTotalMB
+		for _, d := range s.Swap {
+			p.Memory.SwapMB += d.SizeMB
+			p.Memory.Zram = p.Memory.Zram || d.Zram
+		}
+		p.Storage.Type = s.DiskType
+	}
+	if t := r.Thermal; t != nil {
+		p.CPU.SustainedFreqMHz = t.MinFreqMHz
+		p.CPU.Throttled = !t.Stable
+	}
+	if par := r.CPU.Parallel; par.OK() && len(par.Operations) > 0 {
+		var speedup float64
+		for _, op := range par.Operations {
+			speedup += op.Speedup
+		}
+		p.CPU.ParallelSpeedup = speedup / float64(len(par.Operations))
+	}
+	if lat := r.Memory.Latency; lat.OK() {
+		p.Memory.DRAMLatencyNs = lat.DRAMLatencyNs
+	}
+
+	d := r.Disk
+	if d.Filesystem != nil {
+		p.Storage.Filesystem = d.Filesystem.Type
+	}
+	if d.Sequential.OK() {
+		p.Storage.SeqReadMBps = d.Sequential.ReadSpeedMBps
+		p.Storage.SeqWriteMBps = d.Sequential.WriteSpeedMBps
+	}
+	if d.Random.OK() {
+		p.Storage.RandReadIOPS = d.Random.ReadIOPS
+		p.Storage.RandWriteIOPS = d.Random.WriteIOPS
+		for _, qd := range d.Random.QueueDepths {
+			if p.Storage.RandReadIOPSByQD == nil {
+				p.Storage.RandReadIOPSByQD = make(map[int]float64)
+			}
+			p.Storage.RandReadIOPSByQD[qd.Depth] = qd.ReadIOPS
+		}
+	}
+	if d.Fsync.OK() {
+		p.Storage.FsyncP50Ms = d.Fsync.P50LatencyMs
+		p.Storage.FsyncP99Ms = d.Fsync.P99LatencyMs
+	}
+	if d.Batch.OK() {
+		p.Storage.BatchP99Ms = d.Batch.P99BatchLatencyMs
+		p.Storage.BestSyncMode = d.Batch.BestSyncMode
+	}
+
+	c := r.CPU
+	if c.Keccak.OK() {
+		p.Crypto.KeccakHashesPerSec = c.Keccak.HashesPerSecond
+	}
+	if c.ECDSA.OK() {
+		p.Crypto.ECDSARecoverPerSec = c.ECDSA.RecoveriesPerSecond
+	}
+	if c.BLS.OK() {
+		p.Crypto.BLSVerifyPerSec = c.BLS.VerificationsPerSecond
+	}
+	if c.KZG.OK() {
+		p.Crypto.KZGVerifyPerSec = c.KZG.VerificationsPerSecond
+	}
+	return p
+}
+
+// SaveHardwareProfile saves the report's hardware profile as a JSON file
+// with timestamp in filename
+func SaveHardwareProfile(r *Report, outputDir string) (string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+	data, err := json.MarshalIndent(NewHardwareProfile(r), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal hardware profile: %w", err)
+	}
+	path := filepath.Join(outputDir, fmt.Sprintf("ethbench-hwprofile-%s.json", time.Now().Format("2006-01-02_15-04-05")))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write hardware profile: %w", err)
+	}
+	return path, nil
+}
+
+// maxCoreFreqMHz is the fastest core's maximum; cpu0 is a LITTLE core on
+// most big.LITTLE boards
+func maxCoreFreqMHz(s *system.Info) int {
+	freq := max(s.CPUMaxFreqMHz, s.CPUFreqMHz)
+	for _, core := range s.CPUFrequencies {
+		freq = max(freq, core.MaxMHz)
+	}
+	return freq
+}
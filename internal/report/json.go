@@ -36,9 +36,22 @@ func SaveJSON(r *Report, outputDir string) (string, error) {
 	}
 
 	// Write to file
-	if err := os.WriteFile(filepath, data, 0644); err != nil {
+	if err := writeFileAtomic(filepath, data); err != nil {
 		return "", fmt.Errorf("failed to write report file: %w", err)
 	}
 
 	return filepath, nil
 }
+
+// LoadReport reads a report saved by SaveJSON
+func LoadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse report %s: %w", path, err)
+	}
+	return &r, nil
+}
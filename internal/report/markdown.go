@@ -0,0 +1,189 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// FormatMarkdown generates a table-based Markdown report for pasting into
+// GitHub issues or forum posts
+func FormatMarkdown(r *Report) string {
+	var sb strings.Builder
+
+	sb.WriteString("## Ethereum Node Benchmark Report\n\n")
+	sb.WriteString(fmt.Sprintf("Generated %s by ethbench %s (workload %s, scoring %s) in %s\n\n",
+		r.Metadata.Timestamp.Format("2006-01-02 15:04:05"), r.Metadata.Version, r.Metadata.WorkloadVersion, r.Metadata.ScoringProfile, r.Metadata.Duration))
+	if r.Metadata.Incomplete {
+		sb.WriteString("**Incomplete:** the run was interrupted; unfinished benchmarks are marked skipped.\n\n")
+	}
+	if r.Metadata.Offline {
+		sb.WriteString("**Offline:** network probes skipped, DNS and the default HTTP client blocked.\n\n")
+	}
+	if len(r.Metadata.Tags) > 0 {
+		sb.WriteString(fmt.Sprintf("**Tags:** %s\n\n", FormatTags(r.Metadata.Tags)))
+	}
+
+	// System
+	sb.WriteString("### System\n\n")
+	sb.WriteString("| | |\n|---|---|\n")
+	if r.System.RPiModel != "" {
+		sb.WriteString(fmt.Sprintf("| Model | %s |\n", mdEscape(r.System.RPiModel)))
+	}
+	sb.WriteString(fmt.Sprintf("| OS | %s %s (%s) |\n", mdEscape(r.System.OS), mdEscape(r.System.OSVersion), r.System.Architecture))
+	sb.WriteString(fmt.Sprintf("| CPU | %s (%d cores) |\n", mdEscape(r.System.CPUModel), r.System.CPUCores))
+	sb.WriteString(fmt.Sprintf("| RAM | %d MB |\n", r.System.RAMTotalMB))
+	sb.WriteString(fmt.Sprintf("| Storage | %s |\n", mdEscape(r.System.DiskModel)))
+	if r.System.KernelVersion != "" {
+		sb.WriteString(fmt.Sprintf("| Kernel | %s |\n", mdEscape(r.System.KernelVersion)))
+	}
+
+	// Scores
+	sb.WriteString("\n### Scores\n\n")
+	sb.WriteString("| Category | Score |\n|---|---:|\n")
+	sb.WriteString(fmt.Sprintf("| CPU | %s |\n", r.Summary.categoryScore("cpu", r.Summary.CPUScore)))
+	sb.WriteString(fmt.Sprintf("| Memory | %s |\n", r.Summary.categoryScore("memory", r.Summary.MemoryScore)))
+	sb.WriteString(fmt.Sprintf("| Disk | %s |\n", r.Summary.categoryScore("disk", r.Summary.DiskScore)))
+	sb.WriteString(fmt.Sprintf("| **Overall** | **%d/100** |\n", r.Summary.TotalScore))
+	for _, s := range reportSections(r) {
+		if s.Score >= 0 {
+			continue
+		}
+		for _, c := range s.Components {
+			if c.ok {
+				sb.WriteString(fmt.Sprintf("| %s (not in overall) | %.0f/100 |\n", c.label, c.score))
+			}
+		}
+	}
+	if r.Summary.Partial {
+		sb.WriteString("\n_Partial: failed or skipped benchmarks excluded from scoring._\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("\n**Execution client:** %s · **Consensus client:** %s\n", r.Verdict.ExecutionClient, r.Verdict.ConsensusClient))
+
+	// Baseline profile
+	if b := r.Baseline; b != nil {
+		result := "✅ pass"
+		if !b.Passed {
+			result = "❌ fail"
+		}
+		sb.WriteString(fmt.Sprintf("\n### Baseline: %s (%s)\n\n%s\n\n", b.Profile, result, mdEscape(b.Description)))
+		sb.WriteString("| Metric | Required | Measured | Result |\n|---|---|---:|---|\n")
+		for _, c := range b.Checks {
+			measured, result := "not run", "❌"
+			if c.Measured {
+				measured = fmt.Sprintf("%.2f", c.Value)
+			}
+			if c.Passed {
+				result = "✅"
+			}
+			sb.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s |\n", c.Metric, mdEscape(c.Required), measured, result))
+		}
+	}
+
+	// Reference comparison
+	if c := r.Reference; c != nil {
+		sb.WriteString(fmt.Sprintf("\n### Compared to %s\n\n%s\n\n", c.Profile, mdEscape(c.Description)))
+		sb.WriteString("| Metric | Measured | Reference | Yours |\n|---|---:|---:|---:|\n")
+		for _, m := range c.Metrics {
+			sb.WriteString(fmt.Sprintf("| `%s` | %.2f | %.2f | %.0f%% |\n", m.Metric, m.Value, m.Reference, m.Percent))
+		}
+	}
+
+	// Benchmark tables
+	for _, s := range reportSections(r) {
+		sb.WriteString(fmt.Sprintf("\n### %s\n\n", s.Title))
+		sb.WriteString("| Benchmark | Result | Rating |\n|---|---|---|\n")
+		for _, row := range s.Rows {
+			if row.Error != "" {
+				sb.WriteString(fmt.Sprintf("| %s | ⚠️ %s | |\n", row.Name, mdEscape(row.Error)))
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", row.Name, row.Value, row.Rating))
+		}
+	}
+
+	// Thermal
+	if r.Thermal != nil {
+		status := "stable"
+		if !r.Thermal.Stable {
+			status = "**throttled, scores are affected**"
+		}
+		sb.WriteString(fmt.Sprintf("\n**Thermal:** %s, max %.1f°C, %d throttle events, %d frequency drops\n",
+			status, r.Thermal.MaxTemperatureC, r.Thermal.ThrottleEvents, r.Thermal.FreqDrops))
+	}
+
+	// Suspend or governor change
+	if r.Sleep != nil && r.Sleep.Interrupted() {
+		sb.WriteString(fmt.Sprintf("\n**Power:** **interrupted during %s, results are affected** (%d suspends, %d governor changes)\n",
+			strings.Join(r.Sleep.Phases, ", "), r.Sleep.Suspends, len(r.Sleep.GovernorChanges)))
+	}
+
+	// Real client trial
+	if t := r.Trial; t != nil {
+		sb.WriteString(fmt.Sprintf("\n**Client trial:** %s on %s, %s, %.2f slots/sec, %.0f%% avg CPU, %.0f MB peak memory, %.0f MB/hour written\n",
+			t.Client, t.Network, trialHead(t), t.SlotsPerSecond, t.AvgCPUPercent, t.PeakMemoryMB, t.WriteMBPerHour))
+	}
+
+	// Recommendations and findings
+	if len(r.Verdict.Recommendations) > 0 || len(r.Findings) > 0 {
+		sb.WriteString("\n### Recommendations\n\n")
+		for _, rec := range r.Verdict.Recommendations {
+			sb.WriteString(fmt.Sprintf("- %s\n", mdEscape(rec)))
+		}
+		for _, f := range r.Findings {
+			sb.WriteString(fmt.Sprintf("- **%s** (%s): %s\n", f.Component, f.Severity, mdEscape(f.Message)))
+		}
+	}
+
+	// Repeated runs
+	if rs := r.RunStatistics; rs != nil {
+		sb.WriteString(fmt.Sprintf("\n### Run Statistics (%d runs)\n\n", rs.Runs))
+		sb.WriteString("| Metric | Mean | Median | CV | 95% CI |\n|---|---|---|---|---|\n")
+		for _, m := range append(slices.Clone(rs.Scores), rs.Unreliable()...) {
+			name := fmt.Sprintf("`%s`", m.Metric)
+			if m.Unreliable {
+				name += " ⚠️ unreliable"
+			}
+			sb.WriteString(fmt.Sprintf("| %s | %.2f | %.2f | %.1f%% | ± %.2f |\n", name, m.Mean, m.Median, m.CVPercent, m.CI95))
+		}
+	}
+
+	// Errors
+	if len(r.Errors) > 0 {
+		sb.WriteString("\n### Errors\n\n")
+		sb.WriteString("| Benchmark | Error |\n|---|---|\n")
+		for _, e := range r.Errors {
+			sb.WriteString(fmt.Sprintf("| `%s` | %s |\n", e.Benchmark, mdEscape(e.Error)))
+		}
+	}
+
+	return sb.String()
+}
+
+// SaveMarkdown saves the report as a Markdown file with timestamp in filename
+func SaveMarkdown(r *Report, outputDir string) (string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	timestamp := time.Now().Format("2006-01-02_15-04-05")
+	path := filepath.Join(outputDir, fmt.Sprintf("ethbench-%s.md", timestamp))
+
+	if err := os.WriteFile(path, []byte(FormatMarkdown(r)), 0644); err != nil {
+		return "", fmt.Errorf("failed to write Markdown report: %w", err)
+	}
+	return path, nil
+}
+
+// mdEscape keeps free text from breaking table cells or being read as markup
+var mdEscape = strings.NewReplacer(
+	"|", `\|`,
+	"\n", " ",
+	"*", `\*`,
+	"_", `\_`,
+	"<", "&lt;",
+).Replace
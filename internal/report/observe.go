@@ -0,0 +1,120 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vBenchmark/internal/system"
+	"github.com/vBenchmark/internal/types"
+)
+
+// lowHeadroomPercent is the headroom at peak below which a resource is
+// flagged; a client needs spare capacity for busy slots and resyncs
+const lowHeadroomPercent = 20
+
+// Headroom compares a running client's resource use with the capacity of
+// the machine it runs on
+type Headroom struct {
+	Usage     types.ProcessUsage
+	Resources []ResourceHeadroom
+	// Recommendations name the resources that are close to their limit
+	Recommendations []string
+}
+
+// ResourceHeadroom holds the use of one resource against its capacity
+type ResourceHeadroom struct {
+	Resource string
+	Unit     string
+	Average  float64
+	Peak     float64
+	Capacity float64
+	// Headroom is the unused share of capacity in percent, at the average
+	// and at the peak; negative when use exceeds the benchmarked capacity
+	AvgHeadroomPercent  float64
+	PeakHeadroomPercent float64
+}
+
+// ComputeHeadroom relates the observed usage to the detected CPU and RAM
+// and, when a benchmark report is given, to its measured disk throughput
+func ComputeHeadroom(usage types.ProcessUsage, sys *system.Info, disk *types.DiskResults) *Headroom {
+	h := &Headroom{Usage: usage}
+	add := func(resource, unit string, average, peak, capacity float64) {
+		if capacity <= 0 {
+			return
+		}
+		h.Resources = append(h.Resources, ResourceHeadroom{
+			Resource:            resource,
+			Unit:                unit,
+			Average:             average,
+			Peak:                peak,
+			Capacity:            capacity,
+			AvgHeadroomPercent:  (1 - average/capacity) * 100,
+			PeakHeadroomPercent: (1 - peak/capacity) * 100,
+		})
+	}
+	if sys != nil {
+		add("CPU", "%", usage.AvgCPUPercent, usage.PeakCPUPercent, float64(sys.CPUCores*100))
+		add("Memory", "MB", usage.AvgRSSMB, usage.PeakRSSMB, float64(sys.RAMTotalMB))
+	}
+	// Sequential throughput is the ceiling; client I/O is mostly random, so
+	// real headroom is lower than shown
+	if disk != nil && disk.Sequential.OK() {
+		add("Disk Read", "MB/s", usage.ReadMBps, usage.PeakReadMBps, disk.Sequential.ReadSpeedMBps)
+		add("Disk Write", "MB/s", usage.WriteMBps, usage.PeakWriteMBps, disk.Sequential.WriteSpeedMBps)
+	}
+	add("Open Files", "", float64(usage.PeakOpenFDs), float64(usage.PeakOpenFDs), float64(usage.MaxOpenFDs))
+
+	for _, r := range h.Resources {
+		if r.PeakHeadroomPercent >= lowHeadroomPercent {
+			continue
+		}
+		var advice string
+		switch r.Resource {
+		case "CPU":
+			advice = "block processing will be delayed during busy slots; stop other services or move to a faster CPU"
+		case "Memory":
+			advice = "the client risks being OOM-killed; lower its cache size or add RAM or zram swap"
+		case "Disk Read", "Disk Write":
+			advice = "storage is the bottleneck; compaction and resyncs will fall behind, consider a faster NVMe drive"
+		case "Open Files":
+			advice = "raise LimitNOFILE in the service unit before the client runs out of descriptors"
+		}
+		h.Recommendations = append(h.Recommendations,
+			fmt.Sprintf("%s peaks leave %.0f%% headroom: %s.", r.Resource, max(0, r.PeakHeadroomPercent), advice))
+	}
+	return h
+}
+
+// FormatHeadroom renders the observed usage and headroom per resource
+func FormatHeadroom(h *Headroom) string {
+	var sb strings.Builder
+	u := h.Usage
+
+	sb.WriteString(strings.Repeat("=", 80) + "\n")
+	sb.WriteString("                         RUNNING NODE HEADROOM\n")
+	sb.WriteString(strings.Repeat("=", 80) + "\n\n")
+	sb.WriteString(fmt.Sprintf("  Process:        %s (pid %d, %d threads)\n", u.Command, u.PID, u.Threads))
+	sb.WriteString(fmt.Sprintf("  Observed:       %s, %d samples\n", u.Duration.Round(time.Second), u.Samples))
+	sb.WriteString(fmt.Sprintf("  CPU:            %.0f%% average, %.0f%% peak (100%% = one core)\n", u.AvgCPUPercent, u.PeakCPUPercent))
+	sb.WriteString(fmt.Sprintf("  RSS:            %.0f MB average, %.0f MB peak\n", u.AvgRSSMB, u.PeakRSSMB))
+	sb.WriteString(fmt.Sprintf("  Disk Read:      %.2f MB/s average, %.2f MB/s peak\n", u.ReadMBps, u.PeakReadMBps))
+	sb.WriteString(fmt.Sprintf("  Disk Write:     %.2f MB/s average, %.2f MB/s peak\n", u.WriteMBps, u.PeakWriteMBps))
+	sb.WriteString(fmt.Sprintf("  Open Files:     %d peak, limit %d\n", u.PeakOpenFDs, u.MaxOpenFDs))
+
+	sb.WriteString(fmt.Sprintf("\n  %-12s %14s %14s %16s %16s\n", "Resource", "Peak", "Capacity", "Headroom (avg)", "Headroom (peak)"))
+	sb.WriteString("  " + strings.Repeat("-", 76) + "\n")
+	for _, r := range h.Resources {
+		sb.WriteString(fmt.Sprintf("  %-12s %14s %14s %15.0f%% %15.0f%%\n", r.Resource,
+			strings.TrimSpace(fmt.Sprintf("%.0f %s", r.Peak, r.Unit)), strings.TrimSpace(fmt.Sprintf("%.0f %s", r.Capacity, r.Unit)),
+			r.AvgHeadroomPercent, r.PeakHeadroomPercent))
+	}
+
+	if len(h.Recommendations) > 0 {
+		sb.WriteString("\nRecommendations:\n")
+		for _, rec := range h.Recommendations {
+			sb.WriteString(fmt.Sprintf("  - %s\n", rec))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,308 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OperatorReadinessSchema names the operator readiness format, versioned
+// like the hardware profile: fields are only added within a version
+const (
+	OperatorReadinessSchema  = "ethbench/operator-readiness"
+	OperatorReadinessVersion = 1
+)
+
+// OperatorReadiness summarizes a run in the terms distributed validator
+// onboarding checklists (Obol, SSV) ask for: hardware specs, network and
+// cluster latency, and measurements that stand in for expected uptime. It
+// leaves out the hostname, serial numbers and addresses other than the
+// cluster peers the operator listed.
+type OperatorReadiness struct {
+	Schema          string    `json:"schema"`
+	SchemaVersion   int       `json:"schema_version"`
+	EthbenchVersion string    `json:"ethbench_version"`
+	Timestamp       time.Time `json:"timestamp"`
+	ScoringProfile  string    `json:"scoring_profile"`
+	Score           int       `json:"score"`
+	// Ready is set when no check failed; warnings and unknowns are left to
+	// the reviewer
+	Ready    bool             `json:"ready"`
+	Checks   []OperatorCheck  `json:"checks"`
+	Hardware OperatorHardware `json:"hardware"`
+	Network  OperatorNetwork  `json:"network"`
+	Uptime   OperatorUptime   `json:"uptime"`
+}
+
+// OperatorCheck is one checklist item with its measured value
+type OperatorCheck struct {
+	ID          string `json:"id"`
+	Status      string `json:"status"` // "pass", "warn", "fail" or "unknown"
+	Value       string `json:"value"`
+	Requirement string `json:"requirement"`
+}
+
+// OperatorHardware lists the specs applications ask for
+type OperatorHardware struct {
+	CPUModel     string  `json:"cpu_model"`
+	Architecture string  `json:"architecture"`
+	CPUCores     int     `json:"cpu_cores"`
+	RAMMB        int     `json:"ram_mb"`
+	SwapMB       int     `json:"swap_mb"`
+	StorageType  string  `json:"storage_type"`
+	StorageModel string  `json:"storage_model,omitempty"`
+	DiskTotalGB  float64 `json:"disk_total_gb,omitempty"`
+	DiskFreeGB   float64 `json:"disk_free_gb,omitempty"`
+	RandReadIOPS float64 `json:"rand_read_iops,omitempty"`
+	BLSVerifyPS  float64 `json:"bls_verify_per_sec,omitempty"`
+	// MaxValidators is the dvt-operator estimate of the validators this
+	// node can verify partial signatures for
+	MaxValidators int `json:"max_validators,omitempty"`
+}
+
+// OperatorNetwork holds the link, its rating and the latency to the other
+// cluster nodes
+type OperatorNetwork struct {
+	LinkType        string         `json:"link_type,omitempty"`
+	LinkSpeedMbps   int            `json:"link_speed_mbps,omitempty"`
+	GatewayJitterMs float64        `json:"gateway_jitter_ms,omitempty"`
+	GatewayLossPct  float64        `json:"gateway_loss_percent,omitempty"`
+	Rating          string         `json:"rating,omitempty"`
+	RatingReasons   []string       `json:"rating_reasons,omitempty"`
+	ClusterPeers    []OperatorPeer `json:"cluster_peers,omitempty"`
+	ClusterLatency  string         `json:"cluster_latency,omitempty"`
+}
+
+// OperatorPeer is the latency to one cluster peer
+type OperatorPeer struct {
+	Peer      string  `json:"peer"`
+	Reachable bool    `json:"reachable"`
+	AvgMs     float64 `json:"avg_ms,omitempty"`
+	JitterMs  float64 `json:"jitter_ms,omitempty"`
+	MaxMs     float64 `json:"max_ms,omitempty"`
+}
+
+// OperatorUptime holds what the run shows about staying online: heat,
+// power, suspends and the state of the drive. Nil pointers were not
+// measured.
+type OperatorUptime struct {
+	ThermalStable    *bool   `json:"thermal_stable,omitempty"`
+	MaxTemperatureC  float64 `json:"max_temperature_c,omitempty"`
+	ThrottleEvents   int     `json:"throttle_events,omitempty"`
+	UnderVoltage     *bool   `json:"under_voltage,omitempty"`
+	SuspendedInRun   bool    `json:"suspended_during_run"`
+	DiskWearPercent  *int    `json:"disk_wear_percent,omitempty"`
+	DiskMediaErrors  int     `json:"disk_media_errors,omitempty"`
+	DiskCritical     bool    `json:"disk_critical_warning,omitempty"`
+	DiskPowerOnHours int     `json:"disk_power_on_hours,omitempty"`
+}
+
+// NewOperatorReadiness extracts the operator readiness summary from a
+// report and runs the checklist over it
+func NewOperatorReadiness(r *Report) *OperatorReadiness {
+	o := &OperatorReadiness{
+		Schema:          OperatorReadinessSchema,
+		SchemaVersion:   OperatorReadinessVersion,
+		EthbenchVersion: r.Metadata.Version,
+		Timestamp:       r.Metadata.Timestamp,
+		ScoringProfile:  r.Metadata.ScoringProfile,
+		Score:           r.Verdict.OverallScore,
+	}
+	h, n, u := &o.Hardware, &o.Network, &o.Uptime
+	if s := r.System; s != nil {
+		h.CPUModel = s.CPUModel
+		h.Architecture = s.Architecture
+		h.CPUCores = s.CPUCores
+		h.RAMMB = s.RAMTotalMB
+		for _, d := range s.Swap {
+			h.SwapMB += d.SizeMB
+		}
+		h.StorageType = s.DiskType
+		h.StorageModel = s.DiskModel
+
+		if link := s.Network; link != nil {
+			n.LinkType = link.Type
+			n.LinkSpeedMbps = link.SpeedMbps
+			if p := link.Probe; p != nil && p.Received > 0 {
+				n.GatewayJitterMs = p.JitterMs
+				n.GatewayLossPct = p.LossPercent
+			}
+			n.Rating, n.RatingReasons = link.Rating()
+		}
+		for _, p := range s.ClusterPeers {
+			n.ClusterPeers = append(n.ClusterPeers, OperatorPeer{
+				Peer:      p.Peer,
+				Reachable: p.Connected > 0,
+				AvgMs:     p.AvgMs,
+				JitterMs:  p.JitterMs,
+				MaxMs:     p.MaxMs,
+			})
+		}
+
+		if flags, err := strconv.ParseUint(strings.TrimPrefix(s.ThrottledFlags, "0x"), 16, 32); err == nil {
+			underVoltage := flags&0x10001 != 0
+			u.UnderVoltage = &underVoltage
+		}
+		if d := s.DiskHealth; d != nil {
+			wear := d.PercentUsed
+			u.DiskWearPercent = &wear
+			u.DiskMediaErrors = d.MediaErrors
+			u.DiskCritical = d.CriticalWarning
+			u.DiskPowerOnHours = d.PowerOnHours
+		}
+	}
+	if st := r.Verdict.Storage; st != nil {
+		h.DiskTotalGB = st.TotalGB
+		h.DiskFreeGB = st.FreeGB
+	}
+	if r.Disk.Random.OK() {
+		h.RandReadIOPS = r.Disk.Random.ReadIOPS
+	}
+	if r.CPU.BLS.OK() {
+		h.BLSVerifyPS = r.CPU.BLS.VerificationsPerSecond
+	}
+	if cl := r.Verdict.Cluster; cl != nil {
+		h.MaxValidators = cl.MaxValidators
+		n.ClusterLatency = cl.Latency
+	}
+	if t := r.Thermal; t != nil {
+		stable := t.Stable
+		u.ThermalStable = &stable
+		u.MaxTemperatureC = t.MaxTemperatureC
+		u.ThrottleEvents = t.ThrottleEvents
+	}
+	u.SuspendedInRun = r.Sleep != nil && r.Sleep.Suspends > 0
+
+	o.Checks = operatorChecks(r, o)
+	o.Ready = true
+	for _, c := range o.Checks {
+		if c.Status == "fail" {
+			o.Ready = false
+		}
+	}
+	return o
+}
+
+// operatorChecks runs the checklist. The requirements follow the common
+// ground of the Obol and SSV operator guides: 4 cores, 16 GB of RAM, a 2 TB
+// NVMe drive, a wired link and cluster nodes within 100 ms of each other.
+func operatorChecks(r *Report, o *OperatorReadiness) []OperatorCheck {
+	h, n, u := o.Hardware, o.Network, o.Uptime
+	var checks []OperatorCheck
+	add := func(id, status, value, requirement string) {
+		checks = append(checks, OperatorCheck{id, status, value, requirement})
+	}
+	grade := func(ok, acceptable bool) string {
+		switch {
+		case ok:
+			return "pass"
+		case acceptable:
+			return "warn"
+		}
+		return "fail"
+	}
+
+	add("cpu_cores", grade(h.CPUCores >= 4, false), strconv.Itoa(h.CPUCores), "4 or more cores")
+	add("memory", grade(h.RAMMB >= 15000, h.RAMMB >= 7500), fmt.Sprintf("%d MB", h.RAMMB), "16 GB (8 GB with reduced client caches)")
+	if h.StorageType != "" {
+		add("storage_type", grade(h.StorageType == "nvme", h.StorageType != "sd"), h.StorageType, "NVMe SSD")
+	} else {
+		add("storage_type", "unknown", "", "NVMe SSD")
+	}
+
+	switch st := r.Verdict.Storage; {
+	case st == nil || len(st.Configs) == 0:
+		add("disk_capacity", "unknown", "", "2 TB")
+	default:
+		fits := st.Configs[0].FitsDisk
+		pruned := len(st.Configs) > 1 && st.Configs[1].FitsDisk
+		add("disk_capacity", grade(fits, pruned), fmt.Sprintf("%.0f GB", st.TotalGB), fmt.Sprintf("~%.0f GB for the node", st.Configs[0].RequiredGB))
+	}
+	if h.RandReadIOPS > 0 {
+		add("random_iops", grade(h.RandReadIOPS >= 10000, h.RandReadIOPS >= 5000), fmt.Sprintf("%.0f", h.RandReadIOPS), "10000 random 4K read IOPS")
+	} else {
+		add("random_iops", "unknown", "", "10000 random 4K read IOPS")
+	}
+
+	if n.Rating != "" {
+		value := n.Rating
+		if len(n.RatingReasons) > 0 {
+			value += ": " + strings.Join(n.RatingReasons, ", ")
+		}
+		add("network", grade(n.Rating == "good", n.Rating == "fair"), value, "wired gigabit link without loss")
+	} else {
+		add("network", "unknown", "", "wired gigabit link without loss")
+	}
+
+	var unreachable []string
+	for _, p := range n.ClusterPeers {
+		if !p.Reachable {
+			unreachable = append(unreachable, p.Peer)
+		}
+	}
+	switch cl := r.Verdict.Cluster; {
+	case len(n.ClusterPeers) == 0:
+		add("cluster_latency", "unknown", "no peers given", fmt.Sprintf("under %d ms to every cluster node", clusterLatencyWarnMs))
+	case len(unreachable) > 0:
+		add("cluster_latency", "warn", "unreachable: "+strings.Join(unreachable, ", "), fmt.Sprintf("under %d ms to every cluster node", clusterLatencyWarnMs))
+	case cl != nil && cl.Latency != "":
+		add("cluster_latency", grade(cl.Latency == "good", cl.Latency == "fair"), fmt.Sprintf("%.1f ms", cl.SlowestMs), fmt.Sprintf("under %d ms to every cluster node", clusterLatencyWarnMs))
+	default:
+		// Peers probed but not assessed: the run did not use dvt-operator
+		slowest := 0.0
+		for _, p := range n.ClusterPeers {
+			slowest = max(slowest, p.AvgMs)
+		}
+		add("cluster_latency", grade(slowest < clusterLatencyWarnMs, slowest < clusterLatencyMaxMs), fmt.Sprintf("%.1f ms", slowest), fmt.Sprintf("under %d ms to every cluster node", clusterLatencyWarnMs))
+	}
+
+	if u.ThermalStable != nil {
+		add("thermal", grade(*u.ThermalStable, true), fmt.Sprintf("max %.0f°C, %d throttle events", u.MaxTemperatureC, u.ThrottleEvents), "no throttling under sustained load")
+	} else {
+		add("thermal", "unknown", "", "no throttling under sustained load")
+	}
+	switch {
+	case u.UnderVoltage == nil:
+	case *u.UnderVoltage:
+		add("power", "fail", "under-voltage detected", "stable power supply")
+	default:
+		add("power", "pass", "no under-voltage", "stable power supply")
+	}
+	if u.DiskWearPercent != nil {
+		wear := *u.DiskWearPercent
+		status := grade(wear < 70, wear < 90)
+		if u.DiskCritical || u.DiskMediaErrors > 0 {
+			status = "fail"
+		}
+		add("disk_health", status, fmt.Sprintf("%d%% worn, %d media errors", wear, u.DiskMediaErrors), "under 70% of rated endurance, no media errors")
+	} else {
+		add("disk_health", "unknown", "", "under 70% of rated endurance, no media errors")
+	}
+	if u.SuspendedInRun {
+		add("suspend", "warn", "suspended during the run", "no sleep or suspend")
+	} else {
+		add("suspend", "pass", "stayed awake", "no sleep or suspend")
+	}
+	return checks
+}
+
+// SaveOperatorReadiness saves the report's operator readiness summary as a
+// JSON file with timestamp in filename
+func SaveOperatorReadiness(r *Report, outputDir string) (string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+	data, err := json.MarshalIndent(NewOperatorReadiness(r), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal operator readiness: %w", err)
+	}
+	path := filepath.Join(outputDir, fmt.Sprintf("ethbench-operator-%s.json", time.Now().Format("2006-01-02_15-04-05")))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write operator readiness: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,118 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vBenchmark/internal/system"
+	"github.com/vBenchmark/internal/types"
+)
+
+// PartialFileName is the in-progress report kept in the output directory
+// while a run is active
+const PartialFileName = "ethbench-partial.json"
+
+// Checkpoint keeps the results of a run in the output directory as it goes,
+// rewritten after every completed benchmark, so a panic, OOM kill or power
+// loss during the last disk test loses only the benchmark that was running
+type Checkpoint struct {
+	path    string
+	version string
+	sysInfo *system.Info
+	scoring *ScoringProfile
+}
+
+// NewCheckpoint creates a checkpoint for a run saving its reports to outputDir
+func NewCheckpoint(outputDir, version string, sysInfo *system.Info, scoring *ScoringProfile) *Checkpoint {
+	return &Checkpoint{
+		path:    filepath.Join(outputDir, PartialFileName),
+		version: version,
+		sysInfo: sysInfo,
+		scoring: scoring,
+	}
+}
+
+// Save replaces the checkpoint with the results so far, marked incomplete
+func (c *Checkpoint) Save(results *types.Results, duration time.Duration) error {
+	r := NewReport(c.version, c.sysInfo, results, duration, c.scoring)
+	r.Metadata.Incomplete = true
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	if err := writeFileAtomic(c.path, data); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Remove deletes the checkpoint once the final report is saved
+func (c *Checkpoint) Remove() {
+	os.Remove(c.path)
+}
+
+// RecoverPartial keeps the checkpoint of a run that never finished in
+// outputDir by renaming it to a timestamped report, and returns its path
+// ("" if there was none). Call it before a new run starts checkpointing.
+func RecoverPartial(outputDir string) (string, error) {
+	path := filepath.Join(outputDir, PartialFileName)
+	// Temporary files of a write cut short never replaced the checkpoint
+	if stale, err := filepath.Glob(filepath.Join(outputDir, "."+PartialFileName+".tmp*")); err == nil {
+		for _, f := range stale {
+			os.Remove(f)
+		}
+	}
+
+	r, err := LoadReport(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	recovered := filepath.Join(outputDir, fmt.Sprintf("ethbench-%s-recovered.json", r.Metadata.Timestamp.Format("2006-01-02_15-04-05")))
+	if err := os.Rename(path, recovered); err != nil {
+		return "", fmt.Errorf("failed to recover %s: %w", path, err)
+	}
+	return recovered, nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path, syncs it and
+// renames it over path, so after a crash path holds either the old or the
+// new contents and never a torn file
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	f, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return err
+	}
+	// Persist the rename itself
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
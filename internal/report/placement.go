@@ -0,0 +1,77 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/vBenchmark/internal/reference"
+)
+
+// Placement shows where the scores fall within the detected hardware class
+type Placement struct {
+	Profile          string  `json:"profile"`
+	Description      string  `json:"description"`
+	CPUPercentile    float64 `json:"cpu_percentile"`
+	MemoryPercentile float64 `json:"memory_percentile"`
+	DiskPercentile   float64 `json:"disk_percentile"`
+	TotalPercentile  float64 `json:"total_percentile"`
+}
+
+// lowPercentile marks scores that point to misconfiguration rather than
+// inherent hardware limits
+const lowPercentile = 10
+
+// PlaceInClass records the reference profile used for the report and
+// computes percentile placement of each score within that hardware class
+func (r *Report) PlaceInClass(profile *reference.Profile) {
+	if profile == nil {
+		return
+	}
+	r.Metadata.ReferenceProfile = profile.Name
+
+	placement := &Placement{
+		Profile:     profile.Name,
+		Description: profile.Description,
+	}
+	scores := []struct {
+		metric string
+		score  int
+		label  string
+		out    *float64
+	}{
+		{"summary.cpu_score", r.Summary.CPUScore, "CPU", &placement.CPUPercentile},
+		{"summary.memory_score", r.Summary.MemoryScore, "memory", &placement.MemoryPercentile},
+		{"summary.disk_score", r.Summary.DiskScore, "disk", &placement.DiskPercentile},
+		{"summary.total_score", r.Summary.TotalScore, "overall", &placement.TotalPercentile},
+	}
+	for _, s := range scores {
+		pct, ok := profile.Percentile(s.metric, float64(s.score))
+		if !ok {
+			continue
+		}
+		*s.out = pct
+		if pct < lowPercentile {
+			r.Verdict.Recommendations = append(r.Verdict.Recommendations,
+				fmt.Sprintf("Your %s score is in the %s percentile of %s results. This usually indicates misconfiguration rather than an inherent hardware limit.",
+					s.label, ordinal(pct), profile.Description),
+			)
+		}
+	}
+
+	r.Placement = placement
+}
+
+// ordinal formats a percentile as "35th", "1st", "22nd"
+func ordinal(pct float64) string {
+	n := int(pct)
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
@@ -0,0 +1,67 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// prometheusPrefix namespaces every exported metric
+const prometheusPrefix = "ethbench_"
+
+// FormatPrometheus renders the scores and metrics of the report in the
+// Prometheus text exposition format. Report metrics keep their dotted name
+// with dots turned into underscores, e.g. disk.random.read_iops becomes
+// ethbench_disk_random_read_iops. Failed and skipped benchmarks are left
+// out rather than exported as zero.
+func FormatPrometheus(r *Report) string {
+	var sb strings.Builder
+
+	sb.WriteString("# HELP ethbench_info Benchmark version, workload and scoring profiles and hardware of the last run\n")
+	sb.WriteString("# TYPE ethbench_info gauge\n")
+	sb.WriteString(fmt.Sprintf("ethbench_info{version=\"%s\",workload_version=\"%s\",scoring_profile=\"%s\",cpu_model=\"%s\",disk_model=\"%s\",disk_type=\"%s\"} 1\n",
+		promLabel(r.Metadata.Version), promLabel(r.Metadata.WorkloadVersion), promLabel(r.Metadata.ScoringProfile), promLabel(r.System.CPUModel),
+		promLabel(r.System.DiskModel), promLabel(r.System.DiskType)))
+	writePromGauge(&sb, "last_run_timestamp_seconds", "Unix time the last run finished", float64(r.Metadata.Timestamp.Unix()))
+	writePromGauge(&sb, "last_run_duration_seconds", "Duration of the last run", r.Metadata.DurationSeconds)
+	writePromGauge(&sb, "last_run_errors", "Benchmarks that failed in the last run", float64(len(r.Errors)))
+
+	tree := reportTree(r)
+	metrics := comparableMetrics(tree)
+	keys := make([]string, 0, len(metrics))
+	for key := range metrics {
+		if completed(tree, key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		writePromGauge(&sb, promName(key), "", metrics[key])
+	}
+	return sb.String()
+}
+
+// writePromGauge writes one unlabelled gauge with its type and optional help
+func writePromGauge(sb *strings.Builder, name, help string, value float64) {
+	name = prometheusPrefix + name
+	if help != "" {
+		sb.WriteString(fmt.Sprintf("# HELP %s %s\n", name, help))
+	}
+	sb.WriteString(fmt.Sprintf("# TYPE %s gauge\n", name))
+	sb.WriteString(fmt.Sprintf("%s %g\n", name, value))
+}
+
+// promName turns a dotted report metric into a valid Prometheus metric name
+func promName(metric string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, metric)
+}
+
+// promLabel escapes a label value as the exposition format requires
+var promLabel = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace
@@ -0,0 +1,76 @@
+package report
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/vBenchmark/internal/reference"
+	"github.com/vBenchmark/internal/types"
+)
+
+// ReferenceComparison sets each metric against the mean of a reference device,
+// chosen with -reference, so results read as "x% of a Pi 5"
+type ReferenceComparison struct {
+	Profile     string            `json:"profile"`
+	Description string            `json:"description"`
+	Metrics     []ReferenceMetric `json:"metrics"`
+}
+
+// ReferenceMetric is one measured metric next to the reference mean.
+// Every reference metric is higher-is-better, so above 100% is faster.
+type ReferenceMetric struct {
+	Metric    string  `json:"metric"`
+	Value     float64 `json:"value"`
+	Reference float64 `json:"reference"`
+	Percent   float64 `json:"percent"`
+}
+
+// scored reports whether a metric is not a score, or is the score of a
+// category that ran. The total only compares when every category ran.
+func (s Summary) scored(metric string) bool {
+	category, ok := strings.CutPrefix(metric, "summary.")
+	if !ok {
+		return true
+	}
+	category = strings.TrimSuffix(category, "_score")
+	if category == "total" {
+		return len(s.NotRun) == 0
+	}
+	return !slices.Contains(s.NotRun, category)
+}
+
+// CompareTo records the results as a percentage of the reference profile's
+// means. Metrics of failed, skipped or unselected benchmarks are left out.
+func (r *Report) CompareTo(profile *reference.Profile) {
+	if profile == nil {
+		return
+	}
+	tree := reportTree(r)
+	metrics := types.FlattenMetrics(tree)
+
+	comparison := &ReferenceComparison{
+		Profile:     profile.Name,
+		Description: profile.Description,
+	}
+	for metric := range profile.Metrics {
+		mean, ok := profile.Mean(metric)
+		value, measured := metrics[metric]
+		if !ok || !measured || !completed(tree, metric) || !r.Summary.scored(metric) {
+			continue
+		}
+		comparison.Metrics = append(comparison.Metrics, ReferenceMetric{
+			Metric:    metric,
+			Value:     value,
+			Reference: mean,
+			Percent:   value / mean * 100,
+		})
+	}
+	if len(comparison.Metrics) == 0 {
+		return
+	}
+	sort.Slice(comparison.Metrics, func(i, j int) bool {
+		return comparison.Metrics[i].Metric < comparison.Metrics[j].Metric
+	})
+	r.Reference = comparison
+}
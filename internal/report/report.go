@@ -2,28 +2,125 @@
 package report
 
 import (
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/vBenchmark/internal/system"
 	"github.com/vBenchmark/internal/types"
+	"github.com/vBenchmark/internal/workload"
 )
 
+// SchemaVersion is the version of the JSON report layout.
+// Version 2 adds human-readable "duration" and "duration_iso8601" fields.
+// Version 3 records the scoring profile in "metadata.scoring_profile".
+const SchemaVersion = 3
+
 // Report contains the complete benchmark report
 type Report struct {
-	Metadata Metadata          `json:"metadata"`
-	System   *system.Info      `json:"system"`
+	Metadata Metadata            `json:"metadata"`
+	Runtime  *types.RuntimeInfo  `json:"runtime,omitempty"`
+	System   *system.Info        `json:"system"`
+	Idle     *types.IdleResult   `json:"idle_baseline,omitempty"`
 	CPU      types.CPUResults    `json:"cpu"`
 	Memory   types.MemoryResults `json:"memory"`
 	Disk     types.DiskResults   `json:"disk"`
-	Summary  Summary           `json:"summary"`
-	Verdict  Verdict           `json:"verdict"`
+	Forks    *types.ForkResults  `json:"forks,omitempty"`
+	Summary  Summary             `json:"summary"`
+	Verdict  Verdict             `json:"verdict"`
+
+	Placement *Placement           `json:"placement,omitempty"`
+	Reference *ReferenceComparison `json:"reference,omitempty"`
+	Baseline  *BaselineCheck       `json:"baseline,omitempty"`
+	Findings  []Finding            `json:"findings,omitempty"`
+
+	Timeline     []types.PhaseTiming    `json:"timeline"`
+	Interference []types.Interference   `json:"interference,omitempty"`
+	Thermal      *types.ThermalResult   `json:"thermal,omitempty"`
+	Sleep        *types.SleepResult     `json:"sleep,omitempty"`
+	Anomalies    []types.Anomaly        `json:"anomalies,omitempty"`
+	GCSweep      []types.GCSweepPoint   `json:"gc_sweep,omitempty"`
+	Harness      *types.HarnessOverhead `json:"harness_overhead,omitempty"`
+	IRQTuning    *types.IRQTuning       `json:"irq_tuning,omitempty"`
+	Paranoid     *types.ParanoidChecks  `json:"paranoid,omitempty"`
+	Annotations  *Annotations           `json:"annotations,omitempty"`
+	Trial        *types.TrialResult     `json:"trial,omitempty"`
+	// RunStatistics aggregates repeated runs (-runs); the other sections
+	// hold the final run
+	RunStatistics *RunStatistics `json:"run_statistics,omitempty"`
+	// Soak holds the iterations of a soak test (-soak); the other sections
+	// hold the final iteration
+	Soak   *SoakResult            `json:"soak,omitempty"`
+	Errors []types.BenchmarkError `json:"errors,omitempty"`
+
+	// scoringProfile is the profile the report was scored with, which a
+	// custom profile cannot be looked up again by name
+	scoringProfile *ScoringProfile
 }
 
 // Metadata contains report metadata
 type Metadata struct {
+	SchemaVersion   int       `json:"schema_version"`
 	Version         string    `json:"version"`
 	Timestamp       time.Time `json:"timestamp"`
 	DurationSeconds float64   `json:"duration_seconds"`
+	Duration        string    `json:"duration"`
+	DurationISO8601 string    `json:"duration_iso8601"`
+	// WorkloadVersion identifies the workload profile the benchmarks ran
+	// with; results are only directly comparable within one version
+	WorkloadVersion string `json:"workload_version"`
+	// ScoringProfile names the weights and thresholds the scores were
+	// computed with; scores are only comparable within one profile
+	ScoringProfile string `json:"scoring_profile"`
+	// ReferenceProfile names the embedded reference used for comparisons
+	ReferenceProfile string `json:"reference_profile,omitempty"`
+	// Incomplete is set when the run was interrupted; benchmarks that did
+	// not finish are marked skipped
+	Incomplete bool `json:"incomplete,omitempty"`
+	// Offline is set when the run was made with -offline, with the network
+	// probes skipped and DNS and the default HTTP client blocked
+	Offline bool `json:"offline,omitempty"`
+	// Tags are the key=value labels given with -tags, e.g. case=argon40,
+	// which "ethbench query" selects runs by
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// ParseTags parses comma-separated key=value labels, e.g.
+// "case=argon40,cooling=fan"
+func ParseTags(s string) (map[string]string, error) {
+	tags := make(map[string]string)
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(field, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid tag %q: want key=value", field)
+		}
+		if _, dup := tags[key]; dup {
+			return nil, fmt.Errorf("tag %q given twice", key)
+		}
+		tags[key] = value
+	}
+	return tags, nil
+}
+
+// FormatTags renders tags as sorted comma-separated key=value pairs
+func FormatTags(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + tags[key]
+	}
+	return strings.Join(pairs, ",")
 }
 
 // Summary contains score summaries for each category
@@ -32,116 +129,183 @@ type Summary struct {
 	MemoryScore int `json:"memory_score"`
 	DiskScore   int `json:"disk_score"`
 	TotalScore  int `json:"total_score"`
+
+	// Partial is set when failed or skipped benchmarks were left out of the scores
+	Partial bool `json:"partial,omitempty"`
+
+	// NotRun lists the categories with no completed scored benchmarks, which are
+	// left out of TotalScore
+	NotRun []string `json:"not_run,omitempty"`
+
+	// ForkScores holds optional fork pack scores (0-100); they are not part
+	// of TotalScore so results stay comparable with and without packs
+	ForkScores map[string]int `json:"fork_scores,omitempty"`
 }
 
 // Verdict contains the final hardware assessment
 type Verdict struct {
-	OverallScore      int      `json:"overall_score"`
-	ExecutionClient   string   `json:"execution_client"`
-	ConsensusClient   string   `json:"consensus_client"`
-	Recommendations   []string `json:"recommendations"`
+	OverallScore    int    `json:"overall_score"`
+	ExecutionClient string `json:"execution_client"`
+	ConsensusClient string `json:"consensus_client"`
+	// Deployment is the scoring profile's: "consensus" or "execution" when
+	// only that client runs here and the other is "Remote", "dvt" for a
+	// distributed validator node, else empty
+	Deployment      string   `json:"deployment,omitempty"`
+	Recommendations []string `json:"recommendations"`
+	// Confidence is "high", or "reduced" when the benchmarks could not run
+	// as designed on this machine, with the reasons in ConfidenceNotes
+	Confidence      string   `json:"confidence"`
+	ConfidenceNotes []string `json:"confidence_notes,omitempty"`
+
+	// ContentionPenaltyPercent is the throughput the CPU, trie and disk
+	// loads lose on average when run together (disk.contention)
+	ContentionPenaltyPercent float64 `json:"contention_penalty_percent,omitempty"`
+
+	Storage *StorageAssessment `json:"storage,omitempty"`
+	Cluster *ClusterAssessment `json:"cluster,omitempty"`
 }
 
-// NewReport creates a new benchmark report
-func NewReport(version string, sysInfo *system.Info, results *types.Results, duration time.Duration) *Report {
+// NewReport creates a new benchmark report scored with the given profile
+func NewReport(version string, sysInfo *system.Info, results *types.Results, duration time.Duration, scoring *ScoringProfile) *Report {
 	report := &Report{
 		Metadata: Metadata{
+			SchemaVersion:   SchemaVersion,
 			Version:         version,
 			Timestamp:       time.Now(),
 			DurationSeconds: duration.Seconds(),
+			Duration:        duration.Round(time.Second).String(),
+			DurationISO8601: types.ISO8601Duration(duration),
+			WorkloadVersion: workload.Current.Version,
+			ScoringProfile:  scoring.Name,
+			Incomplete:      results.Interrupted,
 		},
-		System: sysInfo,
-		CPU:    results.CPU,
-		Memory: results.Memory,
-		Disk:   results.Disk,
+		System:         sysInfo,
+		scoringProfile: scoring,
+		Idle:           results.Idle,
+		CPU:            results.CPU,
+		Memory:         results.Memory,
+		Disk:           results.Disk,
+		Forks:          results.Forks,
+
+		Timeline:     results.Timeline,
+		Interference: results.Interference,
+		Thermal:      results.Thermal,
+		Sleep:        results.Sleep,
+		Errors:       results.Errors,
+		Anomalies:    results.Anomalies,
+		IRQTuning:    results.IRQTuning,
+		GCSweep:      results.GCSweep,
+		Harness:      results.Harness,
+		Paranoid:     results.Paranoid,
 	}
 
 	// Calculate scores
-	report.Summary = calculateSummary(results)
-	report.Verdict = determineVerdict(report.Summary.TotalScore, results)
+	report.Summary = calculateSummary(results, scoring)
+	report.Verdict = determineVerdict(report.Summary.TotalScore, results, scoring.Deployment)
+	report.Verdict.Recommendations = append(report.Verdict.Recommendations, swapRecommendations(sysInfo, results)...)
+	report.Verdict.Recommendations = append(report.Verdict.Recommendations, wearRecommendations(sysInfo)...)
+	report.Verdict.Recommendations = append(report.Verdict.Recommendations, cpuExtensionRecommendations(sysInfo, results)...)
+	report.Verdict.Recommendations = append(report.Verdict.Recommendations, peerRecommendations(sysInfo, scoring.Deployment)...)
+	report.Verdict.Recommendations = append(report.Verdict.Recommendations, governorRecommendations(sysInfo)...)
+	if scoring.Deployment == DeploymentDVT {
+		var recommendations []string
+		report.Verdict.Cluster, recommendations = assessCluster(sysInfo, results)
+		report.Verdict.Recommendations = append(report.Verdict.Recommendations, recommendations...)
+	}
+	report.Findings = detectMisconfigurations(sysInfo, results)
 
 	return report
 }
 
-// calculateSummary calculates scores for each category
-func calculateSummary(results *types.Results) Summary {
-	cpuScore := calculateCPUScore(&results.CPU)
-	memoryScore := calculateMemoryScore(&results.Memory)
-	diskScore := calculateDiskScore(&results.Disk)
-
-	// Weighted total: CPU 40%, Disk 35%, Memory 25%
-	totalScore := int(float64(cpuScore)*0.40 + float64(diskScore)*0.35 + float64(memoryScore)*0.25)
+// calculateSummary calculates scores for each category. Failed or skipped
+// benchmarks are left out and the remaining weights re-normalized, so a
+// single failure lowers coverage rather than dragging the score to zero.
+func calculateSummary(results *types.Results, scoring *ScoringProfile) Summary {
+	cpuScore, cpuCoverage := weightedScore(scoring.cpu(results))
+	memoryScore, memoryCoverage := weightedScore(scoring.memory(results))
+	diskScore, diskCoverage := weightedScore(scoring.disk(results))
+
+	// Weighted total, by default CPU 40%, Disk 35%, Memory 25%
+	weights := scoring.Weights
+	totalScore, _ := weightedScore([]scoreComponent{
+		{"CPU", float64(cpuScore), weights.CPU, cpuCoverage > 0},
+		{"Disk", float64(diskScore), weights.Disk, diskCoverage > 0},
+		{"Memory", float64(memoryScore), weights.Memory, memoryCoverage > 0},
+	})
+
+	var notRun []string
+	for _, c := range []struct {
+		name     string
+		coverage float64
+	}{{"cpu", cpuCoverage}, {"memory", memoryCoverage}, {"disk", diskCoverage}} {
+		if c.coverage == 0 {
+			notRun = append(notRun, c.name)
+		}
+	}
 
 	return Summary{
 		CPUScore:    cpuScore,
 		MemoryScore: memoryScore,
 		DiskScore:   diskScore,
 		TotalScore:  totalScore,
+		Partial:     cpuCoverage < 1 || memoryCoverage < 1 || diskCoverage < 1,
+		NotRun:      notRun,
+		ForkScores:  calculateForkScores(results.Forks),
 	}
 }
 
-// calculateCPUScore scores CPU benchmark results (0-100)
-func calculateCPUScore(cpu *types.CPUResults) int {
-	var score float64
-
-	// Keccak256 scoring (25% weight)
-	keccakScore := scoreMetric(cpu.Keccak.HashesPerSecond, 50000, 100000, 200000, 500000)
-	score += keccakScore * 0.25
-
-	// ECDSA scoring (35% weight) - uses verification rate
-	ecdsaScore := scoreMetric(cpu.ECDSA.VerificationsPerSecond, 250, 500, 1000, 2000)
-	score += ecdsaScore * 0.35
-
-	// BLS scoring (25% weight)
-	blsScore := scoreMetric(cpu.BLS.VerificationsPerSecond, 50, 100, 200, 500)
-	score += blsScore * 0.25
-
-	// BN256 scoring (15% weight)
-	bn256Score := scoreMetric(cpu.BN256.PairingsPerSecond, 10, 25, 50, 100)
-	score += bn256Score * 0.15
-
-	return int(score)
+// categoryScore formats a category score, or "not scored" when none of its
+// scored benchmarks completed
+func (s Summary) categoryScore(category string, score int) string {
+	if slices.Contains(s.NotRun, category) {
+		return "not scored"
+	}
+	return fmt.Sprintf("%d/100", score)
 }
 
-// calculateMemoryScore scores memory benchmark results (0-100)
-func calculateMemoryScore(mem *types.MemoryResults) int {
-	var score float64
-
-	// Trie operations scoring (40% weight)
-	trieScore := scoreMetric(mem.Trie.InsertsPerSecond, 5000, 10000, 20000, 50000)
-	score += trieScore * 0.40
-
-	// Pool operations scoring (30% weight)
-	poolOps := mem.Pool.AllocationsPerSecond + mem.Pool.ReusesPerSecond
-	poolScore := scoreMetric(poolOps, 50000, 100000, 200000, 500000)
-	score += poolScore * 0.30
-
-	// State cache scoring (30% weight)
-	cacheScore := scoreMetric(mem.StateCache.CacheHitsPerSecond, 50000, 100000, 200000, 500000)
-	score += cacheScore * 0.30
-
-	return int(score)
+// scoreComponent is one weighted input to a score
+type scoreComponent struct {
+	label  string
+	score  float64
+	weight float64
+	ok     bool
 }
 
-// calculateDiskScore scores disk benchmark results (0-100)
-func calculateDiskScore(disk *types.DiskResults) int {
-	var score float64
-
-	// Sequential I/O scoring (30% weight)
-	seqAvg := (disk.Sequential.WriteSpeedMBps + disk.Sequential.ReadSpeedMBps) / 2
-	seqScore := scoreMetric(seqAvg, 50, 100, 200, 400)
-	score += seqScore * 0.30
-
-	// Random I/O scoring (45% weight) - most important for Ethereum
-	randomAvg := (disk.Random.ReadIOPS + disk.Random.WriteIOPS) / 2
-	randomScore := scoreMetric(randomAvg, 5000, 10000, 20000, 50000)
-	score += randomScore * 0.45
-
-	// Batch write scoring (25% weight)
-	batchScore := scoreMetric(disk.Batch.ThroughputMBps, 10, 25, 50, 100)
-	score += batchScore * 0.25
+// weightedScore combines the usable components, re-normalizing their weights.
+// coverage is the fraction of the total weight that could be scored.
+func weightedScore(components []scoreComponent) (score int, coverage float64) {
+	var sum, used, total float64
+	for _, c := range components {
+		total += c.weight
+		if !c.ok {
+			continue
+		}
+		sum += c.score * c.weight
+		used += c.weight
+	}
+	if used == 0 {
+		return 0, 0
+	}
+	if used == total {
+		// Avoid rounding drift so complete runs score exactly as before
+		return int(sum), 1
+	}
+	return int(sum / used), used / total
+}
 
-	return int(score)
+// calculateForkScores scores each enabled fork pack (0-100)
+func calculateForkScores(forks *types.ForkResults) map[string]int {
+	if forks == nil {
+		return nil
+	}
+	scores := make(map[string]int)
+	if forks.Pectra != nil && forks.Pectra.OK() {
+		scores["pectra"] = int(scoreMetric(forks.Pectra.MGasPerSecond, 10, 20, 40, 80))
+	}
+	if forks.Fusaka != nil && forks.Fusaka.OK() {
+		scores["fusaka"] = int(scoreMetric(forks.Fusaka.RealtimeFactor, 5, 10, 25, 50))
+	}
+	return scores
 }
 
 // scoreMetric converts a metric value to a 0-100 score
@@ -160,15 +324,39 @@ func scoreMetric(value, poor, marginal, good, excellent float64) float64 {
 	}
 }
 
-// determineVerdict determines hardware readiness for Ethereum nodes
-func determineVerdict(score int, results *types.Results) Verdict {
+// determineVerdict determines hardware readiness for Ethereum nodes. For a
+// split deployment only the client running here is assessed and the other
+// is marked Remote, and advice about the other client's workload is left
+// out.
+func determineVerdict(score int, results *types.Results, deployment string) Verdict {
 	verdict := Verdict{
 		OverallScore:    score,
+		Deployment:      deployment,
 		Recommendations: make([]string, 0),
+		Confidence:      "high",
 	}
+	// Which clients run on this machine
+	execution := deployment != DeploymentConsensus
+	consensus := deployment != DeploymentExecution
 
 	// Determine client readiness
 	switch {
+	case deployment == DeploymentConsensus:
+		verdict.ExecutionClient = "Remote"
+		verdict.ConsensusClient = splitReadiness(score, 60, 30)
+		verdict.Recommendations = append(verdict.Recommendations, map[string]string{
+			"Ready":      "Hardware meets consensus client requirements. Keep the execution client's engine API on the LAN.",
+			"Marginal":   "Consensus client may fall behind during epoch processing and blob-heavy periods.",
+			"Unsuitable": "Hardware is too slow even for a consensus client on its own.",
+		}[verdict.ConsensusClient])
+	case deployment == DeploymentExecution:
+		verdict.ConsensusClient = "Remote"
+		verdict.ExecutionClient = splitReadiness(score, 80, 40)
+		verdict.Recommendations = append(verdict.Recommendations, map[string]string{
+			"Ready":      "Hardware meets execution client requirements without a consensus client competing for it.",
+			"Marginal":   "Execution client may struggle during high network activity. Consider checkpoint sync to reduce initial sync time.",
+			"Unsuitable": "Hardware does not meet minimum requirements for an execution client. Consider upgrading to NVMe storage.",
+		}[verdict.ExecutionClient])
 	case score >= 80:
 		verdict.ExecutionClient = "Ready"
 		verdict.ConsensusClient = "Ready"
@@ -202,22 +390,394 @@ func determineVerdict(score int, results *types.Results) Verdict {
 		)
 	}
 
+	// Scores computed from partial data are less reliable
+	if len(results.Errors) > 0 {
+		verdict.Recommendations = append(verdict.Recommendations,
+			fmt.Sprintf("%d benchmark(s) failed and were left out of the scores. Fix the errors listed above and re-run for a complete assessment.", len(results.Errors)),
+		)
+	}
+
+	// A smaller trie or state cache than a node holds fits better in the CPU
+	// caches, so capped memory benchmarks overstate the hardware
+	if g := results.Memory.Guard; g != nil {
+		verdict.Confidence = "reduced"
+		verdict.ConfidenceNotes = append(verdict.ConfidenceNotes,
+			fmt.Sprintf("Only %d MB of memory was available, so %s ran with working sets capped at %d MB to avoid the OOM killer. Their ratings may be optimistic.", g.AvailableMB, strings.Join(g.Capped, ", "), g.LimitMB),
+		)
+	}
+
+	// Wrong results mean the hardware is unstable, whatever the speed
+	if results.Memory.Correctness.Failures > 0 {
+		verdict.Recommendations = append(verdict.Recommendations,
+			fmt.Sprintf("%d of %d correctness checks returned wrong results. RAM, overclock or power supply is unstable; a node on this system may corrupt its database or miss attestations.",
+				results.Memory.Correctness.Failures, results.Memory.Correctness.Checks),
+		)
+	}
+
+	// Flag runs that started on a busy system
+	if results.Idle != nil && results.Idle.Error == "" && !results.Idle.Quiet {
+		verdict.Recommendations = append(verdict.Recommendations,
+			fmt.Sprintf("System was not idle before benchmarking (CPU idle %.0f%%, %.0f disk IOPS). Stop other services and re-run for accurate results.",
+				results.Idle.CPUIdlePercent, results.Idle.DiskIOPS),
+		)
+	}
+
+	// Flag background jobs that ran during disk benchmarks
+	for _, item := range results.Interference {
+		for _, phase := range item.Phases {
+			if strings.HasPrefix(phase, "disk.") {
+				verdict.Recommendations = append(verdict.Recommendations,
+					fmt.Sprintf("Background %s job (%s) was running during disk benchmarks. Disk results are likely understated; re-run after it finishes.", item.Kind, item.Name),
+				)
+				break
+			}
+		}
+	}
+
+	// Flag scores reduced by thermal throttling
+	if results.Thermal != nil && !results.Thermal.Stable {
+		var affected []string
+		for _, phase := range results.Thermal.Phases {
+			if phase.Affected {
+				affected = append(affected, phase.Phase)
+			}
+		}
+		verdict.Recommendations = append(verdict.Recommendations,
+			fmt.Sprintf("CPU throttled or dropped frequency during %s (max %.1f°C). Scores are understated; improve cooling and re-run.",
+				strings.Join(affected, ", "), results.Thermal.MaxTemperatureC),
+		)
+	}
+
+	// A suspend pauses the clocks of a running benchmark; a governor switch
+	// changes the CPU speed partway through
+	if s := results.Sleep; s != nil && s.Interrupted() {
+		var events []string
+		if s.Suspends > 0 {
+			events = append(events, fmt.Sprintf("suspended %d time(s) for %.0f s", s.Suspends, s.SuspendedSeconds))
+		}
+		if len(s.GovernorChanges) > 0 {
+			events = append(events, "switched CPU governor "+strings.Join(s.GovernorChanges, ", "))
+		}
+		verdict.Recommendations = append(verdict.Recommendations,
+			fmt.Sprintf("System %s during %s. Those results are invalid; keep the machine on AC power with sleep disabled and re-run.",
+				strings.Join(events, " and "), strings.Join(s.Phases, ", ")),
+		)
+	}
+
 	// Add specific recommendations based on weak areas
-	if results.Disk.Random.ReadIOPS < 10000 {
+	if execution && results.Disk.Random.OK() && results.Disk.Random.ReadIOPS < 10000 {
 		verdict.Recommendations = append(verdict.Recommendations,
 			"Random I/O performance is low. NVMe SSD strongly recommended.",
 		)
 	}
-	if results.CPU.ECDSA.VerificationsPerSecond < 500 {
+	if fsync := results.Disk.Fsync; execution && fsync.Count > 0 && fsync.P99LatencyMs > 20 {
+		verdict.Recommendations = append(verdict.Recommendations,
+			fmt.Sprintf("fsync tail latency is high (p99 %.1f ms, p999 %.1f ms). Block commits will stall; use an NVMe SSD with power-loss protection or a DRAM cache.", fsync.P99LatencyMs, fsync.P999LatencyMs),
+		)
+		// Throughput alone can hide stalls that make blocks miss the slot
+		if fsync.P99LatencyMs > 50 && verdict.ExecutionClient == "Ready" {
+			verdict.ExecutionClient = "Marginal"
+		}
+	}
+	// Blocks processed after the attestation deadline lose the head vote
+	if slot := results.Disk.Slot; execution && slot.OK() && slot.SuccessRate < 100 {
+		verdict.Recommendations = append(verdict.Recommendations,
+			fmt.Sprintf("Only %.0f%% of simulated blocks were processed within the %.0f s attestation deadline (p99 %.0f ms). The validator would miss head votes; find the slow stage in the slot deadline results.", slot.SuccessRate, slot.DeadlineMs/1000, slot.P99Ms),
+		)
+		if verdict.ExecutionClient == "Ready" {
+			verdict.ExecutionClient = "Marginal"
+		}
+	}
+	// A node verifies, hashes and writes at once, while every score above
+	// measures one load alone
+	if c := results.Disk.Contention; c.OK() {
+		verdict.ContentionPenaltyPercent = c.PenaltyPercent
+		if c.PenaltyPercent >= contentionPenaltyWarn {
+			verdict.Recommendations = append(verdict.Recommendations, contentionRecommendation(c))
+		}
+	}
+	// A single long stall delays the block commit it hits, whatever the average
+	if batch := results.Disk.Batch; batch.OK() && batch.MaxBatchLatencyMs >= 1000 {
+		verdict.Recommendations = append(verdict.Recommendations,
+			fmt.Sprintf("A batch commit stalled for %.1f s (p99 %.0f ms). Stalls this long cause missed attestations; check for a failing or overheating drive, or use an NVMe SSD.", batch.MaxBatchLatencyMs/1000, batch.P99BatchLatencyMs),
+		)
+	}
+	verdict.Recommendations = append(verdict.Recommendations, filesystemRecommendations(results.Disk.Filesystem)...)
+	// Durability mode advice for database configuration
+	modes := make(map[string]types.SyncModeResult)
+	for _, m := range results.Disk.Batch.SyncModes {
+		modes[m.Mode] = m
+	}
+	if oSync, fdatasync := modes["o_sync"], modes["fdatasync"]; execution && oSync.ThroughputMBps > 0 && fdatasync.ThroughputMBps >= 1.5*oSync.ThroughputMBps {
+		verdict.Recommendations = append(verdict.Recommendations,
+			fmt.Sprintf("O_SYNC writes are %.1fx slower than fdatasync on this storage. Avoid database settings that open the WAL with O_SYNC/O_DSYNC; the Pebble and LevelDB defaults use fdatasync.", fdatasync.ThroughputMBps/oSync.ThroughputMBps),
+		)
+	}
+	if fdatasync, ranged := modes["fdatasync"], modes["sync_file_range"]; execution && fdatasync.ThroughputMBps > 0 && ranged.ThroughputMBps >= 2*fdatasync.ThroughputMBps {
+		verdict.Recommendations = append(verdict.Recommendations,
+			fmt.Sprintf("Ranged writeback (sync_file_range) is %.1fx faster than fdatasync here, so the cost is in cache flushes. RocksDB-based clients (Nethermind, Besu) benefit from bytes_per_sync; a drive with power-loss protection makes flushes cheap.", ranged.ThroughputMBps/fdatasync.ThroughputMBps),
+		)
+	}
+	switch scheme := results.Disk.StateScheme; {
+	case !execution:
+	case scheme.Favored == "path" && scheme.PathSpeedup >= 1.2:
+		verdict.Recommendations = append(verdict.Recommendations,
+			fmt.Sprintf("Path-based state scheme is %.1fx faster on this storage. Run Geth with --state.scheme=path (default since v1.14).", scheme.PathSpeedup),
+		)
+	case scheme.Favored == "hash":
+		verdict.Recommendations = append(verdict.Recommendations,
+			"Hash-based state scheme performed better on this storage; path-based buffer flushes are slow here.",
+		)
+	}
+	if blob := results.Disk.Blob; consensus && blob.BlocksPerSecond > 0 && blob.RealtimeFactor < 10 {
+		verdict.Recommendations = append(verdict.Recommendations,
+			fmt.Sprintf("Blob sidecar storage only keeps up %.1fx faster than real-time. Consensus client may fall behind during blob-heavy periods.", blob.RealtimeFactor),
+		)
+	}
+	if execution && results.CPU.ECDSA.OK() && results.CPU.ECDSA.VerificationsPerSecond < 500 {
 		verdict.Recommendations = append(verdict.Recommendations,
 			"ECDSA verification is slow. This may cause transaction validation delays.",
 		)
 	}
-	if results.CPU.BLS.VerificationsPerSecond < 100 {
+	if consensus && results.CPU.BLS.OK() && results.CPU.BLS.VerificationsPerSecond < 100 {
 		verdict.Recommendations = append(verdict.Recommendations,
 			"BLS signature verification is slow. Consensus layer may lag.",
 		)
 	}
+	if kzg := results.CPU.KZG; kzg.OK() && kzg.VerificationsPerSecond < 40 {
+		verdict.Recommendations = append(verdict.Recommendations,
+			fmt.Sprintf("KZG blob proof verification is slow (%.0f/sec, %.0fx real-time at 6 blobs per block). Blob sidecar validation may delay block import.", kzg.VerificationsPerSecond, kzg.RealtimeFactor),
+		)
+	}
+	// SHA-256 is the consensus layer's hash (hash_tree_root, state roots), so a
+	// slow one holds the consensus client back whatever the overall score
+	if sha := results.CPU.SHA256; consensus && sha.OK() {
+		if sha.NodeHashesPerSecond < 500000 {
+			verdict.Recommendations = append(verdict.Recommendations,
+				fmt.Sprintf("SHA-256 is slow (%.0f merkle node hashes/sec). Consensus client state hashing and epoch processing may fall behind.", sha.NodeHashesPerSecond),
+			)
+			if verdict.ConsensusClient == "Ready" {
+				verdict.ConsensusClient = "Marginal"
+			}
+		}
+		if !sha.HardwareAccelerated && runtime.GOARCH == "arm64" {
+			verdict.Recommendations = append(verdict.Recommendations,
+				"CPU lacks ARMv8 SHA-256 crypto extensions (e.g. Raspberry Pi 4). A board with crypto extensions (Pi 5, RK3588) hashes several times faster.",
+			)
+		}
+	}
 
 	return verdict
 }
+
+// splitReadiness rates the one client of a split deployment from a score
+// over only its subsystems
+func splitReadiness(score, readyAt, marginalAt int) string {
+	switch {
+	case score >= readyAt:
+		return "Ready"
+	case score >= marginalAt:
+		return "Marginal"
+	}
+	return "Unsuitable"
+}
+
+// filesystemRecommendations suggests mount and layout changes for the
+// filesystem the disk benchmarks ran on
+func filesystemRecommendations(fs *types.FilesystemInfo) []string {
+	if fs == nil {
+		return nil
+	}
+	var recommendations []string
+	if !fs.NoAtime && fs.Type != "zfs" {
+		recommendations = append(recommendations,
+			fmt.Sprintf("%s is mounted without noatime, so reads of database files also write access times. Add noatime to its options in /etc/fstab.", fs.MountPoint),
+		)
+	}
+	if fs.CopyOnWrite && fs.Type == "btrfs" {
+		recommendations = append(recommendations,
+			"Test directory is on btrfs with copy-on-write, which fragments database files that are rewritten in place. Create the chaindata directory with `chattr +C` before the first sync.",
+		)
+	}
+	if fs.Discard && fs.Type != "btrfs" {
+		recommendations = append(recommendations,
+			fmt.Sprintf("%s is mounted with continuous discard, which adds latency to every database compaction. Remove discard and enable fstrim.timer instead.", fs.MountPoint),
+		)
+	}
+	var elsewhere []string
+	for _, c := range fs.ChainData {
+		if !c.SameDevice {
+			elsewhere = append(elsewhere, c.Path)
+		}
+	}
+	if len(elsewhere) > 0 {
+		recommendations = append(recommendations,
+			fmt.Sprintf("Existing chain data (%s) is on a different filesystem than the one benchmarked (%s). Re-run with -test-dir on the disk that holds it.", strings.Join(elsewhere, ", "), fs.MountPoint),
+		)
+	}
+	return recommendations
+}
+
+// Sustained writes of a synced execution and consensus client pair,
+// including database compaction, in TB per day
+const (
+	nodeWritesLowTBPerDay  = 1.0
+	nodeWritesHighTBPerDay = 2.0
+)
+
+// wearLifeMonths is the remaining life below which the disk wear is flagged
+const wearLifeMonths = 36
+
+// wearRecommendations estimates how long the disk's remaining rated
+// endurance lasts under node writes
+func wearRecommendations(sysInfo *system.Info) []string {
+	if sysInfo == nil || sysInfo.DiskHealth == nil {
+		return nil
+	}
+	h := sysInfo.DiskHealth
+	var recommendations []string
+	if h.CriticalWarning {
+		recommendations = append(recommendations,
+			fmt.Sprintf("Disk %s reports a critical health warning (%d%% of rated endurance used, %d media errors). Back up and replace it before syncing a node.", h.Device, h.PercentUsed, h.MediaErrors),
+		)
+	}
+	const daysPerMonth = 30.4
+	if remaining := h.RemainingTB(); remaining > 0 {
+		shortest := remaining / nodeWritesHighTBPerDay / daysPerMonth
+		longest := remaining / nodeWritesLowTBPerDay / daysPerMonth
+		if longest < wearLifeMonths {
+			recommendations = append(recommendations,
+				fmt.Sprintf("Disk %s has ~%.0f TB of rated endurance left (%d%% used after %.0f TB written). At %.0f-%.0f TB/day of node writes it wears out in about %.0f-%.0f months; use a drive with a higher TBW rating or plan the replacement.",
+					h.Device, remaining, h.PercentUsed, h.WrittenTB, nodeWritesLowTBPerDay, nodeWritesHighTBPerDay, shortest, longest),
+			)
+		}
+	} else if h.PercentUsed >= 80 {
+		recommendations = append(recommendations,
+			fmt.Sprintf("Disk %s has used %d%% of its rated endurance. At %.0f-%.0f TB/day of node writes the rest lasts weeks, not months; replace it before syncing.", h.Device, h.PercentUsed, nodeWritesLowTBPerDay, nodeWritesHighTBPerDay),
+		)
+	}
+	return recommendations
+}
+
+// governorRecommendations warns when cores are not on the performance
+// governor. The others lower the clock while idle and raise it only after
+// load shows up, which lands on block import and attestation signing: the
+// short bursts a node's latency depends on.
+func governorRecommendations(sysInfo *system.Info) []string {
+	if sysInfo == nil {
+		return nil
+	}
+	var slow []string
+	for _, c := range sysInfo.CPUClusters() {
+		if c.Governor != "" && c.Governor != "performance" {
+			slow = append(slow, fmt.Sprintf("%s on cpu%s", c.Governor, system.FormatCPUList(c.CPUs)))
+		}
+	}
+	if len(slow) == 0 {
+		return nil
+	}
+	return []string{
+		fmt.Sprintf("CPU governor is %s rather than performance. Cores ramping up from idle add latency to block import and attestation signing; re-run with -set-performance-governor (root) and set it at boot, e.g. GOVERNOR=\"performance\" for cpufrequtils.",
+			strings.Join(slow, " and ")),
+	}
+}
+
+// contentionPenaltyWarn is the average throughput loss under combined load,
+// in percent, from which the verdict warns
+const contentionPenaltyWarn = 50
+
+// contentionRecommendation names the load that suffers most when the node's
+// work runs at once and what usually causes it
+func contentionRecommendation(c types.ContentionResult) string {
+	name, worst := "signature recovery", c.Crypto
+	cause := "the cores are shared with the other loads; a CPU with more cores keeps verification from stalling block import"
+	if c.Trie.DegradationPercent > worst.DegradationPercent {
+		name, worst = "trie updates", c.Trie
+		cause = "caches and memory bandwidth are shared; a CPU with more cache or faster RAM helps"
+	}
+	if c.Disk.DegradationPercent > worst.DegradationPercent {
+		name, worst = "disk I/O", c.Disk
+		cause = "the I/O path competes with the CPU work, as on USB storage or with I/O interrupts on a busy core; an NVMe SSD helps"
+	}
+	return fmt.Sprintf("Under combined CPU, trie and disk load the node's work loses %.0f%% of its throughput on average, %s the most (-%.0f%%): %s. The scores above measure each load alone and overstate what a busy node gets during sync and busy slots.",
+		c.PenaltyPercent, name, worst.DegradationPercent, cause)
+}
+
+// peerRecommendations rates the latency to the other client of a split
+// deployment. The consensus client sends the execution client a payload and
+// a forkchoice update every slot and waits for both, so each millisecond
+// comes off the time left to attest; an external RPC provider usually sits
+// tens of milliseconds away.
+func peerRecommendations(sysInfo *system.Info, deployment string) []string {
+	if sysInfo == nil || sysInfo.Peer == nil || (deployment != DeploymentConsensus && deployment != DeploymentExecution) {
+		return nil
+	}
+	p := sysInfo.Peer
+	switch {
+	case p.Connected == 0:
+		return []string{
+			fmt.Sprintf("Could not connect to the other client at %s (%s). The verdict assumes it is reachable; check the address, the client's listen address and the firewall.", p.Peer, p.Error),
+		}
+	case p.AvgMs >= 50:
+		return []string{
+			fmt.Sprintf("The other client is %.0f ms away (%s). Engine API calls wait on that every slot, which delays block import and attestations; run both clients on the same LAN rather than over a WAN or a distant RPC provider.", p.AvgMs, p.Peer),
+		}
+	case p.AvgMs >= 10 || p.JitterMs >= 5:
+		return []string{
+			fmt.Sprintf("Latency to the other client is %.1f ms with %.1f ms jitter (%s), more than a wired LAN (under 1 ms). Expect slower engine API calls; a wired link between both hosts keeps them predictable.", p.AvgMs, p.JitterMs, p.Peer),
+		}
+	}
+	return nil
+}
+
+// cpuExtensionRecommendations warns about CPUs without the crypto extensions
+// clients rely on. The libraries fall back to portable code, which still
+// works but costs several times the CPU per signature, hash or TLS record;
+// the crypto benchmarks measure that, this names the cause.
+func cpuExtensionRecommendations(sysInfo *system.Info, results *types.Results) []string {
+	if sysInfo == nil {
+		return nil
+	}
+	var names, uses []string
+	for _, ext := range sysInfo.MissingCPUExtensions() {
+		// The SHA-256 benchmark already reports its missing extension
+		if ext.Flag == "sha2" && results.CPU.SHA256.OK() && !results.CPU.SHA256.HardwareAccelerated {
+			continue
+		}
+		names = append(names, ext.Name)
+		uses = append(uses, fmt.Sprintf("%s (%s)", ext.Name, ext.Purpose))
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return []string{
+		fmt.Sprintf("CPU lacks %s. Clients fall back to slower code paths for %s; expect higher CPU load during sync and attestation, and prefer a CPU with these extensions for a validator.",
+			strings.Join(names, ", "), strings.Join(uses, "; ")),
+	}
+}
+
+// swapRecommendations warns about memory setups that get the execution client
+// OOM-killed during sync. Geth with its default cache peaks above 8 GB while
+// syncing, so an 8 GB board needs swap that can page the excess back quickly.
+func swapRecommendations(sysInfo *system.Info, results *types.Results) []string {
+	if sysInfo == nil || sysInfo.RAMTotalMB == 0 || sysInfo.RAMTotalMB > 8192 {
+		return nil
+	}
+	var recommendations []string
+	if len(sysInfo.Swap) == 0 {
+		recommendations = append(recommendations,
+			fmt.Sprintf("No swap is configured on %d MB of RAM. The execution client is likely to be OOM-killed during sync; enable zram swap (e.g. zram-tools or systemd-zram-generator) or lower the client cache.", sysInfo.RAMTotalMB),
+		)
+	}
+	if p := results.Memory.Pressure; p != nil && p.OK() && p.SwapInMB > 0 && p.RetouchMBPerSec < 100 {
+		kind := "Disk-backed swap"
+		if !sysInfo.HasDiskSwap() {
+			kind = "Swap"
+		}
+		recommendations = append(recommendations,
+			fmt.Sprintf("%s pages back in at only %.0f MB/s under memory pressure (slowest 64 MB took %.0f ms). With %d MB of RAM the execution client will stall or be OOM-killed during sync; use zram swap or a board with more RAM.",
+				kind, p.RetouchMBPerSec, p.MaxStallMs, sysInfo.RAMTotalMB),
+		)
+	}
+	return recommendations
+}
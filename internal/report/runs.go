@@ -0,0 +1,136 @@
+package report
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// UnreliableCV is the coefficient of variation (percent) above which a
+// metric is flagged as too noisy to trust across repeated runs
+const UnreliableCV = 10.0
+
+// RunStatistics aggregates every metric over repeated runs of the suite
+type RunStatistics struct {
+	Runs         int                `json:"runs"`
+	UnreliableCV float64            `json:"unreliable_cv_percent"`
+	Scores       []MetricStatistics `json:"scores"`
+	Metrics      []MetricStatistics `json:"metrics"`
+}
+
+// MetricStatistics holds the distribution of one metric across runs
+type MetricStatistics struct {
+	Metric  string  `json:"metric"`
+	Samples int     `json:"samples"`
+	Mean    float64 `json:"mean"`
+	Median  float64 `json:"median"`
+	StdDev  float64 `json:"stddev"`
+	// CVPercent is the standard deviation relative to the mean
+	CVPercent float64 `json:"cv_percent"`
+	// CI95 is the half-width of the 95% confidence interval of the mean
+	CI95       float64 `json:"ci95"`
+	Min        float64 `json:"min"`
+	Max        float64 `json:"max"`
+	Unreliable bool    `json:"unreliable,omitempty"`
+}
+
+// AggregateRuns computes per-metric statistics over the results of repeated
+// runs, scoring each run with the given profile. Metrics missing from a run
+// (a failed benchmark) use the runs that have them.
+func AggregateRuns(runs []*types.Results, scoring *ScoringProfile) *RunStatistics {
+	samples := make(map[string][]float64)
+	for _, results := range runs {
+		for metric, value := range runMetrics(results, scoring) {
+			samples[metric] = append(samples[metric], value)
+		}
+	}
+
+	names := make([]string, 0, len(samples))
+	for metric := range samples {
+		names = append(names, metric)
+	}
+	sort.Strings(names)
+
+	stats := &RunStatistics{Runs: len(runs), UnreliableCV: UnreliableCV}
+	for _, metric := range names {
+		s := metricStatistics(metric, samples[metric])
+		if strings.HasPrefix(metric, "summary.") {
+			stats.Scores = append(stats.Scores, s)
+		} else {
+			stats.Metrics = append(stats.Metrics, s)
+		}
+	}
+	return stats
+}
+
+// Unreliable returns the benchmark metrics flagged as too noisy
+func (s *RunStatistics) Unreliable() []MetricStatistics {
+	var noisy []MetricStatistics
+	for _, m := range s.Metrics {
+		if m.Unreliable {
+			noisy = append(noisy, m)
+		}
+	}
+	return noisy
+}
+
+// runMetrics returns the score and benchmark metrics of one run
+func runMetrics(results *types.Results, scoring *ScoringProfile) map[string]float64 {
+	return comparableMetrics(map[string]any{
+		"summary": calculateSummary(results, scoring),
+		"cpu":     results.CPU,
+		"memory":  results.Memory,
+		"disk":    results.Disk,
+		"forks":   results.Forks,
+	})
+}
+
+// metricStatistics summarizes the samples of one metric
+func metricStatistics(metric string, values []float64) MetricStatistics {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	n := len(sorted)
+
+	s := MetricStatistics{Metric: metric, Samples: n, Min: sorted[0], Max: sorted[n-1]}
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	s.Mean = sum / float64(n)
+	if n%2 == 1 {
+		s.Median = sorted[n/2]
+	} else {
+		s.Median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	if n < 2 {
+		return s
+	}
+
+	var sq float64
+	for _, v := range sorted {
+		sq += (v - s.Mean) * (v - s.Mean)
+	}
+	s.StdDev = math.Sqrt(sq / float64(n-1))
+	s.CI95 = studentT95(n-1) * s.StdDev / math.Sqrt(float64(n))
+	if s.Mean != 0 {
+		s.CVPercent = s.StdDev / math.Abs(s.Mean) * 100
+	}
+	s.Unreliable = s.CVPercent > UnreliableCV
+	return s
+}
+
+// studentT95 returns the two-sided 95% Student's t critical value
+func studentT95(df int) float64 {
+	table := []float64{12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+		2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086}
+	if df <= len(table) {
+		return table[df-1]
+	}
+	if df <= 30 {
+		return 2.042
+	}
+	return 1.96
+}
@@ -0,0 +1,454 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// ScoringProfile is a named set of score weights and thresholds. The
+// versioned profiles are frozen: when a benchmark change shifts raw numbers,
+// a new one is added rather than an existing one edited, so a score always
+// means what it meant under the profile that produced it. Role profiles
+// weight the same benchmarks for a kind of node, and custom profiles can be
+// loaded from JSON with LoadScoringProfile.
+type ScoringProfile struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// SchemaVersion is the report schema a versioned profile was calibrated
+	// for; reports that do not record a profile were scored with the newest
+	// profile at or below their schema version. 0 for role and custom
+	// profiles, which never stand in for an unnamed one.
+	SchemaVersion int `json:"-"`
+
+	// Deployment is "consensus" or "execution" for split setups where only
+	// that client runs on this machine, and the other on another host or as
+	// an external RPC. The verdict then assesses the local client alone.
+	// "dvt" adds distributed validator middleware and cluster checks to a
+	// node running both. Empty when both run here alone.
+	Deployment string `json:"deployment,omitempty"`
+
+	// Weights of the category scores in the overall score
+	Weights CategoryWeights `json:"weights"`
+	// Scored benchmarks of each category with their weight in the category
+	CPU    []MetricScore `json:"cpu"`
+	Memory []MetricScore `json:"memory"`
+	Disk   []MetricScore `json:"disk"`
+}
+
+// CategoryWeights weights the category scores in the overall score
+type CategoryWeights struct {
+	CPU    float64 `json:"cpu"`
+	Memory float64 `json:"memory"`
+	Disk   float64 `json:"disk"`
+}
+
+// MetricScore scores one benchmark: its value maps to 25 at Poor, 50 at
+// Marginal, 75 at Good and 100 at Excellent, linearly in between
+type MetricScore struct {
+	Metric     string     `json:"metric"`
+	Weight     float64    `json:"weight"`
+	Thresholds Thresholds `json:"thresholds"`
+}
+
+// Thresholds are the values of a metric that score 25, 50, 75 and 100
+type Thresholds struct {
+	Poor      float64 `json:"poor"`
+	Marginal  float64 `json:"marginal"`
+	Good      float64 `json:"good"`
+	Excellent float64 `json:"excellent"`
+}
+
+// scoredMetric is a benchmark result scoring profiles can weight
+type scoredMetric struct {
+	label string
+	value func(r *types.Results) float64
+	ok    func(r *types.Results) bool
+}
+
+// scoredMetrics are the benchmarks a profile can score, by name
+var scoredMetrics = map[string]scoredMetric{
+	"cpu.keccak": {"Keccak256",
+		func(r *types.Results) float64 { return r.CPU.Keccak.HashesPerSecond },
+		func(r *types.Results) bool { return r.CPU.Keccak.OK() }},
+	// Verification rate
+	"cpu.ecdsa": {"ECDSA",
+		func(r *types.Results) float64 { return r.CPU.ECDSA.VerificationsPerSecond },
+		func(r *types.Results) bool { return r.CPU.ECDSA.OK() }},
+	"cpu.bls": {"BLS12-381",
+		func(r *types.Results) float64 { return r.CPU.BLS.VerificationsPerSecond },
+		func(r *types.Results) bool { return r.CPU.BLS.OK() }},
+	"cpu.bn256": {"BN256",
+		func(r *types.Results) float64 { return r.CPU.BN256.PairingsPerSecond },
+		func(r *types.Results) bool { return r.CPU.BN256.OK() }},
+	// Average of encode and decode rates
+	"cpu.rlp": {"RLP",
+		func(r *types.Results) float64 { return (r.CPU.RLP.EncodesPerSecond + r.CPU.RLP.DecodesPerSecond) / 2 },
+		func(r *types.Results) bool { return r.CPU.RLP.OK() }},
+	// Blob proof verification rate
+	"cpu.kzg": {"KZG",
+		func(r *types.Results) float64 { return r.CPU.KZG.VerificationsPerSecond },
+		func(r *types.Results) bool { return r.CPU.KZG.OK() }},
+	"memory.trie": {"Trie",
+		func(r *types.Results) float64 { return r.Memory.Trie.InsertsPerSecond },
+		func(r *types.Results) bool { return r.Memory.Trie.OK() }},
+	"memory.pool": {"Pool",
+		func(r *types.Results) float64 {
+			return r.Memory.Pool.AllocationsPerSecond + r.Memory.Pool.ReusesPerSecond
+		},
+		func(r *types.Results) bool { return r.Memory.Pool.OK() }},
+	"memory.state_cache": {"State Cache",
+		func(r *types.Results) float64 { return r.Memory.StateCache.CacheHitsPerSecond },
+		func(r *types.Results) bool { return r.Memory.StateCache.OK() }},
+	// Average of write and read speeds
+	"disk.sequential": {"Sequential",
+		func(r *types.Results) float64 {
+			return (r.Disk.Sequential.WriteSpeedMBps + r.Disk.Sequential.ReadSpeedMBps) / 2
+		},
+		func(r *types.Results) bool { return r.Disk.Sequential.OK() }},
+	// Average of read and write IOPS
+	"disk.random": {"Random 4K",
+		func(r *types.Results) float64 { return (r.Disk.Random.ReadIOPS + r.Disk.Random.WriteIOPS) / 2 },
+		func(r *types.Results) bool { return r.Disk.Random.OK() }},
+	"disk.batch": {"Batch Writes",
+		func(r *types.Results) float64 { return r.Disk.Batch.ThroughputMBps },
+		func(r *types.Results) bool { return r.Disk.Batch.OK() }},
+	// Random page-fault reads from the freezer
+	"disk.mmap": {"Mmap Reads",
+		func(r *types.Results) float64 { return r.Disk.Mmap.RandomReadsPerSecond },
+		func(r *types.Results) bool { return r.Disk.Mmap.OK() }},
+}
+
+// DefaultScoringProfile is the profile new reports are scored with
+const DefaultScoringProfile = "v2-2026"
+
+// Thresholds shared by the built-in profiles
+var (
+	keccakThresholds     = Thresholds{50000, 100000, 200000, 500000}
+	ecdsaThresholds      = Thresholds{250, 500, 1000, 2000}
+	blsThresholds        = Thresholds{50, 100, 200, 500}
+	bn256Thresholds      = Thresholds{10, 25, 50, 100}
+	rlpThresholds        = Thresholds{50000, 100000, 200000, 400000}
+	kzgThresholds        = Thresholds{40, 100, 200, 500}
+	trieThresholds       = Thresholds{3300, 6600, 13000, 33000}
+	poolThresholds       = Thresholds{50000, 100000, 200000, 500000}
+	stateCacheThresholds = Thresholds{50000, 100000, 200000, 500000}
+	sequentialThresholds = Thresholds{50, 100, 200, 400}
+	randomThresholds     = Thresholds{5000, 10000, 20000, 50000}
+	batchThresholds      = Thresholds{10, 25, 50, 100}
+
+	defaultWeights = CategoryWeights{CPU: 0.40, Memory: 0.25, Disk: 0.35}
+	defaultCPU     = []MetricScore{
+		{"cpu.keccak", 0.20, keccakThresholds},
+		{"cpu.ecdsa", 0.28, ecdsaThresholds},
+		{"cpu.bls", 0.20, blsThresholds},
+		{"cpu.bn256", 0.12, bn256Thresholds},
+		{"cpu.rlp", 0.10, rlpThresholds},
+		{"cpu.kzg", 0.10, kzgThresholds},
+	}
+	defaultMemory = []MetricScore{
+		{"memory.trie", 0.40, trieThresholds},
+		{"memory.pool", 0.30, poolThresholds},
+		{"memory.state_cache", 0.30, stateCacheThresholds},
+	}
+	defaultDisk = []MetricScore{
+		// Random I/O is the most important for Ethereum
+		{"disk.sequential", 0.30, sequentialThresholds},
+		{"disk.random", 0.45, randomThresholds},
+		{"disk.batch", 0.25, batchThresholds},
+	}
+)
+
+// scoringProfiles lists the versioned profiles oldest first, then the role
+// profiles
+var scoringProfiles = []*ScoringProfile{
+	{
+		Name:          "v1-2024",
+		Description:   "Original weights and thresholds",
+		SchemaVersion: 2,
+		Weights:       defaultWeights,
+		CPU:           defaultCPU,
+		// Trie thresholds set before inserts walked a hashed-path trie,
+		// which made them about a third slower on the same hardware
+		Memory: []MetricScore{
+			{"memory.trie", 0.40, Thresholds{5000, 10000, 20000, 50000}},
+			{"memory.pool", 0.30, poolThresholds},
+			{"memory.state_cache", 0.30, stateCacheThresholds},
+		},
+		Disk: defaultDisk,
+	},
+	{
+		Name:          "v2-2026",
+		Description:   "Trie thresholds rescaled for inserts into a hashed-path trie",
+		SchemaVersion: 3,
+		Weights:       defaultWeights,
+		CPU:           defaultCPU,
+		Memory:        defaultMemory,
+		Disk:          defaultDisk,
+	},
+	{
+		Name:        "solo-staker",
+		Description: "Home validator running an execution and a consensus client: disk latency and signature checks first",
+		Weights:     CategoryWeights{CPU: 0.35, Memory: 0.20, Disk: 0.45},
+		CPU: []MetricScore{
+			{"cpu.keccak", 0.15, keccakThresholds},
+			{"cpu.ecdsa", 0.25, ecdsaThresholds},
+			{"cpu.bls", 0.30, blsThresholds},
+			{"cpu.bn256", 0.10, bn256Thresholds},
+			{"cpu.rlp", 0.10, rlpThresholds},
+			{"cpu.kzg", 0.10, kzgThresholds},
+		},
+		Memory: defaultMemory,
+		Disk: []MetricScore{
+			{"disk.sequential", 0.15, sequentialThresholds},
+			{"disk.random", 0.55, randomThresholds},
+			{"disk.batch", 0.30, batchThresholds},
+		},
+	},
+	{
+		Name:        "archive-node",
+		Description: "Archive or full-history RPC node: random reads over a multi-terabyte database dominate",
+		Weights:     CategoryWeights{CPU: 0.20, Memory: 0.20, Disk: 0.60},
+		CPU:         defaultCPU,
+		Memory: []MetricScore{
+			{"memory.trie", 0.40, trieThresholds},
+			{"memory.pool", 0.20, poolThresholds},
+			{"memory.state_cache", 0.40, stateCacheThresholds},
+		},
+		Disk: []MetricScore{
+			{"disk.sequential", 0.15, Thresholds{100, 200, 400, 800}},
+			{"disk.random", 0.45, Thresholds{10000, 20000, 40000, 100000}},
+			{"disk.batch", 0.20, Thresholds{25, 50, 100, 200}},
+			{"disk.mmap", 0.20, Thresholds{5000, 10000, 20000, 50000}},
+		},
+	},
+	{
+		Name:        "light-infra",
+		Description: "Consensus-only, light client or relay infrastructure: signature verification first, little disk",
+		Weights:     CategoryWeights{CPU: 0.55, Memory: 0.25, Disk: 0.20},
+		CPU: []MetricScore{
+			{"cpu.keccak", 0.15, keccakThresholds},
+			{"cpu.ecdsa", 0.15, ecdsaThresholds},
+			{"cpu.bls", 0.35, blsThresholds},
+			{"cpu.bn256", 0.10, bn256Thresholds},
+			{"cpu.rlp", 0.10, rlpThresholds},
+			{"cpu.kzg", 0.15, kzgThresholds},
+		},
+		Memory: defaultMemory,
+		Disk: []MetricScore{
+			{"disk.sequential", 0.30, Thresholds{25, 50, 100, 200}},
+			{"disk.random", 0.45, Thresholds{1000, 2500, 5000, 10000}},
+			{"disk.batch", 0.25, Thresholds{5, 10, 25, 50}},
+		},
+	},
+	{
+		Name:        "split-consensus",
+		Description: "Consensus client on this machine, execution client on another host or an external RPC",
+		Deployment:  DeploymentConsensus,
+		Weights:     CategoryWeights{CPU: 0.55, Memory: 0.25, Disk: 0.20},
+		CPU: []MetricScore{
+			{"cpu.bls", 0.65, blsThresholds},
+			{"cpu.kzg", 0.35, kzgThresholds},
+		},
+		Memory: []MetricScore{
+			{"memory.pool", 0.50, poolThresholds},
+			{"memory.state_cache", 0.50, stateCacheThresholds},
+		},
+		Disk: []MetricScore{
+			{"disk.sequential", 0.30, Thresholds{25, 50, 100, 200}},
+			{"disk.random", 0.40, Thresholds{1000, 2500, 5000, 10000}},
+			{"disk.batch", 0.30, Thresholds{5, 10, 25, 50}},
+		},
+	},
+	{
+		Name:        "split-execution",
+		Description: "Execution client on this machine, consensus client on another host",
+		Deployment:  DeploymentExecution,
+		Weights:     CategoryWeights{CPU: 0.35, Memory: 0.25, Disk: 0.40},
+		CPU: []MetricScore{
+			{"cpu.keccak", 0.25, keccakThresholds},
+			{"cpu.ecdsa", 0.30, ecdsaThresholds},
+			{"cpu.bn256", 0.15, bn256Thresholds},
+			{"cpu.rlp", 0.15, rlpThresholds},
+			{"cpu.kzg", 0.15, kzgThresholds},
+		},
+		Memory: defaultMemory,
+		Disk: []MetricScore{
+			{"disk.sequential", 0.15, sequentialThresholds},
+			{"disk.random", 0.55, randomThresholds},
+			{"disk.batch", 0.30, batchThresholds},
+		},
+	},
+	{
+		Name:        "dvt-operator",
+		Description: "Distributed validator node (Obol charon, SSV): a full node plus middleware signing partial signatures",
+		Deployment:  DeploymentDVT,
+		Weights:     CategoryWeights{CPU: 0.40, Memory: 0.20, Disk: 0.40},
+		// The middleware takes about a fifth of the CPU and verifies every
+		// operator's partial signatures, so the signature thresholds are a
+		// quarter higher than a solo node's
+		CPU: []MetricScore{
+			{"cpu.keccak", 0.10, keccakThresholds},
+			{"cpu.ecdsa", 0.20, Thresholds{312, 625, 1250, 2500}},
+			{"cpu.bls", 0.40, Thresholds{63, 125, 250, 625}},
+			{"cpu.bn256", 0.10, bn256Thresholds},
+			{"cpu.rlp", 0.10, rlpThresholds},
+			{"cpu.kzg", 0.10, kzgThresholds},
+		},
+		Memory: defaultMemory,
+		Disk: []MetricScore{
+			{"disk.sequential", 0.15, sequentialThresholds},
+			{"disk.random", 0.55, randomThresholds},
+			{"disk.batch", 0.30, batchThresholds},
+		},
+	},
+}
+
+// Deployments a scoring profile can assess
+const (
+	DeploymentConsensus = "consensus"
+	DeploymentExecution = "execution"
+	DeploymentDVT       = "dvt"
+)
+
+// FindScoringProfile returns the named scoring profile; "" selects the default
+func FindScoringProfile(name string) (*ScoringProfile, error) {
+	if name == "" {
+		name = DefaultScoringProfile
+	}
+	for _, p := range scoringProfiles {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown scoring profile %q (available: %s, or a JSON file)", name, strings.Join(ScoringProfileNames(), ", "))
+}
+
+// ResolveScoringProfile returns a built-in profile by name, or loads a
+// custom one when nameOrPath is a JSON file
+func ResolveScoringProfile(nameOrPath string) (*ScoringProfile, error) {
+	if strings.HasSuffix(nameOrPath, ".json") {
+		return LoadScoringProfile(nameOrPath)
+	}
+	return FindScoringProfile(nameOrPath)
+}
+
+// LoadScoringProfile reads and validates a custom scoring profile
+func LoadScoringProfile(path string) (*ScoringProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scoring profile: %w", err)
+	}
+	var p ScoringProfile
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse scoring profile %s: %w", path, err)
+	}
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("invalid scoring profile %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// validate checks a custom profile for mistakes that would silently skew
+// scores
+func (p *ScoringProfile) validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("missing name")
+	}
+	if _, err := FindScoringProfile(p.Name); err == nil {
+		return fmt.Errorf("name %q is taken by a built-in profile", p.Name)
+	}
+	switch p.Deployment {
+	case "", DeploymentConsensus, DeploymentExecution, DeploymentDVT:
+	default:
+		return fmt.Errorf("unknown deployment %q (want %q, %q or %q)", p.Deployment, DeploymentConsensus, DeploymentExecution, DeploymentDVT)
+	}
+	w := p.Weights
+	if w.CPU < 0 || w.Memory < 0 || w.Disk < 0 || w.CPU+w.Memory+w.Disk <= 0 {
+		return fmt.Errorf("category weights must not be negative and must not all be zero")
+	}
+	for category, metrics := range map[string][]MetricScore{"cpu": p.CPU, "memory": p.Memory, "disk": p.Disk} {
+		for _, m := range metrics {
+			if _, ok := scoredMetrics[m.Metric]; !ok || !strings.HasPrefix(m.Metric, category+".") {
+				return fmt.Errorf("unknown %s metric %q", category, m.Metric)
+			}
+			if m.Weight <= 0 {
+				return fmt.Errorf("%s: weight must be positive", m.Metric)
+			}
+			t := m.Thresholds
+			if !(0 < t.Poor && t.Poor < t.Marginal && t.Marginal < t.Good && t.Good < t.Excellent) {
+				return fmt.Errorf("%s: thresholds must be positive and increase from poor to excellent", m.Metric)
+			}
+		}
+	}
+	return nil
+}
+
+// ScoringProfileNames returns the names of all built-in scoring profiles,
+// versioned ones oldest first
+func ScoringProfileNames() []string {
+	names := make([]string, len(scoringProfiles))
+	for i, p := range scoringProfiles {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// scoringProfileForSchema returns the profile a report of the given schema
+// version was scored with when it does not name one
+func scoringProfileForSchema(version int) *ScoringProfile {
+	profile := scoringProfiles[0]
+	for _, p := range scoringProfiles {
+		if p.SchemaVersion > 0 && p.SchemaVersion <= version {
+			profile = p
+		}
+	}
+	return profile
+}
+
+// scoring returns the profile the report's scores were computed with
+func (r *Report) scoring() *ScoringProfile {
+	if r.scoringProfile != nil {
+		return r.scoringProfile
+	}
+	if r.Metadata.ScoringProfile != "" {
+		if p, err := FindScoringProfile(r.Metadata.ScoringProfile); err == nil {
+			return p
+		}
+	}
+	return scoringProfileForSchema(r.Metadata.SchemaVersion)
+}
+
+// components scores the benchmarks of one category of the profile
+func (p *ScoringProfile) components(metrics []MetricScore, results *types.Results) []scoreComponent {
+	components := make([]scoreComponent, 0, len(metrics))
+	for _, m := range metrics {
+		s := scoredMetrics[m.Metric]
+		t := m.Thresholds
+		components = append(components, scoreComponent{
+			s.label,
+			scoreMetric(s.value(results), t.Poor, t.Marginal, t.Good, t.Excellent),
+			m.Weight,
+			s.ok(results),
+		})
+	}
+	return components
+}
+
+// cpu returns the scored CPU benchmarks
+func (p *ScoringProfile) cpu(results *types.Results) []scoreComponent {
+	return p.components(p.CPU, results)
+}
+
+// memory returns the scored memory benchmarks
+func (p *ScoringProfile) memory(results *types.Results) []scoreComponent {
+	return p.components(p.Memory, results)
+}
+
+// disk returns the scored disk benchmarks
+func (p *ScoringProfile) disk(results *types.Results) []scoreComponent {
+	return p.components(p.Disk, results)
+}
@@ -0,0 +1,107 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// section is one benchmark category laid out as a score, per-benchmark
+// score bars and a table of headline metrics
+type section struct {
+	Title      string
+	Score      int
+	Components []scoreComponent
+	Rows       []sectionRow
+}
+
+// sectionRow is the headline metric of a single benchmark
+type sectionRow struct {
+	Name   string
+	Value  string
+	Rating string
+	Error  string
+}
+
+// newRow builds a row, replacing the metric with the error or skip reason
+// when the benchmark did not complete
+func newRow(name string, status types.Status, rating, format string, args ...any) sectionRow {
+	switch {
+	case status.Error != "":
+		return sectionRow{Name: name, Error: status.Error}
+	case status.Skipped:
+		return sectionRow{Name: name, Error: "skipped"}
+	}
+	return sectionRow{Name: name, Value: fmt.Sprintf(format, args...), Rating: rating}
+}
+
+// reportSections returns the CPU, memory, disk and fork pack sections
+func reportSections(r *Report) []section {
+	cpu, mem, disk := &r.CPU, &r.Memory, &r.Disk
+	scoring := r.scoring()
+	scored := &types.Results{CPU: r.CPU, Memory: r.Memory, Disk: r.Disk}
+	sections := []section{
+		{
+			Title:      "CPU",
+			Score:      r.Summary.CPUScore,
+			Components: scoring.cpu(scored),
+			Rows: []sectionRow{
+				newRow("Keccak256", cpu.Keccak.Status, cpu.Keccak.Rating, "%.0f hashes/sec", cpu.Keccak.HashesPerSecond),
+				newRow("ECDSA/secp256k1", cpu.ECDSA.Status, cpu.ECDSA.Rating, "%.0f verify/sec", cpu.ECDSA.VerificationsPerSecond),
+				newRow("BLS12-381", cpu.BLS.Status, cpu.BLS.Rating, "%.0f verify/sec", cpu.BLS.VerificationsPerSecond),
+				newRow("BN256 Pairing", cpu.BN256.Status, cpu.BN256.Rating, "%.0f pairings/sec", cpu.BN256.PairingsPerSecond),
+				newRow("RLP", cpu.RLP.Status, cpu.RLP.Rating, "%.0f encodes/sec, %.0f decodes/sec", cpu.RLP.EncodesPerSecond, cpu.RLP.DecodesPerSecond),
+				newRow("EVM", cpu.EVM.Status, cpu.EVM.Rating, "%.1f Mgas/sec, %.0f ERC-20 transfers/sec", cpu.EVM.MGasPerSecond, cpu.EVM.TransfersPerSecond),
+				newRow("KZG Blob Proofs", cpu.KZG.Status, cpu.KZG.Rating, "%.0f verify/sec", cpu.KZG.VerificationsPerSecond),
+				newRow("SHA-256", cpu.SHA256.Status, cpu.SHA256.Rating, "%.0f node hashes/sec, %.0f MB/s", cpu.SHA256.NodeHashesPerSecond, cpu.SHA256.MBPerSecond),
+				newRow("Multi-core Scaling", cpu.Parallel.Status, cpu.Parallel.Rating, "%.0f%% efficiency, %d workers", cpu.Parallel.ScalingEfficiency, cpu.Parallel.Workers),
+			},
+		},
+		{
+			Title:      "Memory",
+			Score:      r.Summary.MemoryScore,
+			Components: scoring.memory(scored),
+			Rows: []sectionRow{
+				newRow("Trie Operations", mem.Trie.Status, mem.Trie.Rating, "%.0f inserts/sec", mem.Trie.InsertsPerSecond),
+				newRow("Pool Allocation", mem.Pool.Status, mem.Pool.Rating, "%.0f allocs/sec", mem.Pool.AllocationsPerSecond),
+				newRow("State Cache", mem.StateCache.Status, mem.StateCache.Rating, "%.0f hits/sec", mem.StateCache.CacheHitsPerSecond),
+				newRow("Memory Latency", mem.Latency.Status, mem.Latency.Rating, "%.1f ns L1, %.0f ns DRAM", mem.Latency.L1LatencyNs, mem.Latency.DRAMLatencyNs),
+				newRow("Correctness", mem.Correctness.Status, mem.Correctness.Rating, "%d checks, %d failures", mem.Correctness.Checks, mem.Correctness.Failures),
+				newRow("MPT (go-ethereum)", mem.MPT.Status, mem.MPT.Rating, "%.0f inserts/sec, %.0f gets/sec", mem.MPT.Memory.InsertsPerSecond, mem.MPT.Memory.GetsPerSecond),
+			},
+		},
+		{
+			Title:      "Disk",
+			Score:      r.Summary.DiskScore,
+			Components: scoring.disk(scored),
+			Rows: []sectionRow{
+				newRow("Sequential I/O", disk.Sequential.Status, disk.Sequential.Rating, "%.1f MB/s write, %.1f MB/s read", disk.Sequential.WriteSpeedMBps, disk.Sequential.ReadSpeedMBps),
+				newRow("Random 4K I/O", disk.Random.Status, disk.Random.Rating, "%.0f read IOPS, %.0f write IOPS", disk.Random.ReadIOPS, disk.Random.WriteIOPS),
+				newRow("Mmap Reads", disk.Mmap.Status, disk.Mmap.Rating, "%.0f random reads/sec, %.1f MB/s sequential", disk.Mmap.RandomReadsPerSecond, disk.Mmap.SequentialMBps),
+				newRow("Batch Writes", disk.Batch.Status, disk.Batch.Rating, "%.1f MB/s, max %.0f ms", disk.Batch.ThroughputMBps, disk.Batch.MaxBatchLatencyMs),
+				newRow("State Scheme", disk.StateScheme.Status, disk.StateScheme.Rating, "%s favored, path %.2fx", disk.StateScheme.Favored, disk.StateScheme.PathSpeedup),
+				newRow("Blob Store", disk.Blob.Status, disk.Blob.Rating, "%.0fx real-time", disk.Blob.RealtimeFactor),
+				newRow("Key-Value Store", disk.KVStore.Status, disk.KVStore.Rating, "%.0f Pebble writes/sec", disk.KVStore.Pebble.WritesPerSecond),
+				newRow("Fsync Latency", disk.Fsync.Status, disk.Fsync.Rating, "p99 %.2f ms", disk.Fsync.P99LatencyMs),
+				newRow("Slot Deadline", disk.Slot.Status, disk.Slot.Rating, "%.1f%% within %.0f s, p99 %.0f ms", disk.Slot.SuccessRate, disk.Slot.DeadlineMs/1000, disk.Slot.P99Ms),
+				newRow("Contention", disk.Contention.Status, disk.Contention.Rating, "%.0f%% lost under combined load", disk.Contention.PenaltyPercent),
+				newRow("Trie Commit", disk.TrieCommit.Status, disk.TrieCommit.Rating, "%.1f ms p99 per %d updates", disk.TrieCommit.P99CommitMs, disk.TrieCommit.CommitEvery),
+			},
+		},
+	}
+
+	if r.Forks != nil {
+		forks := section{Title: "Fork Packs", Score: -1}
+		if p := r.Forks.Pectra; p != nil {
+			forks.Components = append(forks.Components, scoreComponent{"Pectra", float64(r.Summary.ForkScores["pectra"]), 1, p.OK()})
+			forks.Rows = append(forks.Rows, newRow("Pectra", p.Status, p.Rating, "%.1f Mgas/sec", p.MGasPerSecond))
+		}
+		if f := r.Forks.Fusaka; f != nil {
+			forks.Components = append(forks.Components, scoreComponent{"Fusaka", float64(r.Summary.ForkScores["fusaka"]), 1, f.OK()})
+			forks.Rows = append(forks.Rows, newRow("Fusaka", f.Status, f.Rating, "%.0fx real-time", f.RealtimeFactor))
+		}
+		sections = append(sections, forks)
+	}
+
+	return sections
+}
@@ -0,0 +1,247 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// Drift limits of the soak verdict, as the change from the first to the
+// last quarter of the iterations
+const (
+	soakDegradedDrop = 10.0
+	soakUnstableDrop = 25.0
+	// Sequential writes falling this far are a write cliff: the SLC cache
+	// of an SSD or the erase blocks of an SD card are exhausted
+	soakWriteCliffDrop = 50.0
+	// soakUnstableErrors is the share of iterations with failed benchmarks,
+	// in percent, that makes the soak unstable
+	soakUnstableErrors = 10.0
+)
+
+// soakSeries are the metrics each soak iteration records
+var soakSeries = []struct {
+	metric string
+	label  string
+	value  func(r *types.Results) (float64, bool)
+}{
+	{"cpu.keccak", "Keccak256 hashes/s", func(r *types.Results) (float64, bool) {
+		return r.CPU.Keccak.HashesPerSecond, r.CPU.Keccak.OK()
+	}},
+	{"cpu.bls", "BLS verifications/s", func(r *types.Results) (float64, bool) {
+		return r.CPU.BLS.VerificationsPerSecond, r.CPU.BLS.OK()
+	}},
+	{"memory.state_cache", "State cache hits/s", func(r *types.Results) (float64, bool) {
+		return r.Memory.StateCache.CacheHitsPerSecond, r.Memory.StateCache.OK()
+	}},
+	{"disk.sequential_write", "Sequential write MB/s", func(r *types.Results) (float64, bool) {
+		return r.Disk.Sequential.WriteSpeedMBps, r.Disk.Sequential.OK()
+	}},
+	{"disk.random", "Random 4K IOPS", func(r *types.Results) (float64, bool) {
+		return (r.Disk.Random.ReadIOPS + r.Disk.Random.WriteIOPS) / 2, r.Disk.Random.OK()
+	}},
+	{"disk.batch", "Batch writes MB/s", func(r *types.Results) (float64, bool) {
+		return r.Disk.Batch.ThroughputMBps, r.Disk.Batch.OK()
+	}},
+}
+
+// SoakSample is one iteration of a soak test
+type SoakSample struct {
+	Iteration int       `json:"iteration"`
+	Start     time.Time `json:"start"`
+	// OffsetSeconds is the iteration's start since the soak began
+	OffsetSeconds   float64            `json:"offset_seconds"`
+	Score           int                `json:"score"`
+	Metrics         map[string]float64 `json:"metrics"`
+	MaxTemperatureC float64            `json:"max_temperature_c,omitempty"`
+	MinFreqMHz      int                `json:"min_freq_mhz,omitempty"`
+	ThrottleEvents  int                `json:"throttle_events,omitempty"`
+	Errors          []string           `json:"errors,omitempty"`
+}
+
+// SoakDrift is the change of one metric over the soak, comparing the mean
+// of the first and of the last quarter of the iterations
+type SoakDrift struct {
+	Metric        string  `json:"metric"`
+	Label         string  `json:"label"`
+	Samples       int     `json:"samples"`
+	First         float64 `json:"first"`
+	Last          float64 `json:"last"`
+	Min           float64 `json:"min"`
+	Max           float64 `json:"max"`
+	ChangePercent float64 `json:"change_percent"`
+}
+
+// SoakResult is the time series of a soak test (-soak) with its stability
+// verdict: "stable", "degraded", "unstable", or "inconclusive" with fewer
+// than three iterations
+type SoakResult struct {
+	Duration         time.Duration `json:"duration_ns"`
+	Iterations       int           `json:"iterations"`
+	FailedIterations int           `json:"failed_iterations"`
+	ErrorRatePercent float64       `json:"error_rate_percent"`
+	MaxTemperatureC  float64       `json:"max_temperature_c,omitempty"`
+	ThrottleEvents   int           `json:"throttle_events"`
+	Drift            []SoakDrift   `json:"drift"`
+	Verdict          string        `json:"verdict"`
+	Reasons          []string      `json:"reasons,omitempty"`
+	Samples          []SoakSample  `json:"samples"`
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r SoakResult) MarshalJSON() ([]byte, error) {
+	type alias SoakResult
+	return types.MarshalWithDuration(alias(r), r.Duration)
+}
+
+// NewSoakSample records one soak iteration, scored with the given profile
+func NewSoakSample(iteration int, soakStart, start time.Time, results *types.Results, scoring *ScoringProfile) SoakSample {
+	s := SoakSample{
+		Iteration:     iteration,
+		Start:         start,
+		OffsetSeconds: start.Sub(soakStart).Seconds(),
+		Score:         calculateSummary(results, scoring).TotalScore,
+		Metrics:       make(map[string]float64),
+	}
+	for _, series := range soakSeries {
+		if v, ok := series.value(results); ok {
+			s.Metrics[series.metric] = v
+		}
+	}
+	if t := results.Thermal; t != nil {
+		s.MaxTemperatureC = t.MaxTemperatureC
+		s.MinFreqMHz = t.MinFreqMHz
+		s.ThrottleEvents = t.ThrottleEvents
+	}
+	for _, e := range results.Errors {
+		s.Errors = append(s.Errors, e.Benchmark+": "+e.Error)
+	}
+	return s
+}
+
+// AnalyzeSoak computes the drift of every metric over the samples and the
+// stability verdict
+func AnalyzeSoak(samples []SoakSample, duration time.Duration) *SoakResult {
+	soak := &SoakResult{Duration: duration, Iterations: len(samples), Samples: samples}
+	for _, s := range samples {
+		if len(s.Errors) > 0 {
+			soak.FailedIterations++
+		}
+		soak.MaxTemperatureC = max(soak.MaxTemperatureC, s.MaxTemperatureC)
+		soak.ThrottleEvents += s.ThrottleEvents
+	}
+	if len(samples) > 0 {
+		soak.ErrorRatePercent = float64(soak.FailedIterations) / float64(len(samples)) * 100
+	}
+
+	for _, series := range soakSeries {
+		var values []float64
+		for _, s := range samples {
+			if v, ok := s.Metrics[series.metric]; ok {
+				values = append(values, v)
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		quarter := max(len(values)/4, 1)
+		d := SoakDrift{
+			Metric:  series.metric,
+			Label:   series.label,
+			Samples: len(values),
+			First:   mean(values[:quarter]),
+			Last:    mean(values[len(values)-quarter:]),
+			Min:     values[0],
+			Max:     values[0],
+		}
+		for _, v := range values {
+			d.Min, d.Max = min(d.Min, v), max(d.Max, v)
+		}
+		if d.First > 0 {
+			d.ChangePercent = (d.Last - d.First) / d.First * 100
+		}
+		soak.Drift = append(soak.Drift, d)
+	}
+
+	var unstable, degraded []string
+	for _, d := range soak.Drift {
+		drop := -d.ChangePercent
+		switch {
+		case d.Metric == "disk.sequential_write" && drop >= soakWriteCliffDrop:
+			unstable = append(unstable, fmt.Sprintf("sequential writes fell %.0f%% (%.0f to %.0f MB/s): a write cliff, the drive's cache or erase blocks ran out", drop, d.First, d.Last))
+		case drop >= soakUnstableDrop:
+			unstable = append(unstable, fmt.Sprintf("%s fell %.0f%%", d.Label, drop))
+		case drop >= soakDegradedDrop:
+			degraded = append(degraded, fmt.Sprintf("%s fell %.0f%%", d.Label, drop))
+		}
+	}
+	switch {
+	case soak.ErrorRatePercent >= soakUnstableErrors:
+		unstable = append(unstable, fmt.Sprintf("benchmarks failed in %d of %d iterations", soak.FailedIterations, soak.Iterations))
+	case soak.FailedIterations > 0:
+		degraded = append(degraded, fmt.Sprintf("benchmarks failed in %d of %d iterations", soak.FailedIterations, soak.Iterations))
+	}
+	if soak.ThrottleEvents > 0 {
+		degraded = append(degraded, fmt.Sprintf("%d throttle events, up to %.0f°C", soak.ThrottleEvents, soak.MaxTemperatureC))
+	}
+
+	switch {
+	case len(samples) < 3:
+		soak.Verdict = "inconclusive"
+		soak.Reasons = append([]string{fmt.Sprintf("only %d iteration(s); soak for longer to measure drift", len(samples))}, append(unstable, degraded...)...)
+	case len(unstable) > 0:
+		soak.Verdict = "unstable"
+		soak.Reasons = append(unstable, degraded...)
+	case len(degraded) > 0:
+		soak.Verdict = "degraded"
+		soak.Reasons = degraded
+	default:
+		soak.Verdict = "stable"
+	}
+	return soak
+}
+
+// ApplySoak attaches a soak test to the report and adds its verdict to the
+// recommendations when the machine did not hold up
+func (r *Report) ApplySoak(soak *SoakResult) {
+	r.Soak = soak
+	switch soak.Verdict {
+	case "unstable":
+		r.Verdict.Recommendations = append(r.Verdict.Recommendations,
+			fmt.Sprintf("Performance did not hold up over a %s soak: %s. A node would fall behind after hours of load even though short runs look fine; check cooling, power and the drive before relying on this machine.", soak.Duration.Round(time.Minute), strings.Join(soak.Reasons, "; ")))
+	case "degraded":
+		r.Verdict.Recommendations = append(r.Verdict.Recommendations,
+			fmt.Sprintf("Performance degraded over a %s soak: %s.", soak.Duration.Round(time.Minute), strings.Join(soak.Reasons, "; ")))
+	}
+}
+
+// mean returns the average of values
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// SaveSoak saves the soak time series as a JSON file with timestamp in
+// filename
+func SaveSoak(soak *SoakResult, outputDir string) (string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+	data, err := json.MarshalIndent(soak, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal soak results: %w", err)
+	}
+	path := filepath.Join(outputDir, fmt.Sprintf("ethbench-soak-%s.json", time.Now().Format("2006-01-02_15-04-05")))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write soak results: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,214 @@
+package report
+
+import (
+	"fmt"
+)
+
+// storageHeadroom is the fraction of free space kept for compaction and growth
+const storageHeadroom = 0.20
+
+// historyConfig describes node storage needs for one history retention mode
+type historyConfig struct {
+	name        string
+	description string
+	executionGB float64
+	consensusGB float64
+}
+
+// historyConfigs lists approximate mainnet datadir sizes (Geth + Nimbus,
+// excluding blobs). Pre-merge history expiry (EIP-4444) drops ~350 GB of
+// block bodies and receipts from the execution client.
+var historyConfigs = []historyConfig{
+	{"full-history", "Geth snap sync with full chain history", 1250, 100},
+	{"postmerge-history", "Geth with pre-merge history expired (EIP-4444)", 900, 100},
+}
+
+// clientStorage is one client's approximate mainnet datadir size and its
+// monthly growth at current chain activity
+type clientStorage struct {
+	name          string
+	layer         string // "execution" or "consensus"
+	sizeGB        float64
+	growthGBMonth float64
+}
+
+// clientStorageTable lists approximate mainnet datadir sizes and growth for
+// a snap/checkpoint synced node keeping full chain history, excluding blobs.
+// Execution growth is dominated by block bodies and receipts, consensus
+// growth by finalized block history.
+var clientStorageTable = []clientStorage{
+	{"Geth", "execution", 1250, 35},
+	{"Nethermind", "execution", 1150, 30},
+	{"Nimbus", "consensus", 100, 3},
+	{"Lighthouse", "consensus", 130, 4},
+}
+
+// blobRetentionGB is the consensus client blob store size at full blob usage:
+// 4096 epochs x 32 slots x 6 blobs x 128 KB (~18 days)
+const blobRetentionGB = 4096 * 32 * 6 * 128.0 / (1024 * 1024)
+
+// StorageAssessment checks disk capacity against node storage requirements
+type StorageAssessment struct {
+	TotalGB         float64            `json:"total_gb"`
+	FreeGB          float64            `json:"free_gb"`
+	BlobRetentionGB float64            `json:"blob_retention_gb"`
+	Configs         []StorageFitResult `json:"configs"`
+	Clients         []ClientProjection `json:"clients"`
+}
+
+// ClientProjection projects when one execution and consensus client pairing
+// fills the disk at current chain growth
+type ClientProjection struct {
+	Execution        string  `json:"execution,omitempty"`
+	Consensus        string  `json:"consensus,omitempty"`
+	CurrentGB        float64 `json:"current_gb"` // Including blobs and headroom
+	GrowthGBPerMonth float64 `json:"growth_gb_per_month"`
+	// MonthsUntilFull is 0 when the pairing does not fit today
+	MonthsUntilFull float64 `json:"months_until_full"`
+}
+
+// Clients names the pairing, e.g. "Geth + Nimbus", or the one client of a
+// split deployment
+func (c ClientProjection) Clients() string {
+	switch {
+	case c.Execution == "":
+		return c.Consensus
+	case c.Consensus == "":
+		return c.Execution
+	}
+	return c.Execution + " + " + c.Consensus
+}
+
+// StorageFitResult tells whether one history configuration fits on the disk
+type StorageFitResult struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	RequiredGB  float64 `json:"required_gb"`
+	FitsDisk    bool    `json:"fits_disk"`
+	FitsFree    bool    `json:"fits_free_space"`
+}
+
+// AssessStorage compares the test directory's filesystem capacity with the
+// requirements of full and expired-history node configurations. For a split
+// deployment only the client running here is counted.
+func (r *Report) AssessStorage(totalBytes, freeBytes uint64) {
+	if totalBytes == 0 {
+		return
+	}
+	const gb = 1024 * 1024 * 1024
+	execution := r.Verdict.Deployment != DeploymentConsensus
+	consensus := r.Verdict.Deployment != DeploymentExecution
+	assessment := &StorageAssessment{
+		TotalGB: float64(totalBytes) / gb,
+		FreeGB:  float64(freeBytes) / gb,
+	}
+	if consensus {
+		assessment.BlobRetentionGB = blobRetentionGB
+	}
+
+	configs := historyConfigs
+	if !execution {
+		// History expiry only shrinks the execution client
+		configs = []historyConfig{{"consensus-only", "Nimbus with the execution client on another host", 0, historyConfigs[0].consensusGB}}
+	}
+	for _, cfg := range configs {
+		required := assessment.BlobRetentionGB
+		if execution {
+			required += cfg.executionGB
+		}
+		if consensus {
+			required += cfg.consensusGB
+		}
+		required *= 1 + storageHeadroom
+		assessment.Configs = append(assessment.Configs, StorageFitResult{
+			Name:        cfg.name,
+			Description: cfg.description,
+			RequiredGB:  required,
+			FitsDisk:    assessment.TotalGB >= required,
+			FitsFree:    assessment.FreeGB >= required,
+		})
+	}
+	assessment.Clients = projectClients(assessment.TotalGB, execution, consensus)
+	r.Verdict.Storage = assessment
+
+	if !execution {
+		if only := assessment.Configs[0]; !only.FitsFree {
+			r.Verdict.Recommendations = append(r.Verdict.Recommendations,
+				fmt.Sprintf("Free space (%.0f GB) is too small for a consensus client with blob retention (~%.0f GB needed).", assessment.FreeGB, only.RequiredGB),
+			)
+		}
+	} else {
+		full, pruned := assessment.Configs[0], assessment.Configs[1]
+		switch {
+		case !pruned.FitsDisk:
+			r.Verdict.Recommendations = append(r.Verdict.Recommendations,
+				fmt.Sprintf("Disk (%.0f GB) is too small even with history expiry (~%.0f GB needed). A 2 TB drive is recommended.", assessment.TotalGB, pruned.RequiredGB),
+			)
+		case !full.FitsDisk:
+			r.Verdict.Recommendations = append(r.Verdict.Recommendations,
+				fmt.Sprintf("Disk (%.0f GB) only fits a node with pre-merge history expired. Run Geth with --history.chain=postmerge.", assessment.TotalGB),
+			)
+		case !full.FitsFree && pruned.FitsFree:
+			r.Verdict.Recommendations = append(r.Verdict.Recommendations,
+				fmt.Sprintf("Free space (%.0f GB) only fits a node with pre-merge history expired. Free up space or run Geth with --history.chain=postmerge.", assessment.FreeGB),
+			)
+		}
+	}
+
+	// Disk capacity: the largest pairing that still fits decides how long the
+	// drive lasts; pairings that do not fit today are covered above
+	var tightest *ClientProjection
+	for i, c := range assessment.Clients {
+		if c.MonthsUntilFull > 0 && (tightest == nil || c.MonthsUntilFull < tightest.MonthsUntilFull) {
+			tightest = &assessment.Clients[i]
+		}
+	}
+	if tightest != nil && tightest.MonthsUntilFull < capacityWarnMonths {
+		r.Verdict.Recommendations = append(r.Verdict.Recommendations,
+			fmt.Sprintf("Disk capacity: %s would fill the disk in ~%.0f months at ~%.0f GB/month of chain growth. Plan for a larger drive or history expiry.", tightest.Clients(), tightest.MonthsUntilFull, tightest.GrowthGBPerMonth),
+		)
+	}
+}
+
+// capacityWarnMonths is the projected lifetime below which the verdict warns
+// that the disk fills up
+const capacityWarnMonths = 12
+
+// projectClients projects, for each execution and consensus client pairing
+// in clientStorageTable, how many months a disk of totalGB lasts. The disk is
+// assumed to be dedicated to the node, keeping the usual headroom free. A
+// layer that runs elsewhere is left out of the pairings.
+func projectClients(totalGB float64, execution, consensus bool) []ClientProjection {
+	layer := func(name string, local bool) []clientStorage {
+		if !local {
+			return []clientStorage{{}}
+		}
+		var clients []clientStorage
+		for _, c := range clientStorageTable {
+			if c.layer == name {
+				clients = append(clients, c)
+			}
+		}
+		return clients
+	}
+	blobs := 0.0
+	if consensus {
+		blobs = blobRetentionGB
+	}
+	var projections []ClientProjection
+	for _, el := range layer("execution", execution) {
+		for _, cl := range layer("consensus", consensus) {
+			p := ClientProjection{
+				Execution:        el.name,
+				Consensus:        cl.name,
+				CurrentGB:        (el.sizeGB + cl.sizeGB + blobs) * (1 + storageHeadroom),
+				GrowthGBPerMonth: el.growthGBMonth + cl.growthGBMonth,
+			}
+			if p.CurrentGB < totalGB {
+				p.MonthsUntilFull = (totalGB - p.CurrentGB) / (p.GrowthGBPerMonth * (1 + storageHeadroom))
+			}
+			projections = append(projections, p)
+		}
+	}
+	return projections
+}
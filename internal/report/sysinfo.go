@@ -0,0 +1,133 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vBenchmark/internal/system"
+)
+
+// FormatSystemInfo renders every detected hardware and OS field for
+// `ethbench sysinfo`, grouped by component. Fields that were not detected
+// are left out.
+func FormatSystemInfo(info *system.Info) string {
+	var sb strings.Builder
+	field := func(label, value string) {
+		if value != "" && value != "0" {
+			sb.WriteString(fmt.Sprintf("  %-15s%s\n", label+":", value))
+		}
+	}
+	section := func(title string) {
+		sb.WriteString("\n" + title + "\n")
+		sb.WriteString(strings.Repeat("-", 40) + "\n")
+	}
+	mhz := func(v int) string {
+		if v == 0 {
+			return ""
+		}
+		return fmt.Sprintf("%d MHz", v)
+	}
+
+	sb.WriteString(strings.Repeat("=", 80) + "\n")
+	sb.WriteString("                     ETHEREUM NODE HARDWARE INVENTORY\n")
+	sb.WriteString(strings.Repeat("=", 80) + "\n")
+
+	section("SYSTEM")
+	field("Hostname", info.Hostname)
+	field("Serial", info.SerialNumber)
+	field("Fingerprint", info.Fingerprint)
+	field("OS", strings.TrimSpace(info.OS+" "+info.OSVersion))
+	field("Kernel", info.KernelVersion)
+	field("Architecture", info.Architecture)
+	field("Board", info.RPiModel)
+	field("GPU Firmware", info.GPUFirmware)
+	field("Bootloader", info.BootloaderVersion)
+
+	section("CPU")
+	field("Model", info.CPUModel)
+	field("Cores", fmt.Sprint(info.CPUCores))
+	field("Governor", info.CPUGovernor)
+	field("Frequency", mhz(info.CPUFreqMHz))
+	field("Max Frequency", mhz(info.CPUMaxFreqMHz))
+	field("Scaling Max", mhz(info.CPUScalingMaxMHz))
+	field("Core Voltage", info.CoreVoltage)
+	field("Throttled", info.ThrottledFlags)
+	for i, c := range info.CPUClusters() {
+		label := "Scaling:"
+		if i > 0 {
+			label = ""
+		}
+		sb.WriteString(fmt.Sprintf("  %-15s%s\n", label, describeCluster(c)))
+	}
+	for _, core := range info.CPUFrequencies {
+		field(fmt.Sprintf("  cpu%d", core.CPU), fmt.Sprintf("%s, %d MHz of %d-%d MHz, %s", core.Governor, core.CurMHz, core.MinMHz, core.MaxMHz, core.Policy))
+	}
+	field("Crypto", formatCPUExtensions(info))
+	field("Features", strings.Join(info.CPUFeatures, " "))
+
+	section("MEMORY")
+	field("RAM", fmt.Sprintf("%d MB", info.RAMTotalMB))
+	field("Swap", formatSwap(info.Swap))
+	for _, d := range info.Swap {
+		swap := fmt.Sprintf("%s, %d MB, %d MB used, priority %d", d.Type, d.SizeMB, d.UsedMB, d.Priority)
+		if d.Zram {
+			swap += ", zram " + d.Compressor
+		}
+		field("  "+d.Name, swap)
+	}
+
+	section("STORAGE")
+	field("Model", info.DiskModel)
+	field("Type", info.DiskType)
+	if info.PCIeLinkSpeed != "" {
+		link := info.PCIeLinkSpeed
+		if info.PCIeLinkWidth != "" {
+			link += " x" + strings.TrimPrefix(info.PCIeLinkWidth, "x")
+		}
+		if info.PCIeMaxLinkSpeed != "" {
+			link += fmt.Sprintf(" (max %s)", info.PCIeMaxLinkSpeed)
+		}
+		field("PCIe Link", link)
+	}
+	if info.USBStorageSpeedMb > 0 {
+		field("USB Link", fmt.Sprintf("%d Mbps", info.USBStorageSpeedMb))
+	}
+	if h := info.DiskHealth; h != nil {
+		field("Health Source", fmt.Sprintf("%s (%s)", h.Device, h.Source))
+		field("Wear", fmt.Sprintf("%d%% of rated endurance used", h.PercentUsed))
+		if h.WrittenTB > 0 {
+			field("Written", fmt.Sprintf("%.1f TB (about %.0f TB left)", h.WrittenTB, h.RemainingTB()))
+		}
+		if h.PowerOnHours > 0 {
+			field("Power-On", fmt.Sprintf("%d hours", h.PowerOnHours))
+		}
+		field("Media Errors", fmt.Sprint(h.MediaErrors))
+		if h.CriticalWarning {
+			field("Warning", "critical warning raised by the drive")
+		}
+	}
+
+	section("NETWORK")
+	if link := info.Network; link != nil {
+		field("Interface", fmt.Sprintf("%s (%s)", link.Interface, link.Type))
+		if link.SpeedMbps > 0 {
+			field("Link Speed", fmt.Sprintf("%d Mbps", link.SpeedMbps))
+		}
+		if w := link.WiFi; w != nil && w.SignalDBm != 0 {
+			field("Wi-Fi SSID", w.SSID)
+			field("Wi-Fi Signal", fmt.Sprintf("%d dBm (%s), quality %d%%", w.SignalDBm, w.Signal(), w.QualityPct))
+			field("Wi-Fi Band", mhz(w.FrequencyMHz))
+			if w.BitrateMbps > 0 {
+				field("Wi-Fi Bitrate", fmt.Sprintf("%.0f Mbit/s", w.BitrateMbps))
+			}
+		}
+		field("Gateway", link.Gateway)
+	} else {
+		field("Interface", "no default route")
+	}
+	for _, nic := range info.NetworkInterfaces {
+		field("  "+nic.Name, strings.TrimPrefix(describeInterface(nic), nic.Name+" "))
+	}
+
+	return sb.String()
+}
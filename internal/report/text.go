@@ -2,7 +2,12 @@ package report
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
+
+	"github.com/vBenchmark/internal/system"
+	"github.com/vBenchmark/internal/types"
 )
 
 // FormatText generates a human-readable text report
@@ -14,6 +19,17 @@ func FormatText(r *Report) string {
 	sb.WriteString(strings.Repeat("=", 80) + "\n")
 	sb.WriteString("                    Ethereum Node Benchmark Report\n")
 	sb.WriteString(fmt.Sprintf("                    Generated: %s\n", r.Metadata.Timestamp.Format("2006-01-02 15:04:05")))
+	sb.WriteString(fmt.Sprintf("                    Workload:  %s\n", r.Metadata.WorkloadVersion))
+	sb.WriteString(fmt.Sprintf("                    Scoring:   %s\n", r.Metadata.ScoringProfile))
+	if r.Metadata.Incomplete {
+		sb.WriteString("                    INCOMPLETE: run was interrupted\n")
+	}
+	if r.Metadata.Offline {
+		sb.WriteString("                    Offline:   network probes skipped\n")
+	}
+	if len(r.Metadata.Tags) > 0 {
+		sb.WriteString(fmt.Sprintf("                    Tags:      %s\n", FormatTags(r.Metadata.Tags)))
+	}
 	sb.WriteString(strings.Repeat("=", 80) + "\n")
 
 	// System Information
@@ -21,11 +37,53 @@ func FormatText(r *Report) string {
 	sb.WriteString(strings.Repeat("-", 40) + "\n")
 	sb.WriteString(fmt.Sprintf("  Hostname:      %s\n", r.System.Hostname))
 	sb.WriteString(fmt.Sprintf("  Serial:        %s\n", r.System.SerialNumber))
+	if r.System.Fingerprint != "" {
+		sb.WriteString(fmt.Sprintf("  Fingerprint:   %s\n", r.System.Fingerprint))
+	}
 	sb.WriteString(fmt.Sprintf("  OS:            %s %s\n", r.System.OS, r.System.OSVersion))
 	sb.WriteString(fmt.Sprintf("  Architecture:  %s\n", r.System.Architecture))
 	sb.WriteString(fmt.Sprintf("  CPU:           %s (%d cores)\n", r.System.CPUModel, r.System.CPUCores))
+	if crypto := formatCPUExtensions(r.System); crypto != "" {
+		sb.WriteString(fmt.Sprintf("  CPU Crypto:    %s\n", crypto))
+	}
+	for i, c := range r.System.CPUClusters() {
+		label := "  CPU Scaling:   "
+		if i > 0 {
+			label = strings.Repeat(" ", len(label))
+		}
+		sb.WriteString(label + describeCluster(c) + "\n")
+	}
+	for _, g := range r.System.CPUGovernorChanges {
+		switch {
+		case g.Applied && g.Restored:
+			sb.WriteString(fmt.Sprintf("  Governor Set:  cpu%s %s -> performance (-set-performance-governor), restored after the run\n", g.CPUs, g.From))
+		case g.Applied:
+			sb.WriteString(fmt.Sprintf("  Governor Set:  cpu%s %s -> performance (-set-performance-governor), not restored\n", g.CPUs, g.From))
+		default:
+			sb.WriteString(fmt.Sprintf("  Governor Set:  cpu%s left on %s: %s\n", g.CPUs, g.From, g.Error))
+		}
+	}
 	sb.WriteString(fmt.Sprintf("  RAM:           %d MB\n", r.System.RAMTotalMB))
+	sb.WriteString(fmt.Sprintf("  Swap:          %s\n", formatSwap(r.System.Swap)))
 	sb.WriteString(fmt.Sprintf("  Storage:       %s\n", r.System.DiskModel))
+	if h := r.System.DiskHealth; h != nil {
+		wear := fmt.Sprintf("%d%% of rated endurance used", h.PercentUsed)
+		if h.WrittenTB > 0 {
+			wear += fmt.Sprintf(", %.1f TB written", h.WrittenTB)
+		}
+		if h.PowerOnHours > 0 {
+			wear += fmt.Sprintf(", %d power-on hours", h.PowerOnHours)
+		}
+		sb.WriteString(fmt.Sprintf("  Disk Wear:     %s (%s)\n", wear, h.Source))
+	}
+
+	if r.Runtime != nil {
+		sb.WriteString(fmt.Sprintf("  Go Runtime:    %s, GOMAXPROCS=%d, GOGC=%s", r.Runtime.GoVersion, r.Runtime.GOMAXPROCS, r.Runtime.GOGC))
+		if r.Runtime.GOMEMLIMIT > 0 {
+			sb.WriteString(fmt.Sprintf(", GOMEMLIMIT=%d MB", r.Runtime.GOMEMLIMIT/(1024*1024)))
+		}
+		sb.WriteString("\n")
+	}
 
 	// Raspberry Pi specific information
 	if r.System.RPiModel != "" {
@@ -49,12 +107,154 @@ func FormatText(r *Report) string {
 		if r.System.CoreVoltage != "" {
 			sb.WriteString(fmt.Sprintf("  Core Voltage:  %s\n", r.System.CoreVoltage))
 		}
-		if len(r.System.CPUFeatures) > 0 {
-			relevant := filterRelevantCPUFeatures(r.System.CPUFeatures)
-			if len(relevant) > 0 {
-				sb.WriteString(fmt.Sprintf("  CPU Features:  %s\n", strings.Join(relevant, ", ")))
+	}
+
+	// Network link
+	if link := r.System.Network; link != nil {
+		sb.WriteString("\nNETWORK\n")
+		sb.WriteString(strings.Repeat("-", 40) + "\n")
+		if link.Pinned {
+			sb.WriteString(fmt.Sprintf("  Interface:     %s (%s, chosen with -iface)\n", link.Interface, link.Type))
+		} else {
+			sb.WriteString(fmt.Sprintf("  Interface:     %s (%s)\n", link.Interface, link.Type))
+		}
+		if link.SpeedMbps > 0 {
+			sb.WriteString(fmt.Sprintf("  Link Speed:    %d Mbps\n", link.SpeedMbps))
+		}
+		if w := link.WiFi; w != nil && w.SignalDBm != 0 {
+			wifi := fmt.Sprintf("%d dBm (%s), quality %d%%", w.SignalDBm, w.Signal(), w.QualityPct)
+			if w.FrequencyMHz > 0 {
+				wifi += fmt.Sprintf(", %d MHz", w.FrequencyMHz)
+			}
+			if w.BitrateMbps > 0 {
+				wifi += fmt.Sprintf(", %.0f Mbit/s", w.BitrateMbps)
+			}
+			sb.WriteString(fmt.Sprintf("  Wi-Fi Signal:  %s\n", wifi))
+		}
+		if link.Gateway != "" {
+			gateway := link.Gateway
+			if link.Probe != nil {
+				gateway += ", " + link.Probe.String()
+			}
+			sb.WriteString(fmt.Sprintf("  Gateway:       %s\n", gateway))
+		} else if link.Pinned {
+			sb.WriteString("  Gateway:       none, no default route through this interface\n")
+		}
+		for i, path := range link.Paths {
+			label := "  Paths:         "
+			if i > 0 {
+				label = strings.Repeat(" ", len(label))
+			}
+			sb.WriteString(label + describePath(path) + "\n")
+		}
+		if rating, reasons := link.Rating(); len(reasons) > 0 {
+			sb.WriteString(fmt.Sprintf("  Rating:        %s (%s)\n", rating, strings.Join(reasons, ", ")))
+		} else {
+			sb.WriteString(fmt.Sprintf("  Rating:        %s\n", rating))
+		}
+		if len(r.System.NetworkInterfaces) > 1 {
+			for i, nic := range r.System.NetworkInterfaces {
+				label := "  All NICs:      "
+				if i > 0 {
+					label = strings.Repeat(" ", len(label))
+				}
+				sb.WriteString(label + describeInterface(nic) + "\n")
 			}
 		}
+		if link.Type == "wifi" {
+			sb.WriteString("  WARNING:       A validator on this machine would run over Wi-Fi.\n")
+			if p := link.Probe; p != nil && p.Received > 1 {
+				sb.WriteString(fmt.Sprintf("                 Round trips to the gateway varied by %.1f ms on average and\n", p.JitterMs))
+				sb.WriteString(fmt.Sprintf("                 peaked at %.1f ms with %.0f%% lost; a wired LAN stays under 1 ms.\n", p.MaxRTTMs, p.LossPercent))
+			}
+			sb.WriteString("                 Latency spikes and dropouts delay attestations and block\n")
+			sb.WriteString("                 proposals. Connect the node with Ethernet.\n")
+		}
+	}
+
+	// Idle baseline
+	if r.Idle != nil && r.Idle.Error != "" {
+		sb.WriteString("\nIDLE BASELINE\n")
+		sb.WriteString(strings.Repeat("-", 40) + "\n")
+		sb.WriteString(fmt.Sprintf("  Status:        Unknown - %s\n", r.Idle.Error))
+	} else if r.Idle != nil {
+		sb.WriteString("\nIDLE BASELINE\n")
+		sb.WriteString(strings.Repeat("-", 40) + "\n")
+		sb.WriteString(fmt.Sprintf("  CPU Idle:      %.1f%%\n", r.Idle.CPUIdlePercent))
+		sb.WriteString(fmt.Sprintf("  Disk IOPS:     %.1f\n", r.Idle.DiskIOPS))
+		sb.WriteString(fmt.Sprintf("  Network:       %.1f KB/s\n", r.Idle.NetworkKBps))
+		if r.Idle.MaxTemperatureC > 0 {
+			sb.WriteString(fmt.Sprintf("  Temperature:   %.1f°C avg, %.1f°C max\n", r.Idle.AvgTemperatureC, r.Idle.MaxTemperatureC))
+		}
+		if r.Idle.Quiet {
+			sb.WriteString("  Status:        Quiet\n")
+		} else {
+			sb.WriteString("  Status:        Busy - results may be affected\n")
+		}
+	}
+
+	// Background jobs
+	if len(r.Interference) > 0 {
+		sb.WriteString("\nBACKGROUND JOBS\n")
+		sb.WriteString(strings.Repeat("-", 40) + "\n")
+		for _, item := range r.Interference {
+			kind := item.Kind
+			if item.CPUPercent >= 0.1 {
+				kind += fmt.Sprintf(" (%.1f%% CPU)", item.CPUPercent)
+			}
+			if len(item.Phases) > 0 {
+				sb.WriteString(fmt.Sprintf("  %-16s %s, active during: %s\n", item.Name, kind, strings.Join(item.Phases, ", ")))
+			} else if item.Kind == "timer" {
+				sb.WriteString(fmt.Sprintf("  %-16s scheduled timer\n", item.Name))
+			} else {
+				sb.WriteString(fmt.Sprintf("  %-16s %s\n", item.Name, kind))
+			}
+		}
+	}
+
+	// Thermal stability
+	if r.Thermal != nil {
+		sb.WriteString("\nTHERMAL STABILITY\n")
+		sb.WriteString(strings.Repeat("-", 40) + "\n")
+		if r.Thermal.MaxTemperatureC > 0 {
+			sb.WriteString(fmt.Sprintf("  Max Temp:      %.1f°C\n", r.Thermal.MaxTemperatureC))
+		}
+		if r.Thermal.MaxFreqMHz > 0 {
+			sb.WriteString(fmt.Sprintf("  CPU Freq:      %d-%d MHz\n", r.Thermal.MinFreqMHz, r.Thermal.MaxFreqMHz))
+		}
+		sb.WriteString(fmt.Sprintf("  Throttling:    %d events, %d frequency drops\n", r.Thermal.ThrottleEvents, r.Thermal.FreqDrops))
+		if r.Thermal.Stable {
+			sb.WriteString("  Status:        Stable\n")
+		} else {
+			for _, phase := range r.Thermal.Phases {
+				if phase.Affected {
+					sb.WriteString(fmt.Sprintf("  %-14s %.1f°C, min %d MHz, %d throttle events\n", phase.Phase+":", phase.MaxTemperatureC, phase.MinFreqMHz, phase.ThrottleEvents))
+				}
+			}
+			sb.WriteString("  WARNING:       Throttling occurred - scores are affected\n")
+		}
+	}
+
+	// Sleep inhibition and suspends
+	if r.Sleep != nil {
+		sb.WriteString("\nPOWER MANAGEMENT\n")
+		sb.WriteString(strings.Repeat("-", 40) + "\n")
+		if r.Sleep.Inhibitor == "none" {
+			sb.WriteString("  Sleep Inhibit: none (no inhibitor available or lock refused)\n")
+		} else {
+			sb.WriteString(fmt.Sprintf("  Sleep Inhibit: %s\n", r.Sleep.Inhibitor))
+		}
+		if r.Sleep.Suspends > 0 {
+			sb.WriteString(fmt.Sprintf("  Suspends:      %d (%.0f s asleep)\n", r.Sleep.Suspends, r.Sleep.SuspendedSeconds))
+		}
+		for _, change := range r.Sleep.GovernorChanges {
+			sb.WriteString(fmt.Sprintf("  Governor:      %s\n", change))
+		}
+		if r.Sleep.Interrupted() {
+			sb.WriteString(fmt.Sprintf("  WARNING:       Interrupted during %s - results are affected\n", strings.Join(r.Sleep.Phases, ", ")))
+		} else {
+			sb.WriteString("  Status:        Not interrupted\n")
+		}
 	}
 
 	// CPU Benchmarks
@@ -63,83 +263,726 @@ func FormatText(r *Report) string {
 	sb.WriteString(strings.Repeat("=", 80) + "\n")
 
 	sb.WriteString("\nKeccak256 Hashing (state trie, tx hashing)\n")
-	sb.WriteString(fmt.Sprintf("  Throughput:     %.2f hashes/sec\n", r.CPU.Keccak.HashesPerSecond))
-	sb.WriteString(fmt.Sprintf("  Data Processed: %.2f MB\n", r.CPU.Keccak.DataProcessedMB))
-	sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.CPU.Keccak.Rating))
+	if sectionOK(&sb, r.CPU.Keccak.Status) {
+		sb.WriteString(fmt.Sprintf("  Throughput:     %.2f hashes/sec\n", r.CPU.Keccak.HashesPerSecond))
+		sb.WriteString(fmt.Sprintf("  Data Processed: %.2f MB\n", r.CPU.Keccak.DataProcessedMB))
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.CPU.Keccak.Rating))
+	}
 
 	sb.WriteString("\nECDSA/secp256k1 (transaction signatures)\n")
-	sb.WriteString(fmt.Sprintf("  Sign:           %.2f sig/sec\n", r.CPU.ECDSA.SignaturesPerSecond))
-	sb.WriteString(fmt.Sprintf("  Verify:         %.2f verify/sec\n", r.CPU.ECDSA.VerificationsPerSecond))
-	sb.WriteString(fmt.Sprintf("  ECRECOVER:      %.2f recover/sec\n", r.CPU.ECDSA.RecoveriesPerSecond))
-	sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.CPU.ECDSA.Rating))
+	if sectionOK(&sb, r.CPU.ECDSA.Status) {
+		sb.WriteString(fmt.Sprintf("  Sign:           %.2f sig/sec\n", r.CPU.ECDSA.SignaturesPerSecond))
+		sb.WriteString(fmt.Sprintf("  Verify:         %.2f verify/sec\n", r.CPU.ECDSA.VerificationsPerSecond))
+		sb.WriteString(fmt.Sprintf("  ECRECOVER:      %.2f recover/sec\n", r.CPU.ECDSA.RecoveriesPerSecond))
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.CPU.ECDSA.Rating))
+	}
 
 	sb.WriteString("\nBLS12-381 (consensus layer signatures)\n")
-	sb.WriteString(fmt.Sprintf("  Sign:           %.2f sig/sec\n", r.CPU.BLS.SignaturesPerSecond))
-	sb.WriteString(fmt.Sprintf("  Verify:         %.2f verify/sec\n", r.CPU.BLS.VerificationsPerSecond))
-	sb.WriteString(fmt.Sprintf("  Aggregate:      %.2f agg/sec\n", r.CPU.BLS.AggregationsPerSecond))
-	sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.CPU.BLS.Rating))
+	if sectionOK(&sb, r.CPU.BLS.Status) {
+		sb.WriteString(fmt.Sprintf("  Sign:           %.2f sig/sec\n", r.CPU.BLS.SignaturesPerSecond))
+		sb.WriteString(fmt.Sprintf("  Verify:         %.2f verify/sec\n", r.CPU.BLS.VerificationsPerSecond))
+		sb.WriteString(fmt.Sprintf("  Aggregate:      %.2f agg/sec\n", r.CPU.BLS.AggregationsPerSecond))
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.CPU.BLS.Rating))
+	}
 
 	sb.WriteString("\nBN256 Pairing (zkSNARK precompiles)\n")
-	sb.WriteString(fmt.Sprintf("  G1 Add:         %.2f ops/sec\n", r.CPU.BN256.G1AddsPerSecond))
-	sb.WriteString(fmt.Sprintf("  G1 ScalarMul:   %.2f ops/sec\n", r.CPU.BN256.G1ScalarMulsPerSecond))
-	sb.WriteString(fmt.Sprintf("  Pairing:        %.2f ops/sec\n", r.CPU.BN256.PairingsPerSecond))
-	sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.CPU.BN256.Rating))
+	if sectionOK(&sb, r.CPU.BN256.Status) {
+		sb.WriteString(fmt.Sprintf("  G1 Add:         %.2f ops/sec\n", r.CPU.BN256.G1AddsPerSecond))
+		sb.WriteString(fmt.Sprintf("  G1 ScalarMul:   %.2f ops/sec\n", r.CPU.BN256.G1ScalarMulsPerSecond))
+		sb.WriteString(fmt.Sprintf("  Pairing:        %.2f ops/sec\n", r.CPU.BN256.PairingsPerSecond))
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.CPU.BN256.Rating))
+	}
+
+	sb.WriteString("\nRLP Encoding (transactions, headers, receipts)\n")
+	if sectionOK(&sb, r.CPU.RLP.Status) {
+		sb.WriteString(fmt.Sprintf("  Encode:         %.2f ops/sec\n", r.CPU.RLP.EncodesPerSecond))
+		sb.WriteString(fmt.Sprintf("  Decode:         %.2f ops/sec\n", r.CPU.RLP.DecodesPerSecond))
+		sb.WriteString(fmt.Sprintf("  Encode Rate:    %.2f MB/sec\n", r.CPU.RLP.EncodeMBPerSec))
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.CPU.RLP.Rating))
+	}
+
+	sb.WriteString("\nEVM Execution (arithmetic, storage, ERC-20 transfers)\n")
+	if sectionOK(&sb, r.CPU.EVM.Status) {
+		sb.WriteString(fmt.Sprintf("  Overall:        %.2f Mgas/sec\n", r.CPU.EVM.MGasPerSecond))
+		sb.WriteString(fmt.Sprintf("  Arithmetic:     %.2f Mgas/sec\n", r.CPU.EVM.ArithmeticMGasPerSecond))
+		sb.WriteString(fmt.Sprintf("  Storage:        %.2f Mgas/sec\n", r.CPU.EVM.StorageMGasPerSecond))
+		sb.WriteString(fmt.Sprintf("  ERC-20:         %.2f Mgas/sec (%.0f transfers/sec)\n", r.CPU.EVM.TransferMGasPerSecond, r.CPU.EVM.TransfersPerSecond))
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.CPU.EVM.Rating))
+	}
+
+	sb.WriteString("\nKZG Blob Proofs (EIP-4844)\n")
+	if sectionOK(&sb, r.CPU.KZG.Status) {
+		sb.WriteString(fmt.Sprintf("  Commit:         %.2f blobs/sec\n", r.CPU.KZG.CommitmentsPerSecond))
+		sb.WriteString(fmt.Sprintf("  Verify:         %.2f proofs/sec\n", r.CPU.KZG.VerificationsPerSecond))
+		sb.WriteString(fmt.Sprintf("  Real-time:      %.0fx (6 blobs per 12s slot)\n", r.CPU.KZG.RealtimeFactor))
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.CPU.KZG.Rating))
+	}
+
+	sb.WriteString("\nSHA-256 Hashing (consensus layer, not scored)\n")
+	if sectionOK(&sb, r.CPU.SHA256.Status) {
+		accel := "no"
+		if r.CPU.SHA256.HardwareAccelerated {
+			accel = "yes"
+		}
+		sb.WriteString(fmt.Sprintf("  Merkle Nodes:   %.2f hashes/sec\n", r.CPU.SHA256.NodeHashesPerSecond))
+		sb.WriteString(fmt.Sprintf("  Bulk:           %.2f MB/sec\n", r.CPU.SHA256.MBPerSecond))
+		sb.WriteString(fmt.Sprintf("  Software:       %.2f MB/sec (%.1fx speedup)\n", r.CPU.SHA256.SoftwareMBPerSecond, r.CPU.SHA256.AccelerationSpeedup))
+		sb.WriteString(fmt.Sprintf("  SHA Extensions: %s\n", accel))
+		sb.WriteString(fmt.Sprintf("  BLAKE2b:        %.2f MB/sec\n", r.CPU.SHA256.BLAKE2bMBPerSecond))
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.CPU.SHA256.Rating))
+	}
+
+	sb.WriteString(fmt.Sprintf("\nMulti-core Scaling (%d workers, parallel verification)\n", r.CPU.Parallel.Workers))
+	if sectionOK(&sb, r.CPU.Parallel.Status) {
+		for _, op := range r.CPU.Parallel.Operations {
+			sb.WriteString(fmt.Sprintf("  %-15s %.2f -> %.2f ops/sec (%.2fx, %.0f%%)\n", op.Operation+":", op.SingleCoreRate, op.AllCoreRate, op.Speedup, op.Efficiency))
+		}
+		sb.WriteString(fmt.Sprintf("  Efficiency:     %.1f%%\n", r.CPU.Parallel.ScalingEfficiency))
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.CPU.Parallel.Rating))
+	}
 
 	// Memory Benchmarks
 	sb.WriteString("\n" + strings.Repeat("=", 80) + "\n")
 	sb.WriteString("MEMORY BENCHMARKS\n")
 	sb.WriteString(strings.Repeat("=", 80) + "\n")
+	if r.Memory.GOGC != "" {
+		gcSettings := "GOGC " + r.Memory.GOGC
+		if r.Memory.BallastBytes > 0 {
+			gcSettings += fmt.Sprintf(", %.0f MB ballast", float64(r.Memory.BallastBytes)/(1024*1024))
+		}
+		sb.WriteString(fmt.Sprintf("GC Settings:      %s (collection forced before each phase)\n", gcSettings))
+	}
 
 	sb.WriteString("\nMerkle Patricia Trie (state storage)\n")
-	sb.WriteString(fmt.Sprintf("  Insert:         %.2f ops/sec\n", r.Memory.Trie.InsertsPerSecond))
-	sb.WriteString(fmt.Sprintf("  Lookup:         %.2f ops/sec\n", r.Memory.Trie.LookupsPerSecond))
-	sb.WriteString(fmt.Sprintf("  Hash:           %.2f ops/sec\n", r.Memory.Trie.HashesPerSecond))
-	sb.WriteString(fmt.Sprintf("  Peak Memory:    %.2f MB\n", r.Memory.Trie.PeakMemoryMB))
-	sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Memory.Trie.Rating))
+	if sectionOK(&sb, r.Memory.Trie.Status) {
+		sb.WriteString(fmt.Sprintf("  Insert:         %.2f ops/sec\n", r.Memory.Trie.InsertsPerSecond))
+		sb.WriteString(fmt.Sprintf("  Lookup:         %.2f ops/sec\n", r.Memory.Trie.LookupsPerSecond))
+		sb.WriteString(fmt.Sprintf("  Hash:           %.2f ops/sec\n", r.Memory.Trie.HashesPerSecond))
+		sb.WriteString(fmt.Sprintf("  Peak Memory:    %.2f MB\n", r.Memory.Trie.PeakMemoryMB))
+		for _, p := range r.Memory.Trie.ParallelCommit {
+			sb.WriteString(fmt.Sprintf("  Commit x%-2d:     %.2f commits/sec (%.2fx, %.0f%% efficiency)\n", p.Workers, p.CommitsPerSecond, p.Speedup, p.Efficiency))
+		}
+		writeGCPhases(&sb, r.Memory.Trie.GC)
+		if r.Memory.Trie.WorkingSetCapped {
+			sb.WriteString("  Working Set:    capped to available memory\n")
+		}
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Memory.Trie.Rating))
+	}
 
 	sb.WriteString("\nObject Pool Allocation (EVM memory)\n")
-	sb.WriteString(fmt.Sprintf("  Allocations:    %.2f alloc/sec\n", r.Memory.Pool.AllocationsPerSecond))
-	sb.WriteString(fmt.Sprintf("  Reuses:         %.2f reuse/sec\n", r.Memory.Pool.ReusesPerSecond))
-	sb.WriteString(fmt.Sprintf("  Memory Churn:   %.2f MB\n", r.Memory.Pool.MemoryChurnMB))
-	sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Memory.Pool.Rating))
+	if sectionOK(&sb, r.Memory.Pool.Status) {
+		sb.WriteString(fmt.Sprintf("  Allocations:    %.2f alloc/sec\n", r.Memory.Pool.AllocationsPerSecond))
+		sb.WriteString(fmt.Sprintf("  Reuses:         %.2f reuse/sec\n", r.Memory.Pool.ReusesPerSecond))
+		sb.WriteString(fmt.Sprintf("  Memory Churn:   %.2f MB\n", r.Memory.Pool.MemoryChurnMB))
+		writeGCPhases(&sb, r.Memory.Pool.GC)
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Memory.Pool.Rating))
+	}
 
 	sb.WriteString("\nState Cache (account/storage)\n")
-	sb.WriteString(fmt.Sprintf("  Cache Hits:     %.2f ops/sec\n", r.Memory.StateCache.CacheHitsPerSecond))
-	sb.WriteString(fmt.Sprintf("  Cache Misses:   %.2f ops/sec\n", r.Memory.StateCache.CacheMissesPerSecond))
-	sb.WriteString(fmt.Sprintf("  Hit Ratio:      %.2f%%\n", r.Memory.StateCache.HitRatio*100))
-	sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Memory.StateCache.Rating))
+	if sectionOK(&sb, r.Memory.StateCache.Status) {
+		sb.WriteString(fmt.Sprintf("  Cache Hits:     %.2f ops/sec\n", r.Memory.StateCache.CacheHitsPerSecond))
+		sb.WriteString(fmt.Sprintf("  Cache Misses:   %.2f ops/sec\n", r.Memory.StateCache.CacheMissesPerSecond))
+		sb.WriteString(fmt.Sprintf("  Hit Ratio:      %.2f%%\n", r.Memory.StateCache.HitRatio*100))
+		writeGCPhases(&sb, r.Memory.StateCache.GC)
+		if r.Memory.StateCache.WorkingSetCapped {
+			sb.WriteString("  Working Set:    capped to available memory\n")
+		}
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Memory.StateCache.Rating))
+	}
+
+	sb.WriteString("\nMemory Latency (pointer chase, trie traversal)\n")
+	if sectionOK(&sb, r.Memory.Latency.Status) {
+		for _, p := range r.Memory.Latency.Points {
+			size := fmt.Sprintf("%d KB", p.WorkingSetKB)
+			if p.WorkingSetKB >= 1024 {
+				size = fmt.Sprintf("%d MB", p.WorkingSetKB/1024)
+			}
+			sb.WriteString(fmt.Sprintf("  %-16s%.1f ns\n", size+":", p.LatencyNs))
+		}
+		if r.Memory.Latency.WorkingSetCapped {
+			sb.WriteString("  Working Set:    capped to available memory\n")
+		}
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Memory.Latency.Rating))
+	}
+
+	sb.WriteString("\nCorrectness Cross-checks (crypto and trie vs independent results)\n")
+	if sectionOK(&sb, r.Memory.Correctness.Status) {
+		sb.WriteString(fmt.Sprintf("  Checks:         %d\n", r.Memory.Correctness.Checks))
+		sb.WriteString(fmt.Sprintf("  Failures:       %d\n", r.Memory.Correctness.Failures))
+		for _, failure := range r.Memory.Correctness.FailedChecks {
+			sb.WriteString(fmt.Sprintf("    %s\n", failure))
+		}
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Memory.Correctness.Rating))
+	}
+
+	sb.WriteString("\nMerkle Patricia Trie (go-ethereum trie.Trie, not scored)\n")
+	if sectionOK(&sb, r.Memory.MPT.Status) {
+		backends := []types.MPTBackendResult{r.Memory.MPT.Memory}
+		if r.Memory.MPT.Disk != nil {
+			backends = append(backends, *r.Memory.MPT.Disk)
+		}
+		for _, b := range backends {
+			sb.WriteString(fmt.Sprintf("  %s (%d keys)\n", b.Backend, b.Keys))
+			sb.WriteString(fmt.Sprintf("    Insert:       %.2f ops/sec\n", b.InsertsPerSecond))
+			sb.WriteString(fmt.Sprintf("    Get:          %.2f ops/sec\n", b.GetsPerSecond))
+			sb.WriteString(fmt.Sprintf("    Commit:       %.2f ms (initial)\n", b.InitialCommitMs))
+			sb.WriteString(fmt.Sprintf("    Blocks:       %.2f blocks/sec of %d updates (hash %.2f ms, commit %.2f ms, %.0f nodes)\n",
+				b.BlocksPerSecond, b.UpdatesPerBlock, b.AvgHashMs, b.AvgCommitMs, b.AvgNodesPerBlock))
+			if b.WorkingSetCapped {
+				sb.WriteString("    Working Set:  capped to available memory\n")
+			}
+		}
+		if sim := r.Memory.Trie; sim.OK() && sim.InsertsPerSecond > 0 {
+			sb.WriteString(fmt.Sprintf("  vs Simulated:   %.2fx the memory.trie insert rate\n", r.Memory.MPT.Memory.InsertsPerSecond/sim.InsertsPerSecond))
+		}
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Memory.MPT.Rating))
+	}
+
+	if p := r.Memory.Pressure; p != nil {
+		sb.WriteString("\nMemory Pressure (swap behavior, not scored)\n")
+		if sectionOK(&sb, p.Status) {
+			sb.WriteString(fmt.Sprintf("  Allocated:      %d of %d MB target (%d MB available)\n", p.AllocatedMB, p.TargetMB, p.AvailableMB))
+			sb.WriteString(fmt.Sprintf("  Fill:           %.2f MB/s\n", p.FillMBPerSec))
+			sb.WriteString(fmt.Sprintf("  Re-read:        %.2f MB/s (slowest 64 MB: %.1f ms)\n", p.RetouchMBPerSec, p.MaxStallMs))
+			sb.WriteString(fmt.Sprintf("  Swapped:        %.0f MB out, %.0f MB in\n", p.SwapOutMB, p.SwapInMB))
+			sb.WriteString(fmt.Sprintf("  Rating:         %s\n", p.Rating))
+		}
+	}
+
+	if len(r.GCSweep) > 0 {
+		sb.WriteString("\nGOGC Sweep (GC tuning impact)\n")
+		sb.WriteString("  GOGC   Trie Insert/s   Pool Ops/s   Cache Hits/s   GCs   GC Pause\n")
+		for _, p := range r.GCSweep {
+			sb.WriteString(fmt.Sprintf("  %-5d  %13.0f  %11.0f  %13.0f  %4d  %9s\n",
+				p.GOGC, p.TrieInsertsPerSecond, p.PoolOpsPerSecond, p.CacheHitsPerSecond, p.NumGC, p.GCPauseTotal.Round(time.Microsecond)))
+		}
+	}
 
 	// Disk Benchmarks
 	sb.WriteString("\n" + strings.Repeat("=", 80) + "\n")
 	sb.WriteString("DISK I/O BENCHMARKS\n")
 	sb.WriteString(strings.Repeat("=", 80) + "\n")
+	if fs := r.Disk.Filesystem; fs != nil {
+		sb.WriteString(fmt.Sprintf("  Filesystem:     %s on %s (%s)\n", fs.Type, fs.Device, fs.MountPoint))
+		sb.WriteString(fmt.Sprintf("  Mount Options:  %s\n", strings.Join(fs.Options, ",")))
+		for _, c := range fs.ChainData {
+			location := "same filesystem"
+			if !c.SameDevice {
+				location = "different filesystem"
+			}
+			sb.WriteString(fmt.Sprintf("  Chain Data:     %s (%s)\n", c.Path, location))
+		}
+	}
+	if r.Disk.DirectIO != "" && r.Disk.DirectIO != "on" {
+		sb.WriteString(fmt.Sprintf("  Direct I/O:     skipped, %s\n", r.Disk.DirectIO))
+	}
 
 	sb.WriteString("\nSequential I/O (state sync, snapshots)\n")
-	sb.WriteString(fmt.Sprintf("  Write Speed:    %.2f MB/s\n", r.Disk.Sequential.WriteSpeedMBps))
-	sb.WriteString(fmt.Sprintf("  Read Speed:     %.2f MB/s\n", r.Disk.Sequential.ReadSpeedMBps))
-	sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Disk.Sequential.Rating))
+	if sectionOK(&sb, r.Disk.Sequential.Status) {
+		sb.WriteString(fmt.Sprintf("  Write Speed:    %.2f MB/s\n", r.Disk.Sequential.WriteSpeedMBps))
+		sb.WriteString(fmt.Sprintf("  Read Speed:     %.2f MB/s\n", r.Disk.Sequential.ReadSpeedMBps))
+		if r.Disk.Sequential.DirectReadSpeedMBps > 0 {
+			sb.WriteString(fmt.Sprintf("  O_DIRECT:       %.2f MB/s write, %.2f MB/s read\n", r.Disk.Sequential.DirectWriteSpeedMBps, r.Disk.Sequential.DirectReadSpeedMBps))
+		}
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Disk.Sequential.Rating))
+	}
 
 	sb.WriteString("\nRandom 4K I/O (trie node access)\n")
-	sb.WriteString(fmt.Sprintf("  Read IOPS:      %.0f\n", r.Disk.Random.ReadIOPS))
-	sb.WriteString(fmt.Sprintf("  Write IOPS:     %.0f\n", r.Disk.Random.WriteIOPS))
-	sb.WriteString(fmt.Sprintf("  Avg Latency:    %.2f us\n", r.Disk.Random.AvgLatencyUs))
-	sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Disk.Random.Rating))
+	if sectionOK(&sb, r.Disk.Random.Status) {
+		sb.WriteString(fmt.Sprintf("  Read IOPS:      %.0f\n", r.Disk.Random.ReadIOPS))
+		sb.WriteString(fmt.Sprintf("  Write IOPS:     %.0f\n", r.Disk.Random.WriteIOPS))
+		sb.WriteString(fmt.Sprintf("  Avg Latency:    %.2f us\n", r.Disk.Random.AvgLatencyUs))
+		if r.Disk.Random.MixedIOPS > 0 {
+			sb.WriteString(fmt.Sprintf("  %-16s%.0f IOPS, %.2f us read, %.2f us write latency\n", fmt.Sprintf("Mixed %d/%d:", 100-r.Disk.Random.MixedWritePercent, r.Disk.Random.MixedWritePercent),
+				r.Disk.Random.MixedIOPS, r.Disk.Random.MixedReadLatencyUs, r.Disk.Random.MixedWriteLatencyUs))
+		}
+		if r.Disk.Random.Jobs > 1 {
+			sb.WriteString(fmt.Sprintf("  %-16s%.0f read, %.0f write IOPS\n", fmt.Sprintf("%d Jobs:", r.Disk.Random.Jobs), r.Disk.Random.ConcurrentReadIOPS, r.Disk.Random.ConcurrentWriteIOPS))
+		}
+		for _, qd := range r.Disk.Random.QueueDepths {
+			sb.WriteString(fmt.Sprintf("  %-16s%.0f read IOPS (%.1f MB/s), %.0f us avg\n", fmt.Sprintf("QD%d:", qd.Depth), qd.ReadIOPS, qd.ReadMBps, qd.AvgLatencyUs))
+		}
+		if r.Disk.Random.DirectReadIOPS > 0 {
+			sb.WriteString(fmt.Sprintf("  O_DIRECT:       %.0f read, %.0f write IOPS\n", r.Disk.Random.DirectReadIOPS, r.Disk.Random.DirectWriteIOPS))
+		}
+		if r.Disk.Random.RequestedFileMB > 0 {
+			sb.WriteString(fmt.Sprintf("  Test File:      %.0f MB (fully written, shrunk from %.0f MB by -max-write)\n", r.Disk.Random.TestFileMB, r.Disk.Random.RequestedFileMB))
+		} else {
+			sb.WriteString(fmt.Sprintf("  Test File:      %.0f MB (fully written)\n", r.Disk.Random.TestFileMB))
+		}
+		if r.System != nil && r.Disk.Random.TestFileMB < float64(r.System.RAMTotalMB) {
+			sb.WriteString(fmt.Sprintf("  Warning:        test file is smaller than the %d MB of RAM; the page cache may serve reads and overstate IOPS\n", r.System.RAMTotalMB))
+		}
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Disk.Random.Rating))
+	}
+
+	sb.WriteString("\nMmap Reads (freezer/ancient store, not scored)\n")
+	if sectionOK(&sb, r.Disk.Mmap.Status) {
+		sb.WriteString(fmt.Sprintf("  Sequential:     %.2f MB/s\n", r.Disk.Mmap.SequentialMBps))
+		sb.WriteString(fmt.Sprintf("  Random:         %.0f reads/sec, %.1f us avg\n", r.Disk.Mmap.RandomReadsPerSecond, r.Disk.Mmap.AvgRandomLatencyUs))
+		sb.WriteString(fmt.Sprintf("  Major Faults:   %d\n", r.Disk.Mmap.MajorFaults))
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Disk.Mmap.Rating))
+	}
 
 	sb.WriteString("\nBatch Write (block commitment)\n")
-	sb.WriteString(fmt.Sprintf("  Batch Rate:     %.2f batch/sec\n", r.Disk.Batch.BatchesPerSecond))
-	sb.WriteString(fmt.Sprintf("  Throughput:     %.2f MB/s\n", r.Disk.Batch.ThroughputMBps))
-	sb.WriteString(fmt.Sprintf("  Avg Latency:    %.2f ms\n", r.Disk.Batch.AvgBatchLatencyMs))
-	sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Disk.Batch.Rating))
+	if sectionOK(&sb, r.Disk.Batch.Status) {
+		sb.WriteString(fmt.Sprintf("  Batch Rate:     %.2f batch/sec\n", r.Disk.Batch.BatchesPerSecond))
+		sb.WriteString(fmt.Sprintf("  Throughput:     %.2f MB/s\n", r.Disk.Batch.ThroughputMBps))
+		sb.WriteString(fmt.Sprintf("  Avg Latency:    %.2f ms\n", r.Disk.Batch.AvgBatchLatencyMs))
+		sb.WriteString(fmt.Sprintf("  p99 Latency:    %.2f ms\n", r.Disk.Batch.P99BatchLatencyMs))
+		sb.WriteString(fmt.Sprintf("  Max Latency:    %.2f ms\n", r.Disk.Batch.MaxBatchLatencyMs))
+		for _, m := range r.Disk.Batch.SyncModes {
+			best := ""
+			if m.Mode == r.Disk.Batch.BestSyncMode {
+				best = " (best)"
+			}
+			sb.WriteString(fmt.Sprintf("  %-16s%.2f MB/s, p99 %.2f ms%s\n", m.Mode+":", m.ThroughputMBps, m.P99LatencyMs, best))
+		}
+		if r.Disk.Batch.DirectThroughputMBps > 0 {
+			sb.WriteString(fmt.Sprintf("  O_DIRECT:       %.2f MB/s, p99 %.2f ms\n", r.Disk.Batch.DirectThroughputMBps, r.Disk.Batch.DirectP99LatencyMs))
+		}
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Disk.Batch.Rating))
+	}
+
+	sb.WriteString("\nState Scheme (hash-based vs path-based trie storage)\n")
+	if sectionOK(&sb, r.Disk.StateScheme.Status) {
+		sb.WriteString(fmt.Sprintf("  Hash Scheme:    %.2f blocks/sec\n", r.Disk.StateScheme.HashBlocksPerSecond))
+		sb.WriteString(fmt.Sprintf("  Path Scheme:    %.2f blocks/sec\n", r.Disk.StateScheme.PathBlocksPerSecond))
+		sb.WriteString(fmt.Sprintf("  Buffer Flush:   %.2f ms avg (%d flushes)\n", r.Disk.StateScheme.AvgFlushLatencyMs, r.Disk.StateScheme.PathFlushes))
+		sb.WriteString(fmt.Sprintf("  Favored:        %s\n", r.Disk.StateScheme.Favored))
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Disk.StateScheme.Rating))
+	}
+
+	sb.WriteString("\nBlob Sidecar Store (EIP-4844 write/read/prune)\n")
+	if sectionOK(&sb, r.Disk.Blob.Status) {
+		sb.WriteString(fmt.Sprintf("  Block Rate:     %.2f blocks/sec (%.0fx real-time)\n", r.Disk.Blob.BlocksPerSecond, r.Disk.Blob.RealtimeFactor))
+		sb.WriteString(fmt.Sprintf("  Write:          %.2f MB/s\n", r.Disk.Blob.WriteMBps))
+		sb.WriteString(fmt.Sprintf("  Read:           %.2f MB/s\n", r.Disk.Blob.ReadMBps))
+		sb.WriteString(fmt.Sprintf("  Prune Latency:  %.2f ms\n", r.Disk.Blob.AvgPruneLatencyMs))
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Disk.Blob.Rating))
+	}
+
+	sb.WriteString("\nKey-Value Store (Geth Pebble/LevelDB, 32B keys, 100B values)\n")
+	if sectionOK(&sb, r.Disk.KVStore.Status) {
+		for _, e := range []types.KVEngineResult{r.Disk.KVStore.Pebble, r.Disk.KVStore.LevelDB} {
+			if e.Error != "" {
+				sb.WriteString(fmt.Sprintf("  %-15s Error: %s\n", e.Engine+":", e.Error))
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("  %-15s %.0f writes/sec, %.0f reads/sec, %.0f scanned keys/sec", e.Engine+":", e.WritesPerSecond, e.ReadsPerSecond, e.ScanKeysPerSecond))
+			if e.WriteAmplification > 0 {
+				sb.WriteString(fmt.Sprintf(", %.1fx write amp", e.WriteAmplification))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Disk.KVStore.Rating))
+	}
+
+	sb.WriteString("\nFsync Latency (single 4K block, block commitment)\n")
+	if sectionOK(&sb, r.Disk.Fsync.Status) {
+		sb.WriteString(fmt.Sprintf("  Syncs:          %.2f/sec\n", r.Disk.Fsync.SyncsPerSecond))
+		sb.WriteString(fmt.Sprintf("  p50/p95:        %.2f / %.2f ms\n", r.Disk.Fsync.P50LatencyMs, r.Disk.Fsync.P95LatencyMs))
+		sb.WriteString(fmt.Sprintf("  p99/p999:       %.2f / %.2f ms\n", r.Disk.Fsync.P99LatencyMs, r.Disk.Fsync.P999LatencyMs))
+		sb.WriteString(fmt.Sprintf("  Max:            %.2f ms\n", r.Disk.Fsync.MaxLatencyMs))
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Disk.Fsync.Rating))
+	}
+
+	sb.WriteString(fmt.Sprintf("\nSlot Deadline (block pipeline vs %.0f s attestation deadline)\n", r.Disk.Slot.DeadlineMs/1000))
+	if sectionOK(&sb, r.Disk.Slot.Status) {
+		sb.WriteString(fmt.Sprintf("  Slots:          %d of %d txs, %.1f%% within deadline\n", r.Disk.Slot.Slots, r.Disk.Slot.Transactions, r.Disk.Slot.SuccessRate))
+		sb.WriteString(fmt.Sprintf("  p50/p99:        %.1f / %.1f ms\n", r.Disk.Slot.P50Ms, r.Disk.Slot.P99Ms))
+		sb.WriteString(fmt.Sprintf("  Max:            %.1f ms\n", r.Disk.Slot.MaxMs))
+		sb.WriteString(fmt.Sprintf("  Stages:         recover %.1f, state %.1f, root %.1f, commit %.1f ms avg\n", r.Disk.Slot.AvgRecoverMs, r.Disk.Slot.AvgStateMs, r.Disk.Slot.AvgRootMs, r.Disk.Slot.AvgCommitMs))
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", r.Disk.Slot.Rating))
+	}
+
+	sb.WriteString("\nContention (CPU, trie and disk load alone vs together)\n")
+	if c := r.Disk.Contention; sectionOK(&sb, c.Status) {
+		for _, comp := range []struct {
+			name string
+			unit string
+			c    types.ContentionComponent
+		}{
+			{"Crypto:", "recoveries/s", c.Crypto},
+			{"Trie:", "updates/s", c.Trie},
+			{"Disk:", "4K ops/s", c.Disk},
+		} {
+			sb.WriteString(fmt.Sprintf("  %-15s %.0f alone, %.0f together %s (-%.1f%%)\n", comp.name, comp.c.IsolatedPerSecond, comp.c.CombinedPerSecond, comp.unit, comp.c.DegradationPercent))
+		}
+		sb.WriteString(fmt.Sprintf("  Penalty:        %.1f%% average throughput lost\n", c.PenaltyPercent))
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", c.Rating))
+	}
+
+	sb.WriteString("\nTrie Commit (go-ethereum trie to Pebble, not scored)\n")
+	if t := r.Disk.TrieCommit; sectionOK(&sb, t.Status) {
+		sb.WriteString(fmt.Sprintf("  Trie:           %d accounts\n", t.Accounts))
+		sb.WriteString(fmt.Sprintf("  Commits:        %d of %d updates (%.0f nodes each)\n", t.Commits, t.CommitEvery, t.AvgNodesPerCommit))
+		sb.WriteString(fmt.Sprintf("  Latency:        %.2f ms avg, %.2f ms p50, %.2f ms p99, %.2f ms max\n", t.AvgCommitMs, t.P50CommitMs, t.P99CommitMs, t.MaxCommitMs))
+		sb.WriteString(fmt.Sprintf("  Updates:        %.0f updates/sec with commits\n", t.UpdatesPerSecond))
+		if t.WriteAmplification > 0 {
+			sb.WriteString(fmt.Sprintf("  Write Amp:      %.1fx (%.1f MB written for %.1f MB of nodes)\n", t.WriteAmplification, t.DeviceMB, t.LogicalMB))
+		}
+		sb.WriteString(fmt.Sprintf("  Rating:         %s\n", t.Rating))
+	}
+
+	if c := r.Disk.Copy; c != nil {
+		sb.WriteString(fmt.Sprintf("\nBackup/Restore Copy (to %s, not scored)\n", c.Destination))
+		if sectionOK(&sb, c.Status) {
+			sb.WriteString(fmt.Sprintf("  Backup:         %.2f MB/s\n", c.BackupMBps))
+			sb.WriteString(fmt.Sprintf("  Restore:        %.2f MB/s\n", c.RestoreMBps))
+			sb.WriteString(fmt.Sprintf("  Datadir Copy:   %.1f h backup, %.1f h restore (%.0f GB)\n", c.BackupHours, c.RestoreHours, c.DatadirGB))
+			sb.WriteString(fmt.Sprintf("  Test File:      %.0f MB\n", c.TestFileMB))
+			sb.WriteString(fmt.Sprintf("  Rating:         %s\n", c.Rating))
+		}
+	}
+
+	if m := r.Disk.Migration; m != nil {
+		sb.WriteString(fmt.Sprintf("\nDatadir Migration (to %s, not scored)\n", m.Destination))
+		if sectionOK(&sb, m.Status) {
+			sb.WriteString(fmt.Sprintf("  Small Files:    %.0f files/sec (%.2f MB/s)\n", m.SmallFilesPerSecond, m.SmallMBps))
+			sb.WriteString(fmt.Sprintf("  Large File:     %.2f MB/s\n", m.LargeMBps))
+			sb.WriteString(fmt.Sprintf("  Per-file Cost:  %.2f ms\n", m.PerFileOverheadMs))
+			for _, e := range m.Estimates {
+				sb.WriteString(fmt.Sprintf("  %-16s%.1f h (%.0f GB, %d files)\n", e.Client+":", e.Hours, e.DatadirGB, e.Files))
+			}
+			sb.WriteString(fmt.Sprintf("  Rating:         %s\n", m.Rating))
+		}
+	}
+
+	if rp := r.Disk.Replay; rp != nil {
+		sb.WriteString(fmt.Sprintf("\nBlock Import Replay (%s, not scored)\n", rp.Source))
+		if sectionOK(&sb, rp.Status) {
+			sb.WriteString(fmt.Sprintf("  Blocks:         %d-%d %s (%d blocks, %d txs)\n", rp.FirstBlock, rp.LastBlock, rp.Chain, rp.Blocks, rp.Transactions))
+			sb.WriteString(fmt.Sprintf("  Import:         %.2f Mgas/sec\n", rp.MGasPerSecond))
+			sb.WriteString(fmt.Sprintf("  Blocks/sec:     %.2f (%.0f txs/sec)\n", rp.BlocksPerSecond, rp.TxPerSecond))
+			sb.WriteString(fmt.Sprintf("  Rating:         %s\n", rp.Rating))
+		}
+	}
+
+	// Bytes written against the flash wear limit
+	writes := r.Disk.Writes
+	sb.WriteString(fmt.Sprintf("\n  Data Written:   %.2f GB", float64(writes.WrittenBytes)/(1<<30)))
+	if writes.LimitBytes > 0 {
+		sb.WriteString(fmt.Sprintf(" of %.2f GB limit", float64(writes.LimitBytes)/(1<<30)))
+	}
+	if writes.LimitReached {
+		sb.WriteString(" (limit reached, results cut short)")
+	}
+	sb.WriteString("\n")
+	if writes.VolumeError != "" {
+		sb.WriteString(fmt.Sprintf("  Stopped:        %s\n", writes.VolumeError))
+	}
+
+	// Fork benchmark packs
+	if r.Forks != nil {
+		sb.WriteString("\n" + strings.Repeat("=", 80) + "\n")
+		sb.WriteString("FORK READINESS (optional packs, not part of overall score)\n")
+		sb.WriteString(strings.Repeat("=", 80) + "\n")
+
+		if p := r.Forks.Pectra; p != nil {
+			sb.WriteString("\nPectra: BLS12-381 Precompiles (EIP-2537)\n")
+			if sectionOK(&sb, p.Status) {
+				sb.WriteString(fmt.Sprintf("  G1 MSM (128):   %.2f ops/sec\n", p.G1MSMPerSecond))
+				sb.WriteString(fmt.Sprintf("  Pairing Check:  %.2f ops/sec\n", p.PairingChecksPerSecond))
+				sb.WriteString(fmt.Sprintf("  Map to G1:      %.2f ops/sec\n", p.MapToG1PerSecond))
+				sb.WriteString(fmt.Sprintf("  Gas Rate:       %.2f Mgas/sec\n", p.MGasPerSecond))
+				sb.WriteString(fmt.Sprintf("  Rating:         %s\n", p.Rating))
+			}
+		}
+		if f := r.Forks.Fusaka; f != nil {
+			sb.WriteString("\nFusaka: PeerDAS Blob Extension (EIP-7594)\n")
+			if sectionOK(&sb, f.Status) {
+				sb.WriteString(fmt.Sprintf("  Extensions:     %.2f blobs/sec\n", f.BlobExtensionsPerSecond))
+				sb.WriteString(fmt.Sprintf("  Real-time:      %.0fx at %d blobs/slot\n", f.RealtimeFactor, f.MaxBlobsPerSlot))
+				sb.WriteString(fmt.Sprintf("  Rating:         %s\n", f.Rating))
+			}
+		}
+	}
+
+	// Invariant checks of -paranoid
+	if p := r.Paranoid; p != nil {
+		sb.WriteString("\n" + strings.Repeat("=", 80) + "\n")
+		sb.WriteString("PARANOID CHECKS (shortened run, scores are not comparable)\n")
+		sb.WriteString(strings.Repeat("=", 80) + "\n")
+		race := "off (data races are not detected)"
+		if p.RaceDetector {
+			race = "on (races are reported on stderr)"
+		}
+		sb.WriteString(fmt.Sprintf("\n  Race Detector:  %s\n", race))
+		sb.WriteString(fmt.Sprintf("  GOMAXPROCS:     %d\n", p.GOMAXPROCS))
+		sb.WriteString(fmt.Sprintf("  Checked:        %d benchmarks (goroutine leaks, cancellation, values)\n", p.Benchmarks))
+		if len(p.Violations) == 0 {
+			sb.WriteString("  Violations:     none\n")
+		} else {
+			sb.WriteString(fmt.Sprintf("  Violations:     %d\n", len(p.Violations)))
+			for _, v := range p.Violations {
+				sb.WriteString(fmt.Sprintf("    %s [%s]: %s\n", v.Benchmark, v.Check, v.Detail))
+			}
+		}
+	}
+
+	// Harness overhead
+	if h := r.Harness; h != nil {
+		sb.WriteString("\n" + strings.Repeat("=", 80) + "\n")
+		sb.WriteString("HARNESS OVERHEAD (scores use measured values)\n")
+		sb.WriteString(strings.Repeat("=", 80) + "\n")
+		sb.WriteString(fmt.Sprintf("\n  Loop Check:     %.1f ns\n", h.LoopCheckNs))
+		sb.WriteString(fmt.Sprintf("  RNG Draw:       %.1f ns + %.2f ns/byte\n", h.RNGCallNs, h.RNGByteNs))
+		sb.WriteString(fmt.Sprintf("  Map Lookup:     %.1f ns\n", h.MapLookupNs))
+		if len(h.Metrics) > 0 {
+			sb.WriteString(fmt.Sprintf("\n  %-42s %12s %7s %9s %12s\n", "Metric", "Measured", "Duty", "Overhead", "Corrected"))
+			for _, m := range h.Metrics {
+				duty := "-"
+				if m.DutyCycle > 0 {
+					duty = fmt.Sprintf("%.1f%%", m.DutyCycle*100)
+				}
+				sb.WriteString(fmt.Sprintf("  %-42s %12.0f %7s %8.1f%% %12.0f\n", m.Metric, m.MeasuredValue, duty, m.OverheadPercent, m.CorrectedValue))
+			}
+		}
+	}
+
+	// CPU accounting
+	if phases := accountedPhases(r.Timeline); len(phases) > 0 {
+		sb.WriteString("\n" + strings.Repeat("=", 80) + "\n")
+		sb.WriteString("CPU ACCOUNTING\n")
+		sb.WriteString(strings.Repeat("=", 80) + "\n")
+		sb.WriteString(fmt.Sprintf("\n  %-24s %8s %8s %8s %7s %8s  %s\n", "Phase", "Wall s", "User s", "Sys s", "Cores", "IO Wait", "Bound"))
+		var notes []string
+		for _, p := range phases {
+			c := p.CPU
+			sb.WriteString(fmt.Sprintf("  %-24s %8.1f %8.1f %8.1f %7.2f %8.2f  %s\n",
+				p.Name, p.End.Sub(p.Start).Seconds(), c.UserSeconds, c.SystemSeconds, c.Utilization, c.IOWaitCores, c.Bound))
+			switch {
+			case strings.HasPrefix(p.Name, "disk.") && c.Bound == "cpu":
+				notes = append(notes, fmt.Sprintf("%s was CPU-bound, so it may understate the storage", p.Name))
+			case (strings.HasPrefix(p.Name, "cpu.") || strings.HasPrefix(p.Name, "memory.")) && c.Bound == "io_wait":
+				notes = append(notes, fmt.Sprintf("%s waited on I/O, likely from background activity", p.Name))
+			}
+		}
+		sb.WriteString("\n  Cores is CPU time over wall time; IO Wait is CPUs idle waiting for I/O, system-wide\n")
+		for _, note := range notes {
+			sb.WriteString(fmt.Sprintf("  Note: %s\n", note))
+		}
+	}
+
+	// Interrupt load
+	if phases := irqPhases(r.Timeline); len(phases) > 0 {
+		sb.WriteString("\n" + strings.Repeat("=", 80) + "\n")
+		sb.WriteString("INTERRUPT LOAD\n")
+		sb.WriteString(strings.Repeat("=", 80) + "\n")
+		sb.WriteString(fmt.Sprintf("\n  %-20s %9s %10s %11s  %s\n", "Phase", "IRQ/s", "SoftIRQ/s", "Busiest CPU", "Top Source"))
+		for _, p := range phases {
+			irq := p.IRQ
+			busiest := fmt.Sprintf("%d: %.0f%%", irq.BusiestCPU, irq.BusiestCPUPercent)
+			source := "-"
+			if irq.TopSource != "" {
+				source = fmt.Sprintf("%s (IRQ %s, CPU %d, %.0f/s)", irq.TopSource, irq.TopSourceIRQ, irq.TopSourceCPU, irq.TopSourcePerSecond)
+			}
+			sb.WriteString(fmt.Sprintf("  %-20s %9.0f %10.0f %11s  %s\n", p.Name, irq.InterruptsPerSecond, irq.SoftIRQsPerSecond, busiest, source))
+		}
+		sb.WriteString("\n  Busiest CPU is the core with the largest share of time in hard and soft IRQ handlers\n")
+		for _, p := range phases {
+			if p.IRQ.Saturated {
+				sb.WriteString(fmt.Sprintf("  Note: CPU %d was saturated by interrupts during %s\n", p.IRQ.BusiestCPU, p.Name))
+			}
+		}
+	}
+
+	// Interrupt affinity tuning
+	if t := r.IRQTuning; t != nil {
+		sb.WriteString("\n" + strings.Repeat("=", 80) + "\n")
+		sb.WriteString("IRQ AFFINITY TUNING\n")
+		sb.WriteString(strings.Repeat("=", 80) + "\n")
+		sb.WriteString(fmt.Sprintf("\n  CPU %d handled %.0f%% of the device interrupts during %s\n", t.CPU, t.CPUPercent, t.Phase))
+		sb.WriteString(fmt.Sprintf("\n  %-5s %-24s %9s %5s %5s  %s\n", "IRQ", "Source", "Rate/s", "From", "To", "Status"))
+		for _, m := range t.Moves {
+			status := "planned"
+			switch {
+			case m.Applied:
+				status = "applied"
+			case m.Error != "":
+				status = "failed: " + m.Error
+			}
+			sb.WriteString(fmt.Sprintf("  %-5s %-24s %9.0f %5d %5d  %s\n", m.IRQ, m.Source, m.PerSecond, m.FromCPU, m.ToCPU, status))
+		}
+		switch {
+		case len(t.Metrics) > 0:
+			sb.WriteString(fmt.Sprintf("\n  %s re-run after the change:\n", t.Phase))
+			sb.WriteString(fmt.Sprintf("  %-44s %12s %12s %8s\n", "Metric", "Before", "After", "Change"))
+			for _, m := range t.Metrics {
+				sb.WriteString(fmt.Sprintf("  %-44s %12.2f %12.2f %+7.1f%%\n", m.Metric, m.Before, m.After, m.ChangePercent))
+			}
+			if t.After != nil {
+				sb.WriteString(fmt.Sprintf("  Busiest CPU for device interrupts: CPU %d at %.0f%% (was CPU %d at %.0f%%)\n",
+					t.After.DeviceCPU, t.After.DeviceCPUPercent, t.CPU, t.CPUPercent))
+			}
+			sb.WriteString("\n  The benchmark's results above are from the re-run. The affinity lasts until reboot;\n")
+			sb.WriteString("  stop irqbalance or set it at boot to keep it.\n")
+		case !t.Applied:
+			sb.WriteString("\n  To apply as root:\n")
+			for _, m := range t.Moves {
+				sb.WriteString(fmt.Sprintf("    echo %d > /proc/irq/%s/smp_affinity_list\n", m.ToCPU, m.IRQ))
+			}
+			sb.WriteString(fmt.Sprintf("  or let ethbench apply it and measure the difference: sudo ethbench -apply-tuning -only %s\n", t.Phase))
+		default:
+			sb.WriteString("\n  The affinity lasts until reboot; stop irqbalance or set it at boot to keep it.\n")
+		}
+	}
+
+	// Anomalies
+	if len(r.Anomalies) > 0 {
+		sb.WriteString("\n" + strings.Repeat("=", 80) + "\n")
+		sb.WriteString("ANOMALIES\n")
+		sb.WriteString(strings.Repeat("=", 80) + "\n")
+		if r.Metadata.ReferenceProfile != "" {
+			sb.WriteString(fmt.Sprintf("\n  Reference:      %s\n", r.Metadata.ReferenceProfile))
+		}
+		for _, a := range r.Anomalies {
+			sb.WriteString(fmt.Sprintf("\n  %s\n", a.Metric))
+			sb.WriteString(fmt.Sprintf("    First Run:    %.2f (%.1f sigma)\n", a.FirstValue, a.Sigma))
+			sb.WriteString(fmt.Sprintf("    Re-run:       %.2f\n", a.RerunValue))
+			sb.WriteString(fmt.Sprintf("    Reference:    %.2f ± %.2f\n", a.ReferenceMean, a.ReferenceStdDev))
+			sb.WriteString(fmt.Sprintf("    Note:         %s\n", a.Note))
+		}
+	}
+
+	// Environmental sensors
+	if r.Annotations != nil {
+		sb.WriteString("\n" + strings.Repeat("=", 80) + "\n")
+		sb.WriteString("ENVIRONMENTAL SENSORS\n")
+		sb.WriteString(strings.Repeat("=", 80) + "\n")
+		sb.WriteString(fmt.Sprintf("\n  Source:         %s (%d samples)\n", r.Annotations.Source, r.Annotations.Samples))
+		for _, phase := range r.Annotations.Phases {
+			if phase.Samples == 0 {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("\n  %s (%d samples)\n", phase.Phase, phase.Samples))
+			for _, col := range r.Annotations.Columns {
+				v, ok := phase.Values[col]
+				if !ok {
+					continue
+				}
+				sb.WriteString(fmt.Sprintf("    %-14s mean %.2f, min %.2f, max %.2f\n", col+":", v.Mean, v.Min, v.Max))
+			}
+		}
+	}
+
+	// Benchmark errors
+	if len(r.Errors) > 0 {
+		sb.WriteString("\n" + strings.Repeat("=", 80) + "\n")
+		sb.WriteString("ERRORS (results below are incomplete)\n")
+		sb.WriteString(strings.Repeat("=", 80) + "\n\n")
+		for _, e := range r.Errors {
+			sb.WriteString(fmt.Sprintf("  %-20s %s\n", e.Benchmark+":", e.Error))
+		}
+	}
+
+	// Repeated runs
+	if rs := r.RunStatistics; rs != nil {
+		sb.WriteString("\n" + strings.Repeat("=", 80) + "\n")
+		sb.WriteString(fmt.Sprintf("RUN STATISTICS (%d runs, details above are from the final run)\n", rs.Runs))
+		sb.WriteString(strings.Repeat("=", 80) + "\n\n")
+		writeStatisticsTable(&sb, rs.Scores, "summary.")
+		if noisy := rs.Unreliable(); len(noisy) > 0 {
+			sb.WriteString(fmt.Sprintf("\n  Unreliable metrics (CV above %.0f%%):\n\n", rs.UnreliableCV))
+			writeStatisticsTable(&sb, noisy, "")
+		} else {
+			sb.WriteString(fmt.Sprintf("\n  All metrics varied less than %.0f%% between runs.\n", rs.UnreliableCV))
+		}
+	}
+
+	// Soak test
+	if soak := r.Soak; soak != nil {
+		sb.WriteString("\n" + strings.Repeat("=", 80) + "\n")
+		sb.WriteString(fmt.Sprintf("SOAK TEST (%d iterations over %s, details above are from the final iteration)\n", soak.Iterations, soak.Duration.Round(time.Second)))
+		sb.WriteString(strings.Repeat("=", 80) + "\n\n")
+		sb.WriteString(fmt.Sprintf("  %-24s %14s %14s %14s %9s\n", "Metric", "First", "Last", "Min", "Change"))
+		sb.WriteString("  " + strings.Repeat("-", 78) + "\n")
+		for _, d := range soak.Drift {
+			sb.WriteString(fmt.Sprintf("  %-24s %14.2f %14.2f %14.2f %+8.1f%%\n", d.Label, d.First, d.Last, d.Min, d.ChangePercent))
+		}
+		sb.WriteString(fmt.Sprintf("\n  Failed Iterations: %d of %d\n", soak.FailedIterations, soak.Iterations))
+		if soak.MaxTemperatureC > 0 {
+			sb.WriteString(fmt.Sprintf("  Max Temperature:   %.1f°C, %d throttle events\n", soak.MaxTemperatureC, soak.ThrottleEvents))
+		}
+		sb.WriteString(fmt.Sprintf("  Stability:         %s\n", soak.Verdict))
+		for _, reason := range soak.Reasons {
+			sb.WriteString(fmt.Sprintf("    %s\n", reason))
+		}
+	}
 
 	// Summary
 	sb.WriteString("\n" + strings.Repeat("=", 80) + "\n")
 	sb.WriteString("SUMMARY\n")
 	sb.WriteString(strings.Repeat("=", 80) + "\n")
-	sb.WriteString(fmt.Sprintf("\n  CPU Score:      %d/100\n", r.Summary.CPUScore))
-	sb.WriteString(fmt.Sprintf("  Memory Score:   %d/100\n", r.Summary.MemoryScore))
-	sb.WriteString(fmt.Sprintf("  Disk Score:     %d/100\n", r.Summary.DiskScore))
+	sb.WriteString(fmt.Sprintf("\n  CPU Score:      %s\n", r.Summary.categoryScore("cpu", r.Summary.CPUScore)))
+	sb.WriteString(fmt.Sprintf("  Memory Score:   %s\n", r.Summary.categoryScore("memory", r.Summary.MemoryScore)))
+	sb.WriteString(fmt.Sprintf("  Disk Score:     %s\n", r.Summary.categoryScore("disk", r.Summary.DiskScore)))
 	sb.WriteString(fmt.Sprintf("  ─────────────────────\n"))
 	sb.WriteString(fmt.Sprintf("  Overall Score:  %d/100\n", r.Summary.TotalScore))
+	if r.Summary.Partial {
+		sb.WriteString("  (partial: failed or skipped benchmarks excluded from scoring)\n")
+	}
+	packs := make([]string, 0, len(r.Summary.ForkScores))
+	for pack := range r.Summary.ForkScores {
+		packs = append(packs, pack)
+	}
+	sort.Strings(packs)
+	for _, pack := range packs {
+		sb.WriteString(fmt.Sprintf("  %-15s %d/100 (not in overall)\n", strings.ToUpper(pack[:1])+pack[1:]+":", r.Summary.ForkScores[pack]))
+	}
+
+	if r.Placement != nil {
+		sb.WriteString(fmt.Sprintf("\n  Compared to %s:\n", r.Placement.Description))
+		sb.WriteString(fmt.Sprintf("  CPU:            %s percentile\n", ordinal(r.Placement.CPUPercentile)))
+		sb.WriteString(fmt.Sprintf("  Memory:         %s percentile\n", ordinal(r.Placement.MemoryPercentile)))
+		sb.WriteString(fmt.Sprintf("  Disk:           %s percentile\n", ordinal(r.Placement.DiskPercentile)))
+		sb.WriteString(fmt.Sprintf("  Overall:        %s percentile\n", ordinal(r.Placement.TotalPercentile)))
+	}
+
+	if c := r.Reference; c != nil {
+		sb.WriteString("\n" + strings.Repeat("=", 80) + "\n")
+		sb.WriteString(fmt.Sprintf("REFERENCE COMPARISON: %s\n", c.Profile))
+		sb.WriteString(strings.Repeat("=", 80) + "\n")
+		sb.WriteString(fmt.Sprintf("\n  %s\n\n", c.Description))
+		sb.WriteString(fmt.Sprintf("  %-40s %12s %14s %8s\n", "Metric", "Measured", "Reference", "Yours"))
+		sb.WriteString("  " + strings.Repeat("-", 77) + "\n")
+		for _, m := range c.Metrics {
+			sb.WriteString(fmt.Sprintf("  %-40s %12.2f %14.2f %7.0f%%\n", m.Metric, m.Value, m.Reference, m.Percent))
+		}
+	}
+
+	// Baseline profile
+	if b := r.Baseline; b != nil {
+		result := "PASS"
+		if !b.Passed {
+			result = "FAIL"
+		}
+		sb.WriteString("\n" + strings.Repeat("=", 80) + "\n")
+		sb.WriteString(fmt.Sprintf("BASELINE: %s (%s)\n", b.Profile, result))
+		sb.WriteString(strings.Repeat("=", 80) + "\n")
+		sb.WriteString(fmt.Sprintf("\n  %s\n\n", b.Description))
+		sb.WriteString(fmt.Sprintf("  %-40s %12s %14s %8s\n", "Metric", "Required", "Measured", "Result"))
+		sb.WriteString("  " + strings.Repeat("-", 77) + "\n")
+		for _, c := range b.Checks {
+			measured, result := "not run", "FAIL"
+			if c.Measured {
+				measured = fmt.Sprintf("%.2f", c.Value)
+			}
+			if c.Passed {
+				result = "pass"
+			}
+			sb.WriteString(fmt.Sprintf("  %-40s %12s %14s %8s\n", c.Metric, c.Required, measured, result))
+		}
+	}
+
+	// Real client run from `ethbench trial`
+	if r.Trial != nil {
+		sb.WriteString("\n" + strings.Repeat("=", 80) + "\n")
+		sb.WriteString("CONSENSUS CLIENT TRIAL (real client, overrides the consensus verdict)\n")
+		sb.WriteString(strings.Repeat("=", 80) + "\n")
+		writeTrial(&sb, r.Trial)
+	}
 
 	// Verdict
 	sb.WriteString("\n" + strings.Repeat("=", 80) + "\n")
@@ -148,38 +991,268 @@ func FormatText(r *Report) string {
 	sb.WriteString(fmt.Sprintf("\n  Overall Score:        %d/100\n", r.Verdict.OverallScore))
 	sb.WriteString(fmt.Sprintf("\n  Execution Client:     %s\n", r.Verdict.ExecutionClient))
 	sb.WriteString(fmt.Sprintf("  Consensus Client:     %s\n", r.Verdict.ConsensusClient))
+	switch r.Verdict.Deployment {
+	case DeploymentConsensus, DeploymentExecution:
+		sb.WriteString(fmt.Sprintf("  Deployment:           split, %s client here (Remote: not assessed)\n", r.Verdict.Deployment))
+	case DeploymentDVT:
+		sb.WriteString("  Deployment:           distributed validator node\n")
+	}
+	if r.System != nil && r.System.Peer != nil {
+		sb.WriteString(fmt.Sprintf("  Peer Latency:         %s (%s)\n", r.System.Peer, r.System.Peer.Peer))
+	}
+	if r.Verdict.ContentionPenaltyPercent > 0 {
+		sb.WriteString(fmt.Sprintf("  Contention Penalty:   %.0f%% throughput lost under combined load\n", r.Verdict.ContentionPenaltyPercent))
+	}
+	if r.Verdict.Confidence == "reduced" {
+		sb.WriteString("  Rating Confidence:    reduced\n")
+		for _, note := range r.Verdict.ConfidenceNotes {
+			sb.WriteString(fmt.Sprintf("    %s\n", note))
+		}
+	}
+	if st := r.Verdict.Storage; st != nil {
+		sb.WriteString(fmt.Sprintf("\n  Disk Capacity:        %.0f GB total, %.0f GB free\n", st.TotalGB, st.FreeGB))
+		if st.BlobRetentionGB > 0 {
+			sb.WriteString(fmt.Sprintf("  Blob Retention:       ~%.0f GB (included below)\n", st.BlobRetentionGB))
+		}
+		for _, c := range st.Configs {
+			status := "fits"
+			if !c.FitsDisk {
+				status = "does not fit"
+			} else if !c.FitsFree {
+				status = "fits disk, not current free space"
+			}
+			sb.WriteString(fmt.Sprintf("  %-21s ~%.0f GB, %s\n", c.Name+":", c.RequiredGB, status))
+		}
+		if len(st.Clients) > 0 {
+			sb.WriteString("  Growth Projection:    disk dedicated to the node\n")
+		}
+		for _, c := range st.Clients {
+			projection := "does not fit"
+			if c.MonthsUntilFull > 0 {
+				projection = fmt.Sprintf("full in ~%.0f months", c.MonthsUntilFull)
+			}
+			sb.WriteString(fmt.Sprintf("    %-24s ~%.0f GB, +%.0f GB/month, %s\n", c.Clients(), c.CurrentGB, c.GrowthGBPerMonth, projection))
+		}
+	}
+	if cl := r.Verdict.Cluster; cl != nil {
+		writeCluster(&sb, cl)
+	}
 	sb.WriteString("\nRecommendations:\n")
 	for _, rec := range r.Verdict.Recommendations {
 		sb.WriteString(fmt.Sprintf("  - %s\n", rec))
 	}
 
+	if len(r.Findings) > 0 {
+		sb.WriteString("\nConfiguration Findings:\n")
+		for _, f := range r.Findings {
+			sb.WriteString(fmt.Sprintf("  [%s] %s: %s\n", strings.ToUpper(f.Severity), f.Component, f.Message))
+		}
+	}
+
 	sb.WriteString("\n" + strings.Repeat("=", 80) + "\n")
-	sb.WriteString(fmt.Sprintf("Benchmark completed in %.1f seconds\n", r.Metadata.DurationSeconds))
+	if r.Metadata.Incomplete {
+		sb.WriteString(fmt.Sprintf("Benchmark interrupted after %.1f seconds; results are partial\n", r.Metadata.DurationSeconds))
+	} else {
+		sb.WriteString(fmt.Sprintf("Benchmark completed in %.1f seconds\n", r.Metadata.DurationSeconds))
+	}
 	sb.WriteString(strings.Repeat("=", 80) + "\n")
 
 	return sb.String()
 }
 
-// filterRelevantCPUFeatures returns Ethereum-relevant CPU features
-func filterRelevantCPUFeatures(features []string) []string {
-	// Features important for Ethereum node operations
-	relevant := map[string]bool{
-		"asimd":   true, // NEON/SIMD - crypto operations
-		"aes":     true, // AES acceleration - DevP2P encryption
-		"sha1":    true, // SHA-1 acceleration
-		"sha2":    true, // SHA-256 acceleration
-		"sha3":    true, // SHA-3/Keccak acceleration
-		"crc32":   true, // CRC32 acceleration
-		"pmull":   true, // Polynomial multiply - GCM crypto
-		"atomics": true, // LSE atomics - concurrency
-		"fp":      true, // Floating point
+// formatCPUExtensions lists the crypto-relevant extensions the CPU has,
+// followed by the ones it lacks
+func formatCPUExtensions(sys *system.Info) string {
+	if len(sys.CPUFeatures) == 0 {
+		return ""
+	}
+	var present, missing []string
+	for _, ext := range system.CPUExtensions(sys.Architecture, sys.CPUFeatures) {
+		if ext.Present {
+			present = append(present, ext.Name)
+		} else {
+			missing = append(missing, ext.Name)
+		}
+	}
+	if len(present) == 0 && len(missing) == 0 {
+		return ""
+	}
+	line := strings.Join(present, ", ")
+	if len(present) == 0 {
+		line = "none"
+	}
+	if len(missing) > 0 {
+		line += fmt.Sprintf(" (missing: %s)", strings.Join(missing, ", "))
+	}
+	return line
+}
+
+// sectionOK writes an error or skipped line for an incomplete benchmark and
+// reports whether its metrics should be printed
+func sectionOK(sb *strings.Builder, status types.Status) bool {
+	switch {
+	case status.Error != "":
+		sb.WriteString(fmt.Sprintf("  Error:          %s\n", status.Error))
+		return false
+	case status.Skipped:
+		sb.WriteString("  Skipped\n")
+		return false
+	}
+	return true
+}
+
+// writeStatisticsTable writes one row per metric with its spread across runs
+func writeStatisticsTable(sb *strings.Builder, stats []MetricStatistics, trimPrefix string) {
+	sb.WriteString(fmt.Sprintf("  %-40s %12s %12s %8s %14s\n", "Metric", "Mean", "Median", "CV", "95% CI"))
+	sb.WriteString("  " + strings.Repeat("-", 90) + "\n")
+	for _, m := range stats {
+		sb.WriteString(fmt.Sprintf("  %-40s %12.2f %12.2f %7.1f%% %14s\n",
+			strings.TrimPrefix(m.Metric, trimPrefix), m.Mean, m.Median, m.CVPercent, fmt.Sprintf("± %.2f", m.CI95)))
+	}
+}
+
+// writeGCPhases writes the collections and pause time of each benchmark phase
+func writeGCPhases(sb *strings.Builder, phases []types.GCPhase) {
+	for _, p := range phases {
+		sb.WriteString(fmt.Sprintf("  GC:             %s: %d GCs, %s pause (%.2f%%)\n",
+			p.Phase, p.NumGC, p.GCPauseTotal.Round(time.Microsecond), p.PausePercent))
+	}
+}
+
+// formatSwap summarizes the swap areas, e.g. "4096 MB (zram lz4), 2048 MB (file)"
+func formatSwap(devices []system.SwapDevice) string {
+	if len(devices) == 0 {
+		return "none"
 	}
+	parts := make([]string, len(devices))
+	for i, d := range devices {
+		kind := d.Type
+		if d.Zram {
+			kind = strings.TrimSpace("zram " + d.Compressor)
+		}
+		parts[i] = fmt.Sprintf("%d MB (%s)", d.SizeMB, kind)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// accountedPhases returns the timeline phases with CPU accounting
+func accountedPhases(timeline []types.PhaseTiming) []types.PhaseTiming {
+	var phases []types.PhaseTiming
+	for _, p := range timeline {
+		if p.CPU != nil {
+			phases = append(phases, p)
+		}
+	}
+	return phases
+}
+
+// irqPhases returns the timeline phases with interrupt load
+func irqPhases(timeline []types.PhaseTiming) []types.PhaseTiming {
+	var phases []types.PhaseTiming
+	for _, p := range timeline {
+		if p.IRQ != nil {
+			phases = append(phases, p)
+		}
+	}
+	return phases
+}
+
+// describeInterface formats a NIC, bond or bridge on one line, e.g.
+// "bond0 bond up, 2000 Mbps, 802.3ad over eth0, eth1 (default route)"
+func describeInterface(nic system.NetworkInterface) string {
+	s := fmt.Sprintf("%s %s %s", nic.Name, nic.Type, nic.State)
+	if nic.SpeedMbps > 0 {
+		s += fmt.Sprintf(", %d Mbps", nic.SpeedMbps)
+	}
+	if len(nic.Members) > 0 {
+		s += ", " + strings.TrimSpace(nic.BondMode+" over "+strings.Join(nic.Members, ", "))
+	}
+	if nic.Master != "" {
+		s += ", in " + nic.Master
+	}
+	if nic.DefaultRoute {
+		s += " (default route)"
+	}
+	return s
+}
+
+// describePath summarizes a path probe, e.g. "1.1.1.1: MTU 1492 of 1500,
+// 12.1 ms avg, 0.8 ms jitter, 30.2 ms max, 2% lost over 50 pings"
+func describePath(p system.PathProbe) string {
+	s := p.Endpoint + ": "
+	if p.Address != "" && p.Address != p.Endpoint {
+		s = fmt.Sprintf("%s (%s): ", p.Endpoint, p.Address)
+	}
+	if p.MTU > 0 {
+		if p.Reduced() {
+			s += fmt.Sprintf("MTU %d of %d, ", p.MTU, p.InterfaceMTU)
+		} else {
+			s += fmt.Sprintf("MTU %d, ", p.MTU)
+		}
+	}
+	switch {
+	case p.Echo != nil:
+		s += p.Echo.String()
+	case p.Error != "":
+		s += "not measured: " + p.Error
+	}
+	return s
+}
+
+// describeCluster summarizes a group of cores, e.g. "cpu4-7 schedutil,
+// 408-2256 MHz, now 1800 MHz"
+func describeCluster(c system.CPUCluster) string {
+	s := "cpu" + system.FormatCPUList(c.CPUs)
+	if c.Governor != "" {
+		s += " " + c.Governor
+	}
+	if c.MaxMHz > 0 {
+		s += fmt.Sprintf(", %d-%d MHz", c.MinMHz, c.MaxMHz)
+	}
+	if c.Capped() {
+		s += fmt.Sprintf(" capped at %d MHz", c.ScalingMaxMHz)
+	}
+	switch {
+	case c.CurMaxMHz == 0:
+	case c.CurMinMHz == c.CurMaxMHz:
+		s += fmt.Sprintf(", now %d MHz", c.CurMaxMHz)
+	default:
+		s += fmt.Sprintf(", now %d-%d MHz", c.CurMinMHz, c.CurMaxMHz)
+	}
+	return s
+}
 
-	var result []string
-	for _, f := range features {
-		if relevant[f] {
-			result = append(result, f)
+// writeCluster renders the distributed validator checks of the verdict
+func writeCluster(sb *strings.Builder, cl *ClusterAssessment) {
+	sb.WriteString("\n")
+	for i, mw := range cl.Middleware {
+		label := "Middleware:"
+		if i > 0 {
+			label = ""
 		}
+		status := "fits"
+		if !mw.Fits {
+			status = "tight"
+		}
+		sb.WriteString(fmt.Sprintf("  %-22s%-9s ~%d MB, %.2f cores, %d MB left for the node, %s\n", label, mw.Name, mw.RAMMB, mw.CPUCores, mw.NodeRAMMB, status))
+	}
+	if cl.MaxValidators > 0 {
+		size := "4-node"
+		if cl.Size > 0 {
+			size = fmt.Sprintf("%d-node", cl.Size)
+		}
+		sb.WriteString(fmt.Sprintf("  Validators:           up to ~%d in a %s cluster, at a tenth of a core for partial signatures\n", cl.MaxValidators, size))
+	}
+	if len(cl.Peers) == 0 {
+		sb.WriteString("  Cluster Latency:      not measured (no -cluster-peers)\n")
+		return
+	}
+	if cl.Latency != "" {
+		sb.WriteString(fmt.Sprintf("  Cluster Latency:      %s, slowest peer %.1f ms (budget %d ms)\n", cl.Latency, cl.SlowestMs, clusterLatencyWarnMs))
+	} else {
+		sb.WriteString("  Cluster Latency:      no peer reachable\n")
+	}
+	for _, p := range cl.Peers {
+		sb.WriteString(fmt.Sprintf("    %-22s%s\n", p.Peer, p.String()))
 	}
-	return result
 }
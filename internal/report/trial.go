@@ -0,0 +1,97 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vBenchmark/internal/system"
+	"github.com/vBenchmark/internal/types"
+)
+
+// ApplyTrial attaches a client trial to the report and lets what the real
+// client did override the consensus client verdict derived from the
+// synthetic benchmarks. A consensus client scored as Remote by a split
+// profile is left as is.
+func (r *Report) ApplyTrial(t *types.TrialResult) {
+	r.Trial = t
+	if r.Verdict.ConsensusClient == "Remote" {
+		return
+	}
+	r.Verdict.ConsensusClient, r.Verdict.Recommendations = trialVerdict(t, r.System, r.Verdict.ConsensusClient, r.Verdict.Recommendations)
+}
+
+// trialVerdict returns the consensus client readiness and recommendations
+// after a trial
+func trialVerdict(t *types.TrialResult, sys *system.Info, readiness string, recommendations []string) (string, []string) {
+
+	switch t.Rating {
+	case "Excellent", "Good":
+		readiness = "Ready"
+	case "Adequate", "Marginal":
+		readiness = "Marginal"
+	default:
+		readiness = "Unsuitable"
+	}
+	if t.Synced {
+		recommendations = append(recommendations,
+			fmt.Sprintf("A real %s node reached head %.0f minutes after starting from a checkpoint and followed it with %.0f%% average CPU.", t.Client, t.TimeToHeadSeconds/60, t.AvgCPUPercent))
+	} else {
+		recommendations = append(recommendations,
+			fmt.Sprintf("A real %s node did not reach head within %s (%d slots behind, syncing %.1fx faster than real-time). Expect long resyncs after downtime.", t.Client, t.Duration.Round(time.Second), t.SyncDistance, t.RealtimeFactor))
+	}
+
+	if sys == nil {
+		return readiness, recommendations
+	}
+	if sys.RAMTotalMB > 0 && t.PeakMemoryMB > 0.5*float64(sys.RAMTotalMB) {
+		recommendations = append(recommendations,
+			fmt.Sprintf("%s used up to %.0f MB of %d MB RAM, leaving little for an execution client on the same machine.", t.Client, t.PeakMemoryMB, sys.RAMTotalMB))
+	}
+	if sys.CPUCores > 0 && t.AvgCPUPercent > 50*float64(sys.CPUCores) {
+		recommendations = append(recommendations,
+			fmt.Sprintf("%s kept %.0f%% of the CPU busy on average; an execution client will compete for the remaining cores.", t.Client, t.AvgCPUPercent/float64(sys.CPUCores)))
+	}
+	return readiness, recommendations
+}
+
+// FormatTrial renders a trial run and, without a benchmark report to fold
+// it into, the consensus client readiness it implies
+func FormatTrial(t *types.TrialResult, sys *system.Info) string {
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat("=", 80) + "\n")
+	sb.WriteString("                         CONSENSUS CLIENT TRIAL\n")
+	sb.WriteString(strings.Repeat("=", 80) + "\n")
+	writeTrial(&sb, t)
+
+	readiness, recommendations := trialVerdict(t, sys, "Unknown", nil)
+	sb.WriteString(fmt.Sprintf("\n  Consensus Client:     %s\n", readiness))
+	sb.WriteString("\nRecommendations:\n")
+	for _, rec := range recommendations {
+		sb.WriteString(fmt.Sprintf("  - %s\n", rec))
+	}
+	return sb.String()
+}
+
+// writeTrial writes the observed trial numbers
+func writeTrial(sb *strings.Builder, t *types.TrialResult) {
+	sb.WriteString(fmt.Sprintf("\n%s on %s (%s, checkpoint %s)\n", t.Client, t.Network, t.Image, t.CheckpointURL))
+	if !sectionOK(sb, t.Status) {
+		return
+	}
+	sb.WriteString(fmt.Sprintf("  Startup:        %.0f s until the beacon API reported a head\n", t.StartupSeconds))
+	sb.WriteString(fmt.Sprintf("  Head:           %s\n", trialHead(t)))
+	sb.WriteString(fmt.Sprintf("  Sync Speed:     %.2f slots/sec (%.1fx real-time), slots %d-%d\n", t.SlotsPerSecond, t.RealtimeFactor, t.StartSlot, t.EndSlot))
+	sb.WriteString(fmt.Sprintf("  CPU:            %.0f%% average, %.0f%% peak (100%% = one core)\n", t.AvgCPUPercent, t.PeakCPUPercent))
+	sb.WriteString(fmt.Sprintf("  Memory:         %.0f MB peak\n", t.PeakMemoryMB))
+	sb.WriteString(fmt.Sprintf("  Disk I/O:       %.0f MB read, %.0f MB written (%.0f MB/hour)\n", t.DiskReadMB, t.DiskWriteMB, t.WriteMBPerHour))
+	sb.WriteString(fmt.Sprintf("  Rating:         %s\n", t.Rating))
+}
+
+// trialHead describes whether and when the trial node reached head
+func trialHead(t *types.TrialResult) string {
+	if t.TimeToHeadSeconds > 0 {
+		return fmt.Sprintf("head after %.1f min", t.TimeToHeadSeconds/60)
+	}
+	return fmt.Sprintf("%d slots behind head", t.SyncDistance)
+}
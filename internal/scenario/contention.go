@@ -0,0 +1,269 @@
+package scenario
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+
+	"github.com/vBenchmark/internal/disk"
+	"github.com/vBenchmark/internal/fastrand"
+	"github.com/vBenchmark/internal/types"
+	"github.com/vBenchmark/internal/workload"
+)
+
+const (
+	// contentionFileSize is the file the disk load reads and writes; large
+	// enough that its blocks are spread over the drive
+	contentionFileSize = 128 << 20
+	// contentionWritePercent of the disk load's operations are writes, with
+	// a sync every contentionSyncEvery writes as a database WAL does
+	contentionWritePercent = 30
+	contentionSyncEvery    = 10
+	// contentionHashEvery trie updates the trie root is recomputed, as block
+	// processing does once per block
+	contentionHashEvery = 256
+	// minContentionPhase keeps each phase long enough to average over
+	// scheduler time slices in quick mode
+	minContentionPhase = time.Second
+)
+
+// contentionLoad runs one component of the combined load until ctx ends and
+// counts its operations
+type contentionLoad struct {
+	name    string
+	workers int
+	run     func(ctx context.Context, worker int, ops *atomic.Int64) error
+}
+
+// BenchmarkContention measures what a node's concurrent work costs: parallel
+// sender recovery, state trie updates with periodic root hashing, and random
+// 4K database I/O with syncs. Each load runs alone for a quarter of the
+// duration, then all three together for the last quarter; the drop of each
+// from alone to together shows contention for cores, caches, memory
+// bandwidth and I/O interrupts that the serial benchmarks cannot.
+func BenchmarkContention(ctx context.Context, testDir string, budget *disk.WriteBudget, duration time.Duration, verbose bool) (types.ContentionResult, error) {
+	phase := max(duration/4, minContentionPhase)
+	rng := fastrand.New("disk.contention")
+
+	// Crypto: signed transactions for half the cores to recover, leaving
+	// the others to the trie and disk loads on four or more cores
+	txs := make([]slotTx, 256)
+	for i := range txs {
+		var key *ecdsa.PrivateKey
+		for key == nil {
+			key, _ = crypto.ToECDSA(rng.Bytes(32))
+		}
+		hash := rng.Bytes(32)
+		signature, err := crypto.Sign(hash, key)
+		if err != nil {
+			return types.ContentionResult{}, fmt.Errorf("failed to sign transaction: %w", err)
+		}
+		txs[i] = slotTx{hash: hash, signature: signature}
+	}
+
+	// Trie: the workload's accounts keyed by address hash
+	state := workload.Current.State
+	gen := workload.NewGenerator(rng)
+	accounts := make([][]byte, state.Accounts)
+	accountKeys := make([][]byte, state.Accounts)
+	tr := trie.NewEmpty(triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	for i := range accounts {
+		accounts[i] = rng.Bytes(gen.Account().RLPSize)
+		accountKeys[i] = crypto.Keccak256(rng.Bytes(20))
+		tr.MustUpdate(accountKeys[i], accounts[i])
+	}
+	tr.Hash()
+
+	// Disk: a file written once, then dropped from the page cache so reads
+	// reach the drive
+	testFile := filepath.Join(testDir, "ethbench_contention_test.dat")
+	defer os.Remove(testFile)
+	f, err := os.OpenFile(testFile, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0644)
+	if err != nil {
+		return types.ContentionResult{}, fmt.Errorf("failed to create test file: %w", err)
+	}
+	defer f.Close()
+	if !budget.Take(contentionFileSize) {
+		return types.ContentionResult{}, disk.ErrWriteLimit
+	}
+	chunk := rng.Bytes(1 << 20)
+	for off := int64(0); off < contentionFileSize; off += int64(len(chunk)) {
+		if _, err := f.WriteAt(chunk, off); err != nil {
+			return types.ContentionResult{}, fmt.Errorf("failed to write test file: %w", err)
+		}
+	}
+	if err := f.Sync(); err != nil {
+		return types.ContentionResult{}, fmt.Errorf("failed to sync test file: %w", err)
+	}
+	// Every phase replays the same offsets, so the cache is dropped again
+	// before each
+	dropCache := func() {
+		syscall.Syscall6(syscall.SYS_FADVISE64, f.Fd(), 0, uintptr(contentionFileSize), uintptr(4), 0, 0) // POSIX_FADV_DONTNEED = 4
+	}
+
+	loads := []contentionLoad{
+		{"crypto", max(runtime.NumCPU()/2, 1), func(ctx context.Context, worker int, ops *atomic.Int64) error {
+			for i := worker; ctx.Err() == nil; i++ {
+				tx := txs[i%len(txs)]
+				if _, err := crypto.Ecrecover(tx.hash, tx.signature); err != nil {
+					return fmt.Errorf("failed to recover sender: %w", err)
+				}
+				ops.Add(1)
+			}
+			return nil
+		}},
+		{"trie", 1, func(ctx context.Context, _ int, ops *atomic.Int64) error {
+			src := fastrand.New("disk.contention.trie")
+			for n := 1; ctx.Err() == nil; n++ {
+				idx := int(src.Uint64() % uint64(len(accounts)))
+				accounts[idx][0]++ // Nonce
+				tr.MustUpdate(accountKeys[idx], accounts[idx])
+				if n%contentionHashEvery == 0 {
+					tr.Hash()
+				}
+				ops.Add(1)
+			}
+			return nil
+		}},
+		{"disk", 1, func(ctx context.Context, _ int, ops *atomic.Int64) error {
+			src := fastrand.New("disk.contention.io")
+			block := make([]byte, 4096)
+			blocks := uint64(contentionFileSize / len(block))
+			for writes := 0; ctx.Err() == nil; {
+				offset := int64(src.Uint64()%blocks) * int64(len(block))
+				if src.Uint64()%100 < contentionWritePercent {
+					if !budget.Take(len(block)) {
+						return disk.ErrWriteLimit
+					}
+					src.Read(block)
+					if _, err := f.WriteAt(block, offset); err != nil {
+						return fmt.Errorf("failed to write block: %w", err)
+					}
+					if writes++; writes%contentionSyncEvery == 0 {
+						if err := f.Sync(); err != nil {
+							return fmt.Errorf("failed to sync: %w", err)
+						}
+					}
+				} else if _, err := f.ReadAt(block, offset); err != nil {
+					return fmt.Errorf("failed to read block: %w", err)
+				}
+				ops.Add(1)
+			}
+			return nil
+		}},
+	}
+
+	start := time.Now()
+	isolated := make([]float64, len(loads))
+	for i, load := range loads {
+		dropCache()
+		rates, err := runContention(ctx, phase, load)
+		if err != nil {
+			return types.ContentionResult{}, err
+		}
+		isolated[i] = rates[0]
+		if verbose {
+			fmt.Printf("    %s alone: %.0f ops/sec\n", load.name, isolated[i])
+		}
+	}
+	dropCache()
+	combined, err := runContention(ctx, phase, loads...)
+	if err != nil {
+		return types.ContentionResult{}, err
+	}
+	if ctx.Err() != nil {
+		return types.ContentionResult{}, ctx.Err()
+	}
+
+	components := make([]types.ContentionComponent, len(loads))
+	var penalty float64
+	for i, load := range loads {
+		c := types.ContentionComponent{
+			Workers:           load.workers,
+			IsolatedPerSecond: isolated[i],
+			CombinedPerSecond: combined[i],
+		}
+		if c.IsolatedPerSecond > 0 {
+			c.DegradationPercent = max(0, (1-c.CombinedPerSecond/c.IsolatedPerSecond)*100)
+		}
+		penalty += c.DegradationPercent / float64(len(loads))
+		components[i] = c
+		if verbose {
+			fmt.Printf("    %s combined: %.0f ops/sec (-%.1f%%)\n", load.name, c.CombinedPerSecond, c.DegradationPercent)
+		}
+	}
+	result := types.ContentionResult{
+		Crypto:         components[0],
+		Trie:           components[1],
+		Disk:           components[2],
+		PenaltyPercent: penalty,
+		Duration:       time.Since(start),
+	}
+	result.Rating = rateContention(penalty)
+	return result, nil
+}
+
+// runContention runs the loads together for d and returns the operations
+// per second of each
+func runContention(ctx context.Context, d time.Duration, loads ...contentionLoad) ([]float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+
+	counts := make([]atomic.Int64, len(loads))
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	start := time.Now()
+	for i, load := range loads {
+		for w := 0; w < load.workers; w++ {
+			wg.Add(1)
+			go func(i, w int) {
+				defer wg.Done()
+				if err := loads[i].run(ctx, w, &counts[i]); err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+					cancel()
+				}
+			}(i, w)
+		}
+	}
+	wg.Wait()
+	elapsed := time.Since(start).Seconds()
+	rates := make([]float64, len(loads))
+	for i := range counts {
+		rates[i] = float64(counts[i].Load()) / elapsed
+	}
+	return rates, firstErr
+}
+
+// rateContention provides a rating based on the average throughput the
+// loads lose when run together
+func rateContention(penaltyPercent float64) string {
+	switch {
+	case penaltyPercent < 15:
+		return "Excellent"
+	case penaltyPercent < 30:
+		return "Good"
+	case penaltyPercent < 50:
+		return "Adequate"
+	case penaltyPercent < 70:
+		return "Marginal"
+	default:
+		return "Poor"
+	}
+}
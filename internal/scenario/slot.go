@@ -0,0 +1,261 @@
+// Package scenario runs end-to-end pipelines that combine the CPU, memory
+// and disk work a node does for one block, timed against the slot deadlines
+// validators are judged by
+package scenario
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+
+	"github.com/vBenchmark/internal/disk"
+	"github.com/vBenchmark/internal/fastrand"
+	"github.com/vBenchmark/internal/types"
+	"github.com/vBenchmark/internal/workload"
+)
+
+const (
+	// slotTxGas is the average gas of a transaction, giving ~300
+	// transactions in a 30M gas block
+	slotTxGas = 100_000
+	// slotSigners is the number of distinct senders the transactions are
+	// signed by
+	slotSigners = 64
+	// minSlots is the number of slots simulated even when they take longer
+	// than the time budget, so a slow machine still gets a success rate
+	minSlots = 5
+)
+
+// SlotDeadline is the attestation deadline: a third into the slot,
+// attesters vote for the head they have, so a block not imported by then
+// is attested as missing
+func SlotDeadline() time.Duration {
+	return time.Duration(workload.Current.Block.SlotSeconds) * time.Second / 3
+}
+
+// stateSink keeps the state reads from being optimised away
+var stateSink byte
+
+// slotTx is a signed transaction reduced to what the pipeline touches
+type slotTx struct {
+	hash      []byte
+	signature []byte
+	to        int // Index of the recipient account
+}
+
+// BenchmarkSlot runs a block-processing pipeline back to back: recover the
+// senders of a block's transactions in parallel, apply their balance and
+// nonce updates to cached state objects, recompute the state trie root and
+// commit the block's dirty trie nodes and accounts in one synced batch. Each
+// slot is timed against SlotDeadline, which is what decides whether the
+// validator attests to the new block or misses the head vote.
+// Reference: geth/core/sender_cacher.go, geth/core/state/statedb.go Commit()
+func BenchmarkSlot(ctx context.Context, testDir string, budget *disk.WriteBudget, duration time.Duration, verbose bool) (types.SlotResult, error) {
+	block := workload.Current.Block
+	state := workload.Current.State
+	txCount := int(block.GasUsed / slotTxGas)
+	deadline := SlotDeadline()
+	rng := fastrand.New("disk.slot")
+
+	// Senders and a block of signed transactions. The signatures are reused
+	// every slot; nothing in the pipeline caches recovered senders.
+	keys := make([]*ecdsa.PrivateKey, slotSigners)
+	for i := range keys {
+		for keys[i] == nil {
+			keys[i], _ = crypto.ToECDSA(rng.Bytes(32))
+		}
+	}
+	txs := make([]slotTx, txCount)
+	for i := range txs {
+		hash := rng.Bytes(32)
+		signature, err := crypto.Sign(hash, keys[i%slotSigners])
+		if err != nil {
+			return types.SlotResult{}, fmt.Errorf("failed to sign transaction: %w", err)
+		}
+		txs[i] = slotTx{hash: hash, signature: signature, to: int(rng.Uint64() % uint64(state.Accounts))}
+	}
+
+	// State: cached account objects and the state trie over them, keyed by
+	// the hash of the address as in Geth
+	gen := workload.NewGenerator(rng)
+	accounts := make([][]byte, state.Accounts)
+	accountKeys := make([][]byte, state.Accounts)
+	tr := trie.NewEmpty(triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	for i := range accounts {
+		accounts[i] = rng.Bytes(gen.Account().RLPSize)
+		accountKeys[i] = crypto.Keccak256(rng.Bytes(20))
+		tr.MustUpdate(accountKeys[i], accounts[i])
+	}
+	tr.Hash()
+
+	testFile := filepath.Join(testDir, "ethbench_slot_test.dat")
+	defer os.Remove(testFile)
+	f, err := os.OpenFile(testFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return types.SlotResult{}, fmt.Errorf("failed to create test file: %w", err)
+	}
+	defer f.Close()
+	nodeBlob := rng.Bytes(state.NodesPerBlock * state.NodeSize)
+
+	var slotTimes []time.Duration
+	var recoverTime, stateTime, rootTime, commitTime time.Duration
+	start := time.Now()
+	for ctx.Err() == nil && (time.Since(start) < duration || len(slotTimes) < minSlots) {
+		slotStart := time.Now()
+
+		// 1. Sender recovery, spread over all cores
+		senders, err := recoverSenders(txs)
+		if err != nil {
+			return types.SlotResult{}, err
+		}
+		recovered := time.Now()
+
+		// 2. State updates: debit the sender, credit the recipient and read
+		// the rest of the block's state from the cache
+		dirty := make(map[int]bool, 2*len(txs))
+		for i, tx := range txs {
+			from := int(binary.BigEndian.Uint32(senders[i]) % uint32(state.Accounts))
+			for _, idx := range []int{from, tx.to} {
+				account := accounts[idx]
+				account[0]++ // Nonce
+				account[len(account)-1] ^= senders[i][1]
+				dirty[idx] = true
+			}
+		}
+		for i := 0; i < state.ReadsPerBlock; i++ {
+			stateSink ^= accounts[(i*7919)%state.Accounts][0]
+		}
+		updated := time.Now()
+
+		// 3. State root over the dirty accounts
+		for idx := range dirty {
+			tr.MustUpdate(accountKeys[idx], accounts[idx])
+		}
+		tr.Hash()
+		hashed := time.Now()
+
+		// 4. Commit the block's trie nodes and accounts in one synced batch
+		batch := slices.Clone(nodeBlob)
+		for idx := range dirty {
+			batch = append(batch, accountKeys[idx]...)
+			batch = append(batch, accounts[idx]...)
+		}
+		if !budget.Take(len(batch)) {
+			break
+		}
+		if _, err := f.Write(batch); err != nil {
+			return types.SlotResult{}, fmt.Errorf("failed to write batch: %w", err)
+		}
+		if err := f.Sync(); err != nil {
+			return types.SlotResult{}, fmt.Errorf("failed to sync batch: %w", err)
+		}
+		committed := time.Now()
+
+		recoverTime += recovered.Sub(slotStart)
+		stateTime += updated.Sub(recovered)
+		rootTime += hashed.Sub(updated)
+		commitTime += committed.Sub(hashed)
+		slotTimes = append(slotTimes, committed.Sub(slotStart))
+		if verbose {
+			fmt.Printf("    Slot %d: %.1f ms\n", len(slotTimes), float64(committed.Sub(slotStart).Microseconds())/1000)
+		}
+	}
+	if len(slotTimes) == 0 {
+		if budget.Exhausted() {
+			return types.SlotResult{}, disk.ErrWriteLimit
+		}
+		return types.SlotResult{}, ctx.Err()
+	}
+
+	slots := len(slotTimes)
+	var met int
+	var total time.Duration
+	for _, t := range slotTimes {
+		if t <= deadline {
+			met++
+		}
+		total += t
+	}
+	slices.Sort(slotTimes)
+	avgMs := func(d time.Duration) float64 { return float64(d.Microseconds()) / 1000 / float64(slots) }
+	result := types.SlotResult{
+		Slots:        slots,
+		Transactions: txCount,
+		DeadlineMs:   float64(deadline.Milliseconds()),
+		SuccessRate:  float64(met) / float64(slots) * 100,
+		P50Ms:        durationMs(slotTimes[slots/2]),
+		P99Ms:        durationMs(slotTimes[min(slots*99/100, slots-1)]),
+		MaxMs:        durationMs(slotTimes[slots-1]),
+		AvgRecoverMs: avgMs(recoverTime),
+		AvgStateMs:   avgMs(stateTime),
+		AvgRootMs:    avgMs(rootTime),
+		AvgCommitMs:  avgMs(commitTime),
+		Duration:     total,
+	}
+	result.Rating = rateSlot(result.SuccessRate, result.P99Ms, result.DeadlineMs)
+	return result, nil
+}
+
+// recoverSenders recovers the public key of every transaction, split
+// between one goroutine per core as Geth's sender cacher does
+func recoverSenders(txs []slotTx) ([][]byte, error) {
+	senders := make([][]byte, len(txs))
+	workers := runtime.NumCPU()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < len(txs); i += workers {
+				pub, err := crypto.Ecrecover(txs[i].hash, txs[i].signature)
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = fmt.Errorf("failed to recover sender: %w", err)
+					}
+					mu.Unlock()
+					return
+				}
+				senders[i] = crypto.Keccak256(pub[1:])[12:]
+			}
+		}(w)
+	}
+	wg.Wait()
+	return senders, firstErr
+}
+
+// durationMs converts d to fractional milliseconds
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// rateSlot provides a rating based on the share of slots that met the
+// deadline and how close the slowest came to it
+func rateSlot(successRate, p99Ms, deadlineMs float64) string {
+	switch {
+	case successRate < 95:
+		return "Poor"
+	case successRate < 100:
+		return "Marginal"
+	case p99Ms > deadlineMs/2:
+		return "Adequate"
+	case p99Ms > deadlineMs/4:
+		return "Good"
+	default:
+		return "Excellent"
+	}
+}
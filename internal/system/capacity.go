@@ -0,0 +1,16 @@
+package system
+
+import (
+	"syscall"
+)
+
+// DiskCapacity returns the total and free bytes of the filesystem holding path
+func DiskCapacity(path string) (total, free uint64, err error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, err
+	}
+	total = stat.Blocks * uint64(stat.Bsize)
+	free = stat.Bavail * uint64(stat.Bsize)
+	return total, free, nil
+}
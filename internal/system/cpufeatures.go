@@ -0,0 +1,158 @@
+package system
+
+import (
+	"bufio"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+
+	"golang.org/x/sys/cpu"
+)
+
+// CPUExtension is an instruction set extension that node cryptography
+// depends on, and whether the CPU reports it
+type CPUExtension struct {
+	Name    string `json:"name"`
+	Flag    string `json:"flag"` // as listed in /proc/cpuinfo
+	Present bool   `json:"present"`
+	Purpose string `json:"purpose"`
+	// Important extensions have a measurable cost when missing; the rest
+	// are only shown
+	Important bool `json:"important"`
+}
+
+// arm64Extensions covers the ARMv8 crypto extensions. The Raspberry Pi 4
+// (BCM2711) ships without AES/PMULL/SHA2, the Pi 5 has them but no SHA3.
+var arm64Extensions = []CPUExtension{
+	{Name: "NEON", Flag: "asimd", Purpose: "SIMD for hashing and field arithmetic", Important: true},
+	{Name: "AES", Flag: "aes", Purpose: "AES-GCM for devp2p and libp2p transport encryption", Important: true},
+	{Name: "PMULL", Flag: "pmull", Purpose: "GHASH for AES-GCM", Important: true},
+	{Name: "SHA-256", Flag: "sha2", Purpose: "SHA-256 for beacon state hash tree roots", Important: true},
+	{Name: "SHA3", Flag: "sha3", Purpose: "Keccak-256 in clients with native arm64 code paths"},
+	{Name: "LSE atomics", Flag: "atomics", Purpose: "atomics and locks that scale with core count", Important: true},
+	{Name: "CRC32", Flag: "crc32", Purpose: "checksums in Pebble, LevelDB and RocksDB"},
+}
+
+// amd64Extensions covers the x86 extensions used by blst, gnark and the
+// Go standard library crypto assembly.
+var amd64Extensions = []CPUExtension{
+	{Name: "AES-NI", Flag: "aes", Purpose: "AES-GCM for devp2p and libp2p transport encryption", Important: true},
+	{Name: "PCLMULQDQ", Flag: "pclmulqdq", Purpose: "GHASH for AES-GCM", Important: true},
+	{Name: "AVX2", Flag: "avx2", Purpose: "vectorized SHA-256 and KZG field arithmetic", Important: true},
+	{Name: "ADX", Flag: "adx", Purpose: "carry chains in BLS12-381 and BN254 arithmetic", Important: true},
+	{Name: "BMI2", Flag: "bmi2", Purpose: "MULX in BLS12-381 and BN254 arithmetic", Important: true},
+	{Name: "SHA-NI", Flag: "sha_ni", Purpose: "SHA-256 for beacon state hash tree roots"},
+}
+
+// detectCPUFeatures reads the CPU feature flags from /proc/cpuinfo: the
+// "Features" line on ARM and the "flags" line on x86. When neither is
+// present (containers with a masked /proc) it falls back to the features
+// golang.org/x/sys/cpu detects for the running architecture.
+func detectCPUFeatures() []string {
+	if features := cpuinfoFeatures(); len(features) > 0 {
+		return features
+	}
+	return runtimeCPUFeatures()
+}
+
+func cpuinfoFeatures() []string {
+	file, err := os.Open("/proc/cpuinfo")
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "Features", "flags":
+			return strings.Fields(value)
+		}
+	}
+	return nil
+}
+
+// runtimeCPUFeatures names the features x/sys/cpu detected using the
+// /proc/cpuinfo spelling, so both sources compare the same way
+func runtimeCPUFeatures() []string {
+	var detected map[string]bool
+	switch runtime.GOARCH {
+	case "arm64":
+		detected = map[string]bool{
+			"asimd":   cpu.ARM64.HasASIMD,
+			"aes":     cpu.ARM64.HasAES,
+			"pmull":   cpu.ARM64.HasPMULL,
+			"sha1":    cpu.ARM64.HasSHA1,
+			"sha2":    cpu.ARM64.HasSHA2,
+			"sha3":    cpu.ARM64.HasSHA3,
+			"atomics": cpu.ARM64.HasATOMICS,
+			"crc32":   cpu.ARM64.HasCRC32,
+		}
+	case "amd64", "386":
+		detected = map[string]bool{
+			"aes":       cpu.X86.HasAES,
+			"pclmulqdq": cpu.X86.HasPCLMULQDQ,
+			"avx":       cpu.X86.HasAVX,
+			"avx2":      cpu.X86.HasAVX2,
+			"adx":       cpu.X86.HasADX,
+			"bmi1":      cpu.X86.HasBMI1,
+			"bmi2":      cpu.X86.HasBMI2,
+			"sse4_2":    cpu.X86.HasSSE42,
+		}
+	}
+	var features []string
+	for flag, ok := range detected {
+		if ok {
+			features = append(features, flag)
+		}
+	}
+	// Map iteration order is random; keep the output stable
+	sort.Strings(features)
+	return features
+}
+
+// CPUExtensions reports the crypto-relevant extensions for arch and whether
+// features (as detected into Info.CPUFeatures) contains each. It returns nil
+// for architectures without a known list.
+func CPUExtensions(arch string, features []string) []CPUExtension {
+	var known []CPUExtension
+	switch arch {
+	case "arm64":
+		known = arm64Extensions
+	case "amd64", "386":
+		known = amd64Extensions
+	default:
+		return nil
+	}
+	have := make(map[string]bool, len(features))
+	for _, f := range features {
+		have[f] = true
+	}
+	extensions := make([]CPUExtension, len(known))
+	for i, ext := range known {
+		ext.Present = have[ext.Flag]
+		extensions[i] = ext
+	}
+	return extensions
+}
+
+// MissingCPUExtensions returns the important extensions the CPU lacks. An
+// empty feature list means detection failed, not that everything is
+// missing, so it reports nothing.
+func (info *Info) MissingCPUExtensions() []CPUExtension {
+	if len(info.CPUFeatures) == 0 {
+		return nil
+	}
+	var missing []CPUExtension
+	for _, ext := range CPUExtensions(info.Architecture, info.CPUFeatures) {
+		if ext.Important && !ext.Present {
+			missing = append(missing, ext)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,213 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// cpuSysfs is where the kernel lists the CPUs and their cpufreq policies
+const cpuSysfs = "/sys/devices/system/cpu"
+
+// CPUCore is the frequency scaling state of one core. The limits are the
+// hardware's (cpuinfo_min/max_freq); ScalingMaxMHz is the cap the governor
+// may use, lower when set by config.txt, a power profile or thermal limits.
+type CPUCore struct {
+	CPU           int    `json:"cpu"`
+	Policy        string `json:"policy,omitempty"`
+	Governor      string `json:"governor,omitempty"`
+	CurMHz        int    `json:"cur_mhz,omitempty"`
+	MinMHz        int    `json:"min_mhz,omitempty"`
+	MaxMHz        int    `json:"max_mhz,omitempty"`
+	ScalingMaxMHz int    `json:"scaling_max_mhz,omitempty"`
+}
+
+// CPUCluster is a group of cores with the same governor and limits: the big
+// and the LITTLE cores of a heterogeneous board, or every core of a uniform
+// CPU. CurMinMHz and CurMaxMHz span the cores' current frequencies.
+type CPUCluster struct {
+	CPUs          []int  `json:"cpus"`
+	Governor      string `json:"governor,omitempty"`
+	MinMHz        int    `json:"min_mhz,omitempty"`
+	MaxMHz        int    `json:"max_mhz,omitempty"`
+	ScalingMaxMHz int    `json:"scaling_max_mhz,omitempty"`
+	CurMinMHz     int    `json:"cur_min_mhz,omitempty"`
+	CurMaxMHz     int    `json:"cur_max_mhz,omitempty"`
+}
+
+// Capped reports whether the scaling cap keeps the cores below their
+// hardware maximum
+func (c CPUCluster) Capped() bool {
+	return c.ScalingMaxMHz > 0 && c.MaxMHz > 0 && c.ScalingMaxMHz < c.MaxMHz
+}
+
+// GovernorChange is a cpufreq policy switched to the performance governor
+// by -set-performance-governor, and back to From once the benchmarks ended
+type GovernorChange struct {
+	Policy   string `json:"policy"`
+	CPUs     string `json:"cpus"`
+	From     string `json:"from"`
+	Applied  bool   `json:"applied"`
+	Restored bool   `json:"restored"`
+	Error    string `json:"error,omitempty"`
+}
+
+// DetectCPUFrequencies reads the cpufreq state of every online core, in CPU
+// order. Cores without cpufreq (VMs, some containers) are left out.
+func DetectCPUFrequencies() []CPUCore {
+	dirs, _ := filepath.Glob(filepath.Join(cpuSysfs, "cpu[0-9]*"))
+	var cores []CPUCore
+	for _, dir := range dirs {
+		id, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(dir), "cpu"))
+		if err != nil {
+			continue
+		}
+		freq := filepath.Join(dir, "cpufreq")
+		if _, err := os.Stat(freq); err != nil {
+			continue
+		}
+		core := CPUCore{
+			CPU:           id,
+			Governor:      readSysString(filepath.Join(freq, "scaling_governor")),
+			CurMHz:        readFreqMHz(filepath.Join(freq, "scaling_cur_freq")),
+			MinMHz:        readFreqMHz(filepath.Join(freq, "cpuinfo_min_freq")),
+			MaxMHz:        readFreqMHz(filepath.Join(freq, "cpuinfo_max_freq")),
+			ScalingMaxMHz: readFreqMHz(filepath.Join(freq, "scaling_max_freq")),
+		}
+		// cpuN/cpufreq links to the policy the core shares with its cluster
+		if target, err := filepath.EvalSymlinks(freq); err == nil && strings.HasPrefix(filepath.Base(target), "policy") {
+			core.Policy = filepath.Base(target)
+		}
+		cores = append(cores, core)
+	}
+	sort.Slice(cores, func(i, j int) bool { return cores[i].CPU < cores[j].CPU })
+	return cores
+}
+
+// CPUClusters groups the detected cores by governor and frequency limits,
+// in the order of their first core
+func (info *Info) CPUClusters() []CPUCluster {
+	var clusters []CPUCluster
+	for _, core := range info.CPUFrequencies {
+		i := 0
+		for ; i < len(clusters); i++ {
+			c := &clusters[i]
+			if c.Governor == core.Governor && c.MinMHz == core.MinMHz && c.MaxMHz == core.MaxMHz && c.ScalingMaxMHz == core.ScalingMaxMHz {
+				break
+			}
+		}
+		if i == len(clusters) {
+			clusters = append(clusters, CPUCluster{
+				Governor:      core.Governor,
+				MinMHz:        core.MinMHz,
+				MaxMHz:        core.MaxMHz,
+				ScalingMaxMHz: core.ScalingMaxMHz,
+				CurMinMHz:     core.CurMHz,
+			})
+		}
+		c := &clusters[i]
+		c.CPUs = append(c.CPUs, core.CPU)
+		c.CurMinMHz = min(c.CurMinMHz, core.CurMHz)
+		c.CurMaxMHz = max(c.CurMaxMHz, core.CurMHz)
+	}
+	return clusters
+}
+
+// RefreshCPUFrequencies re-reads the governor and frequencies, e.g. after
+// SetPerformanceGovernor changed them
+func (info *Info) RefreshCPUFrequencies() {
+	info.CPUGovernor = detectCPUGovernor()
+	info.CPUFreqMHz = detectCPUFrequency()
+	info.CPUScalingMaxMHz = readFreqMHz(filepath.Join(cpuSysfs, "cpu0/cpufreq/scaling_max_freq"))
+	info.CPUFrequencies = DetectCPUFrequencies()
+}
+
+// FormatCPUList writes CPU numbers in the kernel's list format, e.g.
+// "0-3,6"
+func FormatCPUList(cpus []int) string {
+	var parts []string
+	for i := 0; i < len(cpus); {
+		j := i
+		for j+1 < len(cpus) && cpus[j+1] == cpus[j]+1 {
+			j++
+		}
+		if j > i {
+			parts = append(parts, fmt.Sprintf("%d-%d", cpus[i], cpus[j]))
+		} else {
+			parts = append(parts, strconv.Itoa(cpus[i]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
+
+// SetPerformanceGovernor switches every cpufreq policy not already on the
+// performance governor to it. Writing needs root; RestoreGovernors undoes
+// the changes.
+func SetPerformanceGovernor() []GovernorChange {
+	policies, _ := filepath.Glob(filepath.Join(cpuSysfs, "cpufreq", "policy[0-9]*"))
+	var changes []GovernorChange
+	for _, policy := range policies {
+		governor := readSysString(filepath.Join(policy, "scaling_governor"))
+		if governor == "" || governor == "performance" {
+			continue
+		}
+		change := GovernorChange{
+			Policy: filepath.Base(policy),
+			CPUs:   relatedCPUs(policy),
+			From:   governor,
+		}
+		available := strings.Fields(readSysString(filepath.Join(policy, "scaling_available_governors")))
+		if len(available) > 0 && !slices.Contains(available, "performance") {
+			change.Error = "performance governor not available, have " + strings.Join(available, ", ")
+			changes = append(changes, change)
+			continue
+		}
+		err := os.WriteFile(filepath.Join(policy, "scaling_governor"), []byte("performance\n"), 0644)
+		switch {
+		case err == nil:
+			change.Applied = true
+		case errors.Is(err, os.ErrPermission), errors.Is(err, syscall.EROFS):
+			change.Error = "needs root"
+		default:
+			change.Error = err.Error()
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// RestoreGovernors switches the policies SetPerformanceGovernor changed back
+// to their previous governor
+func RestoreGovernors(changes []GovernorChange) error {
+	var errs []error
+	for i, change := range changes {
+		if !change.Applied || change.Restored {
+			continue
+		}
+		path := filepath.Join(cpuSysfs, "cpufreq", change.Policy, "scaling_governor")
+		if err := os.WriteFile(path, []byte(change.From+"\n"), 0644); err != nil {
+			errs = append(errs, fmt.Errorf("cpu%s: %w", change.CPUs, err))
+			continue
+		}
+		changes[i].Restored = true
+	}
+	return errors.Join(errs...)
+}
+
+// relatedCPUs lists the CPUs of a cpufreq policy, e.g. "4-7"
+func relatedCPUs(policy string) string {
+	var cpus []int
+	for _, field := range strings.Fields(readSysString(filepath.Join(policy, "related_cpus"))) {
+		if cpu, err := strconv.Atoi(field); err == nil {
+			cpus = append(cpus, cpu)
+		}
+	}
+	return FormatCPUList(cpus)
+}
@@ -0,0 +1,101 @@
+package system
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// CPUSnapshot holds the process's CPU time and the system-wide I/O wait
+// counters at a point in time
+type CPUSnapshot struct {
+	taken   time.Time
+	user    time.Duration
+	system  time.Duration
+	iowait  uint64 // Jiffies summed over all CPUs
+	total   uint64
+	numCPUs int
+}
+
+// TakeCPUSnapshot reads the process's user and system CPU time (getrusage)
+// and the system's I/O wait from /proc/stat
+func TakeCPUSnapshot() CPUSnapshot {
+	snap := CPUSnapshot{taken: time.Now()}
+	var usage syscall.Rusage
+	if syscall.Getrusage(syscall.RUSAGE_SELF, &usage) == nil {
+		snap.user = time.Duration(usage.Utime.Nano())
+		snap.system = time.Duration(usage.Stime.Nano())
+	}
+	snap.iowait, snap.total, snap.numCPUs = readIOWait()
+	return snap
+}
+
+// Since returns the CPU time consumed between before and s, and what bound
+// the phase: "cpu" when the process kept CPUs busy with little I/O wait,
+// "io_wait" when more CPUs sat waiting for I/O than the process kept busy,
+// "idle" when neither happened (timers, sleeps) and "mixed" otherwise
+func (s CPUSnapshot) Since(before CPUSnapshot) *types.CPUAccounting {
+	wall := s.taken.Sub(before.taken)
+	if wall <= 0 {
+		return nil
+	}
+	acct := &types.CPUAccounting{
+		UserSeconds:   (s.user - before.user).Seconds(),
+		SystemSeconds: (s.system - before.system).Seconds(),
+	}
+	acct.Utilization = (acct.UserSeconds + acct.SystemSeconds) / wall.Seconds()
+	if total := s.total - before.total; total > 0 && s.numCPUs > 0 {
+		acct.IOWaitCores = float64(s.iowait-before.iowait) / float64(total) * float64(s.numCPUs)
+	}
+
+	switch {
+	case acct.Utilization < 0.1 && acct.IOWaitCores < 0.1:
+		acct.Bound = "idle"
+	case acct.IOWaitCores > acct.Utilization:
+		acct.Bound = "io_wait"
+	case acct.IOWaitCores < acct.Utilization/4:
+		acct.Bound = "cpu"
+	default:
+		acct.Bound = "mixed"
+	}
+	return acct
+}
+
+// readIOWait returns the iowait and total jiffies summed over all CPUs from
+// /proc/stat, and the number of CPUs counted
+func readIOWait() (iowait, total uint64, numCPUs int) {
+	file, err := os.Open("/proc/stat")
+	if err != nil {
+		return 0, 0, 0
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 || !strings.HasPrefix(fields[0], "cpu") {
+			continue
+		}
+		if fields[0] != "cpu" {
+			numCPUs++
+			continue
+		}
+		for i, field := range fields[1:] {
+			v, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				continue
+			}
+			total += v
+			// Fields: user nice system idle iowait ...
+			if i == 4 {
+				iowait += v
+			}
+		}
+	}
+	return iowait, total, numCPUs
+}
@@ -17,13 +17,20 @@ import (
 type Info struct {
 	Hostname     string `json:"hostname"`
 	SerialNumber string `json:"serial_number"`
-	OS           string `json:"os"`
-	OSVersion    string `json:"os_version"`
-	Architecture string `json:"architecture"`
-	CPUModel     string `json:"cpu_model"`
-	CPUCores     int    `json:"cpu_cores"`
-	RAMTotalMB   int    `json:"ram_total_mb"`
-	DiskModel    string `json:"disk_model"`
+	// Fingerprint identifies the physical machine across hostname changes;
+	// see HardwareFingerprint
+	Fingerprint  string       `json:"fingerprint"`
+	OS           string       `json:"os"`
+	OSVersion    string       `json:"os_version"`
+	Architecture string       `json:"architecture"`
+	CPUModel     string       `json:"cpu_model"`
+	CPUCores     int          `json:"cpu_cores"`
+	RAMTotalMB   int          `json:"ram_total_mb"`
+	Swap         []SwapDevice `json:"swap,omitempty"`
+	DiskModel    string       `json:"disk_model"`
+	DiskType     string       `json:"disk_type"`
+	DiskSerial   string       `json:"disk_serial,omitempty"`
+	DiskHealth   *DiskHealth  `json:"disk_health,omitempty"`
 
 	// Raspberry Pi specific
 	RPiModel          string   `json:"rpi_model,omitempty"`
@@ -34,6 +41,30 @@ type Info struct {
 	CPUFreqMHz        int      `json:"cpu_freq_mhz,omitempty"`
 	CoreVoltage       string   `json:"core_voltage,omitempty"`
 	CPUFeatures       []string `json:"cpu_features,omitempty"`
+	ThrottledFlags    string   `json:"throttled_flags,omitempty"`
+
+	// Frequency limits and storage link details
+	CPUMaxFreqMHz     int    `json:"cpu_max_freq_mhz,omitempty"`
+	CPUScalingMaxMHz  int    `json:"cpu_scaling_max_mhz,omitempty"`
+	PCIeLinkSpeed     string `json:"pcie_link_speed,omitempty"`
+	PCIeMaxLinkSpeed  string `json:"pcie_max_link_speed,omitempty"`
+	PCIeLinkWidth     string `json:"pcie_link_width,omitempty"`
+	USBStorageSpeedMb int    `json:"usb_storage_speed_mbps,omitempty"`
+
+	// Governor and frequencies of every core, the fields above being
+	// cpu0's, and the policies -set-performance-governor switched
+	CPUFrequencies     []CPUCore        `json:"cpu_frequencies,omitempty"`
+	CPUGovernorChanges []GovernorChange `json:"cpu_governor_changes,omitempty"`
+
+	// Network link of the default route, or of the -iface interface, and
+	// the machine's NICs, bonds and bridges
+	Network           *NetworkLink       `json:"network,omitempty"`
+	NetworkInterfaces []NetworkInterface `json:"network_interfaces,omitempty"`
+
+	// Latency to the host running the other client of a split node (-peer)
+	// and to the other nodes of a distributed validator cluster
+	Peer         *PeerLatency  `json:"peer,omitempty"`
+	ClusterPeers []PeerLatency `json:"cluster_peers,omitempty"`
 }
 
 // Detect gathers system information
@@ -60,9 +91,11 @@ func Detect() (*Info, error) {
 
 	// Get RAM total
 	info.RAMTotalMB = detectRAM()
+	info.Swap = detectSwap()
 
 	// Get disk model
-	info.DiskModel = detectDiskModel()
+	info.DiskModel, info.DiskType, info.DiskSerial = detectDiskModel()
+	info.DiskHealth = detectDiskHealth(info.DiskType)
 
 	// Raspberry Pi specific detection
 	info.RPiModel = detectRPiModel()
@@ -73,6 +106,17 @@ func Detect() (*Info, error) {
 	info.CPUFreqMHz = detectCPUFrequency()
 	info.CoreVoltage = detectCoreVoltage()
 	info.CPUFeatures = detectCPUFeatures()
+	info.ThrottledFlags = detectThrottled()
+
+	// Frequency limits and storage links
+	info.CPUMaxFreqMHz = readFreqMHz("/sys/devices/system/cpu/cpu0/cpufreq/cpuinfo_max_freq")
+	info.CPUScalingMaxMHz = readFreqMHz("/sys/devices/system/cpu/cpu0/cpufreq/scaling_max_freq")
+	info.CPUFrequencies = DetectCPUFrequencies()
+	info.PCIeLinkSpeed, info.PCIeMaxLinkSpeed, info.PCIeLinkWidth = detectPCIeLink()
+	info.USBStorageSpeedMb = detectUSBStorageSpeed()
+	info.Network = DetectNetwork()
+	info.NetworkInterfaces = DetectInterfaces()
+	info.Fingerprint = info.HardwareFingerprint()
 
 	return info, nil
 }
@@ -209,15 +253,16 @@ func detectRAM() int {
 	return 0
 }
 
-// detectDiskModel attempts to find the primary disk model
-func detectDiskModel() string {
+// detectDiskModel attempts to find the primary disk model, its type (nvme,
+// sd, scsi) and its serial number
+func detectDiskModel() (model, diskType, serial string) {
 	// Look for NVMe devices first
 	nvmeDevices, _ := filepath.Glob("/sys/block/nvme*")
 	for _, dev := range nvmeDevices {
 		modelPath := filepath.Join(dev, "device", "model")
 		data, err := os.ReadFile(modelPath)
 		if err == nil {
-			return strings.TrimSpace(string(data))
+			return strings.TrimSpace(string(data)), "nvme", readDiskSerial(dev)
 		}
 	}
 
@@ -228,7 +273,7 @@ func detectDiskModel() string {
 		namePath := filepath.Join(dev, "device", "name")
 		data, err := os.ReadFile(namePath)
 		if err == nil {
-			return fmt.Sprintf("SD Card: %s", strings.TrimSpace(string(data)))
+			return fmt.Sprintf("SD Card: %s", strings.TrimSpace(string(data))), "sd", readDiskSerial(dev)
 		}
 	}
 
@@ -238,11 +283,11 @@ func detectDiskModel() string {
 		modelPath := filepath.Join(dev, "device", "model")
 		data, err := os.ReadFile(modelPath)
 		if err == nil {
-			return strings.TrimSpace(string(data))
+			return strings.TrimSpace(string(data)), "scsi", readDiskSerial(dev)
 		}
 	}
 
-	return "unknown"
+	return "unknown", "", ""
 }
 
 // detectRPiModel reads Raspberry Pi model from device tree
@@ -317,7 +362,12 @@ func detectCPUGovernor() string {
 
 // detectCPUFrequency reads current CPU frequency in MHz
 func detectCPUFrequency() int {
-	data, err := os.ReadFile("/sys/devices/system/cpu/cpu0/cpufreq/scaling_cur_freq")
+	return readFreqMHz("/sys/devices/system/cpu/cpu0/cpufreq/scaling_cur_freq")
+}
+
+// readFreqMHz reads a cpufreq value in kHz and converts it to MHz
+func readFreqMHz(path string) int {
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return 0
 	}
@@ -329,39 +379,72 @@ func detectCPUFrequency() int {
 	return freqKHz / 1000
 }
 
-// detectCoreVoltage runs vcgencmd to get core voltage
-func detectCoreVoltage() string {
-	cmd := exec.Command("vcgencmd", "measure_volts", "core")
+// detectThrottled runs vcgencmd to get the throttling flags (e.g. "0x50005")
+func detectThrottled() string {
+	cmd := exec.Command("vcgencmd", "get_throttled")
 	output, err := cmd.Output()
 	if err != nil {
 		return ""
 	}
-	// Output is like "volt=0.8700V"
-	result := strings.TrimSpace(string(output))
-	result = strings.TrimPrefix(result, "volt=")
-	return result
+	// Output is like "throttled=0x0"
+	return strings.TrimPrefix(strings.TrimSpace(string(output)), "throttled=")
 }
 
-// detectCPUFeatures reads CPU features from /proc/cpuinfo
-// On ARM64, this includes NEON (asimd), AES, SHA, CRC32, etc.
-func detectCPUFeatures() []string {
-	file, err := os.Open("/proc/cpuinfo")
-	if err != nil {
-		return nil
+// detectPCIeLink reads the negotiated PCIe link of the first NVMe controller
+func detectPCIeLink() (speed, maxSpeed, width string) {
+	controllers, _ := filepath.Glob("/sys/class/nvme/nvme*")
+	for _, ctrl := range controllers {
+		dev := filepath.Join(ctrl, "device")
+		data, err := os.ReadFile(filepath.Join(dev, "current_link_speed"))
+		if err != nil {
+			continue
+		}
+		speed = strings.TrimSpace(string(data))
+		if data, err := os.ReadFile(filepath.Join(dev, "max_link_speed")); err == nil {
+			maxSpeed = strings.TrimSpace(string(data))
+		}
+		if data, err := os.ReadFile(filepath.Join(dev, "current_link_width")); err == nil {
+			width = strings.TrimSpace(string(data))
+		}
+		return speed, maxSpeed, width
 	}
-	defer file.Close()
+	return "", "", ""
+}
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.HasPrefix(line, "Features") {
-			parts := strings.SplitN(line, ":", 2)
-			if len(parts) == 2 {
-				return strings.Fields(strings.TrimSpace(parts[1]))
+// detectUSBStorageSpeed returns the USB link speed in Mbps of the first
+// USB-attached SCSI disk, or 0 if there is none
+func detectUSBStorageSpeed() int {
+	disks, _ := filepath.Glob("/sys/block/sd*")
+	for _, disk := range disks {
+		path, err := filepath.EvalSymlinks(filepath.Join(disk, "device"))
+		if err != nil {
+			continue
+		}
+		// Walk up the device tree until the USB device exposing its speed
+		for dir := path; dir != "/" && dir != "."; dir = filepath.Dir(dir) {
+			data, err := os.ReadFile(filepath.Join(dir, "speed"))
+			if err != nil {
+				continue
+			}
+			if mbps, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil {
+				return mbps
 			}
 		}
 	}
-	return nil
+	return 0
+}
+
+// detectCoreVoltage runs vcgencmd to get core voltage
+func detectCoreVoltage() string {
+	cmd := exec.Command("vcgencmd", "measure_volts", "core")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	// Output is like "volt=0.8700V"
+	result := strings.TrimSpace(string(output))
+	result = strings.TrimPrefix(result, "volt=")
+	return result
 }
 
 // CheckPrerequisites verifies that required tools are available
@@ -0,0 +1,42 @@
+package system
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// dmesgKeywords selects kernel log lines relevant to benchmark troubleshooting
+var dmesgKeywords = []string{
+	"nvme", "mmc", "usb", "pcie", "ext4", "f2fs", "btrfs",
+	"i/o error", "error", "throttl", "voltage", "thermal", "oom",
+}
+
+// DmesgExcerpt returns the last maxLines kernel log lines relevant to
+// storage, thermal and power issues
+func DmesgExcerpt(maxLines int) string {
+	output, err := exec.Command("dmesg").Output()
+	if err != nil {
+		// dmesg may be restricted to root, fall back to the syslog copy
+		output, err = os.ReadFile("/var/log/kern.log")
+		if err != nil {
+			return ""
+		}
+	}
+
+	var matched []string
+	for _, line := range strings.Split(string(output), "\n") {
+		lower := strings.ToLower(line)
+		for _, kw := range dmesgKeywords {
+			if strings.Contains(lower, kw) {
+				matched = append(matched, line)
+				break
+			}
+		}
+	}
+
+	if len(matched) > maxLines {
+		matched = matched[len(matched)-maxLines:]
+	}
+	return strings.Join(matched, "\n")
+}
@@ -0,0 +1,129 @@
+package system
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// fsNoCOWFlag is FS_NOCOW_FL, set by `chattr +C` to disable btrfs
+// copy-on-write for new files in a directory
+const fsNoCOWFlag = 0x00800000
+
+// chainDataPaths are the default data directories of the common execution
+// and consensus clients; relative paths are under the home directory
+var chainDataPaths = []string{
+	".ethereum", ".local/share/reth", ".nethermind", ".besu", ".lighthouse",
+	".local/share/erigon", "/var/lib/ethereum", "/var/lib/geth",
+	"/var/lib/nethermind", "/var/lib/besu", "/var/lib/erigon", "/var/lib/reth",
+	"/var/lib/nimbus", "/var/lib/lighthouse", "/var/lib/teku", "/var/lib/prysm",
+	"/var/lib/lodestar",
+}
+
+// DetectFilesystem describes the filesystem holding path from
+// /proc/self/mountinfo and lists existing client data directories, noting
+// whether each is on the same filesystem. Returns nil when the mount cannot
+// be found.
+func DetectFilesystem(path string) *types.FilesystemInfo {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	fs := findMount(abs)
+	if fs == nil {
+		return nil
+	}
+	fs.NoAtime = slices.Contains(fs.Options, "noatime")
+	fs.Discard = slices.Contains(fs.Options, "discard")
+	switch fs.Type {
+	case "btrfs":
+		fs.CopyOnWrite = !slices.Contains(fs.Options, "nodatacow") && !noCOW(abs)
+	case "zfs":
+		fs.CopyOnWrite = true
+	}
+
+	var st unix.Stat_t
+	if unix.Stat(abs, &st) != nil {
+		return fs
+	}
+	home, _ := os.UserHomeDir()
+	for _, p := range chainDataPaths {
+		if !filepath.IsAbs(p) {
+			if home == "" {
+				continue
+			}
+			p = filepath.Join(home, p)
+		}
+		var chain unix.Stat_t
+		if unix.Stat(p, &chain) != nil {
+			continue
+		}
+		fs.ChainData = append(fs.ChainData, types.ChainDataPath{Path: p, SameDevice: chain.Dev == st.Dev})
+	}
+	return fs
+}
+
+// findMount returns the mount with the longest mount point containing path.
+// Mount options and per-superblock options (where ext4 reports discard)
+// are merged.
+func findMount(path string) *types.FilesystemInfo {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var best *types.FilesystemInfo
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// 36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+		pre, post, ok := strings.Cut(scanner.Text(), " - ")
+		fields, super := strings.Fields(pre), strings.Fields(post)
+		if !ok || len(fields) < 6 || len(super) < 3 {
+			continue
+		}
+		mountPoint := unescapeMount(fields[4])
+		if !withinMount(path, mountPoint) || (best != nil && len(mountPoint) < len(best.MountPoint)) {
+			continue
+		}
+		options := strings.Split(fields[5], ",")
+		for _, o := range strings.Split(super[2], ",") {
+			if !slices.Contains(options, o) {
+				options = append(options, o)
+			}
+		}
+		best = &types.FilesystemInfo{Type: super[0], Device: super[1], MountPoint: mountPoint, Options: options}
+	}
+	return best
+}
+
+// withinMount reports whether path is mountPoint or below it
+func withinMount(path, mountPoint string) bool {
+	return mountPoint == "/" || path == mountPoint || strings.HasPrefix(path, mountPoint+"/")
+}
+
+// unescapeMount decodes the octal escapes mountinfo uses for spaces, tabs
+// and backslashes in paths
+func unescapeMount(s string) string {
+	return strings.NewReplacer(`\040`, " ", `\011`, "\t", `\012`, "\n", `\134`, `\`).Replace(s)
+}
+
+// noCOW reports whether copy-on-write is disabled for files created in dir
+func noCOW(dir string) bool {
+	f, err := os.Open(dir)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	flags, err := unix.IoctlGetUint32(int(f.Fd()), unix.FS_IOC_GETFLAGS)
+	return err == nil && flags&fsNoCOWFlag != 0
+}
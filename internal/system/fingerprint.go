@@ -0,0 +1,66 @@
+package system
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// fingerprintVersion is hashed with the fingerprint's inputs, so a change
+// of what goes into it cannot collide with fingerprints of the old inputs
+const fingerprintVersion = "ethbench-fingerprint-v1"
+
+// HardwareFingerprint derives a stable identifier of the physical machine
+// from the CPU model, the board serial, the RAM size and the disk serial.
+// The hostname is left out so renamed nodes keep their history. RAM is
+// rounded to whole GiB, as the memory the kernel reserves changes between
+// kernel versions. Boards without a serial fall back to the machine ID,
+// which changes when the OS is reinstalled.
+func (i *Info) HardwareFingerprint() string {
+	serial := i.SerialNumber
+	if serial == "unknown" {
+		serial = ""
+	}
+	ramGiB := (i.RAMTotalMB + 512) / 1024
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		fingerprintVersion, i.CPUModel, serial, fmt.Sprint(ramGiB), i.DiskSerial,
+	}, "\x00")))
+	return hex.EncodeToString(sum[:16])
+}
+
+// readDiskSerial reads the serial number of a block device in /sys/block:
+// the serial attribute of NVMe drives and SD cards, else the SCSI unit
+// serial number VPD page of SATA and USB drives, else udev's database
+func readDiskSerial(dev string) string {
+	if data, err := os.ReadFile(filepath.Join(dev, "device", "serial")); err == nil {
+		if serial := strings.TrimSpace(string(data)); serial != "" {
+			return serial
+		}
+	}
+	// VPD page 0x80: a 4 byte header, then the serial in ASCII
+	if data, err := os.ReadFile(filepath.Join(dev, "device", "vpd_pg80")); err == nil && len(data) > 4 {
+		if serial := strings.TrimSpace(strings.Trim(string(data[4:]), "\x00")); serial != "" {
+			return serial
+		}
+	}
+	devNum, err := os.ReadFile(filepath.Join(dev, "dev"))
+	if err != nil {
+		return ""
+	}
+	f, err := os.Open(filepath.Join("/run/udev/data", "b"+strings.TrimSpace(string(devNum))))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if serial, ok := strings.CutPrefix(scanner.Text(), "E:ID_SERIAL_SHORT="); ok {
+			return strings.TrimSpace(serial)
+		}
+	}
+	return ""
+}
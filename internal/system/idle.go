@@ -0,0 +1,200 @@
+package system
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// idleSnapshot holds cumulative system counters at a point in time
+type idleSnapshot struct {
+	cpuIdle  uint64
+	cpuTotal uint64
+	diskIOs  uint64
+	netBytes uint64
+}
+
+// MeasureIdle samples CPU, disk, network and temperature activity before
+// any benchmark runs so reviewers can tell whether the system was quiescent.
+// It fails when the CPU counters cannot be read, leaving the idle state
+// unknown rather than reporting a busy system.
+func MeasureIdle(ctx context.Context, duration time.Duration) (types.IdleResult, error) {
+	before, err := takeIdleSnapshot()
+	if err != nil {
+		return types.IdleResult{}, err
+	}
+	start := time.Now()
+
+	var tempSum, tempMax float64
+	var tempCount int
+	for time.Since(start) < duration && ctx.Err() == nil {
+		if temp := ReadTemperature(); temp > 0 {
+			tempSum += temp
+			tempCount++
+			if temp > tempMax {
+				tempMax = temp
+			}
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+		}
+	}
+
+	elapsed := time.Since(start)
+	after, err := takeIdleSnapshot()
+	if err != nil {
+		return types.IdleResult{Duration: elapsed}, err
+	}
+
+	result := types.IdleResult{Duration: elapsed}
+	if total := after.cpuTotal - before.cpuTotal; total > 0 {
+		result.CPUIdlePercent = float64(after.cpuIdle-before.cpuIdle) / float64(total) * 100
+	}
+	result.DiskIOPS = float64(after.diskIOs-before.diskIOs) / elapsed.Seconds()
+	result.NetworkKBps = float64(after.netBytes-before.netBytes) / elapsed.Seconds() / 1024
+	if tempCount > 0 {
+		result.AvgTemperatureC = tempSum / float64(tempCount)
+		result.MaxTemperatureC = tempMax
+	}
+	result.Quiet = result.CPUIdlePercent >= 90 && result.DiskIOPS < 50 && result.NetworkKBps < 1024
+
+	return result, nil
+}
+
+// takeIdleSnapshot reads cumulative counters from /proc
+func takeIdleSnapshot() (idleSnapshot, error) {
+	var snap idleSnapshot
+	var err error
+	if snap.cpuIdle, snap.cpuTotal, err = readCPUTimes(); err != nil {
+		return snap, err
+	}
+	snap.diskIOs = readDiskIOs()
+	snap.netBytes = readNetBytes()
+	return snap, nil
+}
+
+// readCPUTimes returns idle (including iowait) and total jiffies from /proc/stat
+func readCPUTimes() (idle, total uint64, err error) {
+	file, err := os.Open("/proc/stat")
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read CPU times: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 || fields[0] != "cpu" {
+			continue
+		}
+		for i, field := range fields[1:] {
+			v, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				continue
+			}
+			total += v
+			// Fields: user nice system idle iowait ...
+			if i == 3 || i == 4 {
+				idle += v
+			}
+		}
+		break
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, 0, fmt.Errorf("failed to read CPU times: %w", err)
+	}
+	if total == 0 {
+		return 0, 0, errors.New("no CPU times in /proc/stat")
+	}
+	return idle, total, nil
+}
+
+// readDiskIOs returns completed reads plus writes across whole block devices
+func readDiskIOs() uint64 {
+	file, err := os.Open("/proc/diskstats")
+	if err != nil {
+		return 0
+	}
+	defer file.Close()
+
+	var total uint64
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 8 {
+			continue
+		}
+		name := fields[2]
+		if strings.HasPrefix(name, "loop") || strings.HasPrefix(name, "ram") || strings.HasPrefix(name, "zram") {
+			continue
+		}
+		// Only count whole devices, partitions would double count
+		if _, err := os.Stat(filepath.Join("/sys/block", name)); err != nil {
+			continue
+		}
+		reads, _ := strconv.ParseUint(fields[3], 10, 64)
+		writes, _ := strconv.ParseUint(fields[7], 10, 64)
+		total += reads + writes
+	}
+	return total
+}
+
+// readNetBytes returns received plus transmitted bytes on non-loopback interfaces
+func readNetBytes() uint64 {
+	file, err := os.Open("/proc/net/dev")
+	if err != nil {
+		return 0
+	}
+	defer file.Close()
+
+	var total uint64
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if strings.TrimSpace(parts[0]) == "lo" {
+			continue
+		}
+		fields := strings.Fields(parts[1])
+		if len(fields) < 9 {
+			continue
+		}
+		rx, _ := strconv.ParseUint(fields[0], 10, 64)
+		tx, _ := strconv.ParseUint(fields[8], 10, 64)
+		total += rx + tx
+	}
+	return total
+}
+
+// ReadTemperature returns the hottest thermal zone's temperature in degrees
+// Celsius, or 0 if unavailable. Zone 0 is not the CPU on every board.
+func ReadTemperature() float64 {
+	zones, _ := filepath.Glob("/sys/class/thermal/thermal_zone*/temp")
+	var hottest float64
+	for _, zone := range zones {
+		data, err := os.ReadFile(zone)
+		if err != nil {
+			continue
+		}
+		// Value is in millidegrees Celsius
+		milli, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil {
+			continue
+		}
+		if temp := float64(milli) / 1000; temp > hottest {
+			hottest = temp
+		}
+	}
+	return hottest
+}
@@ -0,0 +1,197 @@
+package system
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// interferingProcesses lists process names known to generate heavy background
+// I/O on stock OS images (backups, snapshots, package upgrades, indexing)
+var interferingProcesses = map[string]string{
+	"restic":          "backup",
+	"borg":            "backup",
+	"duplicity":       "backup",
+	"rsnapshot":       "backup",
+	"rclone":          "backup",
+	"timeshift":       "snapshot",
+	"snapper":         "snapshot",
+	"btrfs":           "filesystem maintenance",
+	"fstrim":          "filesystem maintenance",
+	"e2scrub":         "filesystem maintenance",
+	"unattended-upgr": "package upgrade",
+	"apt":             "package upgrade",
+	"apt-get":         "package upgrade",
+	"dpkg":            "package upgrade",
+	"packagekitd":     "package upgrade",
+	"updatedb":        "file indexing",
+	"mandb":           "file indexing",
+}
+
+// interferingTimers lists systemd timers that trigger the jobs above
+var interferingTimers = []string{
+	"snapper-timeline", "snapper-cleanup", "timeshift",
+	"apt-daily", "apt-daily-upgrade", "unattended-upgrades",
+	"fstrim", "btrfs-scrub", "btrfs-balance", "e2scrub_all",
+	"plocate-updatedb", "mlocate", "man-db",
+}
+
+// InterferenceMonitor polls for background jobs while benchmarks run
+type InterferenceMonitor struct {
+	mu    sync.Mutex
+	found map[string]*types.Interference
+	// ticks holds the CPU time of each interfering process when first and
+	// last seen, by pid
+	ticks    map[int]*processTicks
+	start    time.Time
+	stop     chan struct{}
+	done     chan struct{}
+	interval time.Duration
+}
+
+// processTicks is the CPU time an interfering process used while observed
+type processTicks struct {
+	name        string
+	first, last uint64
+}
+
+// NewInterferenceMonitor creates a monitor polling at the given interval
+func NewInterferenceMonitor(interval time.Duration) *InterferenceMonitor {
+	return &InterferenceMonitor{
+		found:    make(map[string]*types.Interference),
+		ticks:    make(map[int]*processTicks),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		interval: interval,
+	}
+}
+
+// Start begins polling in the background
+func (m *InterferenceMonitor) Start() {
+	m.start = time.Now()
+	go func() {
+		defer close(m.done)
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		m.scan()
+		for {
+			select {
+			case <-m.stop:
+				return
+			case <-ticker.C:
+				m.scan()
+			}
+		}
+	}()
+}
+
+// Stop ends polling and returns every background job seen, the busiest
+// first, followed by interfering timers that are enabled on the system
+func (m *InterferenceMonitor) Stop() []types.Interference {
+	close(m.stop)
+	<-m.done
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cpuSeconds := make(map[string]float64)
+	for _, t := range m.ticks {
+		cpuSeconds[t.name] += float64(t.last-t.first) / clockTicks
+	}
+	elapsed := time.Since(m.start).Seconds()
+
+	result := make([]types.Interference, 0, len(m.found))
+	for name, item := range m.found {
+		if elapsed > 0 {
+			item.CPUPercent = cpuSeconds[name] / elapsed * 100
+		}
+		result = append(result, *item)
+	}
+	// Map order is random; keep reports of the same run identical
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CPUPercent != result[j].CPUPercent {
+			return result[i].CPUPercent > result[j].CPUPercent
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	timers := detectInterferingTimers()
+	sort.Strings(timers)
+	for _, timer := range timers {
+		result = append(result, types.Interference{Name: timer, Kind: "timer"})
+	}
+	return result
+}
+
+// scan records any interfering processes currently running
+func (m *InterferenceMonitor) scan() {
+	now := time.Now()
+	comms, _ := filepath.Glob("/proc/[0-9]*/comm")
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, path := range comms {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		name := strings.TrimSpace(string(data))
+		kind, ok := interferingProcesses[name]
+		if !ok {
+			continue
+		}
+		if pid, err := strconv.Atoi(filepath.Base(filepath.Dir(path))); err == nil {
+			if snap, err := snapshotProcess(pid); err == nil {
+				if t, seen := m.ticks[pid]; seen {
+					t.last = snap.cpuTicks
+				} else {
+					m.ticks[pid] = &processTicks{name: name, first: snap.cpuTicks, last: snap.cpuTicks}
+				}
+			}
+		}
+		if item, seen := m.found[name]; seen {
+			item.LastSeen = now
+			continue
+		}
+		m.found[name] = &types.Interference{
+			Name:      name,
+			Kind:      kind,
+			FirstSeen: now,
+			LastSeen:  now,
+		}
+	}
+}
+
+// detectInterferingTimers returns interfering systemd timers that are scheduled
+func detectInterferingTimers() []string {
+	output, err := exec.Command("systemctl", "list-timers", "--no-legend", "--no-pager").Output()
+	if err != nil {
+		return nil
+	}
+
+	var timers []string
+	for _, line := range strings.Split(string(output), "\n") {
+		for _, field := range strings.Fields(line) {
+			if !strings.HasSuffix(field, ".timer") {
+				continue
+			}
+			unit := strings.TrimSuffix(field, ".timer")
+			for _, t := range interferingTimers {
+				if strings.HasPrefix(unit, t) {
+					timers = append(timers, field)
+					break
+				}
+			}
+		}
+	}
+	return timers
+}
@@ -0,0 +1,246 @@
+package system
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+const (
+	// irqSaturatedPercent is the share of a CPU's time in hard and soft IRQ
+	// handlers from which it is the bottleneck of the I/O it serves
+	irqSaturatedPercent = 70
+	// Device interrupts are concentrated when one CPU handles at least
+	// irqConcentratedPercent of them at irqConcentratedRate or more
+	irqConcentratedPercent = 90
+	irqConcentratedRate    = 1000
+	// irqTopSources is the number of busiest device interrupts recorded
+	irqTopSources = 8
+)
+
+// irqSource is one device interrupt line from /proc/interrupts
+type irqSource struct {
+	name   string
+	perCPU []uint64
+}
+
+// IRQSnapshot holds the interrupt and softirq counters at a point in time
+type IRQSnapshot struct {
+	taken      time.Time
+	interrupts uint64
+	softirqs   uint64
+	cpuIRQ     []uint64 // Jiffies in hard and soft IRQ handlers, per CPU
+	cpuTotal   []uint64
+	sources    map[string]irqSource // By IRQ number
+}
+
+// TakeIRQSnapshot reads the interrupt counters from /proc/stat and the
+// per-device counts from /proc/interrupts
+func TakeIRQSnapshot() IRQSnapshot {
+	snap := IRQSnapshot{taken: time.Now(), sources: readInterrupts()}
+	file, err := os.Open("/proc/stat")
+	if err != nil {
+		return snap
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024) // The intr line lists every IRQ
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		switch {
+		case fields[0] == "intr":
+			snap.interrupts, _ = strconv.ParseUint(fields[1], 10, 64)
+		case fields[0] == "softirq":
+			snap.softirqs, _ = strconv.ParseUint(fields[1], 10, 64)
+		case strings.HasPrefix(fields[0], "cpu") && fields[0] != "cpu" && len(fields) >= 8:
+			// Fields: user nice system idle iowait irq softirq ...
+			var irq, total uint64
+			for i, field := range fields[1:] {
+				v, _ := strconv.ParseUint(field, 10, 64)
+				total += v
+				if i == 5 || i == 6 {
+					irq += v
+				}
+			}
+			snap.cpuIRQ = append(snap.cpuIRQ, irq)
+			snap.cpuTotal = append(snap.cpuTotal, total)
+		}
+	}
+	return snap
+}
+
+// readInterrupts parses the numbered device interrupts of /proc/interrupts;
+// per-CPU system interrupts such as timers and IPIs are left out
+func readInterrupts() map[string]irqSource {
+	sources := make(map[string]irqSource)
+	file, err := os.Open("/proc/interrupts")
+	if err != nil {
+		return sources
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() {
+		return sources
+	}
+	numCPUs := len(strings.Fields(scanner.Text()))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < numCPUs+2 {
+			continue
+		}
+		irq := strings.TrimSuffix(fields[0], ":")
+		if _, err := strconv.Atoi(irq); err != nil {
+			continue
+		}
+		source := irqSource{name: fields[len(fields)-1], perCPU: make([]uint64, numCPUs)}
+		for i := range numCPUs {
+			source.perCPU[i], _ = strconv.ParseUint(fields[1+i], 10, 64)
+		}
+		sources[irq] = source
+	}
+	return sources
+}
+
+// Since returns the interrupt load between before and s: the interrupt and
+// softirq rates, the device interrupt that fired most and the CPU that spent
+// the largest share of its time handling interrupts
+func (s IRQSnapshot) Since(before IRQSnapshot) *types.IRQLoad {
+	seconds := s.taken.Sub(before.taken).Seconds()
+	if seconds <= 0 {
+		return nil
+	}
+	load := &types.IRQLoad{
+		InterruptsPerSecond: float64(s.interrupts-before.interrupts) / seconds,
+		SoftIRQsPerSecond:   float64(s.softirqs-before.softirqs) / seconds,
+	}
+
+	var topCount, deviceCount uint64
+	var perCPU []uint64
+	for irq, source := range s.sources {
+		prev, ok := before.sources[irq]
+		if !ok || len(prev.perCPU) != len(source.perCPU) {
+			continue
+		}
+		if perCPU == nil {
+			perCPU = make([]uint64, len(source.perCPU))
+		}
+		var count, cpuCount uint64
+		cpu := 0
+		for i, n := range source.perCPU {
+			delta := n - prev.perCPU[i]
+			count += delta
+			perCPU[i] += delta
+			if delta > cpuCount {
+				cpu, cpuCount = i, delta
+			}
+		}
+		if count == 0 {
+			continue
+		}
+		deviceCount += count
+		load.Sources = append(load.Sources, types.IRQSourceRate{IRQ: irq, Name: source.name, PerSecond: float64(count) / seconds, CPU: cpu})
+		if count > topCount {
+			topCount = count
+			load.TopSource = source.name
+			load.TopSourceIRQ = irq
+			load.TopSourceCPU = cpu
+		}
+	}
+	load.TopSourcePerSecond = float64(topCount) / seconds
+	sort.Slice(load.Sources, func(i, j int) bool { return load.Sources[i].PerSecond > load.Sources[j].PerSecond })
+	if len(load.Sources) > irqTopSources {
+		load.Sources = load.Sources[:irqTopSources]
+	}
+
+	if deviceCount > 0 {
+		for i, n := range perCPU {
+			if percent := float64(n) / float64(deviceCount) * 100; percent > load.DeviceCPUPercent {
+				load.DeviceCPU, load.DeviceCPUPercent = i, percent
+			}
+		}
+		load.Concentrated = len(perCPU) > 1 && load.DeviceCPUPercent >= irqConcentratedPercent &&
+			float64(deviceCount)/seconds >= irqConcentratedRate
+	}
+
+	if len(s.cpuIRQ) == len(before.cpuIRQ) {
+		for i := range s.cpuIRQ {
+			total := s.cpuTotal[i] - before.cpuTotal[i]
+			if total == 0 {
+				continue
+			}
+			percent := float64(s.cpuIRQ[i]-before.cpuIRQ[i]) / float64(total) * 100
+			if percent > load.BusiestCPUPercent {
+				load.BusiestCPU, load.BusiestCPUPercent = i, percent
+			}
+		}
+	}
+	load.Saturated = load.BusiestCPUPercent >= irqSaturatedPercent
+	return load
+}
+
+// irqMoveMinRate is the rate below which an interrupt is not worth moving
+const irqMoveMinRate = 100
+
+// PlanIRQAffinity spreads the busy device interrupts of a concentrated load
+// round-robin over the other CPUs, busiest first, leaving the concentrated
+// CPU with everything else. It returns nil when the load is not concentrated.
+func PlanIRQAffinity(load *types.IRQLoad, cpus int) []types.IRQAffinity {
+	if load == nil || !load.Concentrated || cpus < 2 {
+		return nil
+	}
+	var moves []types.IRQAffinity
+	target := 0
+	for _, source := range load.Sources {
+		if source.CPU != load.DeviceCPU || source.PerSecond < irqMoveMinRate {
+			continue
+		}
+		// Next CPU after the last target, skipping the concentrated one
+		target = (target + 1) % cpus
+		if target == load.DeviceCPU {
+			target = (target + 1) % cpus
+		}
+		moves = append(moves, types.IRQAffinity{
+			IRQ:       source.IRQ,
+			Source:    source.Name,
+			PerSecond: source.PerSecond,
+			FromCPU:   source.CPU,
+			ToCPU:     target,
+		})
+	}
+	return moves
+}
+
+// ApplyIRQAffinity writes each move to /proc/irq/N/smp_affinity_list and
+// records whether it took. Interrupts with kernel-managed affinity, such as
+// NVMe queues, refuse the change with EIO. The affinity lasts until reboot
+// or until irqbalance moves the interrupt again.
+func ApplyIRQAffinity(moves []types.IRQAffinity) {
+	for i := range moves {
+		m := &moves[i]
+		path := fmt.Sprintf("/proc/irq/%s/smp_affinity_list", m.IRQ)
+		err := os.WriteFile(path, []byte(strconv.Itoa(m.ToCPU)+"\n"), 0644)
+		switch {
+		case err == nil:
+			m.Applied = true
+		case errors.Is(err, syscall.EIO):
+			m.Error = "affinity is managed by the kernel"
+		case errors.Is(err, os.ErrPermission):
+			m.Error = "needs root"
+		default:
+			m.Error = err.Error()
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// DefaultPathEndpoints are probed when no endpoints are configured: anycast
+// resolvers that answer ICMP from nearly every network
+var DefaultPathEndpoints = []string{"1.1.1.1", "8.8.8.8"}
+
+// minPathMTU is the smallest MTU every IPv4 host must accept (RFC 791), the
+// lower bound of the path MTU search
+const minPathMTU = 576
+
+// PathProbe holds the characteristics of the path to one endpoint beyond the
+// gateway. Gossip and devp2p traffic crosses the same upstream links, so
+// loss here delays blocks and attestations even on a clean LAN.
+type PathProbe struct {
+	Endpoint string     `json:"endpoint"`
+	Address  string     `json:"address,omitempty"`
+	Echo     *EchoProbe `json:"echo,omitempty"`
+	// MTU is the largest packet that reached the endpoint unfragmented, 0
+	// when it could not be measured; InterfaceMTU is the local link's
+	MTU          int    `json:"mtu,omitempty"`
+	InterfaceMTU int    `json:"interface_mtu,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// Reduced reports whether packets the local link accepts are dropped or
+// fragmented on the way, as with PPPoE or a VPN tunnel. Without MSS clamping
+// on the router, large TCP segments then stall (an MTU black hole).
+func (p *PathProbe) Reduced() bool {
+	return p.MTU > 0 && p.InterfaceMTU > 0 && p.MTU < p.InterfaceMTU
+}
+
+// ProbePath sends count echoes to the endpoint, interval apart, to measure
+// loss and jitter, then searches for the path MTU with echoes that may not
+// be fragmented. The probes leave through the link's interface when it was
+// pinned with -iface.
+func (link *NetworkLink) ProbePath(endpoint string, count int, interval time.Duration) PathProbe {
+	probe := PathProbe{Endpoint: endpoint}
+	addr, err := net.ResolveIPAddr("ip4", endpoint)
+	if err != nil {
+		probe.Error = fmt.Sprintf("cannot resolve %s: %v", endpoint, err)
+		return probe
+	}
+	probe.Address = addr.IP.String()
+
+	var bind string
+	if link.Pinned {
+		bind = link.Interface
+	}
+	probe.Echo = probeEcho(addr.IP.To4(), bind, count, interval)
+	if probe.Echo.Received == 0 {
+		// ICMP is filtered or the endpoint is unreachable; the MTU search
+		// would only time out
+		return probe
+	}
+	if mtu, err := strconv.Atoi(readSysString(filepath.Join("/sys/class/net", link.Interface, "mtu"))); err == nil {
+		probe.InterfaceMTU = mtu
+	}
+	if probe.MTU, err = pathMTU(addr.IP.To4(), bind, probe.InterfaceMTU); err != nil {
+		probe.Error = err.Error()
+	}
+	return probe
+}
+
+// pathMTU binary searches for the largest unfragmented echo that gets a
+// reply, between minPathMTU and the interface MTU. Each size gets two tries
+// so a lost packet is not taken for a size limit.
+func pathMTU(ip net.IP, iface string, ifaceMTU int) (int, error) {
+	conn, err := openICMP(iface)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	if err := setDontFragment(conn); err != nil {
+		return 0, fmt.Errorf("cannot set don't-fragment: %w", err)
+	}
+
+	if ifaceMTU <= minPathMTU {
+		ifaceMTU = 1500
+	}
+	buf := make([]byte, ifaceMTU)
+	id := uint16(os.Getpid())
+	seq := uint16(0)
+	fits := func(mtu int) bool {
+		for try := 0; try < 2; try++ {
+			seq++
+			// The kernel adds the 20 byte IPv4 header
+			_, err := conn.WriteTo(echoRequest(id, seq, mtu-20), icmpAddr(conn, ip))
+			if errors.Is(err, unix.EMSGSIZE) {
+				return false
+			}
+			if err == nil && awaitEchoReply(conn, buf, id, seq, time.Now().Add(500*time.Millisecond)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if fits(ifaceMTU) {
+		return ifaceMTU, nil
+	}
+	low, high := minPathMTU, ifaceMTU
+	if !fits(low) {
+		return 0, fmt.Errorf("no replies to %d byte echoes", low)
+	}
+	for high-low > 1 {
+		mid := (low + high) / 2
+		if fits(mid) {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return low, nil
+}
+
+// setDontFragment sets DF on the socket's packets and makes the kernel
+// ignore its cached path MTU, so every size is really sent
+func setDontFragment(conn net.PacketConn) error {
+	sc, ok := conn.(syscall.Conn)
+	if !ok {
+		return errors.New("not a socket")
+	}
+	raw, err := sc.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var sockErr error
+	if err := raw.Control(func(fd uintptr) {
+		sockErr = unix.SetsockoptInt(int(fd), unix.IPPROTO_IP, unix.IP_MTU_DISCOVER, unix.IP_PMTUDISC_PROBE)
+	}); err != nil {
+		return err
+	}
+	return sockErr
+}
+
+// Rating rates the link for running a node: "poor" over Wi-Fi, with 3% or
+// more of echoes lost, or with 5 ms of jitter to the gateway; "fair" with
+// any loss, 1 ms of gateway jitter, a sub-gigabit link or a reduced path
+// MTU; "good" otherwise. The reasons name what lowered it. Probes without a
+// single reply are left out, as ICMP is more often filtered than lost.
+func (link *NetworkLink) Rating() (string, []string) {
+	var poor, fair []string
+	if link.Type == "wifi" {
+		poor = append(poor, "Wi-Fi")
+	} else if link.SpeedMbps > 0 && link.SpeedMbps < 1000 {
+		fair = append(fair, fmt.Sprintf("%d Mbps link", link.SpeedMbps))
+	}
+	loss := func(target string, p *EchoProbe) {
+		switch {
+		case p == nil || p.Received == 0:
+		case p.LossPercent >= 3:
+			poor = append(poor, fmt.Sprintf("%.0f%% loss to %s", p.LossPercent, target))
+		case p.LossPercent > 0:
+			fair = append(fair, fmt.Sprintf("%.0f%% loss to %s", p.LossPercent, target))
+		}
+	}
+	loss("the gateway", link.Probe)
+	if p := link.Probe; p != nil && p.Received > 1 {
+		switch {
+		case p.JitterMs >= 5:
+			poor = append(poor, fmt.Sprintf("%.1f ms gateway jitter", p.JitterMs))
+		case p.JitterMs >= 1:
+			fair = append(fair, fmt.Sprintf("%.1f ms gateway jitter", p.JitterMs))
+		}
+	}
+	for _, path := range link.Paths {
+		loss(path.Endpoint, path.Echo)
+		if path.Reduced() {
+			fair = append(fair, fmt.Sprintf("path MTU %d to %s", path.MTU, path.Endpoint))
+		}
+	}
+	switch {
+	case len(poor) > 0:
+		return "poor", append(poor, fair...)
+	case len(fair) > 0:
+		return "fair", fair
+	}
+	return "good", nil
+}
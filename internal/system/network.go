@@ -0,0 +1,472 @@
+package system
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// NetworkLink describes the interface carrying the default route, which is
+// the one a node's peer traffic and attestation broadcasts leave through
+type NetworkLink struct {
+	Interface string `json:"interface"`
+	// Type is "ethernet", "wifi", or the kernel's device type for other
+	// links, e.g. "bridge", "wwan" or "tunnel"
+	Type      string     `json:"type"`
+	SpeedMbps int        `json:"speed_mbps,omitempty"`
+	Gateway   string     `json:"gateway,omitempty"`
+	WiFi      *WiFiLink  `json:"wifi,omitempty"`
+	Probe     *EchoProbe `json:"gateway_probe,omitempty"`
+	// Paths are the probes to endpoints beyond the gateway
+	Paths []PathProbe `json:"paths,omitempty"`
+	// Pinned is set when -iface chose the interface rather than the
+	// default route; the gateway probe is then bound to it
+	Pinned bool `json:"pinned,omitempty"`
+}
+
+// NetworkInterface is a physical NIC, or a bond or bridge over physical
+// NICs
+type NetworkInterface struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	State     string `json:"state"`
+	SpeedMbps int    `json:"speed_mbps,omitempty"`
+	// Master is the bond or bridge the interface is enslaved to
+	Master string `json:"master,omitempty"`
+	// Members are the interfaces of a bond or bridge, and BondMode the
+	// bonding mode, e.g. "802.3ad" or "active-backup"
+	Members      []string `json:"members,omitempty"`
+	BondMode     string   `json:"bond_mode,omitempty"`
+	DefaultRoute bool     `json:"default_route,omitempty"`
+}
+
+// WiFiLink holds the signal of a Wi-Fi connection
+type WiFiLink struct {
+	SSID         string  `json:"ssid,omitempty"`
+	FrequencyMHz int     `json:"frequency_mhz,omitempty"`
+	SignalDBm    int     `json:"signal_dbm"`
+	QualityPct   int     `json:"quality_percent"`
+	BitrateMbps  float64 `json:"bitrate_mbps,omitempty"`
+}
+
+// Signal rates the signal level: "good" from -60 dBm, "fair" from -70 dBm,
+// "poor" below
+func (w *WiFiLink) Signal() string {
+	switch {
+	case w.SignalDBm >= -60:
+		return "good"
+	case w.SignalDBm >= -70:
+		return "fair"
+	default:
+		return "poor"
+	}
+}
+
+// EchoProbe holds the round trips of ICMP echoes to the default gateway or
+// a path probe endpoint. Jitter is the mean difference between consecutive round trips, as in
+// RFC 3550; a wired LAN stays well under a millisecond.
+type EchoProbe struct {
+	Sent        int     `json:"sent"`
+	Received    int     `json:"received"`
+	LossPercent float64 `json:"loss_percent"`
+	AvgRTTMs    float64 `json:"avg_rtt_ms"`
+	MaxRTTMs    float64 `json:"max_rtt_ms"`
+	JitterMs    float64 `json:"jitter_ms"`
+	Error       string  `json:"error,omitempty"`
+}
+
+// String summarizes the probe, e.g. "2.1 ms avg, 0.4 ms jitter, 8.2 ms max,
+// 0% lost over 20 pings"
+func (p *EchoProbe) String() string {
+	if p.Received == 0 {
+		if p.Error != "" {
+			return "not measured: " + p.Error
+		}
+		return fmt.Sprintf("no replies to %d pings", p.Sent)
+	}
+	return fmt.Sprintf("%.1f ms avg, %.1f ms jitter, %.1f ms max, %.0f%% lost over %d pings",
+		p.AvgRTTMs, p.JitterMs, p.MaxRTTMs, p.LossPercent, p.Sent)
+}
+
+// DetectNetwork returns the link of the default route, or nil without one
+func DetectNetwork() *NetworkLink {
+	iface, gateway := defaultRoute("")
+	if iface == "" {
+		return nil
+	}
+	return newNetworkLink(iface, gateway)
+}
+
+// NetworkLinkOf returns the link of the named interface, with the gateway
+// of a default route through it if there is one
+func NetworkLinkOf(iface string) (*NetworkLink, error) {
+	if _, err := os.Stat(filepath.Join("/sys/class/net", iface)); err != nil || iface == "" {
+		return nil, fmt.Errorf("no network interface %q", iface)
+	}
+	_, gateway := defaultRoute(iface)
+	link := newNetworkLink(iface, gateway)
+	link.Pinned = true
+	return link, nil
+}
+
+// newNetworkLink describes an interface and its gateway
+func newNetworkLink(iface, gateway string) *NetworkLink {
+	link := &NetworkLink{Interface: iface, Gateway: gateway, Type: linkType(iface)}
+	if link.Type == "wifi" {
+		link.WiFi = detectWiFi(iface)
+	} else {
+		link.SpeedMbps = linkSpeed(iface)
+	}
+	return link
+}
+
+// DetectInterfaces lists the physical NICs, bonds, and bridges with a
+// physical member, in name order
+func DetectInterfaces() []NetworkInterface {
+	entries, err := os.ReadDir("/sys/class/net")
+	if err != nil {
+		return nil
+	}
+	defaultIface, _ := defaultRoute("")
+	var ifaces []NetworkInterface
+	for _, entry := range entries {
+		name := entry.Name()
+		dir := filepath.Join("/sys/class/net", name)
+		iface := NetworkInterface{
+			Name:         name,
+			Type:         linkType(name),
+			State:        readSysString(filepath.Join(dir, "operstate")),
+			SpeedMbps:    linkSpeed(name),
+			DefaultRoute: name == defaultIface,
+		}
+		if master, err := os.Readlink(filepath.Join(dir, "master")); err == nil {
+			iface.Master = filepath.Base(master)
+		}
+		_, physicalErr := os.Stat(filepath.Join(dir, "device"))
+		switch {
+		case iface.Type == "bond":
+			iface.Members = strings.Fields(readSysString(filepath.Join(dir, "bonding", "slaves")))
+			iface.BondMode = strings.Fields(readSysString(filepath.Join(dir, "bonding", "mode")) + " ")[0]
+		case iface.Type == "bridge":
+			// Bridges of only virtual ports, such as docker0, are left out
+			members, _ := os.ReadDir(filepath.Join(dir, "brif"))
+			physical := false
+			for _, member := range members {
+				iface.Members = append(iface.Members, member.Name())
+				if _, err := os.Stat(filepath.Join("/sys/class/net", member.Name(), "device")); err == nil {
+					physical = true
+				}
+			}
+			if !physical {
+				continue
+			}
+		case physicalErr != nil:
+			continue
+		}
+		ifaces = append(ifaces, iface)
+	}
+	return ifaces
+}
+
+// linkSpeed returns the negotiated speed of a wired interface in Mbps, or
+// 0 when it is down or reports none
+func linkSpeed(iface string) int {
+	speed, err := strconv.Atoi(readSysString(filepath.Join("/sys/class/net", iface, "speed")))
+	if err != nil || speed <= 0 {
+		return 0
+	}
+	return speed
+}
+
+// defaultRoute reads the interface and gateway of the IPv4 default route
+// with the lowest metric from /proc/net/route, only through the given
+// interface unless it is ""
+func defaultRoute(only string) (iface, gateway string) {
+	file, err := os.Open("/proc/net/route")
+	if err != nil {
+		return "", ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	bestMetric := math.MaxInt
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 8 || fields[1] != "00000000" || fields[7] != "00000000" {
+			continue
+		}
+		if only != "" && fields[0] != only {
+			continue
+		}
+		metric, err := strconv.Atoi(fields[6])
+		if err != nil || metric >= bestMetric {
+			continue
+		}
+		bestMetric = metric
+		iface, gateway = fields[0], ""
+		// The gateway is a little-endian hex IPv4 address
+		if raw, err := hex.DecodeString(fields[2]); err == nil && len(raw) == 4 && fields[2] != "00000000" {
+			ip := make(net.IP, 4)
+			binary.BigEndian.PutUint32(ip, binary.LittleEndian.Uint32(raw))
+			gateway = ip.String()
+		}
+	}
+	return iface, gateway
+}
+
+// linkType classifies an interface from sysfs
+func linkType(iface string) string {
+	dir := filepath.Join("/sys/class/net", iface)
+	if _, err := os.Stat(filepath.Join(dir, "wireless")); err == nil {
+		return "wifi"
+	}
+	if _, err := os.Stat(filepath.Join(dir, "phy80211")); err == nil {
+		return "wifi"
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "uevent")); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			if devType, ok := strings.CutPrefix(line, "DEVTYPE="); ok {
+				if devType == "wlan" {
+					return "wifi"
+				}
+				return devType
+			}
+		}
+	}
+	// ARPHRD_ETHER; tunnels such as WireGuard report ARPHRD_NONE
+	switch readSysString(filepath.Join(dir, "type")) {
+	case "1":
+		return "ethernet"
+	case "65534":
+		return "tunnel"
+	}
+	return "other"
+}
+
+// detectWiFi reads the signal of a Wi-Fi interface from /proc/net/wireless,
+// and the SSID, frequency and bitrate from iw when it is installed
+func detectWiFi(iface string) *WiFiLink {
+	wifi := &WiFiLink{}
+	if data, err := os.ReadFile("/proc/net/wireless"); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) < 4 || fields[0] != iface+":" {
+				continue
+			}
+			// Quality is out of 70 in the kernel's cfg80211 scale
+			quality, _ := strconv.ParseFloat(strings.TrimSuffix(fields[2], "."), 64)
+			level, _ := strconv.ParseFloat(strings.TrimSuffix(fields[3], "."), 64)
+			wifi.QualityPct = min(int(quality*100/70), 100)
+			wifi.SignalDBm = int(level)
+		}
+	}
+
+	output, err := exec.Command("iw", "dev", iface, "link").Output()
+	if err != nil {
+		return wifi
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "SSID":
+			wifi.SSID = value
+		case "freq":
+			freq, _ := strconv.ParseFloat(value, 64)
+			wifi.FrequencyMHz = int(freq)
+		case "signal":
+			if dbm, err := strconv.Atoi(strings.Fields(value + " ")[0]); err == nil {
+				wifi.SignalDBm = dbm
+			}
+		case "tx bitrate":
+			wifi.BitrateMbps, _ = strconv.ParseFloat(strings.Fields(value + " ")[0], 64)
+		}
+	}
+	return wifi
+}
+
+// ProbeGateway sends count ICMP echoes to the gateway, interval apart, and
+// measures the round trips, through the given interface unless it is "". It
+// uses an unprivileged ping socket when net.ipv4.ping_group_range allows
+// one, and a raw socket otherwise.
+func ProbeGateway(gateway, iface string, count int, interval time.Duration) *EchoProbe {
+	ip := net.ParseIP(gateway).To4()
+	if ip == nil {
+		return &EchoProbe{Error: fmt.Sprintf("invalid gateway %q", gateway)}
+	}
+	return probeEcho(ip, iface, count, interval)
+}
+
+// unansweredEchoes is how many echoes probeEcho sends before giving up on a
+// host that has not replied to any
+const unansweredEchoes = 3
+
+// probeEcho sends count ICMP echoes to ip, interval apart, and measures the
+// round trips
+func probeEcho(ip net.IP, iface string, count int, interval time.Duration) *EchoProbe {
+	probe := &EchoProbe{}
+	conn, err := openICMP(iface)
+	if err != nil {
+		probe.Error = err.Error()
+		return probe
+	}
+	defer conn.Close()
+
+	var rtts []float64
+	buf := make([]byte, 1500)
+	id := uint16(os.Getpid())
+	for seq := 1; seq <= count; seq++ {
+		if seq > 1 {
+			time.Sleep(interval)
+		}
+		// A host that ignored the first few echoes filters ICMP; waiting
+		// out the rest would only stall the run
+		if seq > unansweredEchoes && len(rtts) == 0 {
+			break
+		}
+		probe.Sent++
+		sent := time.Now()
+		if _, err := conn.WriteTo(echoRequest(id, uint16(seq), echoSize), icmpAddr(conn, ip)); err != nil {
+			probe.Error = fmt.Sprintf("failed to send echo: %v", err)
+			break
+		}
+		if awaitEchoReply(conn, buf, id, uint16(seq), sent.Add(time.Second)) {
+			rtts = append(rtts, float64(time.Since(sent).Microseconds())/1000)
+		}
+	}
+
+	probe.Received = len(rtts)
+	if probe.Sent > 0 {
+		probe.LossPercent = float64(probe.Sent-probe.Received) / float64(probe.Sent) * 100
+	}
+	var sum, diffs float64
+	for i, rtt := range rtts {
+		sum += rtt
+		probe.MaxRTTMs = max(probe.MaxRTTMs, rtt)
+		if i > 0 {
+			diffs += math.Abs(rtt - rtts[i-1])
+		}
+	}
+	if len(rtts) > 0 {
+		probe.AvgRTTMs = sum / float64(len(rtts))
+	}
+	if len(rtts) > 1 {
+		probe.JitterMs = diffs / float64(len(rtts)-1)
+	}
+	return probe
+}
+
+// awaitEchoReply reads until the echo reply to seq arrives, and reports
+// false when the deadline passes first
+func awaitEchoReply(conn net.PacketConn, buf []byte, id, seq uint16, deadline time.Time) bool {
+	conn.SetReadDeadline(deadline)
+	// A ping socket rewrites the identifier and only delivers its own
+	// replies; a raw socket sees every reply on the host
+	_, pingSocket := conn.(*net.UDPConn)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return false
+		}
+		if n >= 8 && buf[0] == 0 && binary.BigEndian.Uint16(buf[6:8]) == seq &&
+			(pingSocket || binary.BigEndian.Uint16(buf[4:6]) == id) {
+			return true
+		}
+	}
+}
+
+// openICMP opens an unprivileged ICMP ping socket, falling back to a raw
+// ICMP socket, which needs root or CAP_NET_RAW. With an interface, the
+// socket is bound to it.
+func openICMP(iface string) (net.PacketConn, error) {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, unix.IPPROTO_ICMP)
+	if err == nil {
+		file := os.NewFile(uintptr(fd), "icmp")
+		defer file.Close()
+		if iface != "" {
+			if err := unix.SetsockoptString(fd, unix.SOL_SOCKET, unix.SO_BINDTODEVICE, iface); err != nil {
+				return nil, fmt.Errorf("cannot bind to %s: %w", iface, err)
+			}
+		}
+		if conn, err := net.FilePacketConn(file); err == nil {
+			return conn, nil
+		}
+	}
+
+	var bindErr error
+	config := net.ListenConfig{Control: func(network, address string, c syscall.RawConn) error {
+		if iface == "" {
+			return nil
+		}
+		c.Control(func(fd uintptr) {
+			bindErr = unix.SetsockoptString(int(fd), unix.SOL_SOCKET, unix.SO_BINDTODEVICE, iface)
+		})
+		return bindErr
+	}}
+	conn, err := config.ListenPacket(context.Background(), "ip4:icmp", "0.0.0.0")
+	if bindErr != nil {
+		return nil, fmt.Errorf("cannot bind to %s: %w", iface, bindErr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot open an ICMP socket (needs root or net.ipv4.ping_group_range): %w", err)
+	}
+	return conn, nil
+}
+
+// icmpAddr returns the destination address in the form the connection
+// takes: a ping socket is a datagram socket and takes a UDP address
+func icmpAddr(conn net.PacketConn, ip net.IP) net.Addr {
+	if _, ok := conn.(*net.UDPConn); ok {
+		return &net.UDPAddr{IP: ip}
+	}
+	return &net.IPAddr{IP: ip}
+}
+
+// echoSize is the length of a plain echo request: the 8 byte ICMP header
+// and an 8 byte payload
+const echoSize = 16
+
+// echoRequest builds an ICMP echo request of size bytes with a checksum
+func echoRequest(id, seq uint16, size int) []byte {
+	msg := make([]byte, max(size, echoSize))
+	msg[0] = 8 // Echo request
+	binary.BigEndian.PutUint16(msg[4:], id)
+	binary.BigEndian.PutUint16(msg[6:], seq)
+	copy(msg[8:], "ethbench")
+	var sum uint32
+	for i := 0; i < len(msg); i += 2 {
+		sum += uint32(msg[i]) << 8
+		if i+1 < len(msg) {
+			sum += uint32(msg[i+1])
+		}
+	}
+	sum = sum>>16 + sum&0xffff
+	sum += sum >> 16
+	binary.BigEndian.PutUint16(msg[2:], ^uint16(sum))
+	return msg
+}
+
+// readSysString reads a sysfs attribute, or "" if it cannot be read
+func readSysString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
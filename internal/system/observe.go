@@ -0,0 +1,156 @@
+package system
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// clockTicks is USER_HZ, the unit of CPU times in /proc/<pid>/stat; it is
+// 100 on every architecture Linux runs clients on
+const clockTicks = 100
+
+// processSnapshot holds cumulative counters of a process at a point in time
+type processSnapshot struct {
+	time       time.Time
+	cpuTicks   uint64
+	readBytes  uint64
+	writeBytes uint64
+	rssMB      float64
+	threads    int
+	fds        int
+}
+
+// ObserveProcess samples a running process every interval for duration, or
+// until ctx is cancelled, and returns its CPU, memory, storage I/O and file
+// descriptor use. Reading another user's I/O counters and descriptors needs
+// root or the same user.
+func ObserveProcess(ctx context.Context, pid int, duration, interval time.Duration) (types.ProcessUsage, error) {
+	usage := types.ProcessUsage{PID: pid, MaxOpenFDs: readFDLimit(pid)}
+	if data, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pid)); err == nil {
+		usage.Command = strings.TrimSpace(string(data))
+	}
+	first, err := snapshotProcess(pid)
+	if err != nil {
+		return usage, err
+	}
+
+	prev := first
+	var cpuSum, rssSum float64
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for time.Since(first.time) < duration {
+		select {
+		case <-ctx.Done():
+			return usage, nil
+		case <-ticker.C:
+		}
+		cur, err := snapshotProcess(pid)
+		if err != nil {
+			return usage, fmt.Errorf("process %d exited during observation", pid)
+		}
+		elapsed := cur.time.Sub(prev.time).Seconds()
+		cpu := float64(cur.cpuTicks-prev.cpuTicks) / clockTicks / elapsed * 100
+
+		usage.Samples++
+		cpuSum += cpu
+		rssSum += cur.rssMB
+		usage.AvgCPUPercent = cpuSum / float64(usage.Samples)
+		usage.AvgRSSMB = rssSum / float64(usage.Samples)
+		usage.PeakCPUPercent = max(usage.PeakCPUPercent, cpu)
+		usage.PeakRSSMB = max(usage.PeakRSSMB, cur.rssMB)
+		usage.PeakReadMBps = max(usage.PeakReadMBps, mbPerSec(cur.readBytes-prev.readBytes, elapsed))
+		usage.PeakWriteMBps = max(usage.PeakWriteMBps, mbPerSec(cur.writeBytes-prev.writeBytes, elapsed))
+		usage.PeakOpenFDs = max(usage.PeakOpenFDs, cur.fds)
+		usage.Threads = cur.threads
+
+		usage.Duration = cur.time.Sub(first.time)
+		usage.ReadMBps = mbPerSec(cur.readBytes-first.readBytes, usage.Duration.Seconds())
+		usage.WriteMBps = mbPerSec(cur.writeBytes-first.writeBytes, usage.Duration.Seconds())
+		prev = cur
+	}
+	return usage, nil
+}
+
+// mbPerSec converts a byte count over seconds to MB/s
+func mbPerSec(bytes uint64, seconds float64) float64 {
+	if seconds <= 0 {
+		return 0
+	}
+	return float64(bytes) / (1 << 20) / seconds
+}
+
+// snapshotProcess reads the cumulative counters of a process
+func snapshotProcess(pid int) (processSnapshot, error) {
+	snap := processSnapshot{time: time.Now()}
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return snap, fmt.Errorf("process %d not found: %w", pid, err)
+	}
+	// The command name in parentheses may contain spaces; utime and stime
+	// are the 12th and 13th fields after it
+	_, rest, _ := strings.Cut(string(stat), ") ")
+	fields := strings.Fields(rest)
+	if len(fields) < 13 {
+		return snap, fmt.Errorf("unexpected /proc/%d/stat format", pid)
+	}
+	utime, _ := strconv.ParseUint(fields[11], 10, 64)
+	stime, _ := strconv.ParseUint(fields[12], 10, 64)
+	snap.cpuTicks = utime + stime
+
+	status := readProcFields(fmt.Sprintf("/proc/%d/status", pid))
+	if kb, err := strconv.ParseFloat(strings.TrimSuffix(status["VmRSS"], " kB"), 64); err == nil {
+		snap.rssMB = kb / 1024
+	}
+	snap.threads, _ = strconv.Atoi(status["Threads"])
+
+	io := readProcFields(fmt.Sprintf("/proc/%d/io", pid))
+	snap.readBytes, _ = strconv.ParseUint(io["read_bytes"], 10, 64)
+	snap.writeBytes, _ = strconv.ParseUint(io["write_bytes"], 10, 64)
+
+	if entries, err := os.ReadDir(fmt.Sprintf("/proc/%d/fd", pid)); err == nil {
+		snap.fds = len(entries)
+	}
+	return snap, nil
+}
+
+// readProcFields parses a "Key: value" file such as /proc/<pid>/status
+func readProcFields(path string) map[string]string {
+	fields := make(map[string]string)
+	f, err := os.Open(path)
+	if err != nil {
+		return fields
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if key, value, ok := strings.Cut(scanner.Text(), ":"); ok {
+			fields[key] = strings.TrimSpace(value)
+		}
+	}
+	return fields
+}
+
+// readFDLimit returns the soft open file limit of a process, 0 if unknown
+func readFDLimit(pid int) int {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/limits", pid))
+	if err != nil {
+		return 0
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if rest, ok := strings.CutPrefix(line, "Max open files"); ok {
+			if fields := strings.Fields(rest); len(fields) > 0 {
+				limit, _ := strconv.Atoi(fields[0])
+				return limit
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,112 @@
+package system
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"time"
+)
+
+// PeerLatency holds TCP connect times to the host running the other client
+// of a split node, e.g. the execution client's engine API. A connect takes
+// one round trip, so it measures the latency without ICMP, and only
+// succeeds when the client's port is actually reachable.
+type PeerLatency struct {
+	Peer      string  `json:"peer"`
+	Address   string  `json:"address,omitempty"`
+	Attempts  int     `json:"attempts"`
+	Connected int     `json:"connected"`
+	AvgMs     float64 `json:"avg_ms"`
+	MaxMs     float64 `json:"max_ms"`
+	JitterMs  float64 `json:"jitter_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// String summarizes the probe, e.g. "0.4 ms avg, 0.1 ms jitter, 1.2 ms max
+// over 10 connects"
+func (p *PeerLatency) String() string {
+	if p.Connected == 0 {
+		if p.Error != "" {
+			return "unreachable: " + p.Error
+		}
+		return fmt.Sprintf("no connection in %d attempts", p.Attempts)
+	}
+	s := fmt.Sprintf("%.1f ms avg, %.1f ms jitter, %.1f ms max over %d connects", p.AvgMs, p.JitterMs, p.MaxMs, p.Attempts)
+	if p.Connected < p.Attempts {
+		s += fmt.Sprintf(", %d failed", p.Attempts-p.Connected)
+	}
+	return s
+}
+
+// ProbePeer connects to peer count times, interval apart, and measures the
+// connect times. The peer is host:port or a URL such as
+// http://192.168.1.20:8551 or https://rpc.example.org, whose scheme supplies
+// the default port.
+func ProbePeer(peer string, count int, interval time.Duration) *PeerLatency {
+	probe := &PeerLatency{Peer: peer}
+	addr, err := peerAddress(peer)
+	if err != nil {
+		probe.Error = err.Error()
+		return probe
+	}
+	probe.Address = addr
+
+	var times []float64
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			time.Sleep(interval)
+		}
+		probe.Attempts++
+		start := time.Now()
+		conn, err := net.DialTimeout("tcp", addr, 2*time.Second)
+		if err != nil {
+			probe.Error = err.Error()
+			// A refused or unresolvable peer will not come up mid-probe
+			if len(times) == 0 && i+1 >= unansweredEchoes {
+				break
+			}
+			continue
+		}
+		times = append(times, float64(time.Since(start).Microseconds())/1000)
+		conn.Close()
+	}
+
+	probe.Connected = len(times)
+	var sum, diffs float64
+	for i, t := range times {
+		sum += t
+		probe.MaxMs = max(probe.MaxMs, t)
+		if i > 0 {
+			diffs += math.Abs(t - times[i-1])
+		}
+	}
+	if len(times) > 0 {
+		probe.AvgMs = sum / float64(len(times))
+		probe.Error = ""
+	}
+	if len(times) > 1 {
+		probe.JitterMs = diffs / float64(len(times)-1)
+	}
+	return probe
+}
+
+// peerAddress turns a peer given as host:port or URL into a dial address
+func peerAddress(peer string) (string, error) {
+	if u, err := url.Parse(peer); err == nil && u.Host != "" {
+		if u.Port() != "" {
+			return u.Host, nil
+		}
+		switch u.Scheme {
+		case "http", "ws":
+			return net.JoinHostPort(u.Hostname(), "80"), nil
+		case "https", "wss":
+			return net.JoinHostPort(u.Hostname(), "443"), nil
+		}
+		return "", fmt.Errorf("%s has no port", peer)
+	}
+	if _, _, err := net.SplitHostPort(peer); err != nil {
+		return "", fmt.Errorf("peer %q must be host:port or a URL", peer)
+	}
+	return peer, nil
+}
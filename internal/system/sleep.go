@@ -0,0 +1,164 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// suspendThreshold is how far the wall clock has to run ahead of the
+// monotonic clock between two samples to count as a suspend. The monotonic
+// clock stops while the system sleeps; NTP slews stay well below this.
+const suspendThreshold = 2 * time.Second
+
+// suspendEvent is a gap in the monotonic clock found between two samples
+type suspendEvent struct {
+	at       time.Time
+	duration time.Duration
+}
+
+// governorChange is a switch of the CPU frequency governor, e.g. by a
+// laptop power profile daemon when the charger is unplugged
+type governorChange struct {
+	at       time.Time
+	from, to string
+}
+
+// SleepGuard keeps the system awake while benchmarks run and records any
+// suspend or governor change that happened anyway
+type SleepGuard struct {
+	mu        sync.Mutex
+	inhibitor string
+	cmd       *exec.Cmd
+	suspends  []suspendEvent
+	governors []governorChange
+	stop      chan struct{}
+	done      chan struct{}
+	interval  time.Duration
+}
+
+// NewSleepGuard creates a guard checking for suspends at the given interval
+func NewSleepGuard(interval time.Duration) *SleepGuard {
+	return &SleepGuard{
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		interval: interval,
+	}
+}
+
+// Start inhibits sleep and begins watching in the background. Inhibiting
+// needs systemd-inhibit (Linux) or caffeinate (macOS); without either the
+// guard only records suspends.
+func (g *SleepGuard) Start() {
+	g.inhibit()
+
+	go func() {
+		defer close(g.done)
+		ticker := time.NewTicker(g.interval)
+		defer ticker.Stop()
+
+		last := time.Now()
+		governor := detectCPUGovernor()
+		for {
+			select {
+			case <-g.stop:
+				return
+			case <-ticker.C:
+			}
+			now := time.Now()
+			// Round(0) drops the monotonic reading so Sub uses the wall clock
+			if gap := now.Round(0).Sub(last.Round(0)) - now.Sub(last); gap >= suspendThreshold {
+				g.mu.Lock()
+				g.suspends = append(g.suspends, suspendEvent{at: now, duration: gap})
+				g.mu.Unlock()
+			}
+			last = now
+
+			if current := detectCPUGovernor(); current != governor && current != "" && governor != "" {
+				g.mu.Lock()
+				g.governors = append(g.governors, governorChange{at: now, from: governor, to: current})
+				g.mu.Unlock()
+				governor = current
+			}
+		}
+	}()
+}
+
+// inhibit starts a helper process that holds a sleep inhibitor lock until
+// Stop, or until this process exits if it is killed
+func (g *SleepGuard) inhibit() {
+	pid := strconv.Itoa(os.Getpid())
+	var cmd *exec.Cmd
+	if _, err := exec.LookPath("systemd-inhibit"); err == nil {
+		cmd = exec.Command("systemd-inhibit",
+			"--what=sleep:idle:handle-lid-switch", "--who=ethbench",
+			"--why=Benchmark in progress", "--mode=block",
+			"tail", "--pid="+pid, "-f", "/dev/null")
+	} else if _, err := exec.LookPath("caffeinate"); err == nil {
+		cmd = exec.Command("caffeinate", "-ims", "-w", pid)
+	} else {
+		return
+	}
+	if err := cmd.Start(); err != nil {
+		return
+	}
+
+	// The helper exits right away when the lock is refused, e.g. by polkit
+	// in a session without the inhibit permission
+	exited := make(chan struct{})
+	go func() {
+		cmd.Wait()
+		close(exited)
+	}()
+	select {
+	case <-exited:
+		return
+	case <-time.After(200 * time.Millisecond):
+	}
+	g.inhibitor = cmd.Args[0]
+	g.cmd = cmd
+}
+
+// Stop releases the inhibitor and summarizes the interruptions, attributing
+// them to the benchmark phases they fell into
+func (g *SleepGuard) Stop(timeline []types.PhaseTiming) *types.SleepResult {
+	close(g.stop)
+	<-g.done
+	if g.cmd != nil {
+		g.cmd.Process.Kill()
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	result := &types.SleepResult{Inhibitor: g.inhibitor}
+	if result.Inhibitor == "" {
+		result.Inhibitor = "none"
+	}
+	var times []time.Time
+	for _, s := range g.suspends {
+		result.Suspends++
+		result.SuspendedSeconds += s.duration.Seconds()
+		times = append(times, s.at)
+	}
+	for _, c := range g.governors {
+		result.GovernorChanges = append(result.GovernorChanges, fmt.Sprintf("%s -> %s", c.from, c.to))
+		times = append(times, c.at)
+	}
+	for _, phase := range timeline {
+		for _, t := range times {
+			// A suspend is noticed at the first sample after waking, up to
+			// one interval after the phase it interrupted
+			if !t.Before(phase.Start) && !t.After(phase.End.Add(g.interval)) {
+				result.Phases = append(result.Phases, phase.Name)
+				break
+			}
+		}
+	}
+	return result
+}
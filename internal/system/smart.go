@@ -0,0 +1,182 @@
+package system
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DiskHealth is the wear state of the primary disk, from smartctl, the NVMe
+// health log or the eMMC life time estimate in sysfs
+type DiskHealth struct {
+	Device string `json:"device"`
+	Source string `json:"source"` // "smartctl", "nvme-cli" or "emmc"
+	// PercentUsed is the vendor's wear estimate; 100 means the rated
+	// endurance is reached and the drive may fail or turn read-only
+	PercentUsed  int     `json:"percent_used"`
+	WrittenTB    float64 `json:"written_tb,omitempty"`
+	PowerOnHours int     `json:"power_on_hours,omitempty"`
+	MediaErrors  int     `json:"media_errors,omitempty"`
+	// CriticalWarning is set by NVMe critical warning bits or an eMMC
+	// pre-end-of-life warning
+	CriticalWarning bool `json:"critical_warning,omitempty"`
+}
+
+// RemainingTB estimates the writes left before the rated endurance is
+// reached, extrapolated from the writes so far; 0 when unknown
+func (h *DiskHealth) RemainingTB() float64 {
+	if h.WrittenTB <= 0 || h.PercentUsed <= 0 {
+		return 0
+	}
+	return max(0, h.WrittenTB/float64(h.PercentUsed)*float64(100-h.PercentUsed))
+}
+
+// detectDiskHealth reads the wear state of the first disk of the detected
+// type. SMART data needs root; without it only eMMC wear is available.
+func detectDiskHealth(diskType string) *DiskHealth {
+	pattern := map[string]string{"nvme": "nvme*", "sd": "mmcblk*", "scsi": "sd*"}[diskType]
+	if pattern == "" {
+		return nil
+	}
+	devices, _ := filepath.Glob(filepath.Join("/sys/block", pattern))
+	for _, dev := range devices {
+		name := filepath.Base(dev)
+		if strings.Contains(name, "boot") || strings.Contains(name, "rpmb") {
+			continue // eMMC hardware partitions
+		}
+		if h := smartctlHealth(name); h != nil {
+			return h
+		}
+		if diskType == "nvme" {
+			if h := nvmeHealth(name); h != nil {
+				return h
+			}
+		}
+		if diskType == "sd" {
+			return emmcHealth(name)
+		}
+		return nil
+	}
+	return nil
+}
+
+// smartctlHealth reads wear from `smartctl --json`, which covers both the
+// NVMe health log and ATA wear attributes
+func smartctlHealth(device string) *DiskHealth {
+	// smartctl sets exit status bits for disk problems, so parse the output
+	// whatever the status
+	out, _ := exec.Command("smartctl", "--json", "-a", "/dev/"+device).Output()
+	if len(out) == 0 {
+		return nil
+	}
+	var data struct {
+		PowerOnTime struct {
+			Hours int `json:"hours"`
+		} `json:"power_on_time"`
+		LogicalBlockSize int `json:"logical_block_size"`
+		NVMe             *struct {
+			CriticalWarning  int     `json:"critical_warning"`
+			PercentageUsed   int     `json:"percentage_used"`
+			DataUnitsWritten float64 `json:"data_units_written"`
+			MediaErrors      int     `json:"media_errors"`
+		} `json:"nvme_smart_health_information_log"`
+		ATA *struct {
+			Table []struct {
+				ID    int `json:"id"`
+				Value int `json:"value"`
+				Raw   struct {
+					Value float64 `json:"value"`
+				} `json:"raw"`
+			} `json:"table"`
+		} `json:"ata_smart_attributes"`
+	}
+	if json.Unmarshal(out, &data) != nil {
+		return nil
+	}
+	h := &DiskHealth{Device: device, Source: "smartctl", PowerOnHours: data.PowerOnTime.Hours}
+	switch {
+	case data.NVMe != nil:
+		h.PercentUsed = data.NVMe.PercentageUsed
+		h.WrittenTB = data.NVMe.DataUnitsWritten * 512000 / 1e12
+		h.MediaErrors = data.NVMe.MediaErrors
+		h.CriticalWarning = data.NVMe.CriticalWarning != 0
+	case data.ATA != nil:
+		blockSize := float64(max(data.LogicalBlockSize, 512))
+		found := false
+		for _, attr := range data.ATA.Table {
+			switch attr.ID {
+			// Normalized remaining life: Wear_Leveling_Count, SSD_Life_Left,
+			// Media_Wearout_Indicator, Percent_Lifetime_Remain (Crucial/Micron)
+			case 177, 202, 231, 233:
+				h.PercentUsed = max(h.PercentUsed, 100-attr.Value)
+				found = true
+			case 241: // Total_LBAs_Written
+				h.WrittenTB = attr.Raw.Value * blockSize / 1e12
+			case 5: // Reallocated_Sector_Ct
+				h.MediaErrors = int(attr.Raw.Value)
+			}
+		}
+		if !found && h.WrittenTB == 0 {
+			return nil // Spinning disk or no wear attributes
+		}
+	default:
+		return nil
+	}
+	return h
+}
+
+// nvmeHealth reads the NVMe SMART/health log through nvme-cli
+func nvmeHealth(device string) *DiskHealth {
+	out, err := exec.Command("nvme", "smart-log", "-o", "json", "/dev/"+device).Output()
+	if err != nil {
+		return nil
+	}
+	var log map[string]any
+	if json.Unmarshal(out, &log) != nil {
+		return nil
+	}
+	number := func(keys ...string) float64 {
+		for _, key := range keys {
+			if v, ok := log[key].(float64); ok {
+				return v
+			}
+		}
+		return 0
+	}
+	// Key names changed between nvme-cli versions
+	return &DiskHealth{
+		Device:          device,
+		Source:          "nvme-cli",
+		PercentUsed:     int(number("percent_used", "percentage_used")),
+		WrittenTB:       number("data_units_written") * 512000 / 1e12,
+		PowerOnHours:    int(number("power_on_hours")),
+		MediaErrors:     int(number("media_errors")),
+		CriticalWarning: number("critical_warning") != 0,
+	}
+}
+
+// emmcHealth reads the eMMC 5.0 device life time estimates. Each is in 10%
+// steps of rated endurance (0x01 = 0-10% used, 0x0B = exceeded); plain SD
+// cards do not report them.
+func emmcHealth(device string) *DiskHealth {
+	data, err := os.ReadFile(filepath.Join("/sys/block", device, "device", "life_time"))
+	if err != nil {
+		return nil
+	}
+	h := &DiskHealth{Device: device, Source: "emmc"}
+	for _, field := range strings.Fields(string(data)) {
+		if v, err := strconv.ParseInt(field, 0, 0); err == nil {
+			h.PercentUsed = max(h.PercentUsed, int(v)*10)
+		}
+	}
+	// Pre-EOL: 0x01 normal, 0x02 warning (80% of reserved blocks used), 0x03 urgent
+	if data, err := os.ReadFile(filepath.Join("/sys/block", device, "device", "pre_eol_info")); err == nil {
+		if v, err := strconv.ParseInt(strings.TrimSpace(string(data)), 0, 0); err == nil && v >= 2 {
+			h.CriticalWarning = true
+		}
+	}
+	return h
+}
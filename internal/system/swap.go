@@ -0,0 +1,91 @@
+package system
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SwapDevice describes one active swap area from /proc/swaps
+type SwapDevice struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"` // "partition" or "file"
+	SizeMB   int    `json:"size_mb"`
+	UsedMB   int    `json:"used_mb"`
+	Priority int    `json:"priority"`
+	// Zram is set for compressed RAM swap; Compressor is its algorithm
+	Zram       bool   `json:"zram,omitempty"`
+	Compressor string `json:"compressor,omitempty"`
+}
+
+// detectSwap reads the active swap areas, marking zram devices and their
+// compression algorithm
+func detectSwap() []SwapDevice {
+	file, err := os.Open("/proc/swaps")
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var devices []SwapDevice
+	scanner := bufio.NewScanner(file)
+	scanner.Scan() // Header
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		sizeKB, _ := strconv.Atoi(fields[2])
+		usedKB, _ := strconv.Atoi(fields[3])
+		priority, _ := strconv.Atoi(fields[4])
+		device := SwapDevice{
+			Name:     fields[0],
+			Type:     fields[1],
+			SizeMB:   sizeKB / 1024,
+			UsedMB:   usedKB / 1024,
+			Priority: priority,
+		}
+		if base := filepath.Base(device.Name); strings.HasPrefix(base, "zram") {
+			device.Zram = true
+			device.Compressor = zramCompressor(base)
+		}
+		devices = append(devices, device)
+	}
+	return devices
+}
+
+// zramCompressor returns the selected algorithm of a zram device, shown in
+// brackets in comp_algorithm, e.g. "lzo [lz4] zstd"
+func zramCompressor(device string) string {
+	data, err := os.ReadFile(filepath.Join("/sys/block", device, "comp_algorithm"))
+	if err != nil {
+		return ""
+	}
+	for _, algorithm := range strings.Fields(string(data)) {
+		if strings.HasPrefix(algorithm, "[") {
+			return strings.Trim(algorithm, "[]")
+		}
+	}
+	return ""
+}
+
+// SwapTotalMB returns the combined size of all swap areas
+func (info *Info) SwapTotalMB() int {
+	total := 0
+	for _, device := range info.Swap {
+		total += device.SizeMB
+	}
+	return total
+}
+
+// HasDiskSwap reports whether any swap area is backed by storage rather than zram
+func (info *Info) HasDiskSwap() bool {
+	for _, device := range info.Swap {
+		if !device.Zram {
+			return true
+		}
+	}
+	return false
+}
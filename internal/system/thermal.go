@@ -0,0 +1,170 @@
+package system
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+// Throttle flag bits reported by vcgencmd get_throttled that are active now
+const (
+	throttleFreqCapped = 1 << 1
+	throttleThrottled  = 1 << 2
+	throttleSoftTemp   = 1 << 3
+	throttleActiveMask = throttleFreqCapped | throttleThrottled | throttleSoftTemp
+)
+
+// freqDropRatio is the fraction of the peak frequency below which a sample
+// taken during a CPU-bound benchmark counts as a frequency drop
+const freqDropRatio = 0.9
+
+// cpuBoundPhases are the phase name prefixes that keep the CPU busy. Outside
+// them an on-demand governor lowers the clock while waiting on I/O or idling,
+// so a low frequency there is not a sign of throttling.
+var cpuBoundPhases = []string{"cpu.", "memory.", "gogc_sweep."}
+
+// cpuBound reports whether the named phase keeps the CPU busy
+func cpuBound(phase string) bool {
+	for _, prefix := range cpuBoundPhases {
+		if strings.HasPrefix(phase, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// thermalSample is a single reading of temperature, clock and throttle state
+type thermalSample struct {
+	time      time.Time
+	tempC     float64
+	freqMHz   int
+	throttled uint64
+}
+
+// ThermalMonitor samples SoC temperature, CPU frequency and throttle flags
+// while benchmarks run
+type ThermalMonitor struct {
+	mu       sync.Mutex
+	samples  []thermalSample
+	stop     chan struct{}
+	done     chan struct{}
+	interval time.Duration
+}
+
+// NewThermalMonitor creates a monitor sampling at the given interval
+func NewThermalMonitor(interval time.Duration) *ThermalMonitor {
+	return &ThermalMonitor{
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		interval: interval,
+	}
+}
+
+// Start begins sampling in the background
+func (m *ThermalMonitor) Start() {
+	go func() {
+		defer close(m.done)
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		m.sample()
+		for {
+			select {
+			case <-m.stop:
+				return
+			case <-ticker.C:
+				m.sample()
+			}
+		}
+	}()
+}
+
+// Stop ends sampling and summarizes the readings per benchmark phase.
+// Returns nil when neither temperature nor frequency could be read.
+func (m *ThermalMonitor) Stop(timeline []types.PhaseTiming) *types.ThermalResult {
+	close(m.stop)
+	<-m.done
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return summarizeThermal(m.samples, timeline)
+}
+
+// sample records the current thermal state
+func (m *ThermalMonitor) sample() {
+	s := thermalSample{
+		time:    time.Now(),
+		tempC:   ReadTemperature(),
+		freqMHz: readFreqMHz("/sys/devices/system/cpu/cpu0/cpufreq/scaling_cur_freq"),
+	}
+	if flags := detectThrottled(); flags != "" {
+		s.throttled, _ = strconv.ParseUint(strings.TrimPrefix(flags, "0x"), 16, 32)
+	}
+
+	m.mu.Lock()
+	m.samples = append(m.samples, s)
+	m.mu.Unlock()
+}
+
+// summarizeThermal aggregates samples overall and for each benchmark phase
+func summarizeThermal(samples []thermalSample, timeline []types.PhaseTiming) *types.ThermalResult {
+	result := &types.ThermalResult{Samples: len(samples)}
+	var sensors bool
+	for _, s := range samples {
+		if s.tempC > 0 || s.freqMHz > 0 {
+			sensors = true
+		}
+		if s.tempC > result.MaxTemperatureC {
+			result.MaxTemperatureC = s.tempC
+		}
+		if s.freqMHz > result.MaxFreqMHz {
+			result.MaxFreqMHz = s.freqMHz
+		}
+	}
+	if !sensors {
+		return nil
+	}
+
+	result.Stable = true
+	for _, phase := range timeline {
+		tp := types.ThermalPhase{Phase: phase.Name}
+		var wasThrottled bool
+		for _, s := range samples {
+			if s.time.Before(phase.Start) || s.time.After(phase.End) {
+				continue
+			}
+			if s.tempC > tp.MaxTemperatureC {
+				tp.MaxTemperatureC = s.tempC
+			}
+			if s.freqMHz > 0 && (tp.MinFreqMHz == 0 || s.freqMHz < tp.MinFreqMHz) {
+				tp.MinFreqMHz = s.freqMHz
+			}
+
+			throttled := s.throttled&throttleActiveMask != 0
+			if throttled && !wasThrottled {
+				tp.ThrottleEvents++
+			}
+			wasThrottled = throttled
+
+			// Only a busy CPU is expected to hold its peak clock
+			if cpuBound(phase.Name) && s.freqMHz > 0 && float64(s.freqMHz) < float64(result.MaxFreqMHz)*freqDropRatio {
+				tp.FreqDrops++
+			}
+		}
+
+		if tp.MinFreqMHz > 0 && (result.MinFreqMHz == 0 || tp.MinFreqMHz < result.MinFreqMHz) {
+			result.MinFreqMHz = tp.MinFreqMHz
+		}
+		result.ThrottleEvents += tp.ThrottleEvents
+		result.FreqDrops += tp.FreqDrops
+		if tp.ThrottleEvents > 0 || tp.FreqDrops > 0 {
+			tp.Affected = true
+			result.Stable = false
+		}
+		result.Phases = append(result.Phases, tp)
+	}
+	return result
+}
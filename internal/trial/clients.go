@@ -0,0 +1,110 @@
+package trial
+
+import (
+	"fmt"
+	"sort"
+)
+
+// apiPort is the beacon API port inside every client container
+const apiPort = "5052"
+
+// Paths inside the container; the host data directory is mounted at dataDir
+const (
+	dataDir = "/data"
+	jwtPath = dataDir + "/jwt.hex"
+	// No execution client runs during a trial; clients sync optimistically
+	// against an endpoint that never answers
+	engineURL = "http://127.0.0.1:8551"
+)
+
+// checkpointURLs are the default checkpoint sync providers per network
+var checkpointURLs = map[string]string{
+	"mainnet": "https://mainnet.checkpoint.sigp.io",
+	"holesky": "https://holesky.checkpoint.sigp.io",
+	"sepolia": "https://sepolia.checkpoint.sigp.io",
+}
+
+// client describes how to checkpoint sync one consensus client in Docker
+type client struct {
+	image string
+	// prepare runs to completion before the node starts, e.g. Nimbus'
+	// trustedNodeSync; nil when the node checkpoint syncs itself
+	prepare func(network, url string) []string
+	run     func(network, url string) []string
+}
+
+// clients lists the supported consensus clients by name
+var clients = map[string]client{
+	"nimbus": {
+		image: "statusim/nimbus-eth2:multiarch-latest",
+		prepare: func(network, url string) []string {
+			return []string{"trustedNodeSync", "--network=" + network, "--data-dir=" + dataDir,
+				"--trusted-node-url=" + url, "--backfill=false"}
+		},
+		run: func(network, url string) []string {
+			return []string{"--network=" + network, "--data-dir=" + dataDir,
+				"--rest", "--rest-address=0.0.0.0", "--rest-port=" + apiPort,
+				"--el=" + engineURL, "--jwt-secret=" + jwtPath, "--non-interactive"}
+		},
+	},
+	"lighthouse": {
+		image: "sigp/lighthouse:latest",
+		run: func(network, url string) []string {
+			return []string{"lighthouse", "bn", "--network", network, "--datadir", dataDir,
+				"--checkpoint-sync-url", url, "--disable-deposit-contract-sync",
+				"--http", "--http-address", "0.0.0.0", "--http-port", apiPort,
+				"--execution-endpoint", engineURL, "--execution-jwt", jwtPath}
+		},
+	},
+	"teku": {
+		image: "consensys/teku:latest",
+		run: func(network, url string) []string {
+			return []string{"--network=" + network, "--data-path=" + dataDir,
+				"--checkpoint-sync-url=" + url + "/eth/v2/debug/beacon/states/finalized",
+				"--rest-api-enabled=true", "--rest-api-interface=0.0.0.0", "--rest-api-port=" + apiPort,
+				"--rest-api-host-allowlist=*", "--ee-endpoint=" + engineURL, "--ee-jwt-secret-file=" + jwtPath}
+		},
+	},
+	"lodestar": {
+		image: "chainsafe/lodestar:latest",
+		run: func(network, url string) []string {
+			return []string{"beacon", "--network", network, "--dataDir", dataDir,
+				"--checkpointSyncUrl", url,
+				"--rest", "--rest.address", "0.0.0.0", "--rest.port", apiPort,
+				"--execution.urls", engineURL, "--jwt-secret", jwtPath}
+		},
+	},
+	"prysm": {
+		image: "gcr.io/prysmaticlabs/prysm/beacon-chain:stable",
+		run: func(network, url string) []string {
+			return []string{"--" + network, "--datadir=" + dataDir, "--accept-terms-of-use",
+				"--checkpoint-sync-url=" + url, "--genesis-beacon-api-url=" + url,
+				"--grpc-gateway-host=0.0.0.0", "--grpc-gateway-port=" + apiPort,
+				"--execution-endpoint=" + engineURL, "--jwt-secret=" + jwtPath}
+		},
+	},
+}
+
+// Clients returns the names of the supported consensus clients
+func Clients() []string {
+	names := make([]string, 0, len(clients))
+	for name := range clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookup returns the client and the checkpoint URL to use for the network
+func lookup(name, network, url string) (client, string, error) {
+	c, ok := clients[name]
+	if !ok {
+		return client{}, "", fmt.Errorf("unknown client %q (available: %v)", name, Clients())
+	}
+	if url == "" {
+		if url, ok = checkpointURLs[network]; !ok {
+			return client{}, "", fmt.Errorf("no default checkpoint sync URL for network %q, set -checkpoint-url", network)
+		}
+	}
+	return c, url, nil
+}
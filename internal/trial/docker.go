@@ -0,0 +1,121 @@
+package trial
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// containerStats is one `docker stats` reading of the client container
+type containerStats struct {
+	cpuPercent float64 // 100 = one core
+	memoryMB   float64
+	readMB     float64 // Cumulative since container start
+	writeMB    float64
+}
+
+// docker runs the docker CLI and returns its output; errors include the
+// last lines of stderr
+func docker(ctx context.Context, args ...string) (string, error) {
+	var stderr strings.Builder
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("docker %s: %w: %s", args[0], err, tail(stderr.String(), 5))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// dockerAvailable checks that the docker CLI is installed and its daemon
+// answers
+func dockerAvailable(ctx context.Context) error {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return errors.New("docker not found: install Docker to run a client trial")
+	}
+	if _, err := docker(ctx, "version", "--format", "{{.Server.Version}}"); err != nil {
+		return fmt.Errorf("docker daemon not reachable (is it running, and is this user in the docker group?): %w", err)
+	}
+	return nil
+}
+
+// hostAPI returns the URL of the beacon API port published on localhost
+func hostAPI(ctx context.Context, name string) (string, error) {
+	out, err := docker(ctx, "port", name, apiPort+"/tcp")
+	if err != nil {
+		return "", err
+	}
+	// One line per address family; the first is the 127.0.0.1 binding
+	address, _, _ := strings.Cut(out, "\n")
+	return "http://" + strings.TrimSpace(address), nil
+}
+
+// running reports whether the container is still up
+func running(ctx context.Context, name string) bool {
+	out, err := docker(ctx, "inspect", "-f", "{{.State.Running}}", name)
+	return err == nil && out == "true"
+}
+
+// lastLogs returns the client's final log lines to explain an early exit
+func lastLogs(name string) string {
+	out, err := exec.Command("docker", "logs", "--tail", "5", name).CombinedOutput()
+	if err != nil {
+		return "no logs"
+	}
+	return tail(string(out), 5)
+}
+
+// stats reads the container's CPU, memory and block I/O from `docker stats`
+func stats(ctx context.Context, name string) (containerStats, error) {
+	var s containerStats
+	out, err := docker(ctx, "stats", "--no-stream", "--format", "{{.CPUPerc}}|{{.MemUsage}}|{{.BlockIO}}", name)
+	if err != nil {
+		return s, err
+	}
+	fields := strings.Split(out, "|")
+	if len(fields) != 3 {
+		return s, fmt.Errorf("unexpected docker stats output %q", out)
+	}
+	s.cpuPercent, _ = strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(fields[0]), "%"), 64)
+	used, _, _ := strings.Cut(fields[1], "/")
+	s.memoryMB = parseSizeMB(used)
+	read, written, _ := strings.Cut(fields[2], "/")
+	s.readMB = parseSizeMB(read)
+	s.writeMB = parseSizeMB(written)
+	return s, nil
+}
+
+// sizeUnits maps the units docker prints, decimal for I/O and binary for
+// memory, to bytes
+var sizeUnits = map[string]float64{
+	"B":  1,
+	"kB": 1e3, "KB": 1e3, "MB": 1e6, "GB": 1e9, "TB": 1e12,
+	"KiB": 1 << 10, "MiB": 1 << 20, "GiB": 1 << 30, "TiB": 1 << 40,
+}
+
+// parseSizeMB parses a docker size such as "1.5GiB" or "12.3MB" into MB
+// (2^20 bytes); unparsable sizes count as 0
+func parseSizeMB(s string) float64 {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i <= 0 {
+		return 0
+	}
+	value, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return 0
+	}
+	return value * sizeUnits[s[i:]] / (1 << 20)
+}
+
+// tail returns the last n lines of s joined by " | "
+func tail(s string, n int) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, " | ")
+}
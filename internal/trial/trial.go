@@ -0,0 +1,249 @@
+// Package trial runs a real consensus client in Docker against a checkpoint
+// sync for a few minutes and measures how it copes on this machine: time to
+// reach head, sync speed and its CPU, memory and disk use
+package trial
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/vBenchmark/internal/types"
+)
+
+const (
+	sampleInterval = 10 * time.Second
+	secondsPerSlot = 12
+	// headDistance is the sync distance at which a node counts as at head
+	headDistance = 2
+)
+
+// Options configures a trial run
+type Options struct {
+	Client        string
+	Network       string
+	CheckpointURL string // Empty uses the network's default provider
+	Image         string // Empty uses the client's default image
+	Duration      time.Duration
+	DataDir       string // Parent directory of the client's database
+	Keep          bool   // Keep the client database after the run
+	Verbose       bool
+}
+
+// sample is one reading of the node's sync state and container resources
+type sample struct {
+	time     time.Time
+	head     uint64
+	distance uint64
+	syncing  bool
+	stats    containerStats
+}
+
+// Run pulls the client image, checkpoint syncs the client for the trial
+// duration and returns what was observed. The container and, unless Keep is
+// set, its database are removed afterwards.
+func Run(ctx context.Context, opts Options) (types.TrialResult, error) {
+	result := types.TrialResult{Client: opts.Client, Network: opts.Network}
+	c, url, err := lookup(opts.Client, opts.Network, opts.CheckpointURL)
+	if err != nil {
+		return result, err
+	}
+	result.CheckpointURL = url
+	result.Image = c.image
+	if opts.Image != "" {
+		result.Image = opts.Image
+	}
+	if err := dockerAvailable(ctx); err != nil {
+		return result, err
+	}
+
+	dir, err := filepath.Abs(filepath.Join(opts.DataDir, "ethbench_trial_"+opts.Client))
+	if err != nil {
+		return result, err
+	}
+	if err := prepareDataDir(dir); err != nil {
+		return result, err
+	}
+	if !opts.Keep {
+		defer os.RemoveAll(dir)
+	}
+
+	logf(opts.Verbose, "Pulling %s...", result.Image)
+	if _, err := docker(ctx, "pull", result.Image); err != nil {
+		return result, err
+	}
+
+	name := fmt.Sprintf("ethbench-trial-%s-%d", opts.Client, os.Getpid())
+	volume := dir + ":" + dataDir
+	start := time.Now()
+	if c.prepare != nil {
+		logf(opts.Verbose, "Downloading checkpoint state from %s...", url)
+		args := append([]string{"run", "--rm", "--name", name + "-prepare", "-v", volume, result.Image}, c.prepare(opts.Network, url)...)
+		if _, err := docker(ctx, args...); err != nil {
+			return result, fmt.Errorf("checkpoint sync failed: %w", err)
+		}
+	}
+
+	logf(opts.Verbose, "Starting %s for %s...", opts.Client, opts.Duration)
+	args := append([]string{"run", "-d", "--name", name, "-v", volume, "-p", "127.0.0.1::" + apiPort, result.Image}, c.run(opts.Network, url)...)
+	if _, err := docker(ctx, args...); err != nil {
+		return result, err
+	}
+	defer docker(context.Background(), "rm", "-f", name)
+	apiURL, err := hostAPI(ctx, name)
+	if err != nil {
+		return result, err
+	}
+
+	samples, err := watch(ctx, name, apiURL, start, opts)
+	result.Duration = time.Since(start)
+	summarize(&result, start, samples)
+	return result, err
+}
+
+// watch samples the node until the trial duration has passed, ctx is
+// cancelled or the container exits
+func watch(ctx context.Context, name, apiURL string, start time.Time, opts Options) ([]sample, error) {
+	ticker := time.NewTicker(sampleInterval)
+	defer ticker.Stop()
+
+	var samples []sample
+	for time.Since(start) < opts.Duration {
+		select {
+		case <-ctx.Done():
+			return samples, nil
+		case <-ticker.C:
+		}
+		if !running(ctx, name) {
+			return samples, fmt.Errorf("%s exited during the trial: %s", opts.Client, lastLogs(name))
+		}
+		s := sample{time: time.Now()}
+		s.stats, _ = stats(ctx, name)
+		// The API only answers once the client has loaded its checkpoint
+		if err := syncing(ctx, apiURL, &s); err != nil {
+			continue
+		}
+		samples = append(samples, s)
+		logf(opts.Verbose, "head %d, %d slots behind, CPU %.0f%%, %.0f MB", s.head, s.distance, s.stats.cpuPercent, s.stats.memoryMB)
+	}
+	return samples, nil
+}
+
+// summarize fills result from the samples. Sync speed is taken over the
+// samples before the node first reached head; following head afterwards
+// only proceeds at one slot per slot.
+func summarize(result *types.TrialResult, start time.Time, samples []sample) {
+	result.Samples = len(samples)
+	if len(samples) == 0 {
+		result.Rating = "Poor"
+		return
+	}
+	first, last := samples[0], samples[len(samples)-1]
+	result.StartupSeconds = first.time.Sub(start).Seconds()
+	result.StartSlot = first.head
+	result.EndSlot = last.head
+	result.SyncDistance = last.distance
+	result.Synced = !last.syncing && last.distance <= headDistance
+
+	catchUp := last
+	var cpuSum float64
+	for _, s := range samples {
+		if result.TimeToHeadSeconds == 0 && !s.syncing && s.distance <= headDistance {
+			result.TimeToHeadSeconds = s.time.Sub(start).Seconds()
+			catchUp = s
+		}
+		cpuSum += s.stats.cpuPercent
+		result.PeakCPUPercent = max(result.PeakCPUPercent, s.stats.cpuPercent)
+		result.PeakMemoryMB = max(result.PeakMemoryMB, s.stats.memoryMB)
+	}
+	result.AvgCPUPercent = cpuSum / float64(len(samples))
+	result.DiskReadMB = last.stats.readMB
+	result.DiskWriteMB = last.stats.writeMB
+	if elapsed := last.time.Sub(start); elapsed > 0 {
+		result.WriteMBPerHour = last.stats.writeMB / elapsed.Hours()
+	}
+	if elapsed := catchUp.time.Sub(first.time).Seconds(); elapsed > 0 && catchUp.head > first.head {
+		result.SlotsPerSecond = float64(catchUp.head-first.head) / elapsed
+		result.RealtimeFactor = result.SlotsPerSecond * secondsPerSlot
+	}
+	result.Rating = rateTrial(result)
+}
+
+// rateTrial rates a trial by how soon the node reached head, or when it did
+// not, by how fast it was catching up
+func rateTrial(r *types.TrialResult) string {
+	switch {
+	case r.TimeToHeadSeconds > 0 && r.TimeToHeadSeconds <= 600:
+		return "Excellent"
+	case r.TimeToHeadSeconds > 0 && r.TimeToHeadSeconds <= 1800:
+		return "Good"
+	case r.TimeToHeadSeconds > 0 || r.RealtimeFactor >= 20:
+		return "Adequate"
+	case r.RealtimeFactor >= 2:
+		return "Marginal"
+	default:
+		return "Poor"
+	}
+}
+
+// syncing reads the node's sync state from the standard beacon API
+func syncing(ctx context.Context, apiURL string, s *sample) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+"/eth/v1/node/syncing", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("beacon API returned %s", resp.Status)
+	}
+
+	var body struct {
+		Data struct {
+			HeadSlot     string `json:"head_slot"`
+			SyncDistance string `json:"sync_distance"`
+			IsSyncing    bool   `json:"is_syncing"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return err
+	}
+	if s.head, err = strconv.ParseUint(body.Data.HeadSlot, 10, 64); err != nil {
+		return errors.New("beacon API returned no head slot")
+	}
+	s.distance, _ = strconv.ParseUint(body.Data.SyncDistance, 10, 64)
+	s.syncing = body.Data.IsSyncing
+	return nil
+}
+
+// prepareDataDir creates an empty database directory holding the engine API
+// JWT secret the clients refuse to start without. Nimbus requires 0700.
+func prepareDataDir(dir string) error {
+	os.RemoveAll(dir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create trial data directory: %w", err)
+	}
+	secret := make([]byte, 32)
+	rand.Read(secret)
+	return os.WriteFile(filepath.Join(dir, "jwt.hex"), []byte(hex.EncodeToString(secret)), 0600)
+}
+
+// logf prints a progress line in verbose mode
+func logf(verbose bool, format string, args ...any) {
+	if verbose {
+		fmt.Printf("    "+format+"\n", args...)
+	}
+}
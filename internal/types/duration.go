@@ -0,0 +1,278 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MarshalWithDuration marshals v and appends human-readable "duration" and
+// ISO 8601 "duration_iso8601" fields next to the raw duration_ns value.
+// v must be an alias type without this method to avoid recursion.
+func MarshalWithDuration(v any, d time.Duration) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSuffix(bytes.TrimSpace(data), []byte("}"))
+
+	var buf bytes.Buffer
+	buf.Write(data)
+	if len(data) > 1 {
+		buf.WriteByte(',')
+	}
+	fmt.Fprintf(&buf, `"duration":%q,"duration_iso8601":%q}`, d.Round(time.Millisecond).String(), ISO8601Duration(d))
+	return buf.Bytes(), nil
+}
+
+// ISO8601Duration formats d as an ISO 8601 duration, e.g. "PT1M23.456S"
+func ISO8601Duration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	var sb strings.Builder
+	if d < 0 {
+		sb.WriteByte('-')
+		d = -d
+	}
+	sb.WriteString("PT")
+	if h := d / time.Hour; h > 0 {
+		sb.WriteString(strconv.FormatInt(int64(h), 10) + "H")
+		d -= h * time.Hour
+	}
+	if m := d / time.Minute; m > 0 {
+		sb.WriteString(strconv.FormatInt(int64(m), 10) + "M")
+		d -= m * time.Minute
+	}
+	if d > 0 {
+		secs := strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+		secs = strings.TrimRight(strings.TrimRight(secs, "0"), ".")
+		sb.WriteString(secs + "S")
+	}
+	return sb.String()
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r KeccakResult) MarshalJSON() ([]byte, error) {
+	type alias KeccakResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r ECDSAResult) MarshalJSON() ([]byte, error) {
+	type alias ECDSAResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r BLSResult) MarshalJSON() ([]byte, error) {
+	type alias BLSResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r BN256Result) MarshalJSON() ([]byte, error) {
+	type alias BN256Result
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r RLPResult) MarshalJSON() ([]byte, error) {
+	type alias RLPResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r EVMResult) MarshalJSON() ([]byte, error) {
+	type alias EVMResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r SHA256Result) MarshalJSON() ([]byte, error) {
+	type alias SHA256Result
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r KZGResult) MarshalJSON() ([]byte, error) {
+	type alias KZGResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r CPUParallelResult) MarshalJSON() ([]byte, error) {
+	type alias CPUParallelResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r TrieResult) MarshalJSON() ([]byte, error) {
+	type alias TrieResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r PoolResult) MarshalJSON() ([]byte, error) {
+	type alias PoolResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r StateCacheResult) MarshalJSON() ([]byte, error) {
+	type alias StateCacheResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r SequentialResult) MarshalJSON() ([]byte, error) {
+	type alias SequentialResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r RandomResult) MarshalJSON() ([]byte, error) {
+	type alias RandomResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r BatchResult) MarshalJSON() ([]byte, error) {
+	type alias BatchResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r IdleResult) MarshalJSON() ([]byte, error) {
+	type alias IdleResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r StateSchemeResult) MarshalJSON() ([]byte, error) {
+	type alias StateSchemeResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r BlobResult) MarshalJSON() ([]byte, error) {
+	type alias BlobResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r LatencyResult) MarshalJSON() ([]byte, error) {
+	type alias LatencyResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r PressureResult) MarshalJSON() ([]byte, error) {
+	type alias PressureResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r CorrectnessResult) MarshalJSON() ([]byte, error) {
+	type alias CorrectnessResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r FsyncResult) MarshalJSON() ([]byte, error) {
+	type alias FsyncResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r MmapResult) MarshalJSON() ([]byte, error) {
+	type alias MmapResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r SlotResult) MarshalJSON() ([]byte, error) {
+	type alias SlotResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r KVStoreResult) MarshalJSON() ([]byte, error) {
+	type alias KVStoreResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r KVEngineResult) MarshalJSON() ([]byte, error) {
+	type alias KVEngineResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r CopyResult) MarshalJSON() ([]byte, error) {
+	type alias CopyResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r MigrationResult) MarshalJSON() ([]byte, error) {
+	type alias MigrationResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r ReplayResult) MarshalJSON() ([]byte, error) {
+	type alias ReplayResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r TrialResult) MarshalJSON() ([]byte, error) {
+	type alias TrialResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r PectraResult) MarshalJSON() ([]byte, error) {
+	type alias PectraResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r FusakaResult) MarshalJSON() ([]byte, error) {
+	type alias FusakaResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r ProcessUsage) MarshalJSON() ([]byte, error) {
+	type alias ProcessUsage
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r MPTResult) MarshalJSON() ([]byte, error) {
+	type alias MPTResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r MPTBackendResult) MarshalJSON() ([]byte, error) {
+	type alias MPTBackendResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r ContentionResult) MarshalJSON() ([]byte, error) {
+	type alias ContentionResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
+
+// MarshalJSON adds human-readable duration fields
+func (r TrieCommitResult) MarshalJSON() ([]byte, error) {
+	type alias TrieCommitResult
+	return MarshalWithDuration(alias(r), r.Duration)
+}
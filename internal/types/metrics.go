@@ -0,0 +1,39 @@
+package types
+
+import (
+	"encoding/json"
+)
+
+// FlattenMetrics returns every numeric field of v keyed by its dotted JSON
+// path, e.g. "cpu.keccak.hashes_per_second"
+func FlattenMetrics(v any) map[string]float64 {
+	metrics := make(map[string]float64)
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return metrics
+	}
+	var tree map[string]any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return metrics
+	}
+
+	flatten("", tree, metrics)
+	return metrics
+}
+
+// flatten walks a decoded JSON tree collecting numeric leaves
+func flatten(prefix string, node any, metrics map[string]float64) {
+	switch n := node.(type) {
+	case map[string]any:
+		for key, child := range n {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			flatten(path, child, metrics)
+		}
+	case float64:
+		metrics[prefix] = n
+	}
+}
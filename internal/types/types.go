@@ -7,17 +7,369 @@ import (
 
 // Results holds all benchmark results
 type Results struct {
-	CPU    CPUResults    `json:"cpu"`
-	Memory MemoryResults `json:"memory"`
-	Disk   DiskResults   `json:"disk"`
+	Idle     *IdleResult   `json:"idle_baseline,omitempty"`
+	CPU      CPUResults    `json:"cpu"`
+	Memory   MemoryResults `json:"memory"`
+	Disk     DiskResults   `json:"disk"`
+	Timeline []PhaseTiming `json:"timeline"`
+
+	Interference []Interference   `json:"interference,omitempty"`
+	Thermal      *ThermalResult   `json:"thermal,omitempty"`
+	Sleep        *SleepResult     `json:"sleep,omitempty"`
+	Forks        *ForkResults     `json:"forks,omitempty"`
+	Errors       []BenchmarkError `json:"errors,omitempty"`
+	Anomalies    []Anomaly        `json:"anomalies,omitempty"`
+	GCSweep      []GCSweepPoint   `json:"gc_sweep,omitempty"`
+	Harness      *HarnessOverhead `json:"harness_overhead,omitempty"`
+	IRQTuning    *IRQTuning       `json:"irq_tuning,omitempty"`
+	Paranoid     *ParanoidChecks  `json:"paranoid,omitempty"`
+
+	// Interrupted is set when the run was cancelled before every benchmark finished
+	Interrupted bool `json:"interrupted,omitempty"`
+}
+
+// RuntimeInfo records the Go runtime settings the benchmarks ran under
+type RuntimeInfo struct {
+	GoVersion  string `json:"go_version"`
+	GOMAXPROCS int    `json:"gomaxprocs"`
+	NumCPU     int    `json:"num_cpu"`
+	GOGC       string `json:"gogc"`
+	GOMEMLIMIT int64  `json:"gomemlimit_bytes"`
+}
+
+// GCSweepPoint holds memory benchmark results at a single GOGC value
+type GCSweepPoint struct {
+	GOGC                 int           `json:"gogc"`
+	TrieInsertsPerSecond float64       `json:"trie_inserts_per_second"`
+	PoolOpsPerSecond     float64       `json:"pool_ops_per_second"`
+	CacheHitsPerSecond   float64       `json:"cache_hits_per_second"`
+	NumGC                uint32        `json:"num_gc"`
+	GCPauseTotal         time.Duration `json:"gc_pause_total_ns"`
+}
+
+// HarnessOverhead holds the measured cost of the bookkeeping benchmark loops
+// do besides the work being measured, and its share of the fastest metrics
+type HarnessOverhead struct {
+	LoopCheckNs float64         `json:"loop_check_ns"` // Deadline and cancellation check per iteration
+	RNGCallNs   float64         `json:"rng_call_ns"`   // Fixed cost of one payload RNG draw
+	RNGByteNs   float64         `json:"rng_byte_ns"`   // Additional cost per byte drawn
+	MapLookupNs float64         `json:"map_lookup_ns"` // Bare map lookup, the floor for map-based workloads
+	Metrics     []HarnessMetric `json:"metrics,omitempty"`
+}
+
+// HarnessMetric estimates how much of one metric's measured time was harness
+// overhead. Scores use the measured value; CorrectedValue shows the rate
+// with the overhead subtracted.
+type HarnessMetric struct {
+	Metric             string  `json:"metric"`
+	ChecksPerIteration float64 `json:"checks_per_iteration"` // Deadline checks, batched by the loop
+	DutyCycle          float64 `json:"duty_cycle"`           // Share of loop time outside deadline checks, 0 if not recorded
+	OverheadNs         float64 `json:"overhead_ns"`          // Harness time per loop iteration
+	OverheadPercent    float64 `json:"overhead_percent"`
+	MeasuredValue      float64 `json:"measured_value"`
+	CorrectedValue     float64 `json:"corrected_value"`
+}
+
+// Anomaly records a metric that deviated from the hardware reference and was re-run
+type Anomaly struct {
+	Benchmark       string  `json:"benchmark"`
+	Metric          string  `json:"metric"`
+	FirstValue      float64 `json:"first_value"`
+	RerunValue      float64 `json:"rerun_value"`
+	ReferenceMean   float64 `json:"reference_mean"`
+	ReferenceStdDev float64 `json:"reference_stddev"`
+	Sigma           float64 `json:"sigma"`
+	Note            string  `json:"note"`
+}
+
+// Status records whether a benchmark failed or was skipped. A failed or
+// skipped result holds partial or no data and is excluded from scoring.
+type Status struct {
+	Error   string `json:"error,omitempty"`
+	Skipped bool   `json:"skipped,omitempty"`
+}
+
+// OK reports whether the benchmark completed and its data can be scored
+func (s Status) OK() bool {
+	return s.Error == "" && !s.Skipped
+}
+
+// BenchmarkError records a benchmark that failed; its result fields hold
+// whatever was measured before the failure
+type BenchmarkError struct {
+	Benchmark string `json:"benchmark"`
+	Error     string `json:"error"`
+	// Reason classifies failures that are not the benchmark's own result:
+	// "volume_full" or "read_only" when the test volume filled up or
+	// turned read-only mid-run, "timeout" when the watchdog stopped a
+	// benchmark that overran its time budget
+	Reason string `json:"reason,omitempty"`
+}
+
+// ForkResults holds results of the optional fork benchmark packs; a nil
+// field means the pack was not enabled
+type ForkResults struct {
+	Pectra *PectraResult `json:"pectra,omitempty"`
+	Fusaka *FusakaResult `json:"fusaka,omitempty"`
+}
+
+// PectraResult holds EIP-2537 BLS12-381 precompile benchmark results
+type PectraResult struct {
+	G1MSMPerSecond         float64       `json:"g1_msm_per_second"`
+	PairingChecksPerSecond float64       `json:"pairing_checks_per_second"`
+	MapToG1PerSecond       float64       `json:"map_to_g1_per_second"`
+	MGasPerSecond          float64       `json:"mgas_per_second"`
+	Duration               time.Duration `json:"duration_ns"`
+	Rating                 string        `json:"rating"`
+	Status
+}
+
+// FusakaResult holds PeerDAS blob extension benchmark results
+type FusakaResult struct {
+	BlobExtensionsPerSecond float64       `json:"blob_extensions_per_second"`
+	MaxBlobsPerSlot         int           `json:"max_blobs_per_slot"`
+	RealtimeFactor          float64       `json:"realtime_factor"`
+	Duration                time.Duration `json:"duration_ns"`
+	Rating                  string        `json:"rating"`
+	Status
+}
+
+// ThermalResult summarizes temperature, frequency and throttling during the run
+type ThermalResult struct {
+	Samples         int            `json:"samples"`
+	MaxTemperatureC float64        `json:"max_temperature_c"`
+	MinFreqMHz      int            `json:"min_freq_mhz"`
+	MaxFreqMHz      int            `json:"max_freq_mhz"`
+	ThrottleEvents  int            `json:"throttle_events"`
+	FreqDrops       int            `json:"freq_drops"`
+	Stable          bool           `json:"stable"`
+	Phases          []ThermalPhase `json:"phases"`
+}
+
+// ThermalPhase holds thermal readings taken while one benchmark ran
+type ThermalPhase struct {
+	Phase           string  `json:"phase"`
+	MaxTemperatureC float64 `json:"max_temperature_c"`
+	MinFreqMHz      int     `json:"min_freq_mhz"`
+	ThrottleEvents  int     `json:"throttle_events"`
+	FreqDrops       int     `json:"freq_drops"`
+	Affected        bool    `json:"affected"`
+}
+
+// SleepResult records how system sleep was held off during the run and any
+// suspend or governor change that interrupted measurements anyway
+type SleepResult struct {
+	Inhibitor        string   `json:"inhibitor"` // "systemd-inhibit", "caffeinate" or "none"
+	Suspends         int      `json:"suspends"`
+	SuspendedSeconds float64  `json:"suspended_seconds"`
+	GovernorChanges  []string `json:"governor_changes,omitempty"` // e.g. "performance -> powersave"
+	Phases           []string `json:"affected_phases,omitempty"`
+}
+
+// Interrupted reports whether a suspend or governor change hit the run
+func (s *SleepResult) Interrupted() bool {
+	return s.Suspends > 0 || len(s.GovernorChanges) > 0
+}
+
+// Interference records a background job that may have skewed results
+type Interference struct {
+	Name      string    `json:"name"`
+	Kind      string    `json:"kind"`
+	FirstSeen time.Time `json:"first_seen,omitempty"`
+	LastSeen  time.Time `json:"last_seen,omitempty"`
+	// CPUPercent is the CPU time the job used while it was seen, as a
+	// percentage of one core over the monitored run
+	CPUPercent float64  `json:"cpu_percent,omitempty"`
+	Phases     []string `json:"phases,omitempty"`
+}
+
+// IdleResult holds system activity measured before benchmarking started
+type IdleResult struct {
+	CPUIdlePercent  float64       `json:"cpu_idle_percent"`
+	DiskIOPS        float64       `json:"disk_background_iops"`
+	NetworkKBps     float64       `json:"network_kbps"`
+	AvgTemperatureC float64       `json:"avg_temperature_c"`
+	MaxTemperatureC float64       `json:"max_temperature_c"`
+	Quiet           bool          `json:"quiet"`
+	Duration        time.Duration `json:"duration_ns"`
+	// Error is set when the CPU counters could not be read; the idle state
+	// is then unknown and Quiet meaningless
+	Error string `json:"error,omitempty"`
+}
+
+// ProcessUsage holds the resource use of a running client sampled from /proc
+type ProcessUsage struct {
+	PID            int           `json:"pid"`
+	Command        string        `json:"command"`
+	Samples        int           `json:"samples"`
+	AvgCPUPercent  float64       `json:"avg_cpu_percent"` // 100 = one core
+	PeakCPUPercent float64       `json:"peak_cpu_percent"`
+	AvgRSSMB       float64       `json:"avg_rss_mb"`
+	PeakRSSMB      float64       `json:"peak_rss_mb"`
+	ReadMBps       float64       `json:"read_mbps"` // Storage reads, page cache hits excluded
+	WriteMBps      float64       `json:"write_mbps"`
+	PeakReadMBps   float64       `json:"peak_read_mbps"`
+	PeakWriteMBps  float64       `json:"peak_write_mbps"`
+	Threads        int           `json:"threads"`
+	PeakOpenFDs    int           `json:"peak_open_fds"`
+	MaxOpenFDs     int           `json:"max_open_fds"` // Soft limit; 0 when unknown
+	Duration       time.Duration `json:"duration_ns"`
+}
+
+// AttestationPerformance summarizes how a node's validators attested over
+// recent epochs, from the beacon API's attestation rewards
+type AttestationPerformance struct {
+	Endpoint   string `json:"endpoint"`
+	FirstEpoch uint64 `json:"first_epoch"`
+	LastEpoch  uint64 `json:"last_epoch"`
+	Validators int    `json:"validators"`
+	Duties     int    `json:"duties"` // Validators × epochs
+	// Missed attestations earned no timely source reward: not included
+	// within 5 slots, or not made at all
+	Missed int `json:"missed"`
+	// HeadMissed were included but voted late or for the wrong head,
+	// typically because the node imported the block too slowly
+	HeadMissed   int `json:"head_missed"`
+	TargetMissed int `json:"target_missed"`
+	// EffectivenessPercent is earned over ideal head, target and source rewards
+	EffectivenessPercent float64             `json:"effectiveness_percent"`
+	Epochs               []EpochAttestations `json:"epochs"`
+}
+
+// EpochAttestations counts the attestation outcomes of one epoch
+type EpochAttestations struct {
+	Epoch      uint64 `json:"epoch"`
+	Duties     int    `json:"duties"`
+	Missed     int    `json:"missed"`
+	HeadMissed int    `json:"head_missed"`
+}
+
+// PhaseTiming records when a single benchmark ran
+type PhaseTiming struct {
+	Name  string         `json:"name"`
+	Start time.Time      `json:"start"`
+	End   time.Time      `json:"end"`
+	CPU   *CPUAccounting `json:"cpu,omitempty"`
+	// IRQ is the interrupt load during disk benchmarks
+	IRQ *IRQLoad `json:"irq,omitempty"`
+}
+
+// IRQLoad is the interrupt handling load during a phase. USB and NIC
+// interrupts all steered to one core are a common hidden I/O bottleneck on
+// single-board computers.
+type IRQLoad struct {
+	InterruptsPerSecond float64 `json:"interrupts_per_second"`
+	SoftIRQsPerSecond   float64 `json:"softirqs_per_second"`
+	// TopSource is the device interrupt that fired most, with its IRQ
+	// number and the CPU that handled most of it
+	TopSource          string  `json:"top_source,omitempty"`
+	TopSourceIRQ       string  `json:"top_source_irq,omitempty"`
+	TopSourceCPU       int     `json:"top_source_cpu"`
+	TopSourcePerSecond float64 `json:"top_source_per_second"`
+	// BusiestCPU spent the largest share of its time in hard and soft IRQ
+	// handlers; Saturated is set from 70%
+	BusiestCPU        int     `json:"busiest_cpu"`
+	BusiestCPUPercent float64 `json:"busiest_cpu_percent"`
+	Saturated         bool    `json:"saturated"`
+	// Sources are the busiest device interrupts, by rate
+	Sources []IRQSourceRate `json:"sources,omitempty"`
+	// DeviceCPU handled the largest share of the device interrupts;
+	// Concentrated is set when it handled 90% or more of a busy load while
+	// other cores were available
+	DeviceCPU        int     `json:"device_cpu"`
+	DeviceCPUPercent float64 `json:"device_cpu_percent"`
+	Concentrated     bool    `json:"concentrated"`
+}
+
+// IRQSourceRate is one device interrupt during a phase and the CPU that
+// handled most of it
+type IRQSourceRate struct {
+	IRQ       string  `json:"irq"`
+	Name      string  `json:"name"`
+	PerSecond float64 `json:"per_second"`
+	CPU       int     `json:"cpu"`
+}
+
+// ParanoidChecks holds the internal invariant checks of a -paranoid run,
+// for contributors adding benchmark goroutines
+type ParanoidChecks struct {
+	// RaceDetector is set when the binary was built with -race, which then
+	// prints a report for every data race
+	RaceDetector bool                 `json:"race_detector"`
+	GOMAXPROCS   int                  `json:"gomaxprocs"`
+	Benchmarks   int                  `json:"benchmarks"` // Completed benchmarks checked
+	Violations   []InvariantViolation `json:"violations,omitempty"`
+}
+
+// InvariantViolation is one failed check: "goroutine_leak" for goroutines
+// still running after their benchmark returned, "cancellation" for a
+// benchmark that did not return promptly or panicked when cancelled, or
+// "value" for an impossible measurement
+type InvariantViolation struct {
+	Benchmark string `json:"benchmark"`
+	Check     string `json:"check"`
+	Detail    string `json:"detail"`
+}
+
+// IRQTuning is an interrupt affinity spread for the disk benchmark whose
+// interrupts were most concentrated on one CPU, and, when it was applied,
+// the benchmark's results before and after
+type IRQTuning struct {
+	Phase      string        `json:"phase"`
+	CPU        int           `json:"cpu"`
+	CPUPercent float64       `json:"cpu_percent"`
+	Moves      []IRQAffinity `json:"moves"`
+	Applied    bool          `json:"applied"`
+	// Metrics compare the benchmark before and after the moves, and After
+	// is its interrupt load when re-run
+	Metrics []TuningMetric `json:"metrics,omitempty"`
+	After   *IRQLoad       `json:"irq_after,omitempty"`
+}
+
+// IRQAffinity moves one interrupt to another CPU
+type IRQAffinity struct {
+	IRQ       string  `json:"irq"`
+	Source    string  `json:"source"`
+	PerSecond float64 `json:"per_second"`
+	FromCPU   int     `json:"from_cpu"`
+	ToCPU     int     `json:"to_cpu"`
+	Applied   bool    `json:"applied"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// TuningMetric is one benchmark metric before and after a tuning change
+type TuningMetric struct {
+	Metric        string  `json:"metric"`
+	Before        float64 `json:"before"`
+	After         float64 `json:"after"`
+	ChangePercent float64 `json:"change_percent"`
+}
+
+// CPUAccounting is the CPU time a phase consumed, which tells a CPU-bound
+// result apart from one spent waiting on storage
+type CPUAccounting struct {
+	UserSeconds   float64 `json:"user_seconds"`
+	SystemSeconds float64 `json:"system_seconds"`
+	// Utilization is CPU time over wall time: 1 is one core busy throughout
+	Utilization float64 `json:"utilization"`
+	// IOWaitCores is the average number of CPUs, system-wide, that sat idle
+	// waiting for I/O during the phase
+	IOWaitCores float64 `json:"iowait_cores"`
+	// Bound is "cpu", "io_wait", "mixed" or "idle"
+	Bound string `json:"bound"`
 }
 
 // CPUResults contains all CPU benchmark results
 type CPUResults struct {
-	Keccak KeccakResult `json:"keccak"`
-	ECDSA  ECDSAResult  `json:"ecdsa"`
-	BLS    BLSResult    `json:"bls"`
-	BN256  BN256Result  `json:"bn256"`
+	Keccak   KeccakResult      `json:"keccak"`
+	ECDSA    ECDSAResult       `json:"ecdsa"`
+	BLS      BLSResult         `json:"bls"`
+	BN256    BN256Result       `json:"bn256"`
+	RLP      RLPResult         `json:"rlp"`
+	EVM      EVMResult         `json:"evm"`
+	SHA256   SHA256Result      `json:"sha256"`
+	KZG      KZGResult         `json:"kzg"`
+	Parallel CPUParallelResult `json:"parallel"`
 }
 
 // KeccakResult holds Keccak256 benchmark results
@@ -27,6 +379,7 @@ type KeccakResult struct {
 	DataProcessedMB float64       `json:"data_processed_mb"`
 	Duration        time.Duration `json:"duration_ns"`
 	Rating          string        `json:"rating"`
+	Status
 }
 
 // ECDSAResult holds ECDSA/secp256k1 benchmark results
@@ -36,6 +389,7 @@ type ECDSAResult struct {
 	RecoveriesPerSecond    float64       `json:"recoveries_per_second"`
 	Duration               time.Duration `json:"duration_ns"`
 	Rating                 string        `json:"rating"`
+	Status
 }
 
 // BLSResult holds BLS12-381 benchmark results
@@ -45,6 +399,7 @@ type BLSResult struct {
 	AggregationsPerSecond  float64       `json:"aggregations_per_second"`
 	Duration               time.Duration `json:"duration_ns"`
 	Rating                 string        `json:"rating"`
+	Status
 }
 
 // BN256Result holds BN256 pairing benchmark results
@@ -54,13 +409,154 @@ type BN256Result struct {
 	PairingsPerSecond     float64       `json:"pairings_per_second"`
 	Duration              time.Duration `json:"duration_ns"`
 	Rating                string        `json:"rating"`
+	Status
+}
+
+// RLPResult contains RLP encode/decode benchmark results
+type RLPResult struct {
+	EncodesPerSecond float64       `json:"encodes_per_second"`
+	DecodesPerSecond float64       `json:"decodes_per_second"`
+	EncodeMBPerSec   float64       `json:"encode_mb_per_sec"`
+	Duration         time.Duration `json:"duration_ns"`
+	Rating           string        `json:"rating"`
+	Status
+}
+
+// EVMResult holds EVM interpreter benchmark results
+type EVMResult struct {
+	MGasPerSecond           float64       `json:"mgas_per_second"`
+	ArithmeticMGasPerSecond float64       `json:"arithmetic_mgas_per_second"`
+	StorageMGasPerSecond    float64       `json:"storage_mgas_per_second"`
+	TransferMGasPerSecond   float64       `json:"erc20_mgas_per_second"`
+	TransfersPerSecond      float64       `json:"erc20_transfers_per_second"`
+	Duration                time.Duration `json:"duration_ns"`
+	Rating                  string        `json:"rating"`
+	Status
+}
+
+// SHA256Result holds SHA-256 and BLAKE2b hashing benchmark results
+type SHA256Result struct {
+	NodeHashesPerSecond float64       `json:"node_hashes_per_second"`
+	MBPerSecond         float64       `json:"mb_per_second"`
+	SoftwareMBPerSecond float64       `json:"software_mb_per_second"`
+	AccelerationSpeedup float64       `json:"acceleration_speedup"`
+	HardwareAccelerated bool          `json:"hardware_accelerated"`
+	BLAKE2bMBPerSecond  float64       `json:"blake2b_mb_per_second"`
+	Duration            time.Duration `json:"duration_ns"`
+	Rating              string        `json:"rating"`
+	Status
+}
+
+// KZGResult holds blob KZG commitment and proof verification results
+type KZGResult struct {
+	CommitmentsPerSecond   float64       `json:"commitments_per_second"`
+	VerificationsPerSecond float64       `json:"verifications_per_second"`
+	RealtimeFactor         float64       `json:"realtime_factor"`
+	Duration               time.Duration `json:"duration_ns"`
+	Rating                 string        `json:"rating"`
+	Status
+}
+
+// CPUParallelResult holds single-core vs all-core CPU throughput
+type CPUParallelResult struct {
+	Workers           int               `json:"workers"`
+	Operations        []ParallelScaling `json:"operations"`
+	ScalingEfficiency float64           `json:"scaling_efficiency_percent"`
+	Duration          time.Duration     `json:"duration_ns"`
+	Rating            string            `json:"rating"`
+	Status
+}
+
+// ParallelScaling holds throughput of one operation on one core and all workers
+type ParallelScaling struct {
+	Operation      string  `json:"operation"`
+	SingleCoreRate float64 `json:"single_core_ops_per_second"`
+	AllCoreRate    float64 `json:"all_core_ops_per_second"`
+	Speedup        float64 `json:"speedup"`
+	Efficiency     float64 `json:"efficiency_percent"`
 }
 
 // MemoryResults contains all memory benchmark results
 type MemoryResults struct {
-	Trie       TrieResult       `json:"trie"`
-	Pool       PoolResult       `json:"pool"`
-	StateCache StateCacheResult `json:"state_cache"`
+	Trie        TrieResult        `json:"trie"`
+	Pool        PoolResult        `json:"pool"`
+	StateCache  StateCacheResult  `json:"state_cache"`
+	Latency     LatencyResult     `json:"latency"`
+	Correctness CorrectnessResult `json:"correctness"`
+	MPT         MPTResult         `json:"mpt"`
+	Pressure    *PressureResult   `json:"pressure,omitempty"` // Only with -memory-pressure
+
+	// GC settings the memory benchmarks ran under
+	GOGC         string `json:"gogc,omitempty"`
+	BallastBytes int64  `json:"ballast_bytes,omitempty"`
+
+	// Guard is set when available memory was low enough that working sets
+	// were limited to avoid the OOM killer
+	Guard *MemoryGuard `json:"guard,omitempty"`
+}
+
+// MemoryGuard records the working-set limit applied to the memory
+// benchmarks and which of them it reduced
+type MemoryGuard struct {
+	AvailableMB int      `json:"available_mb"`
+	LimitMB     int      `json:"limit_mb"`
+	Capped      []string `json:"capped,omitempty"`
+}
+
+// GCPhase counts the garbage collections during one benchmark phase. A
+// collection is forced before each phase, so only garbage the phase itself
+// produced is counted.
+type GCPhase struct {
+	Phase        string        `json:"phase"`
+	NumGC        uint32        `json:"num_gc"`
+	GCPauseTotal time.Duration `json:"gc_pause_total_ns"`
+	PausePercent float64       `json:"pause_percent"` // Share of the phase's run time
+}
+
+// LatencyResult holds pointer-chase memory latency results
+type LatencyResult struct {
+	Points        []LatencyPoint `json:"points"`
+	L1LatencyNs   float64        `json:"l1_latency_ns"`   // Smallest working set
+	DRAMLatencyNs float64        `json:"dram_latency_ns"` // Largest working set
+	// WorkingSetCapped is set when the largest working sets were left out
+	// for lack of available memory
+	WorkingSetCapped bool          `json:"working_set_capped,omitempty"`
+	Duration         time.Duration `json:"duration_ns"`
+	Rating           string        `json:"rating"`
+	Status
+}
+
+// LatencyPoint holds the average latency of one dependent load at a given
+// working-set size
+type LatencyPoint struct {
+	WorkingSetKB int64   `json:"working_set_kb"`
+	LatencyNs    float64 `json:"latency_ns"`
+}
+
+// PressureResult holds results of the memory-pressure test, which allocates
+// toward the RAM limit and re-reads what was pushed to swap
+type PressureResult struct {
+	AvailableMB     int           `json:"available_mb"`
+	TargetMB        int           `json:"target_mb"`
+	AllocatedMB     int           `json:"allocated_mb"`
+	FillMBPerSec    float64       `json:"fill_mb_per_sec"`
+	RetouchMBPerSec float64       `json:"retouch_mb_per_sec"`
+	MaxStallMs      float64       `json:"max_stall_ms"` // Slowest 64 MB re-read
+	SwapOutMB       float64       `json:"swap_out_mb"`
+	SwapInMB        float64       `json:"swap_in_mb"`
+	Duration        time.Duration `json:"duration_ns"`
+	Rating          string        `json:"rating"`
+	Status
+}
+
+// CorrectnessResult holds results of the randomized cross-check phase
+type CorrectnessResult struct {
+	Checks       uint64        `json:"checks"`
+	Failures     uint64        `json:"failures"`
+	FailedChecks []string      `json:"failed_checks,omitempty"`
+	Duration     time.Duration `json:"duration_ns"`
+	Rating       string        `json:"rating"`
+	Status
 }
 
 // TrieResult holds Merkle Patricia Trie benchmark results
@@ -71,6 +567,58 @@ type TrieResult struct {
 	PeakMemoryMB     float64       `json:"peak_memory_mb"`
 	Duration         time.Duration `json:"duration_ns"`
 	Rating           string        `json:"rating"`
+	Status
+
+	ParallelCommit     []ParallelCommitPoint `json:"parallel_commit,omitempty"`
+	ParallelEfficiency float64               `json:"parallel_efficiency_percent"`
+	GC                 []GCPhase             `json:"gc,omitempty"`
+	// WorkingSetCapped is set when inserts stopped early for lack of
+	// available memory
+	WorkingSetCapped bool `json:"working_set_capped,omitempty"`
+}
+
+// MPTResult holds go-ethereum's own Merkle Patricia Trie (trie.Trie) on a
+// hash-scheme node database: node encoding, hashing and resolving included,
+// which the memory.trie simulation leaves out
+type MPTResult struct {
+	Memory MPTBackendResult `json:"memory"`
+	// Disk repeats the phases on a Pebble database in the test directory;
+	// only with -mpt-disk
+	Disk     *MPTBackendResult `json:"disk,omitempty"`
+	Duration time.Duration     `json:"duration_ns"`
+	Rating   string            `json:"rating"`
+	Status
+}
+
+// MPTBackendResult holds the trie phases on one node database
+type MPTBackendResult struct {
+	Backend          string  `json:"backend"` // "memory" or "pebble"
+	Keys             int     `json:"keys"`
+	InsertsPerSecond float64 `json:"inserts_per_second"`
+	// InitialCommitMs hashes the inserted trie and writes all its nodes
+	InitialCommitMs float64 `json:"initial_commit_ms"`
+	// GetsPerSecond reads from the committed trie, resolving nodes from
+	// the database; one in five keys is absent
+	GetsPerSecond float64 `json:"gets_per_second"`
+	// A block updates UpdatesPerBlock accounts, hashes the root and commits
+	// the dirty nodes
+	BlocksPerSecond  float64       `json:"blocks_per_second"`
+	UpdatesPerBlock  int           `json:"updates_per_block"`
+	AvgHashMs        float64       `json:"avg_hash_ms"`
+	AvgCommitMs      float64       `json:"avg_commit_ms"`
+	AvgNodesPerBlock float64       `json:"avg_nodes_per_block"`
+	Duration         time.Duration `json:"duration_ns"`
+	// WorkingSetCapped is set when inserts stopped early for lack of
+	// available memory
+	WorkingSetCapped bool `json:"working_set_capped,omitempty"`
+}
+
+// ParallelCommitPoint holds trie commit throughput at a given worker count
+type ParallelCommitPoint struct {
+	Workers          int     `json:"workers"`
+	CommitsPerSecond float64 `json:"commits_per_second"`
+	Speedup          float64 `json:"speedup"`
+	Efficiency       float64 `json:"efficiency_percent"`
 }
 
 // PoolResult holds object pool benchmark results
@@ -80,6 +628,8 @@ type PoolResult struct {
 	MemoryChurnMB        float64       `json:"memory_churn_mb"`
 	Duration             time.Duration `json:"duration_ns"`
 	Rating               string        `json:"rating"`
+	GC                   []GCPhase     `json:"gc,omitempty"`
+	Status
 }
 
 // StateCacheResult holds state cache benchmark results
@@ -90,37 +640,362 @@ type StateCacheResult struct {
 	ThroughputMBPerSec   float64       `json:"throughput_mb_per_sec"`
 	Duration             time.Duration `json:"duration_ns"`
 	Rating               string        `json:"rating"`
+	GC                   []GCPhase     `json:"gc,omitempty"`
+	// WorkingSetCapped is set when fewer accounts than the workload
+	// profile's were cached for lack of available memory
+	WorkingSetCapped bool `json:"working_set_capped,omitempty"`
+	Status
 }
 
 // DiskResults contains all disk benchmark results
 type DiskResults struct {
-	Sequential SequentialResult `json:"sequential"`
-	Random     RandomResult     `json:"random"`
-	Batch      BatchResult      `json:"batch"`
+	Sequential  SequentialResult  `json:"sequential"`
+	Random      RandomResult      `json:"random"`
+	Batch       BatchResult       `json:"batch"`
+	StateScheme StateSchemeResult `json:"state_scheme"`
+	Blob        BlobResult        `json:"blob"`
+	KVStore     KVStoreResult     `json:"kvstore"`
+	Fsync       FsyncResult       `json:"fsync"`
+	Mmap        MmapResult        `json:"mmap"`
+	Slot        SlotResult        `json:"slot"`
+	Contention  ContentionResult  `json:"contention"`
+	TrieCommit  TrieCommitResult  `json:"trie_commit"`
+	Copy        *CopyResult       `json:"copy,omitempty"`
+	Migration   *MigrationResult  `json:"migration,omitempty"`
+	Replay      *ReplayResult     `json:"replay,omitempty"`
+	Writes      WriteUsage        `json:"writes"`
+	Filesystem  *FilesystemInfo   `json:"filesystem,omitempty"`
+	// DirectIO is "on" when sequential, random and batch also ran with
+	// O_DIRECT, or why the filesystem could not; empty when not requested
+	DirectIO string `json:"direct_io,omitempty"`
 }
 
-// SequentialResult holds sequential I/O benchmark results
-type SequentialResult struct {
-	WriteSpeedMBps float64       `json:"write_speed_mbps"`
-	ReadSpeedMBps  float64       `json:"read_speed_mbps"`
+// FilesystemInfo describes the filesystem the disk benchmarks ran on
+type FilesystemInfo struct {
+	Type       string   `json:"type"` // e.g. "ext4", "f2fs", "btrfs", "zfs"
+	Device     string   `json:"device"`
+	MountPoint string   `json:"mount_point"`
+	Options    []string `json:"options"`
+	NoAtime    bool     `json:"noatime"`
+	Discard    bool     `json:"discard"` // Continuous TRIM on delete
+	// CopyOnWrite is set on btrfs without nodatacow for the test directory,
+	// and on ZFS
+	CopyOnWrite bool            `json:"copy_on_write"`
+	ChainData   []ChainDataPath `json:"chain_data,omitempty"`
+}
+
+// ChainDataPath is an existing client data directory found on this machine
+type ChainDataPath struct {
+	Path       string `json:"path"`
+	SameDevice bool   `json:"same_device"` // On the filesystem that was benchmarked
+}
+
+// CopyResult holds backup/restore copy throughput between the test directory
+// and a second location, with the time to copy a whole datadir
+type CopyResult struct {
+	Destination  string        `json:"destination"`
+	TestFileMB   float64       `json:"test_file_mb"`
+	BackupMBps   float64       `json:"backup_mbps"`
+	RestoreMBps  float64       `json:"restore_mbps"`
+	DatadirGB    float64       `json:"datadir_gb"`
+	BackupHours  float64       `json:"backup_hours"`
+	RestoreHours float64       `json:"restore_hours"`
+	Duration     time.Duration `json:"duration_ns"`
+	Rating       string        `json:"rating"`
+	Status
+}
+
+// MigrationResult holds small-file and large-file copy rates to a second
+// location and the resulting datadir migration time per client
+type MigrationResult struct {
+	Destination         string              `json:"destination"`
+	SmallFilesPerSecond float64             `json:"small_files_per_second"`
+	SmallMBps           float64             `json:"small_mbps"`
+	LargeMBps           float64             `json:"large_mbps"`
+	PerFileOverheadMs   float64             `json:"per_file_overhead_ms"`
+	Estimates           []MigrationEstimate `json:"estimates"`
+	Duration            time.Duration       `json:"duration_ns"`
+	Rating              string              `json:"rating"`
+	Status
+}
+
+// MigrationEstimate is the estimated time to move one client's datadir
+type MigrationEstimate struct {
+	Client    string  `json:"client"`
+	DatadirGB float64 `json:"datadir_gb"`
+	Files     int64   `json:"files"`
+	Hours     float64 `json:"hours"`
+}
+
+// ReplayResult holds the import rate of a block segment replayed through
+// go-ethereum's block chain
+type ReplayResult struct {
+	Source          string        `json:"source"` // File name or "builtin"
+	Chain           string        `json:"chain"`
+	FirstBlock      uint64        `json:"first_block"`
+	LastBlock       uint64        `json:"last_block"`
+	Blocks          uint64        `json:"blocks"`
+	Transactions    uint64        `json:"transactions"`
+	GasUsed         uint64        `json:"gas_used"`
+	BlocksPerSecond float64       `json:"blocks_per_second"`
+	MGasPerSecond   float64       `json:"mgas_per_second"`
+	TxPerSecond     float64       `json:"transactions_per_second"`
+	Duration        time.Duration `json:"duration_ns"`
+	Rating          string        `json:"rating"`
+	Status
+}
+
+// TrialResult holds what a real consensus client did during a containerized
+// checkpoint-sync trial run
+type TrialResult struct {
+	Client         string  `json:"client"`
+	Image          string  `json:"image"`
+	Network        string  `json:"network"`
+	CheckpointURL  string  `json:"checkpoint_url"`
+	StartupSeconds float64 `json:"startup_seconds"` // Until the beacon API reported a head
+	StartSlot      uint64  `json:"start_slot"`
+	EndSlot        uint64  `json:"end_slot"`
+	SlotsPerSecond float64 `json:"slots_per_second"`
+	RealtimeFactor float64 `json:"realtime_factor"` // Slots synced per slot of wall time
+	SyncDistance   uint64  `json:"sync_distance"`   // Slots behind head at the end
+	Synced         bool    `json:"synced"`
+	// TimeToHeadSeconds is measured from container start; 0 when head was not reached
+	TimeToHeadSeconds float64       `json:"time_to_head_seconds"`
+	AvgCPUPercent     float64       `json:"avg_cpu_percent"` // 100 = one core
+	PeakCPUPercent    float64       `json:"peak_cpu_percent"`
+	PeakMemoryMB      float64       `json:"peak_memory_mb"`
+	DiskReadMB        float64       `json:"disk_read_mb"`
+	DiskWriteMB       float64       `json:"disk_write_mb"`
+	WriteMBPerHour    float64       `json:"write_mb_per_hour"`
+	Samples           int           `json:"samples"`
+	Duration          time.Duration `json:"duration_ns"`
+	Rating            string        `json:"rating"`
+	Status
+}
+
+// WriteUsage records the bytes written by the disk benchmarks against the limit
+type WriteUsage struct {
+	LimitBytes   int64 `json:"limit_bytes"` // 0 = unlimited
+	WrittenBytes int64 `json:"written_bytes"`
+	LimitReached bool  `json:"limit_reached"`
+	// VolumeError is set when the test volume filled up or turned read-only
+	// and the disk benchmarks stopped writing
+	VolumeError string `json:"volume_error,omitempty"`
+}
+
+// FsyncResult holds single-block fsync latency distribution
+type FsyncResult struct {
+	Count          uint64        `json:"count"`
+	SyncsPerSecond float64       `json:"syncs_per_second"`
+	P50LatencyMs   float64       `json:"p50_latency_ms"`
+	P95LatencyMs   float64       `json:"p95_latency_ms"`
+	P99LatencyMs   float64       `json:"p99_latency_ms"`
+	P999LatencyMs  float64       `json:"p999_latency_ms"`
+	MaxLatencyMs   float64       `json:"max_latency_ms"`
 	Duration       time.Duration `json:"duration_ns"`
 	Rating         string        `json:"rating"`
+	Status
 }
 
-// RandomResult holds random I/O benchmark results
-type RandomResult struct {
-	ReadIOPS     float64       `json:"read_iops"`
-	WriteIOPS    float64       `json:"write_iops"`
-	AvgLatencyUs float64       `json:"avg_latency_us"`
+// MmapResult holds reads through a memory-mapped file, as geth's freezer
+// serves ancient headers, bodies and receipts
+type MmapResult struct {
+	SequentialMBps       float64 `json:"sequential_mbps"`
+	RandomReadsPerSecond float64 `json:"random_reads_per_second"`
+	AvgRandomLatencyUs   float64 `json:"avg_random_latency_us"`
+	// MajorFaults counts page faults that read from the device
+	MajorFaults uint64        `json:"major_faults"`
+	TestFileMB  float64       `json:"test_file_mb"`
+	Duration    time.Duration `json:"duration_ns"`
+	Rating      string        `json:"rating"`
+	Status
+}
+
+// SlotResult holds the slot deadline simulation: a block-processing
+// pipeline of sender recovery, state updates, state root and batch commit,
+// run back to back and timed against the attestation deadline
+type SlotResult struct {
+	Slots        int     `json:"slots"`
+	Transactions int     `json:"transactions_per_slot"`
+	DeadlineMs   float64 `json:"deadline_ms"`
+	// SuccessRate is the percentage of slots processed within the deadline
+	SuccessRate  float64       `json:"success_rate_percent"`
+	P50Ms        float64       `json:"p50_ms"`
+	P99Ms        float64       `json:"p99_ms"`
+	MaxMs        float64       `json:"max_ms"`
+	AvgRecoverMs float64       `json:"avg_recover_ms"`
+	AvgStateMs   float64       `json:"avg_state_ms"`
+	AvgRootMs    float64       `json:"avg_root_ms"`
+	AvgCommitMs  float64       `json:"avg_commit_ms"`
 	Duration     time.Duration `json:"duration_ns"`
 	Rating       string        `json:"rating"`
+	Status
+}
+
+// ContentionResult holds the combined-load scenario: signature recovery,
+// trie updates and random disk I/O measured one at a time, then all at once
+// as a node hashes, verifies and writes at the same time
+type ContentionResult struct {
+	Crypto ContentionComponent `json:"crypto"`
+	Trie   ContentionComponent `json:"trie"`
+	Disk   ContentionComponent `json:"disk"`
+	// PenaltyPercent is the throughput the components lose on average
+	// under combined load
+	PenaltyPercent float64       `json:"penalty_percent"`
+	Duration       time.Duration `json:"duration_ns"`
+	Rating         string        `json:"rating"`
+	Status
+}
+
+// TrieCommitResult holds a go-ethereum state trie committed to Pebble every
+// CommitEvery account updates, as block import commits state
+type TrieCommitResult struct {
+	Accounts    int `json:"accounts"` // Accounts in the trie before the first timed commit
+	CommitEvery int `json:"commit_every"`
+	Commits     int `json:"commits"`
+	// UpdatesPerSecond counts the account updates over the whole phase,
+	// commits and reopening the trie at the new root included
+	UpdatesPerSecond  float64 `json:"updates_per_second"`
+	AvgNodesPerCommit float64 `json:"avg_nodes_per_commit"`
+	AvgCommitMs       float64 `json:"avg_commit_ms"`
+	P50CommitMs       float64 `json:"p50_commit_ms"`
+	P99CommitMs       float64 `json:"p99_commit_ms"`
+	MaxCommitMs       float64 `json:"max_commit_ms"`
+	// LogicalMB is the trie node data committed and DeviceMB what the
+	// process wrote to storage meanwhile: Pebble's WAL, memtable flushes and
+	// compactions. WriteAmplification is 0 without I/O accounting.
+	LogicalMB          float64       `json:"logical_mb"`
+	DeviceMB           float64       `json:"device_mb"`
+	WriteAmplification float64       `json:"write_amplification"`
+	Duration           time.Duration `json:"duration_ns"`
+	Rating             string        `json:"rating"`
+	Status
+}
+
+// ContentionComponent is one load of the contention scenario, in its own
+// unit: recoveries, trie updates or 4K I/O operations per second
+type ContentionComponent struct {
+	Workers            int     `json:"workers"`
+	IsolatedPerSecond  float64 `json:"isolated_per_second"`
+	CombinedPerSecond  float64 `json:"combined_per_second"`
+	DegradationPercent float64 `json:"degradation_percent"`
+}
+
+// KVStoreResult holds Pebble and LevelDB key-value workload results
+type KVStoreResult struct {
+	Pebble   KVEngineResult `json:"pebble"`
+	LevelDB  KVEngineResult `json:"leveldb"`
+	Duration time.Duration  `json:"duration_ns"`
+	Rating   string         `json:"rating"`
+	Status
+}
+
+// KVEngineResult holds results of the key-value workload on one engine
+type KVEngineResult struct {
+	Engine             string        `json:"engine"`
+	WritesPerSecond    float64       `json:"writes_per_second"`
+	ReadsPerSecond     float64       `json:"reads_per_second"`
+	ScanKeysPerSecond  float64       `json:"scan_keys_per_second"`
+	WriteAmplification float64       `json:"write_amplification"`
+	Duration           time.Duration `json:"duration_ns"`
+	Error              string        `json:"error,omitempty"`
+}
+
+// SequentialResult holds sequential I/O benchmark results
+type SequentialResult struct {
+	WriteSpeedMBps float64 `json:"write_speed_mbps"`
+	ReadSpeedMBps  float64 `json:"read_speed_mbps"`
+	// Direct speeds repeat the test with O_DIRECT (-direct-io), showing the
+	// device without page cache effects
+	DirectWriteSpeedMBps float64       `json:"direct_write_speed_mbps,omitempty"`
+	DirectReadSpeedMBps  float64       `json:"direct_read_speed_mbps,omitempty"`
+	Duration             time.Duration `json:"duration_ns"`
+	Rating               string        `json:"rating"`
+	Status
+}
+
+// RandomResult holds random I/O benchmark results
+type RandomResult struct {
+	ReadIOPS     float64 `json:"read_iops"`
+	WriteIOPS    float64 `json:"write_iops"`
+	AvgLatencyUs float64 `json:"avg_latency_us"`
+	TestFileMB   float64 `json:"test_file_mb"`
+	Jobs         int     `json:"jobs"`
+	// RequestedFileMB is the configured test file size, set when the write
+	// limit shrank the file to TestFileMB
+	RequestedFileMB float64 `json:"requested_file_mb,omitempty"`
+	// Mixed is the 70/30 read/write phase with reads and writes interleaved
+	MixedIOPS           float64            `json:"mixed_iops,omitempty"`
+	MixedWritePercent   int                `json:"mixed_write_percent,omitempty"`
+	MixedReadLatencyUs  float64            `json:"mixed_read_latency_us,omitempty"`
+	MixedWriteLatencyUs float64            `json:"mixed_write_latency_us,omitempty"`
+	ConcurrentReadIOPS  float64            `json:"concurrent_read_iops,omitempty"`
+	ConcurrentWriteIOPS float64            `json:"concurrent_write_iops,omitempty"`
+	DirectReadIOPS      float64            `json:"direct_read_iops,omitempty"`
+	DirectWriteIOPS     float64            `json:"direct_write_iops,omitempty"`
+	QueueDepths         []QueueDepthResult `json:"queue_depths,omitempty"`
+	Duration            time.Duration      `json:"duration_ns"`
+	Rating              string             `json:"rating"`
+	Status
+}
+
+// QueueDepthResult holds random 4K read performance with Depth requests
+// outstanding
+type QueueDepthResult struct {
+	Depth        int     `json:"depth"`
+	ReadIOPS     float64 `json:"read_iops"`
+	ReadMBps     float64 `json:"read_mbps"`
+	AvgLatencyUs float64 `json:"avg_latency_us"`
 }
 
 // BatchResult holds batch write benchmark results
 type BatchResult struct {
-	BatchesPerSecond  float64       `json:"batches_per_second"`
-	ThroughputMBps    float64       `json:"throughput_mbps"`
-	AvgBatchLatencyMs float64       `json:"avg_batch_latency_ms"`
+	BatchesPerSecond  float64 `json:"batches_per_second"`
+	ThroughputMBps    float64 `json:"throughput_mbps"`
+	AvgBatchLatencyMs float64 `json:"avg_batch_latency_ms"`
+	P99BatchLatencyMs float64 `json:"p99_batch_latency_ms"`
+	MaxBatchLatencyMs float64 `json:"max_batch_latency_ms"`
+	// Direct fields are O_DIRECT batch writes made durable with fdatasync
+	DirectBatchesPerSecond float64          `json:"direct_batches_per_second,omitempty"`
+	DirectThroughputMBps   float64          `json:"direct_throughput_mbps,omitempty"`
+	DirectP99LatencyMs     float64          `json:"direct_p99_latency_ms,omitempty"`
+	SyncModes              []SyncModeResult `json:"sync_modes,omitempty"`
+	BestSyncMode           string           `json:"best_sync_mode,omitempty"`
+	Duration               time.Duration    `json:"duration_ns"`
+	Rating                 string           `json:"rating"`
+	Status
+}
+
+// SyncModeResult holds batch write results for one durability mode
+// (o_sync, fdatasync or sync_file_range)
+type SyncModeResult struct {
+	Mode             string  `json:"mode"`
+	BatchesPerSecond float64 `json:"batches_per_second"`
+	ThroughputMBps   float64 `json:"throughput_mbps"`
+	P99LatencyMs     float64 `json:"p99_latency_ms"`
+}
+
+// StateSchemeResult holds hash-based vs path-based state scheme benchmark results
+type StateSchemeResult struct {
+	HashBlocksPerSecond float64       `json:"hash_blocks_per_second"`
+	PathBlocksPerSecond float64       `json:"path_blocks_per_second"`
+	PathSpeedup         float64       `json:"path_speedup"`
+	PathFlushes         uint64        `json:"path_flushes"`
+	AvgFlushLatencyMs   float64       `json:"avg_flush_latency_ms"`
+	Favored             string        `json:"favored_scheme"`
+	Duration            time.Duration `json:"duration_ns"`
+	Rating              string        `json:"rating"`
+	Status
+}
+
+// BlobResult holds blob sidecar store benchmark results
+type BlobResult struct {
+	BlocksPerSecond   float64       `json:"blocks_per_second"`
+	RealtimeFactor    float64       `json:"realtime_factor"`
+	WriteMBps         float64       `json:"write_mbps"`
+	ReadMBps          float64       `json:"read_mbps"`
+	AvgPruneLatencyMs float64       `json:"avg_prune_latency_ms"`
 	Duration          time.Duration `json:"duration_ns"`
 	Rating            string        `json:"rating"`
+	Status
 }
@@ -0,0 +1,159 @@
+package workload
+
+import (
+	"fmt"
+	mathrand "math/rand"
+
+	"github.com/vBenchmark/internal/fastrand"
+)
+
+// Bucket is a histogram bucket; values are drawn uniformly from [Min, Max]
+type Bucket struct {
+	Min    int     `json:"min"`
+	Max    int     `json:"max"`
+	Weight float64 `json:"weight"`
+}
+
+// Distribution is an empirical size or count histogram
+type Distribution struct {
+	Note    string   `json:"note"`
+	Buckets []Bucket `json:"buckets"`
+}
+
+// Dataset holds the state object distributions accounts and storage are
+// generated from
+type Dataset struct {
+	AccountRLPSize   Distribution `json:"account_rlp_size"`
+	ContractCodeSize Distribution `json:"contract_code_size"` // 0 = externally owned account
+	StorageSlots     Distribution `json:"storage_slots"`      // Per contract account
+	StorageValueSize Distribution `json:"storage_value_size"`
+}
+
+// Sample draws a value from the distribution
+func (d *Distribution) Sample(rng *mathrand.Rand) int {
+	var total float64
+	for _, b := range d.Buckets {
+		total += b.Weight
+	}
+	pick := rng.Float64() * total
+	for _, b := range d.Buckets {
+		if pick < b.Weight {
+			return b.Min + rng.Intn(b.Max-b.Min+1)
+		}
+		pick -= b.Weight
+	}
+	last := d.Buckets[len(d.Buckets)-1]
+	return last.Max
+}
+
+// Mean returns the expected value of the distribution
+func (d *Distribution) Mean() float64 {
+	var sum, total float64
+	for _, b := range d.Buckets {
+		sum += float64(b.Min+b.Max) / 2 * b.Weight
+		total += b.Weight
+	}
+	return sum / total
+}
+
+// Max returns the largest value the distribution can produce
+func (d *Distribution) Max() int {
+	var largest int
+	for _, b := range d.Buckets {
+		largest = max(largest, b.Max)
+	}
+	return largest
+}
+
+// validate checks every distribution has buckets with sane bounds and weights
+func (ds *Dataset) validate() error {
+	for name, d := range map[string]*Distribution{
+		"account_rlp_size":   &ds.AccountRLPSize,
+		"contract_code_size": &ds.ContractCodeSize,
+		"storage_slots":      &ds.StorageSlots,
+		"storage_value_size": &ds.StorageValueSize,
+	} {
+		if len(d.Buckets) == 0 {
+			return fmt.Errorf("workload profile dataset.%s has no buckets", name)
+		}
+		for _, b := range d.Buckets {
+			if b.Min < 0 || b.Max < b.Min || b.Weight <= 0 {
+				return fmt.Errorf("workload profile dataset.%s has an invalid bucket %+v", name, b)
+			}
+		}
+	}
+	return nil
+}
+
+// Account is a generated account
+type Account struct {
+	RLPSize      int // Encoded size of the account in the trie
+	CodeSize     int // 0 for externally owned accounts
+	StorageSlots int
+}
+
+// Generator draws accounts and storage values from the profile's dataset
+// distributions. It is not safe for concurrent use.
+type Generator struct {
+	dataset *Dataset
+	rng     *mathrand.Rand
+	// Share of flat state entries that are accounts rather than storage slots
+	accountShare float64
+}
+
+// NewGenerator returns a generator over the current profile's dataset that
+// draws from src, the benchmark's payload data stream
+func NewGenerator(src *fastrand.Source) *Generator {
+	ds := &Current.Dataset
+	eoaShare := eoaWeight(&ds.ContractCodeSize)
+	slotsPerAccount := (1 - eoaShare) * ds.StorageSlots.Mean()
+	return &Generator{
+		dataset:      ds,
+		rng:          mathrand.New(src),
+		accountShare: 1 / (1 + slotsPerAccount),
+	}
+}
+
+// Account draws an account. Only contract accounts have storage.
+func (g *Generator) Account() Account {
+	a := Account{
+		RLPSize:  g.dataset.AccountRLPSize.Sample(g.rng),
+		CodeSize: g.dataset.ContractCodeSize.Sample(g.rng),
+	}
+	if a.CodeSize > 0 {
+		a.StorageSlots = g.dataset.StorageSlots.Sample(g.rng)
+	}
+	return a
+}
+
+// StorageValueSize draws the encoded size of a storage slot value
+func (g *Generator) StorageValueSize() int {
+	return g.dataset.StorageValueSize.Sample(g.rng)
+}
+
+// StateEntrySize draws the value size of a flat state entry, as stored in
+// the snapshot: accounts and storage slots are mixed in the ratio the
+// distributions imply for the whole state
+func (g *Generator) StateEntrySize() int {
+	if g.rng.Float64() < g.accountShare {
+		return g.dataset.AccountRLPSize.Sample(g.rng)
+	}
+	return g.StorageValueSize()
+}
+
+// MaxStateEntrySize returns the largest size StateEntrySize can return
+func (g *Generator) MaxStateEntrySize() int {
+	return max(g.dataset.AccountRLPSize.Max(), g.dataset.StorageValueSize.Max())
+}
+
+// eoaWeight returns the share of accounts without code
+func eoaWeight(code *Distribution) float64 {
+	var eoa, total float64
+	for _, b := range code.Buckets {
+		if b.Max == 0 {
+			eoa += b.Weight
+		}
+		total += b.Weight
+	}
+	return eoa / total
+}
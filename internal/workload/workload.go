@@ -0,0 +1,110 @@
+// Package workload provides the embedded mainnet workload profile the
+// benchmarks are parameterized with
+package workload
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+//go:embed workload.json
+var workloadData []byte
+
+// Block holds execution layer block parameters
+type Block struct {
+	SlotSeconds int    `json:"slot_seconds"`
+	GasLimit    uint64 `json:"gas_limit"`
+	GasUsed     uint64 `json:"gas_used"`
+	Number      int64  `json:"number"`
+	BaseFeeWei  int64  `json:"base_fee_wei"`
+}
+
+// Blobs holds EIP-4844 blob parameters
+type Blobs struct {
+	TargetPerBlock int `json:"target_per_block"`
+	MaxPerBlock    int `json:"max_per_block"`
+	RetainedSlots  int `json:"retained_slots"` // Scaled-down retention window (mainnet: 4096 epochs)
+	ReadsPerBlock  int `json:"reads_per_block"`
+}
+
+// Batch holds the database write batch shape
+type Batch struct {
+	KVSize  int `json:"kv_size"`
+	KVPairs int `json:"kv_pairs"`
+}
+
+// KVStore holds the key-value store workload shape
+type KVStore struct {
+	KeySize    int `json:"key_size"`
+	BatchSize  int `json:"batch_size"`
+	ScanLength int `json:"scan_length"`
+}
+
+// State holds state access and trie commit parameters
+type State struct {
+	Accounts       int     `json:"accounts"`
+	NodeSize       int     `json:"node_size"`
+	ReadsPerBlock  int     `json:"reads_per_block"`
+	NodesPerBlock  int     `json:"nodes_per_block"`
+	MemoryHitRatio float64 `json:"memory_hit_ratio"`
+}
+
+// Profile is a versioned set of workload parameters. Results are only
+// directly comparable between reports made with the same profile version.
+type Profile struct {
+	Version string  `json:"version"`
+	Network string  `json:"network"`
+	Note    string  `json:"note"`
+	Block   Block   `json:"block"`
+	Blobs   Blobs   `json:"blobs"`
+	Batch   Batch   `json:"batch"`
+	KVStore KVStore `json:"kvstore"`
+	State   State   `json:"state"`
+	Dataset Dataset `json:"dataset"`
+}
+
+// Current is the embedded workload profile
+var Current = mustLoad()
+
+// Parse parses and validates a workload profile
+func Parse(data []byte) (*Profile, error) {
+	var p Profile
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse workload profile: %w", err)
+	}
+	if p.Version == "" {
+		return nil, fmt.Errorf("workload profile has no version")
+	}
+	for name, v := range map[string]int{
+		"block.slot_seconds":    p.Block.SlotSeconds,
+		"blobs.max_per_block":   p.Blobs.MaxPerBlock,
+		"blobs.retained_slots":  p.Blobs.RetainedSlots,
+		"batch.kv_size":         p.Batch.KVSize,
+		"batch.kv_pairs":        p.Batch.KVPairs,
+		"kvstore.key_size":      p.KVStore.KeySize,
+		"kvstore.batch_size":    p.KVStore.BatchSize,
+		"kvstore.scan_length":   p.KVStore.ScanLength,
+		"state.accounts":        p.State.Accounts,
+		"state.node_size":       p.State.NodeSize,
+		"state.nodes_per_block": p.State.NodesPerBlock,
+	} {
+		if v <= 0 {
+			return nil, fmt.Errorf("workload profile %s must be positive", name)
+		}
+	}
+	if err := p.Dataset.validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// mustLoad parses the embedded profile. It ships inside the binary, so a
+// broken profile is a build defect and fails at startup.
+func mustLoad() *Profile {
+	p, err := Parse(workloadData)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}